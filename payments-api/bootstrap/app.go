@@ -23,12 +23,23 @@ type RESTApp struct {
 	Logger logger.Logger
 
 	GRPCpayment pb.PaymentClient
+	GRPCadmin   pb.AdminClient
 }
 
 type ProcessorApp struct {
 	Logger logger.Logger
 
-	PaymentService *service.Payment
+	PaymentService       *service.Payment
+	RefundService        *service.Refund
+	AuthorizationService *service.Authorization
+	CreditService        *service.Credit
+
+	TransactionHistoryService *service.TransactionHistory
+	BalanceService            *service.Balance
+
+	AdminService *service.Admin
+
+	TransactionEventRelay *service.TransactionEventRelay
 }
 
 func NewRESTApp(cfg *config.Config) (*RESTApp, error) {
@@ -42,15 +53,27 @@ func NewRESTApp(cfg *config.Config) (*RESTApp, error) {
 		return nil, fmt.Errorf("failed to initialize gRPC Client: %w", err)
 	}
 
+	gRPCAdminClient, err := gRPC.NewAdminClient(cfg.GRPC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize gRPC Admin Client: %w", err)
+	}
+
 	return &RESTApp{
 		Logger:      log,
 		GRPCpayment: gRPCPaymentClient,
+		GRPCadmin:   gRPCAdminClient,
 	}, nil
 }
 
 func NewProcessorApp(cfg *config.Config) (*ProcessorApp, error) {
 	// Setting Value Objects
 	timeoutSLA := port.TimeoutSLA(time.Duration(cfg.API.TimeoutSLA) * time.Millisecond)
+	holdTTL := port.AuthorizationHoldTTL(time.Duration(cfg.API.HoldTTL) * time.Millisecond)
+	creditBatchWorkers := port.CreditBatchWorkers(cfg.API.CreditBatchWorkers)
+	merchantSimilarityThreshold := port.MerchantSimilarityThreshold(cfg.API.MerchantSimilarityThreshold)
+	fraudRulesReloadInterval := port.FraudRulesReloadInterval(time.Duration(cfg.API.FraudRulesReloadInterval) * time.Millisecond)
+	transactionEventRelayInterval := port.TransactionEventRelayInterval(time.Duration(cfg.Events.RelayInterval) * time.Millisecond)
+	transactionEventRelayBatchSize := port.TransactionEventRelayBatchSize(cfg.Events.RelayBatchSize)
 
 	// Initialize supports
 	log, err := initializeLogger(cfg.Logger)
@@ -64,9 +87,12 @@ func NewProcessorApp(cfg *config.Config) (*ProcessorApp, error) {
 		return nil, err
 	}
 
-	lockClient, err := initializeDatabaseInMemory(cfg.Lock.ToInMemoryDatabase(), "Lock", log)
-	if err != nil {
-		return nil, err
+	var lockClient database.InMemory
+	if cfg.Lock.Strategy != "postgres" {
+		lockClient, err = initializeDatabaseInMemory(cfg.Lock.ToInMemoryDatabase(), "Lock", log)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	cacheClient, err := initializeDatabaseInMemory(cfg.Cache.ToInMemoryDatabase(), "Cache", log)
@@ -79,6 +105,14 @@ func NewProcessorApp(cfg *config.Config) (*ProcessorApp, error) {
 		return nil, err
 	}
 
+	var lockDBConn database.Conn
+	if cfg.Lock.Strategy == "postgres" {
+		lockDBConn, err = initializeDatabase(cfg.Lock.ToDatabase(cfg.Database), log)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Initialize repositories
 	allRepos, err := repository.GetAll(dbConn)
 	if err != nil {
@@ -90,23 +124,163 @@ func NewProcessorApp(cfg *config.Config) (*ProcessorApp, error) {
 		return nil, fmt.Errorf("failed to initialize cached merchant repository: %w", err)
 	}
 
-	memoryLockRepo, err := repository.NewMemoryLock(lockClient, pubSubClient, log)
+	accountRepo, err := repository.NewBalanceInvalidatingAccount(cacheClient, allRepos.Account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize balance invalidating account repository: %w", err)
+	}
+
+	holdRepo, err := repository.NewBalanceInvalidatingHold(cacheClient, allRepos.Hold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize balance invalidating hold repository: %w", err)
+	}
+
+	cachedBalanceRepo, err := repository.NewCachedBalance(cacheClient, allRepos.Account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cached balance repository: %w", err)
+	}
+
+	adminRepo, err := repository.NewBalanceInvalidatingAdmin(cacheClient, allRepos.Admin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize balance invalidating admin repository: %w", err)
+	}
+
+	cachedSpendingUsageRepo, err := repository.NewCachedSpendingUsage(cacheClient, allRepos.SpendingUsage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cached spending usage repository: %w", err)
+	}
+
+	merchantRegistryRepo, err := repository.NewCacheEvictingMerchantRegistry(cacheClient, allRepos.MerchantRegistry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cache evicting merchant registry repository: %w", err)
+	}
+
+	memoryLockRepo, err := repository.NewMemoryLock(
+		cfg.Lock.Strategy,
+		lockClient,
+		dbConn,
+		lockDBConn,
+		allRepos.FencingToken,
+		pubSubClient,
+		log,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize memory lock repository: %w", err)
 	}
 
+	transactionEventPublisher, err := pubSub.NewTransactionEventPublisher(cfg.Events, cfg.PubSub, pubSubClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize transaction event publisher: %w", err)
+	}
+
 	// Initialize services
+	merchantMatcher := service.NewMerchantMatcher(
+		merchantSimilarityThreshold,
+		cachedMerchantRepo,
+		allRepos.MerchantMatchAudit,
+		log,
+	)
+
+	fraudRules := service.NewFraudRules(
+		fraudRulesReloadInterval,
+		allRepos.FraudRule,
+		allRepos.FraudHistory,
+		log,
+	)
+
 	paymentService := service.NewPayment(
 		timeoutSLA,
-		allRepos.Account,
-		cachedMerchantRepo,
+		accountRepo,
+		allRepos.Card,
+		merchantMatcher,
+		fraudRules,
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		cachedSpendingUsageRepo,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		log,
+	)
+
+	refundService := service.NewRefund(
+		timeoutSLA,
+		accountRepo,
+		allRepos.TransactionOutcome,
 		memoryLockRepo,
 		log,
 	)
 
+	authorizationService := service.NewAuthorization(
+		timeoutSLA,
+		holdTTL,
+		accountRepo,
+		allRepos.Card,
+		merchantMatcher,
+		fraudRules,
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		cachedSpendingUsageRepo,
+		holdRepo,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		log,
+	)
+
+	creditService := service.NewCredit(
+		timeoutSLA,
+		creditBatchWorkers,
+		accountRepo,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		log,
+	)
+
+	transactionHistoryService := service.NewTransactionHistory(
+		timeoutSLA,
+		accountRepo,
+		log,
+	)
+
+	balanceService := service.NewBalance(
+		timeoutSLA,
+		cachedBalanceRepo,
+		log,
+	)
+
+	adminService := service.NewAdmin(
+		timeoutSLA,
+		adminRepo,
+		merchantRegistryRepo,
+		allRepos.Ledger,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.Card,
+		memoryLockRepo,
+		log,
+	)
+
+	transactionEventRelay := service.NewTransactionEventRelay(
+		transactionEventRelayInterval,
+		transactionEventRelayBatchSize,
+		allRepos.TransactionEvent,
+		transactionEventPublisher,
+		log,
+	)
+
 	return &ProcessorApp{
-		Logger:         log,
-		PaymentService: paymentService,
+		Logger:               log,
+		PaymentService:       paymentService,
+		RefundService:        refundService,
+		AuthorizationService: authorizationService,
+		CreditService:        creditService,
+
+		TransactionHistoryService: transactionHistoryService,
+		BalanceService:            balanceService,
+
+		AdminService: adminService,
+
+		TransactionEventRelay: transactionEventRelay,
 	}, nil
 }
 
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/jtonynet/go-payments-api/config"
@@ -20,10 +21,22 @@ func main() {
 		log.Fatalf("cannot initiate app: %v", err)
 	}
 
-	gRPCPaymentServer, err := gRPC.NewPaymentServer(cfg.GRPC, *app.PaymentService)
+	gRPCPaymentServer, err := gRPC.NewPaymentServer(
+		cfg.GRPC,
+		*app.PaymentService,
+		*app.RefundService,
+		*app.AuthorizationService,
+		*app.TransactionHistoryService,
+		*app.BalanceService,
+		*app.CreditService,
+		*app.AdminService,
+	)
 	if err != nil {
 		log.Fatalf("cannot initiate gRPCPaymentServer: %v", err)
 	}
+
+	go app.TransactionEventRelay.Run(context.Background())
+
 	gRPCPaymentServer.HandleRequests()
 
 }
@@ -7,13 +7,19 @@ import (
 type API struct {
 	Env string `mapstructure:"ENV"`
 
-	Name            string `mapstructure:"API_NAME"`
-	Port            string `mapstructure:"API_PORT"`
-	RestHost        string `mapstructure:"API_REST_HOST"`
-	TagVersion      string `mapstructure:"API_TAG_VERSION"`
-	TimeoutSLA      int64  `mapstructure:"API_TIMEOUT_SLA_IN_MS"`
-	MetricEnabled   bool   `mapstructure:"API_METRICS_ENABLED"`
-	TransactionPath string `mapstructure:"API_TRANSACTION_PATH"`
+	Name               string `mapstructure:"API_NAME"`
+	Port               string `mapstructure:"API_PORT"`
+	RestHost           string `mapstructure:"API_REST_HOST"`
+	TagVersion         string `mapstructure:"API_TAG_VERSION"`
+	TimeoutSLA         int64  `mapstructure:"API_TIMEOUT_SLA_IN_MS"`
+	HoldTTL            int64  `mapstructure:"API_AUTHORIZATION_HOLD_TTL_IN_MS"`
+	CreditBatchWorkers int    `mapstructure:"API_CREDIT_BATCH_WORKERS"`
+	MetricEnabled      bool   `mapstructure:"API_METRICS_ENABLED"`
+	TransactionPath    string `mapstructure:"API_TRANSACTION_PATH"`
+	AdminToken         string `mapstructure:"API_ADMIN_TOKEN"`
+
+	MerchantSimilarityThreshold float64 `mapstructure:"API_MERCHANT_SIMILARITY_THRESHOLD"`
+	FraudRulesReloadInterval    int64   `mapstructure:"API_FRAUD_RULES_RELOAD_IN_MS"`
 }
 
 type Database struct {
@@ -27,6 +33,8 @@ type Database struct {
 	Port    string `mapstructure:"DATABASE_PORT"`
 	SSLmode string `mapstructure:"DATABASE_SSLMODE"`
 
+	MaxOpenConns int `mapstructure:"DATABASE_MAX_OPEN_CONNS"`
+
 	MetricEnabled       bool   `mapstructure:"DATABASE_METRICS_ENABLED"`
 	MetricDBName        string `mapstructure:"DATABASE_METRICS_NAME"`
 	MetricIntervalInSec uint32 `mapstructure:"DATABASE_METRICS_INTERVAL_IN_SEC"`
@@ -46,6 +54,32 @@ type PubSub struct {
 	Host     string `mapstructure:"PUBSUB_HOST"`
 	DB       int    `mapstructure:"PUBSUB_DB"`
 	Protocol int    `mapstructure:"PUBSUB_PROTOCOL"`
+
+	Topology     string   `mapstructure:"PUBSUB_TOPOLOGY"`
+	Addrs        []string `mapstructure:"PUBSUB_ADDRS"`
+	MasterName   string   `mapstructure:"PUBSUB_MASTER_NAME"`
+	User         string   `mapstructure:"PUBSUB_USER"`
+	SentinelUser string   `mapstructure:"PUBSUB_SENTINEL_USER"`
+	SentinelPass string   `mapstructure:"PUBSUB_SENTINEL_PASSWORD"`
+	TLS          bool     `mapstructure:"PUBSUB_TLS"`
+}
+
+func (p *PubSub) ToInMemoryDatabase() InMemoryDatabase {
+	return InMemoryDatabase{
+		Strategy:     p.Strategy,
+		Pass:         p.Pass,
+		Port:         p.Port,
+		Host:         p.Host,
+		DB:           p.DB,
+		Protocol:     p.Protocol,
+		Topology:     p.Topology,
+		Addrs:        p.Addrs,
+		MasterName:   p.MasterName,
+		User:         p.User,
+		SentinelUser: p.SentinelUser,
+		SentinelPass: p.SentinelPass,
+		TLS:          p.TLS,
+	}
 }
 
 type InMemoryDatabase struct {
@@ -56,12 +90,32 @@ type InMemoryDatabase struct {
 	DB         int
 	Protocol   int
 	Expiration int
+
+	Topology     string
+	Addrs        []string
+	MasterName   string
+	User         string
+	SentinelUser string
+	SentinelPass string
+	TLS          bool
 }
 
 type InMemoryDatabaseConverter interface {
 	ToInMemoryDatabase() (InMemoryDatabase, error)
 }
 
+/*
+  - Downstream delivery of the transaction events relayed from the outbox, on the
+    pub/sub topic or, durable, on a Redis Stream of the pub/sub connection
+*/
+type Events struct {
+	Strategy       string `mapstructure:"EVENTS_STRATEGY"`
+	Topic          string `mapstructure:"EVENTS_TOPIC"`
+	StreamMaxLen   int64  `mapstructure:"EVENTS_STREAM_MAX_LEN"`
+	RelayInterval  int64  `mapstructure:"EVENTS_RELAY_INTERVAL_IN_MS"`
+	RelayBatchSize int    `mapstructure:"EVENTS_RELAY_BATCH_SIZE"`
+}
+
 type Lock struct {
 	Strategy   string `mapstructure:"LOCK_IN_MEMORY_STRATEGY"`
 	Pass       string `mapstructure:"LOCK_IN_MEMORY_PASSWORD"`
@@ -70,17 +124,45 @@ type Lock struct {
 	DB         int    `mapstructure:"LOCK_IN_MEMORY_DB"`
 	Protocol   int    `mapstructure:"LOCK_IN_MEMORY_PROTOCOL"`
 	Expiration int    `mapstructure:"LOCK_IN_MEMORY_EXPIRATION_DEFAULT_IN_MS"`
+
+	Topology     string   `mapstructure:"LOCK_IN_MEMORY_TOPOLOGY"`
+	Addrs        []string `mapstructure:"LOCK_IN_MEMORY_ADDRS"`
+	MasterName   string   `mapstructure:"LOCK_IN_MEMORY_MASTER_NAME"`
+	User         string   `mapstructure:"LOCK_IN_MEMORY_USER"`
+	SentinelUser string   `mapstructure:"LOCK_IN_MEMORY_SENTINEL_USER"`
+	SentinelPass string   `mapstructure:"LOCK_IN_MEMORY_SENTINEL_PASSWORD"`
+	TLS          bool     `mapstructure:"LOCK_IN_MEMORY_TLS"`
+
+	PostgresMaxConns int `mapstructure:"LOCK_IN_MEMORY_POSTGRES_MAX_CONNS"`
+}
+
+/*
+  - Connection of the database dedicated to the `postgres` lock strategy, whose pool
+    holds at most `PostgresMaxConns` locks and waiters, without metrics of its own
+*/
+func (l *Lock) ToDatabase(db Database) Database {
+	db.MaxOpenConns = l.PostgresMaxConns
+	db.MetricEnabled = false
+
+	return db
 }
 
 func (l *Lock) ToInMemoryDatabase() InMemoryDatabase {
 	return InMemoryDatabase{
-		Strategy:   l.Strategy,
-		Pass:       l.Pass,
-		Port:       l.Port,
-		Host:       l.Host,
-		DB:         l.DB,
-		Protocol:   l.Protocol,
-		Expiration: l.Expiration,
+		Strategy:     l.Strategy,
+		Pass:         l.Pass,
+		Port:         l.Port,
+		Host:         l.Host,
+		DB:           l.DB,
+		Protocol:     l.Protocol,
+		Expiration:   l.Expiration,
+		Topology:     l.Topology,
+		Addrs:        l.Addrs,
+		MasterName:   l.MasterName,
+		User:         l.User,
+		SentinelUser: l.SentinelUser,
+		SentinelPass: l.SentinelPass,
+		TLS:          l.TLS,
 	}
 }
 
@@ -92,17 +174,32 @@ type Cache struct {
 	DB         int    `mapstructure:"CACHE_IN_MEMORY_DB"`
 	Protocol   int    `mapstructure:"CACHE_IN_MEMORY_PROTOCOL"`
 	Expiration int    `mapstructure:"CACHE_IN_MEMORY_EXPIRATION_DEFAULT_IN_MS"`
+
+	Topology     string   `mapstructure:"CACHE_IN_MEMORY_TOPOLOGY"`
+	Addrs        []string `mapstructure:"CACHE_IN_MEMORY_ADDRS"`
+	MasterName   string   `mapstructure:"CACHE_IN_MEMORY_MASTER_NAME"`
+	User         string   `mapstructure:"CACHE_IN_MEMORY_USER"`
+	SentinelUser string   `mapstructure:"CACHE_IN_MEMORY_SENTINEL_USER"`
+	SentinelPass string   `mapstructure:"CACHE_IN_MEMORY_SENTINEL_PASSWORD"`
+	TLS          bool     `mapstructure:"CACHE_IN_MEMORY_TLS"`
 }
 
 func (c *Cache) ToInMemoryDatabase() InMemoryDatabase {
 	return InMemoryDatabase{
-		Strategy:   c.Strategy,
-		Pass:       c.Pass,
-		Port:       c.Port,
-		Host:       c.Host,
-		DB:         c.DB,
-		Protocol:   c.Protocol,
-		Expiration: c.Expiration,
+		Strategy:     c.Strategy,
+		Pass:         c.Pass,
+		Port:         c.Port,
+		Host:         c.Host,
+		DB:           c.DB,
+		Protocol:     c.Protocol,
+		Expiration:   c.Expiration,
+		Topology:     c.Topology,
+		Addrs:        c.Addrs,
+		MasterName:   c.MasterName,
+		User:         c.User,
+		SentinelUser: c.SentinelUser,
+		SentinelPass: c.SentinelPass,
+		TLS:          c.TLS,
 	}
 }
 
@@ -111,6 +208,7 @@ type GRPC struct {
 	ServerPort string `mapstructure:"GRPC_SERVER_PORT"`
 	ClientHost string `mapstructure:"GRPC_CLIENT_HOST"`
 	ClientPort string `mapstructure:"GRPC_CLIENT_PORT"`
+	AdminToken string `mapstructure:"GRPC_ADMIN_TOKEN"`
 }
 
 type Logger struct {
@@ -126,6 +224,7 @@ type Config struct {
 	Database Database `mapstructure:",squash"`
 	Router   Router   `mapstructure:",squash"`
 	PubSub   PubSub   `mapstructure:",squash"`
+	Events   Events   `mapstructure:",squash"`
 	Lock     Lock     `mapstructure:",squash"`
 	Cache    Cache    `mapstructure:",squash"`
 	GRPC     GRPC     `mapstructure:",squash"`
@@ -15,9 +15,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/liveness": {
+        "/accounts/{uid}/balance": {
             "get": {
-                "description": "Check API Health Liveness with some app data",
+                "description": "Returns the available balance of each category of the account, with its priority and MCCs, and the total. With **includeHolds** the amounts reserved by pending authorizations are also returned.",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,72 +25,2706 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "API"
+                    "Account"
+                ],
+                "summary": "Account Balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the account",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include amounts reserved by pending authorization holds",
+                        "name": "includeHolds",
+                        "in": "query"
+                    }
                 ],
-                "summary": "API Health Liveness",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/port.APIhealthResponse"
+                            "$ref": "#/definitions/port.BalanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{uid}/transactions": {
+            "get": {
+                "description": "Lists the transactions of an account from the newest to the oldest, with the merchant, MCC, category debited or credited and the resulting category balance. Use **nextCursor** of the response as **cursor** to retrieve the next page.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Account Transactions History",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the account",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 inclusive lower bound of the transaction date",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 exclusive upper bound of the transaction date",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category name",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant Category Code",
+                        "name": "mcc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant name, partial match",
+                        "name": "merchant",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.TransactionHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/accounts": {
+            "get": {
+                "description": "Lists the active accounts with their categories and MCCs, from the oldest to the newest. Use **nextCursor** of the response as **cursor** to retrieve the next page.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin List Accounts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor returned by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.AccountListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates an account without categories. Attach categories to it to allow payments.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Create Account",
+                "parameters": [
+                    {
+                        "description": "Request body for Account creation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.AccountCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/port.AccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/accounts/{uid}": {
+            "delete": {
+                "description": "Soft deletes the account and the links to its categories. The transactions history is kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Delete Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the account",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/accounts/{uid}/block": {
+            "post": {
+                "description": "Blocks an **ACTIVE** account. Payments and authorizations of a blocked account are **rejected by account blocked** (code **62**), while credits and refunds still reach it. The body is optional, its **reason** is kept in the audit trail of the account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Block Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the account",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body for Account Status",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/port.AccountStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.AccountStatusChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/accounts/{uid}/cancel": {
+            "post": {
+                "description": "Cancels an **ACTIVE** or **BLOCKED** account, a cancellation is final. Every transaction of a cancelled account is **rejected by account cancelled** (code **46**). The body is optional, its **reason** is kept in the audit trail of the account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Cancel Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the account",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body for Account Status",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/port.AccountStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.AccountStatusChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/accounts/{uid}/cards": {
+            "get": {
+                "description": "Lists the cards of the account, oldest first. The card tokens are never returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin List Cards",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the account",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.CardListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a tokenized card of the account. Payments may send the card **token** instead of the account UUID. The **maskedPan** keeps only the first six and last four digits, the **expiry** is the MM/YY printed on the card, and a zero cap is not enforced. Payments with a blocked or cancelled card are **rejected by card not permitted** (code **57**), with an expired card **rejected by expired card** (code **54**) and breaching a card cap **rejected by limit exceeded** (code **61**).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Create Card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the account",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body for Create Card",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.CardCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/port.CardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
                         }
                     }
                 }
             }
         },
-        "/payment": {
-            "post": {
-                "description": "Payment executes a transaction  based on the request body json data. The HTTP status is always 200. The transaction can be **approved** (code **00**), **rejected insufficient balance** (code **51**), or **rejected generally** (code **07**). [See more here](https://github.com/jtonynet/go-payments-api/tree/main?tab=readme-ov-file#about)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Payment"
-                ],
-                "summary": "Payment Execute Transaction",
-                "parameters": [
-                    {
-                        "description": "Request body for Execute Transaction Payment",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/port.TransactionPaymentRequest"
-                        }
+        "/admin/accounts/{uid}/categories/{categoryUID}": {
+            "post": {
+                "description": "Attaches a category to the account. A zero balance is opened for the category when the account never had one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Attach Category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the account",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of the category",
+                        "name": "categoryUID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Detaches a category from the account. The category balance is kept and restored if the category is attached again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Detach Category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the account",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of the category",
+                        "name": "categoryUID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/accounts/{uid}/limits": {
+            "get": {
+                "description": "Lists the spending limits of the account, the limits of the whole account first and then the limits of its categories.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin List Spending Limits",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the account",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.SpendingLimitListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the spending limits of the account, or of one of its categories when **category** is given: daily and monthly amount caps, a single transaction amount cap and a cap of transactions per hour. The days, months and hours are UTC windows, and a zero cap is not enforced. All caps zero remove the limits. Payments and authorizations breaching a limit are **rejected by limit exceeded** (code **61**).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Set Spending Limits",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the account",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body for Spending Limits",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.SpendingLimitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.SpendingLimitResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/accounts/{uid}/lock-queue": {
+            "get": {
+                "description": "Returns how many transactions of the account are waiting for its distributed lock. Waiters are granted the lock in arrival order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Get Account Lock Queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the account",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.MemoryLockQueueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/accounts/{uid}/status-changes": {
+            "get": {
+                "description": "Lists the audit trail of the account status changes, oldest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin List Account Status Changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the account",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.AccountStatusChangeListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/accounts/{uid}/unblock": {
+            "post": {
+                "description": "Unblocks a **BLOCKED** account, back to **ACTIVE**. The body is optional, its **reason** is kept in the audit trail of the account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Unblock Account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the account",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body for Account Status",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/port.AccountStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.AccountStatusChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/cards/{uid}": {
+            "put": {
+                "description": "Replaces the **status** (ACTIVE, BLOCKED or CANCELLED), the **expiry** and the caps of the card. A cancelled card can't be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Update Card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the card",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body for Update Card",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.CardUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.CardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/categories": {
+            "post": {
+                "description": "Creates a category. Categories with lower priority are debited first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Create Category",
+                "parameters": [
+                    {
+                        "description": "Request body for Category creation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.CategoryCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/port.CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/categories/{uid}/mccs": {
+            "post": {
+                "description": "Assigns a Merchant Category Code to the category. An MCC belongs to a single active category.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Assign MCC",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the category",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body for MCC assignment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.MCCAssignRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/port.MCCResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/category-rules": {
+            "get": {
+                "description": "Lists the default category rules, along with the overrides of the account when given. Each rule lists its fallback categories in the order they are tried.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin List Category Rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the account whose overrides are listed",
+                        "name": "account",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.CategoryRuleListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the ordered fallback chain tried once the category matched by the MCC runs out of funds, e.g. **MEAL → FOOD → CASH**. Without **category** the chain applies to transactions whose MCC matches no category. Without **account** the chain is the default of every account, otherwise an override of the account. Categories created with **fallbackExcluded** are never accepted as fallbacks. An empty **fallbacks** removes the chain.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Set Category Rule",
+                "parameters": [
+                    {
+                        "description": "Request body for Category Rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.CategoryRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.CategoryRuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/ledger/consistency": {
+            "get": {
+                "description": "Recomputes the category balances of a page of accounts from their ledger entries, the credits and debits of each movement. Reports the balances that differ from the recorded ones, or whose entries have a **balance before** different from the **balance after** of the previous entry. Use **nextCursor** of the response as **cursor** to check the next page.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Check Ledger Consistency",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the account, every account when empty",
+                        "name": "account",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Accounts per page, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.LedgerConsistencyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/merchants": {
+            "get": {
+                "description": "Lists the active merchants, from the oldest to the newest. Use **nextCursor** of the response as **cursor** to retrieve the next page.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin List Merchants",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor returned by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.MerchantListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a merchant to correct the MCC of the transactions sent with its name. The MCC must be assigned to a category. Names that do not match exactly are normalized (casing, padding, city and country columns) and matched against the merchants and their **aliases**; an alias ending with ` + "`" + `*` + "`" + ` is a prefix rule, such as ` + "`" + `PAG*` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Create Merchant",
+                "parameters": [
+                    {
+                        "description": "Request body for Merchant creation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.MerchantCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/port.MerchantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/merchants/{uid}": {
+            "get": {
+                "description": "Retrieves an active merchant.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Get Merchant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the merchant",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.MerchantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the name and the MCC of the merchant. The cached MCC of both the previous and the new name are evicted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Update Merchant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the merchant",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body for Merchant update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.MerchantUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.MerchantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft deletes the merchant. Its transactions are no longer corrected and keep the MCC they are sent with.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin Delete Merchant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the merchant",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit": {
+            "post": {
+                "description": "Credits an amount into a category attached to the account. The HTTP status is always 200. The credit can be **approved** (code **00**), **rejected invalid transaction** (code **12**) when the category is not attached to the account, **rejected invalid amount** (code **13**), **rejected invalid account** (code **14**), **rejected by account cancelled** (code **46**), **rejected by system timeout** (code **91**), **rejected as duplicate transaction** (code **94**) or **rejected generally** (code **07**).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit"
+                ],
+                "summary": "Credit Transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client UUID of the credit, retries with the same key replay the original response code",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Request body for Credit Transaction",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.TransactionCreditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.TransactionPaymentResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/credit/batch": {
+            "post": {
+                "description": "Credits a payroll file of up to 100000 credits in a single call. Credits of the same account are applied in the file order. Each **transaction** is the idempotency key of its line, so the file can be resent after a partial failure; lines without it get a new UUID. The response counts the approved and rejected credits and lists the rejections by their **index** in the file, with the **code** and **reason** of each.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Credit"
+                ],
+                "summary": "Credit Batch",
+                "parameters": [
+                    {
+                        "description": "Request body for Credit Batch",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.TransactionCreditBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.TransactionCreditBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIerrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/liveness": {
+            "get": {
+                "description": "Check API Health Liveness with some app data",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API"
+                ],
+                "summary": "API Health Liveness",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.APIhealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment": {
+            "post": {
+                "description": "Payment executes a transaction  based on the request body json data. The HTTP status is always 200. The transaction can be **approved** (code **00**), **rejected invalid transaction** (code **12**) when its currency is not supported or cannot be converted, **rejected invalid amount** (code **13**), **rejected invalid account** (code **14**), **rejected by account cancelled** (code **46**), **rejected insufficient balance** (code **51**), **rejected by expired card** (code **54**), **rejected by card not permitted** (code **57**), **rejected as suspected fraud** by the fraud rules (code **59**), **rejected by limit exceeded** (code **61**), **rejected by account blocked** (code **62**), **rejected by system timeout** (code **91**), **rejected as duplicate transaction** (code **94**), or **rejected generally** (code **07**). The **reason** carries the machine-readable name of the code. The account can be identified by a registered **card** token instead of its UUID, the card is then checked for its status, expiry and limits and recorded on the ledger rows. [See more here](https://github.com/jtonynet/go-payments-api/tree/main?tab=readme-ov-file#about)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Payment Execute Transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client UUID of the transaction, retries with the same key replay the original response code",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Request body for Execute Transaction Payment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.TransactionPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.TransactionPaymentResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/authorize": {
+            "post": {
+                "description": "Payment authorizes a transaction based on the request body json data, reserving the funds per category without posting it. The hold must be captured or voided before it expires. The HTTP status is always 200. The authorization can be **approved** (code **00**), **rejected invalid transaction** (code **12**) when its currency is not supported or cannot be converted, **rejected invalid amount** (code **13**), **rejected invalid account** (code **14**), **rejected by account cancelled** (code **46**), **rejected insufficient balance** (code **51**), **rejected by expired card** (code **54**), **rejected by card not permitted** (code **57**), **rejected as suspected fraud** by the fraud rules (code **59**), **rejected by limit exceeded** (code **61**), **rejected by account blocked** (code **62**), **rejected by system timeout** (code **91**), **rejected as duplicate transaction** (code **94**), or **rejected generally** (code **07**). The **reason** carries the machine-readable name of the code. The account can be identified by a registered **card** token instead of its UUID, as in the payment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Payment Authorize Transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client UUID of the transaction, retries with the same key replay the original response code",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Request body for Authorize Transaction Payment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.TransactionPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.TransactionPaymentResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/{transactionUID}/capture": {
+            "post": {
+                "description": "Payment captures an authorization hold, posting the reserved amounts. The HTTP status is always 200. The capture can be **approved** (code **00**), **rejected invalid transaction** (code **12**) when the hold expired, **rejected invalid account** (code **14**), **rejected record not found** (code **25**) when the hold is not found, **rejected by limit exceeded** (code **61**), **rejected by system timeout** (code **91**), **rejected as duplicate transaction** (code **94**) or **rejected generally** (code **07**).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Payment Capture Authorization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the authorized transaction",
+                        "name": "transactionUID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body for Capture Authorization",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.TransactionHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.TransactionPaymentResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/{transactionUID}/refund": {
+            "post": {
+                "description": "Payment refunds, totally or partially, a previously approved transaction, restoring the amounts to the categories debited. The **totalAmount** is in the currency of the original payment, converted with the rate the payment was converted with. The HTTP status is always 200. The refund can be **approved** (code **00**), **rejected invalid amount** (code **13**), e.g. when the amount exceeds what was captured, **rejected invalid account** (code **14**), **rejected record not found** (code **25**) when the original transaction is not found, **rejected by account cancelled** (code **46**), **rejected by system timeout** (code **91**), **rejected as duplicate transaction** (code **94**) or **rejected generally** (code **07**).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Payment Refund Transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the original transaction",
+                        "name": "transactionUID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client UUID of the refund, retries with the same key replay the original response code",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Request body for Refund Transaction Payment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.TransactionRefundRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.TransactionPaymentResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/{transactionUID}/void": {
+            "post": {
+                "description": "Payment voids an authorization hold, releasing the reserved amounts. The HTTP status is always 200. The void can be **approved** (code **00**), **rejected record not found** (code **25**) when the hold is not found, **rejected by system timeout** (code **91**) or **rejected generally** (code **07**).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Payment Void Authorization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the authorized transaction",
+                        "name": "transactionUID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body for Void Authorization",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.TransactionHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.TransactionPaymentResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "port.APIerrorResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "field Limit is invalid"
+                }
+            }
+        },
+        "port.APIhealthResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "sumary": {
+                    "type": "string",
+                    "example": "payments-api:8080 in TagVersion: 0.0.0 on Envoriment:dev responds OK"
+                }
+            }
+        },
+        "port.AccountCreateRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "currencyConversion": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3,
+                    "example": "Jonh Doe"
+                }
+            }
+        },
+        "port.AccountListResponse": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.AccountResponse"
+                    }
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "MQ"
+                }
+            }
+        },
+        "port.AccountResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.CategoryResponse"
+                    }
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-12-04T21:50:21Z"
+                },
+                "currencyConversion": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Jonh Doe"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ACTIVE"
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "port.AccountStatusChangeListResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.AccountStatusChangeResponse"
+                    }
+                }
+            }
+        },
+        "port.AccountStatusChangeResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-12-04T21:50:21Z"
+                },
+                "previousStatus": {
+                    "type": "string",
+                    "example": "ACTIVE"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "card reported stolen"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "BLOCKED"
+                }
+            }
+        },
+        "port.AccountStatusRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "card reported stolen"
+                }
+            }
+        },
+        "port.BalanceCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 105.02
+                },
+                "amountHeld": {
+                    "type": "number",
+                    "example": 10
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "mccs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "5411",
+                        "5412"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "FOOD"
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "port.BalanceResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "amountHeld": {
+                    "type": "number",
+                    "example": 10
+                },
+                "amountTotal": {
+                    "type": "number",
+                    "example": 930.66
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.BalanceCategoryResponse"
+                    }
+                }
+            }
+        },
+        "port.CardCreateRequest": {
+            "type": "object",
+            "required": [
+                "expiry",
+                "maskedPan",
+                "token"
+            ],
+            "properties": {
+                "dailyAmount": {
+                    "type": "number",
+                    "example": 150
+                },
+                "expiry": {
+                    "type": "string",
+                    "example": "12/30"
+                },
+                "maskedPan": {
+                    "type": "string",
+                    "example": "411111******1111"
+                },
+                "token": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 8,
+                    "example": "tok_4f9a2c7e1b3d5a60"
+                },
+                "transactionAmount": {
+                    "type": "number",
+                    "example": 80
+                }
+            }
+        },
+        "port.CardListResponse": {
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.CardResponse"
+                    }
+                }
+            }
+        },
+        "port.CardResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-12-04T21:50:21Z"
+                },
+                "dailyAmount": {
+                    "type": "number",
+                    "example": 150
+                },
+                "expiry": {
+                    "type": "string",
+                    "example": "12/30"
+                },
+                "maskedPan": {
+                    "type": "string",
+                    "example": "411111******1111"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ACTIVE"
+                },
+                "transactionAmount": {
+                    "type": "number",
+                    "example": 80
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "5f0c3a4e-8d2b-4c1a-9e7f-2b6d8a1c3e5f"
+                }
+            }
+        },
+        "port.CardUpdateRequest": {
+            "type": "object",
+            "required": [
+                "expiry",
+                "status"
+            ],
+            "properties": {
+                "dailyAmount": {
+                    "type": "number",
+                    "example": 150
+                },
+                "expiry": {
+                    "type": "string",
+                    "example": "12/30"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "BLOCKED"
+                },
+                "transactionAmount": {
+                    "type": "number",
+                    "example": 80
+                }
+            }
+        },
+        "port.CategoryAttemptResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 100.09
+                },
+                "category": {
+                    "type": "string",
+                    "example": "FOOD"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "MCC"
+                }
+            }
+        },
+        "port.CategoryCreateRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "priority"
+            ],
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "fallbackExcluded": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3,
+                    "example": "MOBILITY"
+                },
+                "priority": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                }
+            }
+        },
+        "port.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "fallbackExcluded": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "mccs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "4121"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "MOBILITY"
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "809d8fa8-b726-4ddc-92da-b565fdcad75a"
+                }
+            }
+        },
+        "port.CategoryRuleFallbackResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "FOOD"
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "32e04519-a979-4de2-a20e-77e8342d718f"
+                }
+            }
+        },
+        "port.CategoryRuleListResponse": {
+            "type": "object",
+            "properties": {
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.CategoryRuleResponse"
+                    }
+                }
+            }
+        },
+        "port.CategoryRuleRequest": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "e5ce3deb-7dea-4382-a1fd-1428c9888bdc"
+                },
+                "fallbacks": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "32e04519-a979-4de2-a20e-77e8342d718f",
+                        "4cfdc9f0-a9d8-409d-ba8e-58a36e126ec1"
+                    ]
+                }
+            }
+        },
+        "port.CategoryRuleResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "e5ce3deb-7dea-4382-a1fd-1428c9888bdc"
+                },
+                "fallbacks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.CategoryRuleFallbackResponse"
+                    }
+                }
+            }
+        },
+        "port.LedgerBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "balance": {
+                    "type": "number",
+                    "example": 105.02
+                },
+                "brokenEntries": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category": {
+                    "type": "string",
+                    "example": "FOOD"
+                },
+                "ledgerBalance": {
+                    "type": "number",
+                    "example": 100.02
+                }
+            }
+        },
+        "port.LedgerConsistencyResponse": {
+            "type": "object",
+            "properties": {
+                "checked": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "inconsistencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.LedgerBalanceResponse"
+                    }
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "MQ"
+                }
+            }
+        },
+        "port.MCCAssignRequest": {
+            "type": "object",
+            "required": [
+                "mcc"
+            ],
+            "properties": {
+                "mcc": {
+                    "type": "string",
+                    "maxLength": 4,
+                    "minLength": 4,
+                    "example": "4121"
+                }
+            }
+        },
+        "port.MCCResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "809d8fa8-b726-4ddc-92da-b565fdcad75a"
+                },
+                "mcc": {
+                    "type": "string",
+                    "example": "4121"
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "3f77143d-28bb-4d7f-bcf7-0ecff815aab4"
+                }
+            }
+        },
+        "port.MemoryLockQueueResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "depth": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "port.MerchantCreateRequest": {
+            "type": "object",
+            "required": [
+                "mcc",
+                "name"
+            ],
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "UBER*"
+                    ]
+                },
+                "mcc": {
+                    "type": "string",
+                    "maxLength": 4,
+                    "minLength": 4,
+                    "example": "5412"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3,
+                    "example": "UBER EATS                   SAO PAULO BR"
+                }
+            }
+        },
+        "port.MerchantListResponse": {
+            "type": "object",
+            "properties": {
+                "merchants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.MerchantResponse"
+                    }
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "MQ"
+                }
+            }
+        },
+        "port.MerchantResponse": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "UBER*"
+                    ]
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-12-04T21:50:21Z"
+                },
+                "mcc": {
+                    "type": "string",
+                    "example": "5412"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "UBER EATS                   SAO PAULO BR"
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "0b0364a1-4955-48b6-8c63-8a446b918682"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2024-12-04T21:50:21Z"
+                }
+            }
+        },
+        "port.MerchantUpdateRequest": {
+            "type": "object",
+            "required": [
+                "mcc",
+                "name"
+            ],
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "UBER*"
+                    ]
+                },
+                "mcc": {
+                    "type": "string",
+                    "maxLength": 4,
+                    "minLength": 4,
+                    "example": "5412"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3,
+                    "example": "UBER EATS                   SAO PAULO BR"
+                }
+            }
+        },
+        "port.SpendingLimitListResponse": {
+            "type": "object",
+            "properties": {
+                "limits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.SpendingLimitResponse"
                     }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/port.TransactionPaymentResponse"
-                        }
+                }
+            }
+        },
+        "port.SpendingLimitRequest": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "e5ce3deb-7dea-4382-a1fd-1428c9888bdc"
+                },
+                "dailyAmount": {
+                    "type": "number",
+                    "example": 150
+                },
+                "hourlyTransactions": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "monthlyAmount": {
+                    "type": "number",
+                    "example": 1500
+                },
+                "transactionAmount": {
+                    "type": "number",
+                    "example": 80
+                }
+            }
+        },
+        "port.SpendingLimitResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "e5ce3deb-7dea-4382-a1fd-1428c9888bdc"
+                },
+                "categoryName": {
+                    "type": "string",
+                    "example": "MEAL"
+                },
+                "dailyAmount": {
+                    "type": "number",
+                    "example": 150
+                },
+                "hourlyTransactions": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "monthlyAmount": {
+                    "type": "number",
+                    "example": 1500
+                },
+                "transactionAmount": {
+                    "type": "number",
+                    "example": 80
+                }
+            }
+        },
+        "port.TransactionCreditBatchItemRequest": {
+            "type": "object",
+            "required": [
+                "account",
+                "category",
+                "totalAmount"
+            ],
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3,
+                    "example": "FOOD"
+                },
+                "totalAmount": {
+                    "type": "number",
+                    "minimum": 0.01,
+                    "example": 500
+                },
+                "transaction": {
+                    "type": "string",
+                    "example": "3f77143d-28bb-4d7f-bcf7-0ecff815aab4"
+                }
+            }
+        },
+        "port.TransactionCreditBatchRequest": {
+            "type": "object",
+            "required": [
+                "credits"
+            ],
+            "properties": {
+                "credits": {
+                    "type": "array",
+                    "maxItems": 100000,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/port.TransactionCreditBatchItemRequest"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "port.APIhealthResponse": {
+        },
+        "port.TransactionCreditBatchResponse": {
             "type": "object",
             "properties": {
-                "message": {
+                "approved": {
+                    "type": "integer",
+                    "example": 99999
+                },
+                "rejected": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rejections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.TransactionCreditRejectionResponse"
+                    }
+                }
+            }
+        },
+        "port.TransactionCreditRejectionResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
                     "type": "string",
-                    "example": "OK"
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
                 },
-                "sumary": {
+                "code": {
                     "type": "string",
-                    "example": "payments-api:8080 in TagVersion: 0.0.0 on Envoriment:dev responds OK"
+                    "example": "14"
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "INVALID_ACCOUNT"
+                },
+                "transaction": {
+                    "type": "string",
+                    "example": "3f77143d-28bb-4d7f-bcf7-0ecff815aab4"
                 }
             }
         },
-        "port.TransactionPaymentRequest": {
+        "port.TransactionCreditRequest": {
             "type": "object",
             "required": [
                 "account",
+                "category",
+                "totalAmount"
+            ],
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3,
+                    "example": "FOOD"
+                },
+                "totalAmount": {
+                    "type": "number",
+                    "minimum": 0.01,
+                    "example": 500
+                }
+            }
+        },
+        "port.TransactionHistoryItemResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": -100.09
+                },
+                "balance": {
+                    "type": "number",
+                    "example": 105.02
+                },
+                "category": {
+                    "type": "string",
+                    "example": "FOOD"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-12-04T21:50:21Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "mcc": {
+                    "type": "string",
+                    "example": "5411"
+                },
+                "merchant": {
+                    "type": "string",
+                    "example": "PADARIA DO ZE              SAO PAULO BR"
+                },
+                "operation": {
+                    "type": "string",
+                    "example": "AUTHORIZATION"
+                },
+                "original": {
+                    "type": "string",
+                    "example": "91ee2159-f59f-4c89-a543-81987d563d7a"
+                },
+                "transaction": {
+                    "type": "string",
+                    "example": "91ee2159-f59f-4c89-a543-81987d563d7a"
+                }
+            }
+        },
+        "port.TransactionHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "nextCursor": {
+                    "type": "string",
+                    "example": "MTIz"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.TransactionHistoryItemResponse"
+                    }
+                }
+            }
+        },
+        "port.TransactionHoldRequest": {
+            "type": "object",
+            "required": [
+                "account"
+            ],
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "port.TransactionPaymentRequest": {
+            "type": "object",
+            "required": [
                 "mcc",
                 "merchant",
                 "totalAmount"
@@ -100,6 +2734,16 @@ const docTemplate = `{
                     "type": "string",
                     "example": "123e4567-e89b-12d3-a456-426614174000"
                 },
+                "card": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 8,
+                    "example": "tok_4f9a2c7e1b3d5a60"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
                 "mcc": {
                     "type": "string",
                     "maxLength": 4,
@@ -122,9 +2766,37 @@ const docTemplate = `{
         "port.TransactionPaymentResponse": {
             "type": "object",
             "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.CategoryAttemptResponse"
+                    }
+                },
                 "code": {
                     "type": "string",
                     "example": "00"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "APPROVED"
+                }
+            }
+        },
+        "port.TransactionRefundRequest": {
+            "type": "object",
+            "required": [
+                "account",
+                "totalAmount"
+            ],
+            "properties": {
+                "account": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "totalAmount": {
+                    "type": "number",
+                    "minimum": 0.01,
+                    "example": 50.05
                 }
             }
         }
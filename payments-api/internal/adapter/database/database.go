@@ -39,6 +39,8 @@ func NewInMemory(cfg config.InMemoryDatabase) (InMemory, error) {
 	switch cfg.Strategy {
 	case "redis":
 		return NewRedisClient(cfg)
+	case "memory":
+		return NewMemoryClient(cfg)
 	default:
 		return nil, fmt.Errorf("InMemoryDB strategy not suported: %s", cfg.Strategy)
 	}
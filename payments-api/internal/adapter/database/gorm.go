@@ -32,6 +32,16 @@ func NewGormConn(cfg config.Database) (Conn, error) {
 			return nil, fmt.Errorf("failure on database connection: %w", err)
 		}
 
+		if cfg.MaxOpenConns > 0 {
+			rawDB, err := db.DB()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get database instance: %w", err)
+			}
+
+			rawDB.SetMaxOpenConns(cfg.MaxOpenConns)
+			rawDB.SetMaxIdleConns(cfg.MaxOpenConns)
+		}
+
 		if cfg.MetricEnabled {
 			pushGatewayHost := fmt.Sprintf(`%s:%s`, cfg.MetricServerHost, fmt.Sprint(cfg.MetricServerPort))
 
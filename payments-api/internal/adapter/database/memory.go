@@ -0,0 +1,246 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jtonynet/go-payments-api/config"
+)
+
+var ErrMemoryKeyNotFound = errors.New("memory: nil")
+
+var (
+	memoryDatabasesMu sync.Mutex
+	memoryDatabases   = make(map[int]*MemoryClient)
+)
+
+type memoryEntry struct {
+	value     string
+	timer     *time.Timer
+	expiresAt time.Time
+}
+
+/*
+  - In process implementation of `InMemory` for single node and test mode,
+    without a Redis server
+  - Each `DB` number is a single database shared by the whole process, so the
+    lock and the pub/sub configured with the same `DB` see the same keys
+  - Keys expire on their TTL, notifying the expired key to the subscribers
+    of the database, as the Redis `expired` keyspace notification does
+*/
+type MemoryClient struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+
+	subscribersMu sync.Mutex
+	subscribers   map[chan string]struct{}
+
+	strategy   string
+	expiration time.Duration
+}
+
+func NewMemoryClient(cfg config.InMemoryDatabase) (*MemoryClient, error) {
+	client := SharedMemoryClient(cfg.DB)
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	client.strategy = cfg.Strategy
+	client.expiration = time.Duration(cfg.Expiration * int(time.Millisecond))
+
+	return client, nil
+}
+
+/*
+- Returns the database of the `DB` number, created on its first use
+*/
+func SharedMemoryClient(db int) *MemoryClient {
+	memoryDatabasesMu.Lock()
+	defer memoryDatabasesMu.Unlock()
+
+	client, ok := memoryDatabases[db]
+	if !ok {
+		client = &MemoryClient{
+			entries:     make(map[string]*memoryEntry),
+			subscribers: make(map[chan string]struct{}),
+			strategy:    "memory",
+		}
+		memoryDatabases[db] = client
+	}
+
+	return client
+}
+
+func (c *MemoryClient) Readiness(_ context.Context) error {
+	return nil
+}
+
+func (c *MemoryClient) GetStrategy(_ context.Context) (string, error) {
+	return c.strategy, nil
+}
+
+func (c *MemoryClient) Set(_ context.Context, key string, value interface{}, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(key, string(data), expiration)
+	return nil
+}
+
+/*
+- Sets the raw value only when the key does not exist, reporting if it was set
+*/
+func (c *MemoryClient) SetNX(_ context.Context, key string, value string, expiration time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.entries[key]; exists {
+		return false, nil
+	}
+
+	c.set(key, value, expiration)
+	return true, nil
+}
+
+func (c *MemoryClient) Get(_ context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return "", ErrMemoryKeyNotFound
+	}
+
+	if entry.value == "" {
+		return "", errors.New("get data empty")
+	}
+
+	return entry.value, nil
+}
+
+func (c *MemoryClient) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		c.stopTimer(entry)
+		delete(c.entries, key)
+	}
+
+	return nil
+}
+
+/*
+- A non positive expiration expires the key right away, notifying it
+*/
+func (c *MemoryClient) Expire(_ context.Context, key string, expiration time.Duration) error {
+	c.mu.Lock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		return nil
+	}
+
+	if expiration > 0 {
+		c.expireAfter(key, entry, expiration)
+		c.mu.Unlock()
+		return nil
+	}
+
+	c.stopTimer(entry)
+	delete(c.entries, key)
+	c.mu.Unlock()
+
+	c.notifyExpired(key)
+	return nil
+}
+
+func (c *MemoryClient) GetDefaultExpiration(_ context.Context) (time.Duration, error) {
+	return c.expiration, nil
+}
+
+func (c *MemoryClient) GetClient(_ context.Context) (interface{}, error) {
+	return c, nil
+}
+
+/*
+- Receives each expired key of the database until `unsubscribe` is called
+*/
+func (c *MemoryClient) SubscribeExpired() (<-chan string, func()) {
+	listenerBufferSize := 64
+	expired := make(chan string, listenerBufferSize)
+
+	c.subscribersMu.Lock()
+	c.subscribers[expired] = struct{}{}
+	c.subscribersMu.Unlock()
+
+	unsubscribe := func() {
+		c.subscribersMu.Lock()
+		defer c.subscribersMu.Unlock()
+
+		if _, ok := c.subscribers[expired]; ok {
+			delete(c.subscribers, expired)
+			close(expired)
+		}
+	}
+
+	return expired, unsubscribe
+}
+
+func (c *MemoryClient) set(key, value string, expiration time.Duration) {
+	if entry, ok := c.entries[key]; ok {
+		c.stopTimer(entry)
+	}
+
+	entry := &memoryEntry{value: value}
+	c.entries[key] = entry
+
+	if expiration > 0 {
+		c.expireAfter(key, entry, expiration)
+	}
+}
+
+func (c *MemoryClient) expireAfter(key string, entry *memoryEntry, expiration time.Duration) {
+	c.stopTimer(entry)
+
+	entry.expiresAt = time.Now().Add(expiration)
+	entry.timer = time.AfterFunc(expiration, func() {
+		c.mu.Lock()
+		if c.entries[key] != entry || time.Now().Before(entry.expiresAt) {
+			c.mu.Unlock()
+			return
+		}
+		delete(c.entries, key)
+		c.mu.Unlock()
+
+		c.notifyExpired(key)
+	})
+}
+
+func (c *MemoryClient) stopTimer(entry *memoryEntry) {
+	if entry.timer != nil {
+		entry.timer.Stop()
+		entry.timer = nil
+	}
+}
+
+func (c *MemoryClient) notifyExpired(key string) {
+	c.subscribersMu.Lock()
+	defer c.subscribersMu.Unlock()
+
+	for subscriber := range c.subscribers {
+		select {
+		case subscriber <- key:
+		default:
+		}
+	}
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.idx_transactions_original_uid;
+DROP INDEX IF EXISTS public.idx_transactions_uid;
+ALTER TABLE public.transactions DROP COLUMN IF EXISTS original_uid;
@@ -0,0 +1,3 @@
+ALTER TABLE public.transactions ADD COLUMN original_uid uuid NULL;
+CREATE INDEX idx_transactions_uid ON public.transactions USING btree (uid);
+CREATE INDEX idx_transactions_original_uid ON public.transactions USING btree (original_uid);
@@ -0,0 +1,21 @@
+CREATE TABLE public.holds (
+    id bigserial NOT NULL,
+    created_at timestamptz NULL,
+    updated_at timestamptz NULL,
+    deleted_at timestamptz NULL,
+    uid uuid NOT NULL,
+    account_id int8 NOT NULL,
+    category_id int8 NOT NULL,
+    amount numeric(20, 2) NOT NULL,
+    mcc varchar(5) NULL,
+    merchant_name varchar(255) NULL,
+    status varchar(20) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    CONSTRAINT holds_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_categories_holds FOREIGN KEY (category_id) REFERENCES public.categories(id),
+    CONSTRAINT fk_holds_account FOREIGN KEY (account_id) REFERENCES public.accounts(id)
+);
+CREATE INDEX idx_holds_deleted_at ON public.holds USING btree (deleted_at);
+CREATE INDEX idx_holds_uid ON public.holds USING btree (uid);
+CREATE UNIQUE INDEX idx_holds_uid_category_id ON public.holds USING btree (uid, category_id);
+CREATE INDEX idx_holds_active ON public.holds USING btree (account_id, category_id, status, expires_at);
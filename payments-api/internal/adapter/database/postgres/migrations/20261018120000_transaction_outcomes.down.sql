@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.transaction_outcomes;
+DROP INDEX IF EXISTS public.idx_transactions_uid_category_id;
@@ -0,0 +1,15 @@
+CREATE UNIQUE INDEX idx_transactions_uid_category_id ON public.transactions USING btree (uid, category_id);
+
+CREATE TABLE public.transaction_outcomes (
+    id bigserial NOT NULL,
+    created_at timestamptz NULL,
+    updated_at timestamptz NULL,
+    deleted_at timestamptz NULL,
+    uid uuid NOT NULL,
+    account_id int8 NOT NULL,
+    code varchar(2) NOT NULL,
+    CONSTRAINT transaction_outcomes_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_transaction_outcomes_account FOREIGN KEY (account_id) REFERENCES public.accounts(id)
+);
+CREATE INDEX idx_transaction_outcomes_deleted_at ON public.transaction_outcomes USING btree (deleted_at);
+CREATE UNIQUE INDEX idx_transaction_outcomes_uid ON public.transaction_outcomes USING btree (uid);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public.idx_account_categories_active;
+DROP INDEX IF EXISTS public.idx_mccs_mcc_active;
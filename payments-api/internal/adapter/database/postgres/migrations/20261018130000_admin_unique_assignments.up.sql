@@ -0,0 +1,2 @@
+CREATE UNIQUE INDEX idx_mccs_mcc_active ON public.mccs USING btree (mcc) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_account_categories_active ON public.account_categories USING btree (account_id, category_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public.idx_merchants_name_active;
+CREATE UNIQUE INDEX idx_merchants_name ON public.merchants USING btree ("name");
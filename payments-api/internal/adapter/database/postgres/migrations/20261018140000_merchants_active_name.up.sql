@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public.idx_merchants_name;
+CREATE UNIQUE INDEX idx_merchants_name_active ON public.merchants USING btree ("name") WHERE deleted_at IS NULL;
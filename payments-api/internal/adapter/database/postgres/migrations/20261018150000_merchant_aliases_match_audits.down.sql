@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.merchant_match_audits;
+DROP TABLE IF EXISTS public.merchant_aliases;
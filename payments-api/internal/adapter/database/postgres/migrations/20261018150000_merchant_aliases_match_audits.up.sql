@@ -0,0 +1,32 @@
+CREATE TABLE public.merchant_aliases (
+    id bigserial NOT NULL,
+    created_at timestamptz NULL,
+    updated_at timestamptz NULL,
+    deleted_at timestamptz NULL,
+    merchant_id int8 NOT NULL,
+    alias varchar(255) NOT NULL,
+    CONSTRAINT merchant_aliases_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_merchant_aliases_merchant FOREIGN KEY (merchant_id) REFERENCES public.merchants(id)
+);
+CREATE INDEX idx_merchant_aliases_deleted_at ON public.merchant_aliases USING btree (deleted_at);
+CREATE INDEX idx_merchant_aliases_merchant_id ON public.merchant_aliases USING btree (merchant_id);
+CREATE UNIQUE INDEX idx_merchant_aliases_alias_active ON public.merchant_aliases USING btree (alias) WHERE deleted_at IS NULL;
+
+CREATE TABLE public.merchant_match_audits (
+    id bigserial NOT NULL,
+    created_at timestamptz NULL,
+    updated_at timestamptz NULL,
+    deleted_at timestamptz NULL,
+    transaction_uid uuid NOT NULL,
+    account_id int8 NOT NULL,
+    merchant_name varchar(255) NOT NULL,
+    matched_merchant varchar(255) NOT NULL,
+    match_rule varchar(20) NOT NULL,
+    match_pattern varchar(255) NOT NULL,
+    original_mcc varchar(5) NOT NULL,
+    mcc varchar(5) NOT NULL,
+    CONSTRAINT merchant_match_audits_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_merchant_match_audits_account FOREIGN KEY (account_id) REFERENCES public.accounts(id)
+);
+CREATE INDEX idx_merchant_match_audits_deleted_at ON public.merchant_match_audits USING btree (deleted_at);
+CREATE INDEX idx_merchant_match_audits_transaction_uid ON public.merchant_match_audits USING btree (transaction_uid);
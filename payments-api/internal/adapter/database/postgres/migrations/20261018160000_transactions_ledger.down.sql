@@ -0,0 +1,25 @@
+CREATE OR REPLACE FUNCTION update_latest_transaction() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO transactions_latest (account_id, category_id, transactions_latest_id, amount)
+    VALUES (NEW.account_id, NEW.category_id, NEW.id, NEW.amount)
+    ON CONFLICT (account_id, category_id)
+    DO UPDATE SET transactions_latest_id = EXCLUDED.transactions_latest_id,
+                  amount = EXCLUDED.amount;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS public.idx_transactions_ledger;
+
+ALTER TABLE public.transactions
+    DROP CONSTRAINT IF EXISTS chk_transactions_operation,
+    DROP CONSTRAINT IF EXISTS chk_transactions_entry_type,
+    DROP CONSTRAINT IF EXISTS chk_transactions_amount;
+
+UPDATE public.transactions SET amount = balance_after;
+
+ALTER TABLE public.transactions
+    DROP COLUMN IF EXISTS balance_after,
+    DROP COLUMN IF EXISTS balance_before,
+    DROP COLUMN IF EXISTS entry_type,
+    DROP COLUMN IF EXISTS operation;
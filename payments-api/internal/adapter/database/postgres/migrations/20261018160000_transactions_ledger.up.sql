@@ -0,0 +1,48 @@
+-- Ledger entries: `amount` becomes the debited or credited amount of the movement.
+-- Rows without these columns (seed balance charges) are opening ADJUSTMENT credits.
+ALTER TABLE public.transactions
+    ADD COLUMN operation varchar(20) NOT NULL DEFAULT 'ADJUSTMENT',
+    ADD COLUMN entry_type varchar(6) NOT NULL DEFAULT 'CREDIT',
+    ADD COLUMN balance_before numeric(20, 2) NOT NULL DEFAULT 0;
+
+-- Converts the balance snapshots: each row stored the category balance after the movement.
+WITH snapshots AS (
+    SELECT
+        id,
+        amount AS balance_after,
+        LAG(amount, 1, 0) OVER (PARTITION BY account_id, category_id ORDER BY id) AS balance_before
+    FROM public.transactions
+)
+UPDATE public.transactions AS t
+SET balance_before = s.balance_before,
+    amount = ABS(s.balance_after - s.balance_before),
+    entry_type = CASE WHEN s.balance_after >= s.balance_before THEN 'CREDIT' ELSE 'DEBIT' END,
+    operation = CASE
+        WHEN t.original_uid IS NOT NULL THEN 'REFUND'
+        WHEN t.uid IS NULL THEN 'ADJUSTMENT'
+        WHEN s.balance_after >= s.balance_before THEN 'CREDIT'
+        ELSE 'AUTHORIZATION'
+    END
+FROM snapshots AS s
+WHERE s.id = t.id;
+
+ALTER TABLE public.transactions
+    ADD COLUMN balance_after numeric(20, 2) GENERATED ALWAYS AS (
+        balance_before + CASE WHEN entry_type = 'DEBIT' THEN -amount ELSE amount END
+    ) STORED,
+    ADD CONSTRAINT chk_transactions_amount CHECK (amount >= 0),
+    ADD CONSTRAINT chk_transactions_entry_type CHECK (entry_type IN ('DEBIT', 'CREDIT')),
+    ADD CONSTRAINT chk_transactions_operation CHECK (operation IN ('AUTHORIZATION', 'REFUND', 'CREDIT', 'ADJUSTMENT'));
+
+CREATE INDEX idx_transactions_ledger ON public.transactions USING btree (account_id, category_id, id);
+
+CREATE OR REPLACE FUNCTION update_latest_transaction() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO transactions_latest (account_id, category_id, transactions_latest_id, amount)
+    VALUES (NEW.account_id, NEW.category_id, NEW.id, NEW.balance_after)
+    ON CONFLICT (account_id, category_id)
+    DO UPDATE SET transactions_latest_id = EXCLUDED.transactions_latest_id,
+                  amount = EXCLUDED.amount;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1 @@
+ALTER TABLE public.accounts DROP COLUMN IF EXISTS fencing_token;
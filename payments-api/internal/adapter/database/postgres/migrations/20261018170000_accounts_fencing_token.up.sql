@@ -0,0 +1 @@
+ALTER TABLE public.accounts ADD COLUMN IF NOT EXISTS fencing_token int8 NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS public.memory_lock_fencing_tokens;
@@ -0,0 +1,5 @@
+CREATE TABLE public.memory_lock_fencing_tokens (
+    "key" varchar(255) NOT NULL,
+    fencing_token int8 NOT NULL DEFAULT 0,
+    CONSTRAINT memory_lock_fencing_tokens_pkey PRIMARY KEY ("key")
+);
@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS public.exchange_rates;
+
+ALTER TABLE public.holds
+    DROP COLUMN IF EXISTS exchange_rate,
+    DROP COLUMN IF EXISTS original_currency,
+    DROP COLUMN IF EXISTS original_amount,
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE public.transactions
+    DROP COLUMN IF EXISTS exchange_rate,
+    DROP COLUMN IF EXISTS original_currency,
+    DROP COLUMN IF EXISTS original_amount,
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE public.categories DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE public.accounts DROP COLUMN IF EXISTS currency_conversion;
@@ -0,0 +1,34 @@
+-- ISO-4217 currency codes: rows written before currencies are in BRL.
+ALTER TABLE public.accounts
+    ADD COLUMN currency_conversion boolean NOT NULL DEFAULT false;
+
+ALTER TABLE public.categories
+    ADD COLUMN currency varchar(3) NOT NULL DEFAULT 'BRL';
+
+-- `amount` is in `currency`, the currency of the category. The original columns keep
+-- the requested share of the amount and the rate it was converted with.
+ALTER TABLE public.transactions
+    ADD COLUMN currency varchar(3) NOT NULL DEFAULT 'BRL',
+    ADD COLUMN original_amount numeric(20, 2) NULL,
+    ADD COLUMN original_currency varchar(3) NULL,
+    ADD COLUMN exchange_rate numeric(20, 10) NULL;
+
+ALTER TABLE public.holds
+    ADD COLUMN currency varchar(3) NOT NULL DEFAULT 'BRL',
+    ADD COLUMN original_amount numeric(20, 2) NULL,
+    ADD COLUMN original_currency varchar(3) NULL,
+    ADD COLUMN exchange_rate numeric(20, 10) NULL;
+
+CREATE TABLE public.exchange_rates (
+    id bigserial NOT NULL,
+    created_at timestamptz NULL,
+    updated_at timestamptz NULL,
+    deleted_at timestamptz NULL,
+    from_currency varchar(3) NOT NULL,
+    to_currency varchar(3) NOT NULL,
+    rate numeric(20, 10) NOT NULL,
+    CONSTRAINT exchange_rates_pkey PRIMARY KEY (id),
+    CONSTRAINT chk_exchange_rates_rate CHECK (rate > 0)
+);
+CREATE INDEX idx_exchange_rates_deleted_at ON public.exchange_rates USING btree (deleted_at);
+CREATE UNIQUE INDEX idx_exchange_rates_currencies ON public.exchange_rates USING btree (from_currency, to_currency) WHERE deleted_at IS NULL;
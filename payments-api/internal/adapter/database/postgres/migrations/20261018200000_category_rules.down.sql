@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.category_rules;
+
+ALTER TABLE public.categories DROP COLUMN IF EXISTS fallback_excluded;
@@ -0,0 +1,26 @@
+-- Categories that must never be used as a fallback, by the rules nor by default.
+ALTER TABLE public.categories
+    ADD COLUMN fallback_excluded boolean NOT NULL DEFAULT false;
+
+-- One row per fallback of a chain, tried by position once the category matched by
+-- the MCC (NULL when no category matches it) runs out of funds. Rows without account
+-- are the default chains, the rows of an account override the chain of the category.
+CREATE TABLE public.category_rules (
+    id bigserial NOT NULL,
+    created_at timestamptz NULL,
+    updated_at timestamptz NULL,
+    deleted_at timestamptz NULL,
+    account_id int8 NULL,
+    category_id int8 NULL,
+    fallback_category_id int8 NOT NULL,
+    "position" int4 NOT NULL,
+    CONSTRAINT category_rules_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_category_rules_account FOREIGN KEY (account_id) REFERENCES public.accounts(id),
+    CONSTRAINT fk_category_rules_category FOREIGN KEY (category_id) REFERENCES public.categories(id),
+    CONSTRAINT fk_category_rules_fallback_category FOREIGN KEY (fallback_category_id) REFERENCES public.categories(id),
+    CONSTRAINT chk_category_rules_position CHECK ("position" > 0)
+);
+CREATE INDEX idx_category_rules_deleted_at ON public.category_rules USING btree (deleted_at);
+CREATE INDEX idx_category_rules_account_id ON public.category_rules USING btree (account_id);
+CREATE UNIQUE INDEX idx_category_rules_position_active ON public.category_rules USING btree (COALESCE(account_id, 0), COALESCE(category_id, 0), "position") WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_category_rules_fallback_active ON public.category_rules USING btree (COALESCE(account_id, 0), COALESCE(category_id, 0), fallback_category_id) WHERE deleted_at IS NULL;
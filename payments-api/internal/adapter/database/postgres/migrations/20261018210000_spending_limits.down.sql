@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.idx_transactions_spending;
+
+DROP TABLE IF EXISTS public.spending_limits;
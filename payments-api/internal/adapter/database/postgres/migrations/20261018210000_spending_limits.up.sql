@@ -0,0 +1,25 @@
+-- Caps of an account (category_id NULL) or of one of its categories. A zero cap
+-- is not enforced. The usage is computed from the AUTHORIZATION debits of the ledger.
+CREATE TABLE public.spending_limits (
+    id bigserial NOT NULL,
+    created_at timestamptz NULL,
+    updated_at timestamptz NULL,
+    deleted_at timestamptz NULL,
+    account_id int8 NOT NULL,
+    category_id int8 NULL,
+    daily_amount numeric(20, 2) NOT NULL DEFAULT 0,
+    monthly_amount numeric(20, 2) NOT NULL DEFAULT 0,
+    transaction_amount numeric(20, 2) NOT NULL DEFAULT 0,
+    hourly_transactions int4 NOT NULL DEFAULT 0,
+    CONSTRAINT spending_limits_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_spending_limits_account FOREIGN KEY (account_id) REFERENCES public.accounts(id),
+    CONSTRAINT fk_spending_limits_category FOREIGN KEY (category_id) REFERENCES public.categories(id),
+    CONSTRAINT chk_spending_limits_not_negative CHECK (
+        daily_amount >= 0 AND monthly_amount >= 0 AND transaction_amount >= 0 AND hourly_transactions >= 0
+    )
+);
+CREATE INDEX idx_spending_limits_deleted_at ON public.spending_limits USING btree (deleted_at);
+CREATE UNIQUE INDEX idx_spending_limits_active ON public.spending_limits USING btree (account_id, COALESCE(category_id, 0)) WHERE deleted_at IS NULL;
+
+-- Usage windows of the spending limits, scanned by account and creation time.
+CREATE INDEX idx_transactions_spending ON public.transactions USING btree (account_id, created_at) WHERE operation = 'AUTHORIZATION' AND entry_type = 'DEBIT';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.idx_transactions_fraud_merchant;
+
+DROP TABLE IF EXISTS public.fraud_rules;
@@ -0,0 +1,28 @@
+-- Rules of the pre-authorization risk stage, reloaded by the processor while running.
+-- `decision` is taken when the rule matches, with the parameters used by its kind:
+-- MCC_BLOCKLIST `mccs` (comma separated), FIRST_SEEN_MERCHANT `amount`,
+-- RAPID_REPEAT `count` and `window_seconds`, IMPOSSIBLE_VELOCITY `window_seconds`.
+CREATE TABLE public.fraud_rules (
+    id bigserial NOT NULL,
+    created_at timestamptz NULL,
+    updated_at timestamptz NULL,
+    deleted_at timestamptz NULL,
+    "name" varchar(255) NOT NULL,
+    kind varchar(30) NOT NULL,
+    decision varchar(10) NOT NULL,
+    mccs varchar(255) NOT NULL DEFAULT '',
+    amount numeric(20, 2) NOT NULL DEFAULT 0,
+    count int4 NOT NULL DEFAULT 0,
+    window_seconds int4 NOT NULL DEFAULT 0,
+    enabled bool NOT NULL DEFAULT true,
+    CONSTRAINT fraud_rules_pkey PRIMARY KEY (id),
+    CONSTRAINT chk_fraud_rules_kind CHECK (kind IN ('MCC_BLOCKLIST', 'FIRST_SEEN_MERCHANT', 'RAPID_REPEAT', 'IMPOSSIBLE_VELOCITY')),
+    CONSTRAINT chk_fraud_rules_decision CHECK (decision IN ('REVIEW', 'DECLINE')),
+    CONSTRAINT chk_fraud_rules_not_negative CHECK (amount >= 0 AND count >= 0 AND window_seconds >= 0)
+);
+CREATE INDEX idx_fraud_rules_deleted_at ON public.fraud_rules USING btree (deleted_at);
+CREATE UNIQUE INDEX idx_fraud_rules_name_active ON public.fraud_rules USING btree ("name") WHERE deleted_at IS NULL;
+
+-- Merchants already paid by an account, looked up by the FIRST_SEEN_MERCHANT rules.
+CREATE INDEX idx_transactions_fraud_merchant ON public.transactions USING btree (account_id, merchant_name) WHERE operation = 'AUTHORIZATION' AND entry_type = 'DEBIT';
+
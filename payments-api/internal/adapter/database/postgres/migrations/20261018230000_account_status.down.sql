@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.account_status_changes;
+
+ALTER TABLE public.accounts DROP CONSTRAINT IF EXISTS chk_accounts_status;
+ALTER TABLE public.accounts DROP COLUMN IF EXISTS status;
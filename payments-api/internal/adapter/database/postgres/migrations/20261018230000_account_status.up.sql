@@ -0,0 +1,20 @@
+-- Lifecycle of the accounts: BLOCKED accounts decline debits and CANCELLED ones
+-- decline every transaction. Accounts created before the lifecycle are ACTIVE.
+ALTER TABLE public.accounts ADD COLUMN IF NOT EXISTS status varchar(10) NOT NULL DEFAULT 'ACTIVE';
+ALTER TABLE public.accounts ADD CONSTRAINT chk_accounts_status CHECK (status IN ('ACTIVE', 'BLOCKED', 'CANCELLED'));
+
+-- Audit trail of the status changes, one row per transition.
+CREATE TABLE public.account_status_changes (
+    id bigserial NOT NULL,
+    created_at timestamptz NULL,
+    updated_at timestamptz NULL,
+    deleted_at timestamptz NULL,
+    account_id int8 NOT NULL,
+    previous_status varchar(10) NOT NULL,
+    status varchar(10) NOT NULL,
+    reason varchar(255) NOT NULL DEFAULT '',
+    CONSTRAINT account_status_changes_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_account_status_changes_account FOREIGN KEY (account_id) REFERENCES public.accounts(id)
+);
+CREATE INDEX idx_account_status_changes_deleted_at ON public.account_status_changes USING btree (deleted_at);
+CREATE INDEX idx_account_status_changes_account_id ON public.account_status_changes USING btree (account_id, id);
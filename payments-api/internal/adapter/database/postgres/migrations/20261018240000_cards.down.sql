@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS public.idx_transactions_card_id;
+ALTER TABLE public.transactions DROP CONSTRAINT IF EXISTS fk_transactions_card;
+ALTER TABLE public.transactions DROP COLUMN IF EXISTS card_id;
+
+DROP TABLE IF EXISTS public.cards;
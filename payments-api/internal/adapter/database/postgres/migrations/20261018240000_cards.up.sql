@@ -0,0 +1,30 @@
+-- Tokenized cards of the accounts: payments may identify the account by the card
+-- token, within the status, expiry and limits of the card.
+CREATE TABLE public.cards (
+    id bigserial NOT NULL,
+    created_at timestamptz NULL,
+    updated_at timestamptz NULL,
+    deleted_at timestamptz NULL,
+    uid uuid NOT NULL,
+    account_id int8 NOT NULL,
+    token varchar(64) NOT NULL,
+    masked_pan varchar(19) NOT NULL,
+    status varchar(10) NOT NULL DEFAULT 'ACTIVE',
+    expires_at timestamptz NOT NULL,
+    transaction_amount numeric(20, 2) NOT NULL DEFAULT 0,
+    daily_amount numeric(20, 2) NOT NULL DEFAULT 0,
+    CONSTRAINT cards_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_cards_account FOREIGN KEY (account_id) REFERENCES public.accounts(id),
+    CONSTRAINT chk_cards_status CHECK (status IN ('ACTIVE', 'BLOCKED', 'CANCELLED')),
+    CONSTRAINT chk_cards_limits CHECK (transaction_amount >= 0 AND daily_amount >= 0)
+);
+CREATE UNIQUE INDEX idx_cards_uid ON public.cards USING btree (uid);
+CREATE UNIQUE INDEX idx_cards_token ON public.cards USING btree (token) WHERE deleted_at IS NULL;
+CREATE INDEX idx_cards_account_id ON public.cards USING btree (account_id);
+CREATE INDEX idx_cards_deleted_at ON public.cards USING btree (deleted_at);
+
+-- Card reference of the ledger rows. Rows posted without a card keep it NULL.
+ALTER TABLE public.transactions ADD COLUMN IF NOT EXISTS card_id int8 NULL;
+ALTER TABLE public.transactions ADD CONSTRAINT fk_transactions_card FOREIGN KEY (card_id) REFERENCES public.cards(id);
+CREATE INDEX idx_transactions_card_id ON public.transactions USING btree (card_id, created_at)
+    WHERE operation = 'AUTHORIZATION' AND entry_type = 'DEBIT';
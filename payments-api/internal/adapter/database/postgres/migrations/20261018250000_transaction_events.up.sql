@@ -0,0 +1,25 @@
+-- Transactional outbox of the transaction events, written in the same database
+-- transaction as the ledger rows or the outcome they report. The relay publishes
+-- the pending events in `id` order and sets `published_at`.
+CREATE TABLE public.transaction_events (
+    id bigserial NOT NULL,
+    created_at timestamptz NULL,
+    updated_at timestamptz NULL,
+    deleted_at timestamptz NULL,
+    uid uuid NOT NULL,
+    account_id int8 NOT NULL,
+    event_type varchar(20) NOT NULL,
+    transaction_uid uuid NOT NULL,
+    original_uid uuid NULL,
+    amount numeric(20, 2) NOT NULL DEFAULT 0,
+    currency varchar(3) NOT NULL DEFAULT 'BRL',
+    code varchar(2) NOT NULL,
+    published_at timestamptz NULL,
+    CONSTRAINT transaction_events_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_transaction_events_account FOREIGN KEY (account_id) REFERENCES public.accounts(id),
+    CONSTRAINT chk_transaction_events_event_type CHECK (event_type IN ('TRANSACTION_APPROVED', 'TRANSACTION_DECLINED', 'TRANSACTION_REFUNDED'))
+);
+CREATE UNIQUE INDEX idx_transaction_events_uid ON public.transaction_events USING btree (uid);
+CREATE INDEX idx_transaction_events_pending ON public.transaction_events USING btree (id) WHERE published_at IS NULL;
+CREATE INDEX idx_transaction_events_account_id ON public.transaction_events USING btree (account_id, id);
+CREATE INDEX idx_transaction_events_deleted_at ON public.transaction_events USING btree (deleted_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.idx_holds_spending;
+
+ALTER TABLE public.accounts DROP COLUMN IF EXISTS currency;
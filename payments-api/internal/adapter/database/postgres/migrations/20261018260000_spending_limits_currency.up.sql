@@ -0,0 +1,6 @@
+-- Currency of the spending limits of the account: the usage is converted into it
+-- with the rates of `exchange_rates`. Accounts created before it limit in BRL.
+ALTER TABLE public.accounts ADD COLUMN IF NOT EXISTS currency varchar(3) NOT NULL DEFAULT 'BRL';
+
+-- Active holds of an account, counted in the usage of its spending limits.
+CREATE INDEX idx_holds_spending ON public.holds USING btree (account_id, expires_at) WHERE status = 'AUTHORIZED' AND deleted_at IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE public.holds DROP CONSTRAINT IF EXISTS fk_holds_card;
+ALTER TABLE public.holds DROP COLUMN IF EXISTS card_id;
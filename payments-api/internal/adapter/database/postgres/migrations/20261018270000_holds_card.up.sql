@@ -0,0 +1,3 @@
+-- Card an authorization was requested with, posted on the transactions of its capture.
+ALTER TABLE public.holds ADD COLUMN IF NOT EXISTS card_id int8 NULL;
+ALTER TABLE public.holds ADD CONSTRAINT fk_holds_card FOREIGN KEY (card_id) REFERENCES public.cards(id);
@@ -0,0 +1,5 @@
+DELETE FROM public.transaction_events WHERE account_id IS NULL;
+
+ALTER TABLE public.transaction_events
+    DROP COLUMN IF EXISTS account_uid,
+    ALTER COLUMN account_id SET NOT NULL;
@@ -0,0 +1,11 @@
+-- Rejections of an unknown account or card, a timeout or a failure keep only their
+-- declined event, without an account, under the `account_uid` requested, when one
+-- was requested. Their outcome is not kept, so a retry runs the transaction again.
+ALTER TABLE public.transaction_events
+    ALTER COLUMN account_id DROP NOT NULL,
+    ADD COLUMN account_uid uuid NULL;
+
+UPDATE public.transaction_events AS e
+    SET account_uid = a.uid
+    FROM public.accounts AS a
+    WHERE a.id = e.account_id;
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,20 +19,16 @@ import (
 type RedisClient struct {
 	ctx context.Context
 
-	client     *redis.Client
+	client     redis.UniversalClient
 	strategy   string
 	expiration time.Duration
 }
 
 func NewRedisClient(cfg config.InMemoryDatabase) (*RedisClient, error) {
-	strAddr := fmt.Sprintf("%s:%s", cfg.Host, cfg.Port)
-
-	client := redis.NewClient(&redis.Options{
-		Addr:     strAddr,
-		Password: cfg.Pass,
-		DB:       cfg.DB,
-		Protocol: cfg.Protocol,
-	})
+	client, err := NewRedisUniversalClient(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	Expiration := time.Duration(cfg.Expiration * int(time.Millisecond))
 
@@ -106,3 +103,63 @@ func (c *RedisClient) GetDefaultExpiration(_ context.Context) (time.Duration, er
 func (c *RedisClient) GetClient(_ context.Context) (interface{}, error) {
 	return c.client, nil
 }
+
+/*
+  - Builds the client of the configured topology: a `standalone` node, a master
+    discovered through `sentinel` or a `cluster`, the last two from `Addrs`
+  - Authenticates with the ACL `User` when set and dials over TLS when enabled.
+    The sentinels authenticate with their own `SentinelUser` and `SentinelPass`
+*/
+func NewRedisUniversalClient(cfg config.InMemoryDatabase) (redis.UniversalClient, error) {
+	var tlsConfig *tls.Config
+	if cfg.TLS {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	addrs := cfg.Addrs
+	if len(addrs) == 0 {
+		addrs = []string{fmt.Sprintf("%s:%s", cfg.Host, cfg.Port)}
+	}
+
+	switch cfg.Topology {
+	case "standalone", "":
+		return redis.NewClient(&redis.Options{
+			Addr:      addrs[0],
+			Username:  cfg.User,
+			Password:  cfg.Pass,
+			DB:        cfg.DB,
+			Protocol:  cfg.Protocol,
+			TLSConfig: tlsConfig,
+		}), nil
+	case "sentinel":
+		if cfg.MasterName == "" {
+			return nil, errors.New("redis sentinel topology requires a master name")
+		}
+
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.MasterName,
+			SentinelAddrs:    addrs,
+			SentinelUsername: cfg.SentinelUser,
+			SentinelPassword: cfg.SentinelPass,
+			Username:         cfg.User,
+			Password:         cfg.Pass,
+			DB:               cfg.DB,
+			Protocol:         cfg.Protocol,
+			TLSConfig:        tlsConfig,
+		}), nil
+	case "cluster":
+		if cfg.DB != 0 {
+			return nil, fmt.Errorf("redis cluster topology only supports DB 0, got %d", cfg.DB)
+		}
+
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:     addrs,
+			Username:  cfg.User,
+			Password:  cfg.Pass,
+			Protocol:  cfg.Protocol,
+			TLSConfig: tlsConfig,
+		}), nil
+	default:
+		return nil, fmt.Errorf("redis topology not suported: %s", cfg.Topology)
+	}
+}
@@ -0,0 +1,759 @@
+package gRPC
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	pb "github.com/jtonynet/go-payments-api/internal/adapter/gRPC/pb"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/core/service"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AdminServer struct {
+	pb.UnimplementedAdminServer
+	adminService service.Admin
+}
+
+func NewAdminServer(adminService service.Admin) *AdminServer {
+	return &AdminServer{
+		adminService: adminService,
+	}
+}
+
+func (as *AdminServer) CreateAccount(
+	ctx context.Context,
+	car *pb.CreateAccountRequest,
+) (*pb.AccountResponse, error) {
+
+	account, err := as.adminService.CreateAccount(
+		port.AccountCreateRequest{
+			Name:               car.Name,
+			CurrencyConversion: car.CurrencyConversion,
+		},
+	)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	return mapAccountResponse(account), nil
+}
+
+func (as *AdminServer) DeleteAccount(
+	ctx context.Context,
+	ar *pb.AccountRequest,
+) (*pb.AdminResponse, error) {
+
+	accountUID, err := uuid.Parse(ar.Account)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = as.adminService.DeleteAccount(accountUID)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	return &pb.AdminResponse{}, nil
+}
+
+func (as *AdminServer) ListAccounts(
+	ctx context.Context,
+	lar *pb.ListAccountsRequest,
+) (*pb.ListAccountsResponse, error) {
+
+	accountList, err := as.adminService.ListAccounts(
+		port.AccountListRequest{
+			Cursor: lar.Cursor,
+			Limit:  int(lar.Limit),
+		},
+	)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	accounts := make([]*pb.AccountResponse, 0, len(accountList.Accounts))
+	for _, account := range accountList.Accounts {
+		accounts = append(accounts, mapAccountResponse(account))
+	}
+
+	return &pb.ListAccountsResponse{
+		Accounts:   accounts,
+		NextCursor: accountList.NextCursor,
+	}, nil
+}
+
+func (as *AdminServer) AttachCategory(
+	ctx context.Context,
+	acr *pb.AccountCategoryRequest,
+) (*pb.AdminResponse, error) {
+
+	accountCategoryRequest, err := mapAccountCategoryRequest(acr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = as.adminService.AttachCategory(accountCategoryRequest)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	return &pb.AdminResponse{}, nil
+}
+
+func (as *AdminServer) DetachCategory(
+	ctx context.Context,
+	acr *pb.AccountCategoryRequest,
+) (*pb.AdminResponse, error) {
+
+	accountCategoryRequest, err := mapAccountCategoryRequest(acr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = as.adminService.DetachCategory(accountCategoryRequest)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	return &pb.AdminResponse{}, nil
+}
+
+func (as *AdminServer) CreateCategory(
+	ctx context.Context,
+	ccr *pb.CreateCategoryRequest,
+) (*pb.CategoryResponse, error) {
+
+	category, err := as.adminService.CreateCategory(
+		port.CategoryCreateRequest{
+			Name:     ccr.Name,
+			Priority: int(ccr.Priority),
+			Currency: ccr.Currency,
+
+			FallbackExcluded: ccr.FallbackExcluded,
+		},
+	)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	return mapCategoryResponse(category), nil
+}
+
+func (as *AdminServer) AssignMCC(
+	ctx context.Context,
+	amr *pb.AssignMCCRequest,
+) (*pb.MCCResponse, error) {
+
+	categoryUID, err := uuid.Parse(amr.Category)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	mcc, err := as.adminService.AssignMCC(
+		port.MCCAssignRequest{
+			CategoryUID: categoryUID,
+			MCC:         amr.Mcc,
+		},
+	)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	return &pb.MCCResponse{
+		MccUid:   mcc.UID,
+		Category: mcc.CategoryUID,
+		Mcc:      mcc.MCC,
+	}, nil
+}
+
+func (as *AdminServer) CreateMerchant(
+	ctx context.Context,
+	cmr *pb.CreateMerchantRequest,
+) (*pb.MerchantResponse, error) {
+
+	merchant, err := as.adminService.CreateMerchant(
+		port.MerchantCreateRequest{
+			Name:    cmr.Name,
+			MCC:     cmr.Mcc,
+			Aliases: cmr.Aliases,
+		},
+	)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	return mapMerchantResponse(merchant), nil
+}
+
+func (as *AdminServer) GetMerchant(
+	ctx context.Context,
+	mr *pb.MerchantRequest,
+) (*pb.MerchantResponse, error) {
+
+	merchantUID, err := uuid.Parse(mr.Merchant)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	merchant, err := as.adminService.GetMerchant(merchantUID)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	return mapMerchantResponse(merchant), nil
+}
+
+func (as *AdminServer) ListMerchants(
+	ctx context.Context,
+	lmr *pb.ListMerchantsRequest,
+) (*pb.ListMerchantsResponse, error) {
+
+	merchantList, err := as.adminService.ListMerchants(
+		port.MerchantListRequest{
+			Cursor: lmr.Cursor,
+			Limit:  int(lmr.Limit),
+		},
+	)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	merchants := make([]*pb.MerchantResponse, 0, len(merchantList.Merchants))
+	for _, merchant := range merchantList.Merchants {
+		merchants = append(merchants, mapMerchantResponse(merchant))
+	}
+
+	return &pb.ListMerchantsResponse{
+		Merchants:  merchants,
+		NextCursor: merchantList.NextCursor,
+	}, nil
+}
+
+func (as *AdminServer) UpdateMerchant(
+	ctx context.Context,
+	umr *pb.UpdateMerchantRequest,
+) (*pb.MerchantResponse, error) {
+
+	merchantUID, err := uuid.Parse(umr.Merchant)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	merchant, err := as.adminService.UpdateMerchant(
+		port.MerchantUpdateRequest{
+			UID:     merchantUID,
+			Name:    umr.Name,
+			MCC:     umr.Mcc,
+			Aliases: umr.Aliases,
+		},
+	)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	return mapMerchantResponse(merchant), nil
+}
+
+func (as *AdminServer) DeleteMerchant(
+	ctx context.Context,
+	mr *pb.MerchantRequest,
+) (*pb.AdminResponse, error) {
+
+	merchantUID, err := uuid.Parse(mr.Merchant)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = as.adminService.DeleteMerchant(merchantUID)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	return &pb.AdminResponse{}, nil
+}
+
+func (as *AdminServer) CheckLedger(
+	ctx context.Context,
+	lcr *pb.LedgerConsistencyRequest,
+) (*pb.LedgerConsistencyResponse, error) {
+
+	ledgerRequest := port.LedgerConsistencyRequest{
+		Cursor: lcr.Cursor,
+		Limit:  int(lcr.Limit),
+	}
+
+	if lcr.Account != "" {
+		accountUID, err := uuid.Parse(lcr.Account)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		ledgerRequest.AccountUID = accountUID
+	}
+
+	ledgerConsistency, err := as.adminService.CheckLedger(ledgerRequest)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	inconsistencies := make([]*pb.LedgerBalance, 0, len(ledgerConsistency.Inconsistencies))
+	for _, lb := range ledgerConsistency.Inconsistencies {
+		inconsistencies = append(inconsistencies, &pb.LedgerBalance{
+			Account:       lb.AccountUID,
+			Category:      lb.Category,
+			Balance:       lb.Balance.String(),
+			LedgerBalance: lb.LedgerBalance.String(),
+			BrokenEntries: int32(lb.BrokenEntries),
+		})
+	}
+
+	return &pb.LedgerConsistencyResponse{
+		Checked:         int32(ledgerConsistency.Checked),
+		Inconsistencies: inconsistencies,
+		NextCursor:      ledgerConsistency.NextCursor,
+	}, nil
+}
+
+func (as *AdminServer) GetLockQueue(
+	ctx context.Context,
+	ar *pb.AccountRequest,
+) (*pb.LockQueueResponse, error) {
+
+	accountUID, err := uuid.Parse(ar.Account)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	lockQueue, err := as.adminService.LockQueue(accountUID)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	return &pb.LockQueueResponse{
+		Account: lockQueue.AccountUID,
+		Depth:   lockQueue.Depth,
+	}, nil
+}
+
+func (as *AdminServer) SetCategoryRule(
+	ctx context.Context,
+	crr *pb.CategoryRuleRequest,
+) (*pb.CategoryRuleResponse, error) {
+
+	categoryRule, err := as.adminService.SetCategoryRule(
+		port.CategoryRuleRequest{
+			AccountUID:  crr.Account,
+			CategoryUID: crr.Category,
+			Fallbacks:   crr.Fallbacks,
+		},
+	)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	return mapCategoryRuleResponse(categoryRule), nil
+}
+
+func (as *AdminServer) ListCategoryRules(
+	ctx context.Context,
+	lcr *pb.ListCategoryRulesRequest,
+) (*pb.ListCategoryRulesResponse, error) {
+
+	accountUID := uuid.Nil
+	if lcr.Account != "" {
+		var err error
+		accountUID, err = uuid.Parse(lcr.Account)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	categoryRules, err := as.adminService.ListCategoryRules(accountUID)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	rules := make([]*pb.CategoryRuleResponse, 0, len(categoryRules.Rules))
+	for _, categoryRule := range categoryRules.Rules {
+		rules = append(rules, mapCategoryRuleResponse(categoryRule))
+	}
+
+	return &pb.ListCategoryRulesResponse{Rules: rules}, nil
+}
+
+func (as *AdminServer) SetSpendingLimit(
+	ctx context.Context,
+	slr *pb.SpendingLimitRequest,
+) (*pb.SpendingLimitResponse, error) {
+
+	spendingLimitRequest, err := mapSpendingLimitRequest(slr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	spendingLimit, err := as.adminService.SetSpendingLimit(spendingLimitRequest)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	return mapSpendingLimitResponse(spendingLimit), nil
+}
+
+func (as *AdminServer) ListSpendingLimits(
+	ctx context.Context,
+	ar *pb.AccountRequest,
+) (*pb.ListSpendingLimitsResponse, error) {
+
+	accountUID, err := uuid.Parse(ar.Account)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	spendingLimits, err := as.adminService.ListSpendingLimits(accountUID)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	limits := make([]*pb.SpendingLimitResponse, 0, len(spendingLimits.Limits))
+	for _, spendingLimit := range spendingLimits.Limits {
+		limits = append(limits, mapSpendingLimitResponse(spendingLimit))
+	}
+
+	return &pb.ListSpendingLimitsResponse{Limits: limits}, nil
+}
+
+func (as *AdminServer) ChangeAccountStatus(
+	ctx context.Context,
+	asr *pb.AccountStatusRequest,
+) (*pb.AccountStatusChangeResponse, error) {
+
+	accountUID, err := uuid.Parse(asr.Account)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	statusChange, err := as.adminService.ChangeAccountStatus(port.AccountStatusRequest{
+		AccountUID: accountUID,
+		Action:     asr.Action,
+		Reason:     asr.Reason,
+	})
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	return mapAccountStatusChangeResponse(statusChange), nil
+}
+
+func (as *AdminServer) ListAccountStatusChanges(
+	ctx context.Context,
+	ar *pb.AccountRequest,
+) (*pb.ListAccountStatusChangesResponse, error) {
+
+	accountUID, err := uuid.Parse(ar.Account)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	statusChanges, err := as.adminService.ListAccountStatusChanges(accountUID)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	changes := make([]*pb.AccountStatusChangeResponse, 0, len(statusChanges.Changes))
+	for _, statusChange := range statusChanges.Changes {
+		changes = append(changes, mapAccountStatusChangeResponse(statusChange))
+	}
+
+	return &pb.ListAccountStatusChangesResponse{Changes: changes}, nil
+}
+
+func (as *AdminServer) CreateCard(
+	ctx context.Context,
+	ccr *pb.CreateCardRequest,
+) (*pb.CardResponse, error) {
+
+	cardCreateRequest, err := mapCreateCardRequest(ccr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	card, err := as.adminService.CreateCard(cardCreateRequest)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	return mapCardResponse(card), nil
+}
+
+func (as *AdminServer) ListCards(
+	ctx context.Context,
+	ar *pb.AccountRequest,
+) (*pb.ListCardsResponse, error) {
+
+	accountUID, err := uuid.Parse(ar.Account)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	cardList, err := as.adminService.ListCards(accountUID)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	cards := make([]*pb.CardResponse, 0, len(cardList.Cards))
+	for _, card := range cardList.Cards {
+		cards = append(cards, mapCardResponse(card))
+	}
+
+	return &pb.ListCardsResponse{Cards: cards}, nil
+}
+
+func (as *AdminServer) UpdateCard(
+	ctx context.Context,
+	ucr *pb.UpdateCardRequest,
+) (*pb.CardResponse, error) {
+
+	cardUpdateRequest, err := mapUpdateCardRequest(ucr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	card, err := as.adminService.UpdateCard(cardUpdateRequest)
+	if err != nil {
+		return nil, mapAdminError(err)
+	}
+
+	return mapCardResponse(card), nil
+}
+
+func mapAdminError(err error) error {
+	switch {
+	case errors.Is(err, port.ErrInvalidAdminRequest),
+		errors.Is(err, port.ErrInvalidCursor),
+		errors.Is(err, port.ErrCategoryFallbackExcluded):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, port.ErrAccountNotFound),
+		errors.Is(err, port.ErrCategoryNotFound),
+		errors.Is(err, port.ErrCategoryNotAttached),
+		errors.Is(err, port.ErrMerchantNotFound),
+		errors.Is(err, port.ErrMCCNotFound),
+		errors.Is(err, port.ErrCardNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, port.ErrCategoryAlreadyAttached),
+		errors.Is(err, port.ErrMCCAlreadyAssigned),
+		errors.Is(err, port.ErrMerchantAlreadyExists),
+		errors.Is(err, port.ErrMerchantAliasTaken),
+		errors.Is(err, port.ErrCardTokenTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, port.ErrAccountStatusTransition),
+		errors.Is(err, port.ErrCardCancelled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func mapAccountCategoryRequest(acr *pb.AccountCategoryRequest) (port.AccountCategoryRequest, error) {
+	accountUID, err := uuid.Parse(acr.Account)
+	if err != nil {
+		return port.AccountCategoryRequest{}, err
+	}
+
+	categoryUID, err := uuid.Parse(acr.Category)
+	if err != nil {
+		return port.AccountCategoryRequest{}, err
+	}
+
+	return port.AccountCategoryRequest{
+		AccountUID:  accountUID,
+		CategoryUID: categoryUID,
+	}, nil
+}
+
+func mapAccountResponse(account port.AccountResponse) *pb.AccountResponse {
+	categories := make([]*pb.CategoryResponse, 0, len(account.Categories))
+	for _, category := range account.Categories {
+		categories = append(categories, mapCategoryResponse(category))
+	}
+
+	return &pb.AccountResponse{
+		Account:            account.UID,
+		Name:               account.Name,
+		Categories:         categories,
+		CreatedAt:          account.CreatedAt.Format(time.RFC3339),
+		CurrencyConversion: account.CurrencyConversion,
+		Status:             account.Status,
+	}
+}
+
+func mapCategoryResponse(category port.CategoryResponse) *pb.CategoryResponse {
+	return &pb.CategoryResponse{
+		Category: category.UID,
+		Name:     category.Name,
+		Priority: int32(category.Priority),
+		Mccs:     category.MCCs,
+		Currency: category.Currency,
+
+		FallbackExcluded: category.FallbackExcluded,
+	}
+}
+
+func mapMerchantResponse(merchant port.MerchantResponse) *pb.MerchantResponse {
+	return &pb.MerchantResponse{
+		Merchant:  merchant.UID,
+		Name:      merchant.Name,
+		Mcc:       merchant.MCC,
+		Aliases:   merchant.Aliases,
+		CreatedAt: merchant.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: merchant.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func mapCategoryRuleResponse(categoryRule port.CategoryRuleResponse) *pb.CategoryRuleResponse {
+	fallbacks := make([]*pb.CategoryRuleFallback, 0, len(categoryRule.Fallbacks))
+	for _, fallback := range categoryRule.Fallbacks {
+		fallbacks = append(fallbacks, &pb.CategoryRuleFallback{
+			Category: fallback.UID,
+			Name:     fallback.Name,
+		})
+	}
+
+	return &pb.CategoryRuleResponse{
+		Account:   categoryRule.AccountUID,
+		Category:  categoryRule.CategoryUID,
+		Fallbacks: fallbacks,
+	}
+}
+
+func mapSpendingLimitRequest(slr *pb.SpendingLimitRequest) (port.SpendingLimitRequest, error) {
+	accountUID, err := uuid.Parse(slr.Account)
+	if err != nil {
+		return port.SpendingLimitRequest{}, err
+	}
+
+	amounts := make([]decimal.Decimal, 0, 3)
+	for _, amount := range []string{slr.DailyAmount, slr.MonthlyAmount, slr.TransactionAmount} {
+		if amount == "" {
+			amounts = append(amounts, decimal.Zero)
+			continue
+		}
+
+		parsed, err := decimal.NewFromString(amount)
+		if err != nil {
+			return port.SpendingLimitRequest{}, err
+		}
+
+		amounts = append(amounts, parsed)
+	}
+
+	return port.SpendingLimitRequest{
+		AccountUID:         accountUID,
+		CategoryUID:        slr.Category,
+		DailyAmount:        amounts[0],
+		MonthlyAmount:      amounts[1],
+		TransactionAmount:  amounts[2],
+		HourlyTransactions: int(slr.HourlyTransactions),
+	}, nil
+}
+
+func mapSpendingLimitResponse(spendingLimit port.SpendingLimitResponse) *pb.SpendingLimitResponse {
+	return &pb.SpendingLimitResponse{
+		Account:            spendingLimit.AccountUID,
+		Category:           spendingLimit.CategoryUID,
+		CategoryName:       spendingLimit.CategoryName,
+		DailyAmount:        spendingLimit.DailyAmount.String(),
+		MonthlyAmount:      spendingLimit.MonthlyAmount.String(),
+		TransactionAmount:  spendingLimit.TransactionAmount.String(),
+		HourlyTransactions: int32(spendingLimit.HourlyTransactions),
+	}
+}
+
+func mapAccountStatusChangeResponse(statusChange port.AccountStatusChangeResponse) *pb.AccountStatusChangeResponse {
+	return &pb.AccountStatusChangeResponse{
+		Account:        statusChange.AccountUID,
+		PreviousStatus: statusChange.PreviousStatus,
+		Status:         statusChange.Status,
+		Reason:         statusChange.Reason,
+		CreatedAt:      statusChange.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func mapCreateCardRequest(ccr *pb.CreateCardRequest) (port.CardCreateRequest, error) {
+	accountUID, err := uuid.Parse(ccr.Account)
+	if err != nil {
+		return port.CardCreateRequest{}, err
+	}
+
+	transactionAmount, err := parseCapAmount(ccr.TransactionAmount)
+	if err != nil {
+		return port.CardCreateRequest{}, err
+	}
+
+	dailyAmount, err := parseCapAmount(ccr.DailyAmount)
+	if err != nil {
+		return port.CardCreateRequest{}, err
+	}
+
+	return port.CardCreateRequest{
+		AccountUID:        accountUID,
+		Token:             ccr.Token,
+		MaskedPAN:         ccr.MaskedPan,
+		Expiry:            ccr.Expiry,
+		TransactionAmount: transactionAmount,
+		DailyAmount:       dailyAmount,
+	}, nil
+}
+
+func mapUpdateCardRequest(ucr *pb.UpdateCardRequest) (port.CardUpdateRequest, error) {
+	cardUID, err := uuid.Parse(ucr.Card)
+	if err != nil {
+		return port.CardUpdateRequest{}, err
+	}
+
+	transactionAmount, err := parseCapAmount(ucr.TransactionAmount)
+	if err != nil {
+		return port.CardUpdateRequest{}, err
+	}
+
+	dailyAmount, err := parseCapAmount(ucr.DailyAmount)
+	if err != nil {
+		return port.CardUpdateRequest{}, err
+	}
+
+	return port.CardUpdateRequest{
+		CardUID:           cardUID,
+		Status:            ucr.Status,
+		Expiry:            ucr.Expiry,
+		TransactionAmount: transactionAmount,
+		DailyAmount:       dailyAmount,
+	}, nil
+}
+
+/*
+- An empty cap is not enforced, as a zero one
+*/
+func parseCapAmount(amount string) (decimal.Decimal, error) {
+	if amount == "" {
+		return decimal.Zero, nil
+	}
+
+	return decimal.NewFromString(amount)
+}
+
+func mapCardResponse(card port.CardResponse) *pb.CardResponse {
+	return &pb.CardResponse{
+		Card:              card.UID,
+		Account:           card.AccountUID,
+		MaskedPan:         card.MaskedPAN,
+		Status:            card.Status,
+		Expiry:            card.Expiry,
+		TransactionAmount: card.TransactionAmount.String(),
+		DailyAmount:       card.DailyAmount.String(),
+		CreatedAt:         card.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,114 @@
+package gRPC
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	pb "github.com/jtonynet/go-payments-api/internal/adapter/gRPC/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const adminTokenMetadataKey = "authorization"
+
+/*
+- Every method of the Admin service and the credits of the Payment service require the admin token
+*/
+func requiresAdminToken(fullMethod string) bool {
+	switch fullMethod {
+	case pb.Payment_Credit_FullMethodName, pb.Payment_CreditBatch_FullMethodName:
+		return true
+	}
+
+	return strings.HasPrefix(fullMethod, "/"+pb.Admin_ServiceDesc.ServiceName+"/")
+}
+
+/*
+  - The token is compared in constant time, with no token configured every
+    guarded method is rejected
+*/
+func authorizeAdmin(ctx context.Context, adminToken string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	tokens := md.Get(adminTokenMetadataKey)
+
+	if adminToken == "" || len(tokens) != 1 ||
+		subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(adminToken)) != 1 {
+		return status.Error(codes.Unauthenticated, "missing or invalid admin token")
+	}
+
+	return nil
+}
+
+func adminAuthUnaryServerInterceptor(adminToken string) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if requiresAdminToken(info.FullMethod) {
+			if err := authorizeAdmin(ctx, adminToken); err != nil {
+				return nil, err
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func adminAuthStreamServerInterceptor(adminToken string) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if requiresAdminToken(info.FullMethod) {
+			if err := authorizeAdmin(ss.Context(), adminToken); err != nil {
+				return err
+			}
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+/*
+- The REST client sends the admin token only on the guarded methods
+*/
+func adminAuthUnaryClientInterceptor(adminToken string) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if requiresAdminToken(method) {
+			ctx = metadata.AppendToOutgoingContext(ctx, adminTokenMetadataKey, adminToken)
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func adminAuthStreamClientInterceptor(adminToken string) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		if requiresAdminToken(method) {
+			ctx = metadata.AppendToOutgoingContext(ctx, adminTokenMetadataKey, adminToken)
+		}
+
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
@@ -9,12 +9,21 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+/*
+  - The response of a credit batch lists each rejected credit, up to
+    `port.CREDIT_BATCH_MAX_SIZE` of them, above the gRPC default of 4MB
+*/
+const PAYMENT_CLIENT_MAX_RECV_MSG_SIZE = 32 * 1024 * 1024
+
 func NewPaymentClient(cfg config.GRPC) (pb.PaymentClient, error) {
 	hostAndPort := fmt.Sprintf("%s:%s", cfg.ClientHost, cfg.ClientPort)
 
 	gRPCClientConn, err := grpc.Dial(
 		hostAndPort,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(PAYMENT_CLIENT_MAX_RECV_MSG_SIZE)),
+		grpc.WithUnaryInterceptor(adminAuthUnaryClientInterceptor(cfg.AdminToken)),
+		grpc.WithStreamInterceptor(adminAuthStreamClientInterceptor(cfg.AdminToken)),
 	)
 
 	if err != nil {
@@ -25,3 +34,21 @@ func NewPaymentClient(cfg config.GRPC) (pb.PaymentClient, error) {
 
 	return PaymentClient, nil
 }
+
+func NewAdminClient(cfg config.GRPC) (pb.AdminClient, error) {
+	hostAndPort := fmt.Sprintf("%s:%s", cfg.ClientHost, cfg.ClientPort)
+
+	gRPCClientConn, err := grpc.Dial(
+		hostAndPort,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(adminAuthUnaryClientInterceptor(cfg.AdminToken)),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	AdminClient := pb.NewAdminClient(gRPCClientConn)
+
+	return AdminClient, nil
+}
@@ -19,7 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Payment_Execute_FullMethodName = "/Payment/Execute"
+	Payment_Execute_FullMethodName          = "/Payment/Execute"
+	Payment_Refund_FullMethodName           = "/Payment/Refund"
+	Payment_Authorize_FullMethodName        = "/Payment/Authorize"
+	Payment_Capture_FullMethodName          = "/Payment/Capture"
+	Payment_Void_FullMethodName             = "/Payment/Void"
+	Payment_ListTransactions_FullMethodName = "/Payment/ListTransactions"
+	Payment_GetBalance_FullMethodName       = "/Payment/GetBalance"
+	Payment_Credit_FullMethodName           = "/Payment/Credit"
+	Payment_CreditBatch_FullMethodName      = "/Payment/CreditBatch"
 )
 
 // PaymentClient is the client API for Payment service.
@@ -27,6 +35,14 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PaymentClient interface {
 	Execute(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	Refund(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	Authorize(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	Capture(ctx context.Context, in *HoldRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	Void(ctx context.Context, in *HoldRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	ListTransactions(ctx context.Context, in *TransactionHistoryRequest, opts ...grpc.CallOption) (*TransactionHistoryResponse, error)
+	GetBalance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error)
+	Credit(ctx context.Context, in *CreditRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	CreditBatch(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreditRequest, CreditBatchResponse], error)
 }
 
 type paymentClient struct {
@@ -47,11 +63,102 @@ func (c *paymentClient) Execute(ctx context.Context, in *TransactionRequest, opt
 	return out, nil
 }
 
+func (c *paymentClient) Refund(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, Payment_Refund_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentClient) Authorize(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, Payment_Authorize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentClient) Capture(ctx context.Context, in *HoldRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, Payment_Capture_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentClient) Void(ctx context.Context, in *HoldRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, Payment_Void_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentClient) ListTransactions(ctx context.Context, in *TransactionHistoryRequest, opts ...grpc.CallOption) (*TransactionHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionHistoryResponse)
+	err := c.cc.Invoke(ctx, Payment_ListTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentClient) GetBalance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BalanceResponse)
+	err := c.cc.Invoke(ctx, Payment_GetBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentClient) Credit(ctx context.Context, in *CreditRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, Payment_Credit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentClient) CreditBatch(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreditRequest, CreditBatchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Payment_ServiceDesc.Streams[0], Payment_CreditBatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CreditRequest, CreditBatchResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Payment_CreditBatchClient = grpc.ClientStreamingClient[CreditRequest, CreditBatchResponse]
+
 // PaymentServer is the server API for Payment service.
 // All implementations must embed UnimplementedPaymentServer
 // for forward compatibility.
 type PaymentServer interface {
 	Execute(context.Context, *TransactionRequest) (*TransactionResponse, error)
+	Refund(context.Context, *RefundRequest) (*TransactionResponse, error)
+	Authorize(context.Context, *TransactionRequest) (*TransactionResponse, error)
+	Capture(context.Context, *HoldRequest) (*TransactionResponse, error)
+	Void(context.Context, *HoldRequest) (*TransactionResponse, error)
+	ListTransactions(context.Context, *TransactionHistoryRequest) (*TransactionHistoryResponse, error)
+	GetBalance(context.Context, *BalanceRequest) (*BalanceResponse, error)
+	Credit(context.Context, *CreditRequest) (*TransactionResponse, error)
+	CreditBatch(grpc.ClientStreamingServer[CreditRequest, CreditBatchResponse]) error
 	mustEmbedUnimplementedPaymentServer()
 }
 
@@ -65,6 +172,30 @@ type UnimplementedPaymentServer struct{}
 func (UnimplementedPaymentServer) Execute(context.Context, *TransactionRequest) (*TransactionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Execute not implemented")
 }
+func (UnimplementedPaymentServer) Refund(context.Context, *RefundRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refund not implemented")
+}
+func (UnimplementedPaymentServer) Authorize(context.Context, *TransactionRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Authorize not implemented")
+}
+func (UnimplementedPaymentServer) Capture(context.Context, *HoldRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capture not implemented")
+}
+func (UnimplementedPaymentServer) Void(context.Context, *HoldRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Void not implemented")
+}
+func (UnimplementedPaymentServer) ListTransactions(context.Context, *TransactionHistoryRequest) (*TransactionHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedPaymentServer) GetBalance(context.Context, *BalanceRequest) (*BalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedPaymentServer) Credit(context.Context, *CreditRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Credit not implemented")
+}
+func (UnimplementedPaymentServer) CreditBatch(grpc.ClientStreamingServer[CreditRequest, CreditBatchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method CreditBatch not implemented")
+}
 func (UnimplementedPaymentServer) mustEmbedUnimplementedPaymentServer() {}
 func (UnimplementedPaymentServer) testEmbeddedByValue()                 {}
 
@@ -104,6 +235,139 @@ func _Payment_Execute_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Payment_Refund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServer).Refund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Payment_Refund_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServer).Refund(ctx, req.(*RefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Payment_Authorize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServer).Authorize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Payment_Authorize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServer).Authorize(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Payment_Capture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServer).Capture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Payment_Capture_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServer).Capture(ctx, req.(*HoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Payment_Void_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServer).Void(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Payment_Void_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServer).Void(ctx, req.(*HoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Payment_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Payment_ListTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServer).ListTransactions(ctx, req.(*TransactionHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Payment_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Payment_GetBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServer).GetBalance(ctx, req.(*BalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Payment_Credit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServer).Credit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Payment_Credit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServer).Credit(ctx, req.(*CreditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Payment_CreditBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PaymentServer).CreditBatch(&grpc.GenericServerStream[CreditRequest, CreditBatchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Payment_CreditBatchServer = grpc.ClientStreamingServer[CreditRequest, CreditBatchResponse]
+
 // Payment_ServiceDesc is the grpc.ServiceDesc for Payment service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +379,978 @@ var Payment_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Execute",
 			Handler:    _Payment_Execute_Handler,
 		},
+		{
+			MethodName: "Refund",
+			Handler:    _Payment_Refund_Handler,
+		},
+		{
+			MethodName: "Authorize",
+			Handler:    _Payment_Authorize_Handler,
+		},
+		{
+			MethodName: "Capture",
+			Handler:    _Payment_Capture_Handler,
+		},
+		{
+			MethodName: "Void",
+			Handler:    _Payment_Void_Handler,
+		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _Payment_ListTransactions_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _Payment_GetBalance_Handler,
+		},
+		{
+			MethodName: "Credit",
+			Handler:    _Payment_Credit_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CreditBatch",
+			Handler:       _Payment_CreditBatch_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "transaction.proto",
+}
+
+const (
+	Admin_CreateAccount_FullMethodName            = "/Admin/CreateAccount"
+	Admin_DeleteAccount_FullMethodName            = "/Admin/DeleteAccount"
+	Admin_ListAccounts_FullMethodName             = "/Admin/ListAccounts"
+	Admin_AttachCategory_FullMethodName           = "/Admin/AttachCategory"
+	Admin_DetachCategory_FullMethodName           = "/Admin/DetachCategory"
+	Admin_CreateCategory_FullMethodName           = "/Admin/CreateCategory"
+	Admin_AssignMCC_FullMethodName                = "/Admin/AssignMCC"
+	Admin_CreateMerchant_FullMethodName           = "/Admin/CreateMerchant"
+	Admin_GetMerchant_FullMethodName              = "/Admin/GetMerchant"
+	Admin_ListMerchants_FullMethodName            = "/Admin/ListMerchants"
+	Admin_UpdateMerchant_FullMethodName           = "/Admin/UpdateMerchant"
+	Admin_DeleteMerchant_FullMethodName           = "/Admin/DeleteMerchant"
+	Admin_CheckLedger_FullMethodName              = "/Admin/CheckLedger"
+	Admin_GetLockQueue_FullMethodName             = "/Admin/GetLockQueue"
+	Admin_SetCategoryRule_FullMethodName          = "/Admin/SetCategoryRule"
+	Admin_ListCategoryRules_FullMethodName        = "/Admin/ListCategoryRules"
+	Admin_SetSpendingLimit_FullMethodName         = "/Admin/SetSpendingLimit"
+	Admin_ListSpendingLimits_FullMethodName       = "/Admin/ListSpendingLimits"
+	Admin_ChangeAccountStatus_FullMethodName      = "/Admin/ChangeAccountStatus"
+	Admin_ListAccountStatusChanges_FullMethodName = "/Admin/ListAccountStatusChanges"
+	Admin_CreateCard_FullMethodName               = "/Admin/CreateCard"
+	Admin_ListCards_FullMethodName                = "/Admin/ListCards"
+	Admin_UpdateCard_FullMethodName               = "/Admin/UpdateCard"
+)
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminClient interface {
+	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error)
+	DeleteAccount(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*AdminResponse, error)
+	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	AttachCategory(ctx context.Context, in *AccountCategoryRequest, opts ...grpc.CallOption) (*AdminResponse, error)
+	DetachCategory(ctx context.Context, in *AccountCategoryRequest, opts ...grpc.CallOption) (*AdminResponse, error)
+	CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error)
+	AssignMCC(ctx context.Context, in *AssignMCCRequest, opts ...grpc.CallOption) (*MCCResponse, error)
+	CreateMerchant(ctx context.Context, in *CreateMerchantRequest, opts ...grpc.CallOption) (*MerchantResponse, error)
+	GetMerchant(ctx context.Context, in *MerchantRequest, opts ...grpc.CallOption) (*MerchantResponse, error)
+	ListMerchants(ctx context.Context, in *ListMerchantsRequest, opts ...grpc.CallOption) (*ListMerchantsResponse, error)
+	UpdateMerchant(ctx context.Context, in *UpdateMerchantRequest, opts ...grpc.CallOption) (*MerchantResponse, error)
+	DeleteMerchant(ctx context.Context, in *MerchantRequest, opts ...grpc.CallOption) (*AdminResponse, error)
+	CheckLedger(ctx context.Context, in *LedgerConsistencyRequest, opts ...grpc.CallOption) (*LedgerConsistencyResponse, error)
+	GetLockQueue(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*LockQueueResponse, error)
+	SetCategoryRule(ctx context.Context, in *CategoryRuleRequest, opts ...grpc.CallOption) (*CategoryRuleResponse, error)
+	ListCategoryRules(ctx context.Context, in *ListCategoryRulesRequest, opts ...grpc.CallOption) (*ListCategoryRulesResponse, error)
+	SetSpendingLimit(ctx context.Context, in *SpendingLimitRequest, opts ...grpc.CallOption) (*SpendingLimitResponse, error)
+	ListSpendingLimits(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*ListSpendingLimitsResponse, error)
+	ChangeAccountStatus(ctx context.Context, in *AccountStatusRequest, opts ...grpc.CallOption) (*AccountStatusChangeResponse, error)
+	ListAccountStatusChanges(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*ListAccountStatusChangesResponse, error)
+	CreateCard(ctx context.Context, in *CreateCardRequest, opts ...grpc.CallOption) (*CardResponse, error)
+	ListCards(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*ListCardsResponse, error)
+	UpdateCard(ctx context.Context, in *UpdateCardRequest, opts ...grpc.CallOption) (*CardResponse, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountResponse)
+	err := c.cc.Invoke(ctx, Admin_CreateAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) DeleteAccount(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*AdminResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminResponse)
+	err := c.cc.Invoke(ctx, Admin_DeleteAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, Admin_ListAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) AttachCategory(ctx context.Context, in *AccountCategoryRequest, opts ...grpc.CallOption) (*AdminResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminResponse)
+	err := c.cc.Invoke(ctx, Admin_AttachCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) DetachCategory(ctx context.Context, in *AccountCategoryRequest, opts ...grpc.CallOption) (*AdminResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminResponse)
+	err := c.cc.Invoke(ctx, Admin_DetachCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CategoryResponse)
+	err := c.cc.Invoke(ctx, Admin_CreateCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) AssignMCC(ctx context.Context, in *AssignMCCRequest, opts ...grpc.CallOption) (*MCCResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MCCResponse)
+	err := c.cc.Invoke(ctx, Admin_AssignMCC_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) CreateMerchant(ctx context.Context, in *CreateMerchantRequest, opts ...grpc.CallOption) (*MerchantResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MerchantResponse)
+	err := c.cc.Invoke(ctx, Admin_CreateMerchant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetMerchant(ctx context.Context, in *MerchantRequest, opts ...grpc.CallOption) (*MerchantResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MerchantResponse)
+	err := c.cc.Invoke(ctx, Admin_GetMerchant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListMerchants(ctx context.Context, in *ListMerchantsRequest, opts ...grpc.CallOption) (*ListMerchantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMerchantsResponse)
+	err := c.cc.Invoke(ctx, Admin_ListMerchants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) UpdateMerchant(ctx context.Context, in *UpdateMerchantRequest, opts ...grpc.CallOption) (*MerchantResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MerchantResponse)
+	err := c.cc.Invoke(ctx, Admin_UpdateMerchant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) DeleteMerchant(ctx context.Context, in *MerchantRequest, opts ...grpc.CallOption) (*AdminResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminResponse)
+	err := c.cc.Invoke(ctx, Admin_DeleteMerchant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) CheckLedger(ctx context.Context, in *LedgerConsistencyRequest, opts ...grpc.CallOption) (*LedgerConsistencyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LedgerConsistencyResponse)
+	err := c.cc.Invoke(ctx, Admin_CheckLedger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetLockQueue(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*LockQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LockQueueResponse)
+	err := c.cc.Invoke(ctx, Admin_GetLockQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetCategoryRule(ctx context.Context, in *CategoryRuleRequest, opts ...grpc.CallOption) (*CategoryRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CategoryRuleResponse)
+	err := c.cc.Invoke(ctx, Admin_SetCategoryRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListCategoryRules(ctx context.Context, in *ListCategoryRulesRequest, opts ...grpc.CallOption) (*ListCategoryRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCategoryRulesResponse)
+	err := c.cc.Invoke(ctx, Admin_ListCategoryRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetSpendingLimit(ctx context.Context, in *SpendingLimitRequest, opts ...grpc.CallOption) (*SpendingLimitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SpendingLimitResponse)
+	err := c.cc.Invoke(ctx, Admin_SetSpendingLimit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListSpendingLimits(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*ListSpendingLimitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSpendingLimitsResponse)
+	err := c.cc.Invoke(ctx, Admin_ListSpendingLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ChangeAccountStatus(ctx context.Context, in *AccountStatusRequest, opts ...grpc.CallOption) (*AccountStatusChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountStatusChangeResponse)
+	err := c.cc.Invoke(ctx, Admin_ChangeAccountStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListAccountStatusChanges(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*ListAccountStatusChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountStatusChangesResponse)
+	err := c.cc.Invoke(ctx, Admin_ListAccountStatusChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) CreateCard(ctx context.Context, in *CreateCardRequest, opts ...grpc.CallOption) (*CardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CardResponse)
+	err := c.cc.Invoke(ctx, Admin_CreateCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListCards(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*ListCardsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCardsResponse)
+	err := c.cc.Invoke(ctx, Admin_ListCards_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) UpdateCard(ctx context.Context, in *UpdateCardRequest, opts ...grpc.CallOption) (*CardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CardResponse)
+	err := c.cc.Invoke(ctx, Admin_UpdateCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
+// for forward compatibility.
+type AdminServer interface {
+	CreateAccount(context.Context, *CreateAccountRequest) (*AccountResponse, error)
+	DeleteAccount(context.Context, *AccountRequest) (*AdminResponse, error)
+	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	AttachCategory(context.Context, *AccountCategoryRequest) (*AdminResponse, error)
+	DetachCategory(context.Context, *AccountCategoryRequest) (*AdminResponse, error)
+	CreateCategory(context.Context, *CreateCategoryRequest) (*CategoryResponse, error)
+	AssignMCC(context.Context, *AssignMCCRequest) (*MCCResponse, error)
+	CreateMerchant(context.Context, *CreateMerchantRequest) (*MerchantResponse, error)
+	GetMerchant(context.Context, *MerchantRequest) (*MerchantResponse, error)
+	ListMerchants(context.Context, *ListMerchantsRequest) (*ListMerchantsResponse, error)
+	UpdateMerchant(context.Context, *UpdateMerchantRequest) (*MerchantResponse, error)
+	DeleteMerchant(context.Context, *MerchantRequest) (*AdminResponse, error)
+	CheckLedger(context.Context, *LedgerConsistencyRequest) (*LedgerConsistencyResponse, error)
+	GetLockQueue(context.Context, *AccountRequest) (*LockQueueResponse, error)
+	SetCategoryRule(context.Context, *CategoryRuleRequest) (*CategoryRuleResponse, error)
+	ListCategoryRules(context.Context, *ListCategoryRulesRequest) (*ListCategoryRulesResponse, error)
+	SetSpendingLimit(context.Context, *SpendingLimitRequest) (*SpendingLimitResponse, error)
+	ListSpendingLimits(context.Context, *AccountRequest) (*ListSpendingLimitsResponse, error)
+	ChangeAccountStatus(context.Context, *AccountStatusRequest) (*AccountStatusChangeResponse, error)
+	ListAccountStatusChanges(context.Context, *AccountRequest) (*ListAccountStatusChangesResponse, error)
+	CreateCard(context.Context, *CreateCardRequest) (*CardResponse, error)
+	ListCards(context.Context, *AccountRequest) (*ListCardsResponse, error)
+	UpdateCard(context.Context, *UpdateCardRequest) (*CardResponse, error)
+	mustEmbedUnimplementedAdminServer()
+}
+
+// UnimplementedAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServer struct{}
+
+func (UnimplementedAdminServer) CreateAccount(context.Context, *CreateAccountRequest) (*AccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccount not implemented")
+}
+func (UnimplementedAdminServer) DeleteAccount(context.Context, *AccountRequest) (*AdminResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
+func (UnimplementedAdminServer) ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (UnimplementedAdminServer) AttachCategory(context.Context, *AccountCategoryRequest) (*AdminResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AttachCategory not implemented")
+}
+func (UnimplementedAdminServer) DetachCategory(context.Context, *AccountCategoryRequest) (*AdminResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DetachCategory not implemented")
+}
+func (UnimplementedAdminServer) CreateCategory(context.Context, *CreateCategoryRequest) (*CategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCategory not implemented")
+}
+func (UnimplementedAdminServer) AssignMCC(context.Context, *AssignMCCRequest) (*MCCResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignMCC not implemented")
+}
+func (UnimplementedAdminServer) CreateMerchant(context.Context, *CreateMerchantRequest) (*MerchantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMerchant not implemented")
+}
+func (UnimplementedAdminServer) GetMerchant(context.Context, *MerchantRequest) (*MerchantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMerchant not implemented")
+}
+func (UnimplementedAdminServer) ListMerchants(context.Context, *ListMerchantsRequest) (*ListMerchantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMerchants not implemented")
+}
+func (UnimplementedAdminServer) UpdateMerchant(context.Context, *UpdateMerchantRequest) (*MerchantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMerchant not implemented")
+}
+func (UnimplementedAdminServer) DeleteMerchant(context.Context, *MerchantRequest) (*AdminResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMerchant not implemented")
+}
+func (UnimplementedAdminServer) CheckLedger(context.Context, *LedgerConsistencyRequest) (*LedgerConsistencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckLedger not implemented")
+}
+func (UnimplementedAdminServer) GetLockQueue(context.Context, *AccountRequest) (*LockQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLockQueue not implemented")
+}
+func (UnimplementedAdminServer) SetCategoryRule(context.Context, *CategoryRuleRequest) (*CategoryRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCategoryRule not implemented")
+}
+func (UnimplementedAdminServer) ListCategoryRules(context.Context, *ListCategoryRulesRequest) (*ListCategoryRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCategoryRules not implemented")
+}
+func (UnimplementedAdminServer) SetSpendingLimit(context.Context, *SpendingLimitRequest) (*SpendingLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSpendingLimit not implemented")
+}
+func (UnimplementedAdminServer) ListSpendingLimits(context.Context, *AccountRequest) (*ListSpendingLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSpendingLimits not implemented")
+}
+func (UnimplementedAdminServer) ChangeAccountStatus(context.Context, *AccountStatusRequest) (*AccountStatusChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeAccountStatus not implemented")
+}
+func (UnimplementedAdminServer) ListAccountStatusChanges(context.Context, *AccountRequest) (*ListAccountStatusChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccountStatusChanges not implemented")
+}
+func (UnimplementedAdminServer) CreateCard(context.Context, *CreateCardRequest) (*CardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCard not implemented")
+}
+func (UnimplementedAdminServer) ListCards(context.Context, *AccountRequest) (*ListCardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCards not implemented")
+}
+func (UnimplementedAdminServer) UpdateCard(context.Context, *UpdateCardRequest) (*CardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCard not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+func (UnimplementedAdminServer) testEmbeddedByValue()               {}
+
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
+// result in compilation errors.
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
+}
+
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Admin_ServiceDesc, srv)
+}
+
+func _Admin_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CreateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_CreateAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CreateAccount(ctx, req.(*CreateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_DeleteAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).DeleteAccount(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_AttachCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).AttachCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_AttachCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).AttachCategory(ctx, req.(*AccountCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DetachCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).DetachCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_DetachCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).DetachCategory(ctx, req.(*AccountCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_CreateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CreateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_CreateCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CreateCategory(ctx, req.(*CreateCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_AssignMCC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignMCCRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).AssignMCC(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_AssignMCC_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).AssignMCC(ctx, req.(*AssignMCCRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_CreateMerchant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMerchantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CreateMerchant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_CreateMerchant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CreateMerchant(ctx, req.(*CreateMerchantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetMerchant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MerchantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetMerchant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_GetMerchant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetMerchant(ctx, req.(*MerchantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListMerchants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMerchantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListMerchants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListMerchants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListMerchants(ctx, req.(*ListMerchantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_UpdateMerchant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMerchantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).UpdateMerchant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_UpdateMerchant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).UpdateMerchant(ctx, req.(*UpdateMerchantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DeleteMerchant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MerchantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).DeleteMerchant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_DeleteMerchant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).DeleteMerchant(ctx, req.(*MerchantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_CheckLedger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LedgerConsistencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CheckLedger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_CheckLedger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CheckLedger(ctx, req.(*LedgerConsistencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetLockQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetLockQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_GetLockQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetLockQueue(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetCategoryRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetCategoryRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_SetCategoryRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetCategoryRule(ctx, req.(*CategoryRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListCategoryRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCategoryRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListCategoryRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListCategoryRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListCategoryRules(ctx, req.(*ListCategoryRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetSpendingLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpendingLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetSpendingLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_SetSpendingLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetSpendingLimit(ctx, req.(*SpendingLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListSpendingLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListSpendingLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListSpendingLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListSpendingLimits(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ChangeAccountStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ChangeAccountStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ChangeAccountStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ChangeAccountStatus(ctx, req.(*AccountStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListAccountStatusChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListAccountStatusChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListAccountStatusChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListAccountStatusChanges(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_CreateCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CreateCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_CreateCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CreateCard(ctx, req.(*CreateCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListCards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListCards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListCards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListCards(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_UpdateCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).UpdateCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_UpdateCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).UpdateCard(ctx, req.(*UpdateCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAccount",
+			Handler:    _Admin_CreateAccount_Handler,
+		},
+		{
+			MethodName: "DeleteAccount",
+			Handler:    _Admin_DeleteAccount_Handler,
+		},
+		{
+			MethodName: "ListAccounts",
+			Handler:    _Admin_ListAccounts_Handler,
+		},
+		{
+			MethodName: "AttachCategory",
+			Handler:    _Admin_AttachCategory_Handler,
+		},
+		{
+			MethodName: "DetachCategory",
+			Handler:    _Admin_DetachCategory_Handler,
+		},
+		{
+			MethodName: "CreateCategory",
+			Handler:    _Admin_CreateCategory_Handler,
+		},
+		{
+			MethodName: "AssignMCC",
+			Handler:    _Admin_AssignMCC_Handler,
+		},
+		{
+			MethodName: "CreateMerchant",
+			Handler:    _Admin_CreateMerchant_Handler,
+		},
+		{
+			MethodName: "GetMerchant",
+			Handler:    _Admin_GetMerchant_Handler,
+		},
+		{
+			MethodName: "ListMerchants",
+			Handler:    _Admin_ListMerchants_Handler,
+		},
+		{
+			MethodName: "UpdateMerchant",
+			Handler:    _Admin_UpdateMerchant_Handler,
+		},
+		{
+			MethodName: "DeleteMerchant",
+			Handler:    _Admin_DeleteMerchant_Handler,
+		},
+		{
+			MethodName: "CheckLedger",
+			Handler:    _Admin_CheckLedger_Handler,
+		},
+		{
+			MethodName: "GetLockQueue",
+			Handler:    _Admin_GetLockQueue_Handler,
+		},
+		{
+			MethodName: "SetCategoryRule",
+			Handler:    _Admin_SetCategoryRule_Handler,
+		},
+		{
+			MethodName: "ListCategoryRules",
+			Handler:    _Admin_ListCategoryRules_Handler,
+		},
+		{
+			MethodName: "SetSpendingLimit",
+			Handler:    _Admin_SetSpendingLimit_Handler,
+		},
+		{
+			MethodName: "ListSpendingLimits",
+			Handler:    _Admin_ListSpendingLimits_Handler,
+		},
+		{
+			MethodName: "ChangeAccountStatus",
+			Handler:    _Admin_ChangeAccountStatus_Handler,
+		},
+		{
+			MethodName: "ListAccountStatusChanges",
+			Handler:    _Admin_ListAccountStatusChanges_Handler,
+		},
+		{
+			MethodName: "CreateCard",
+			Handler:    _Admin_CreateCard_Handler,
+		},
+		{
+			MethodName: "ListCards",
+			Handler:    _Admin_ListCards_Handler,
+		},
+		{
+			MethodName: "UpdateCard",
+			Handler:    _Admin_UpdateCard_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "transaction.proto",
@@ -2,9 +2,12 @@ package gRPC
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jtonynet/go-payments-api/config"
@@ -13,18 +16,43 @@ import (
 	"github.com/jtonynet/go-payments-api/internal/core/service"
 	"github.com/shopspring/decimal"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type PaymentServer struct {
 	pb.UnimplementedPaymentServer
-	hostAndPort    string
-	paymentService service.Payment
+	hostAndPort          string
+	adminToken           string
+	paymentService       service.Payment
+	refundService        service.Refund
+	authorizationService service.Authorization
+	historyService       service.TransactionHistory
+	balanceService       service.Balance
+	creditService        service.Credit
+	adminService         service.Admin
 }
 
-func NewPaymentServer(cfg config.GRPC, paymentService service.Payment) (PaymentServer, error) {
+func NewPaymentServer(
+	cfg config.GRPC,
+	paymentService service.Payment,
+	refundService service.Refund,
+	authorizationService service.Authorization,
+	historyService service.TransactionHistory,
+	balanceService service.Balance,
+	creditService service.Credit,
+	adminService service.Admin,
+) (PaymentServer, error) {
 	return PaymentServer{
-		hostAndPort:    fmt.Sprintf("%s:%s", cfg.ServerHost, cfg.ServerPort),
-		paymentService: paymentService,
+		hostAndPort:          fmt.Sprintf("%s:%s", cfg.ServerHost, cfg.ServerPort),
+		adminToken:           cfg.AdminToken,
+		paymentService:       paymentService,
+		refundService:        refundService,
+		authorizationService: authorizationService,
+		historyService:       historyService,
+		balanceService:       balanceService,
+		creditService:        creditService,
+		adminService:         adminService,
 	}, nil
 }
 
@@ -34,8 +62,12 @@ func (ps *PaymentServer) HandleRequests() {
 		log.Fatalf("cannot initiate gRPC listner: %v", err)
 	}
 
-	s := grpc.NewServer()
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(adminAuthUnaryServerInterceptor(ps.adminToken)),
+		grpc.StreamInterceptor(adminAuthStreamServerInterceptor(ps.adminToken)),
+	)
 	pb.RegisterPaymentServer(s, ps)
+	pb.RegisterAdminServer(s, NewAdminServer(ps.adminService))
 	if err := s.Serve(listener); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
@@ -46,11 +78,95 @@ func (ps *PaymentServer) Execute(
 	tr *pb.TransactionRequest,
 ) (*pb.TransactionResponse, error) {
 
-	accountUID, err := uuid.Parse(tr.Account)
+	// Payments sent with a card token may omit the account, resolved from the card
+	accountUID := uuid.Nil
+	if tr.Account != "" || tr.Card == "" {
+		parsed, err := uuid.Parse(tr.Account)
+		if err != nil {
+			return mapCodeResponse(port.CODE_REJECTED_INVALID_ACCOUNT), nil
+		}
+
+		accountUID = parsed
+	}
+
+	transactionUID, err := uuid.Parse(tr.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	totalAmount, err := decimal.NewFromString(tr.TotalAmount)
+	if err != nil {
+		return mapCodeResponse(port.CODE_REJECTED_INVALID_AMOUNT), nil
+	}
+
+	response, _ := ps.paymentService.Execute(
+		port.TransactionPaymentRequest{
+			AccountUID:     accountUID,
+			CardToken:      tr.Card,
+			TransactionUID: transactionUID,
+			TotalAmount:    totalAmount,
+			Currency:       tr.Currency,
+			MCC:            tr.Mcc,
+			Merchant:       tr.Merchant,
+		},
+	)
+
+	return mapTransactionResponse(response), nil
+}
+
+func (ps *PaymentServer) Refund(
+	ctx context.Context,
+	rr *pb.RefundRequest,
+) (*pb.TransactionResponse, error) {
+
+	accountUID, err := uuid.Parse(rr.Account)
+	if err != nil {
+		return mapCodeResponse(port.CODE_REJECTED_INVALID_ACCOUNT), nil
+	}
+
+	transactionUID, err := uuid.Parse(rr.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	refundUID, err := uuid.Parse(rr.Refund)
 	if err != nil {
 		return nil, err
 	}
 
+	totalAmount, err := decimal.NewFromString(rr.TotalAmount)
+	if err != nil {
+		return mapCodeResponse(port.CODE_REJECTED_INVALID_AMOUNT), nil
+	}
+
+	code, _ := ps.refundService.Execute(
+		port.TransactionRefundRequest{
+			AccountUID:     accountUID,
+			TransactionUID: transactionUID,
+			RefundUID:      refundUID,
+			TotalAmount:    totalAmount,
+		},
+	)
+
+	return mapCodeResponse(code), nil
+}
+
+func (ps *PaymentServer) Authorize(
+	ctx context.Context,
+	tr *pb.TransactionRequest,
+) (*pb.TransactionResponse, error) {
+
+	// Authorizations sent with a card token may omit the account, resolved from the card
+	accountUID := uuid.Nil
+	if tr.Account != "" || tr.Card == "" {
+		parsed, err := uuid.Parse(tr.Account)
+		if err != nil {
+			return mapCodeResponse(port.CODE_REJECTED_INVALID_ACCOUNT), nil
+		}
+
+		accountUID = parsed
+	}
+
 	transactionUID, err := uuid.Parse(tr.Transaction)
 	if err != nil {
 		return nil, err
@@ -58,18 +174,325 @@ func (ps *PaymentServer) Execute(
 
 	totalAmount, err := decimal.NewFromString(tr.TotalAmount)
 	if err != nil {
-		return nil, err
+		return mapCodeResponse(port.CODE_REJECTED_INVALID_AMOUNT), nil
 	}
 
-	code, _ := ps.paymentService.Execute(
+	response, _ := ps.authorizationService.Authorize(
 		port.TransactionPaymentRequest{
 			AccountUID:     accountUID,
+			CardToken:      tr.Card,
 			TransactionUID: transactionUID,
 			TotalAmount:    totalAmount,
+			Currency:       tr.Currency,
 			MCC:            tr.Mcc,
 			Merchant:       tr.Merchant,
 		},
 	)
 
-	return &pb.TransactionResponse{Code: code}, nil
+	return mapTransactionResponse(response), nil
+}
+
+func (ps *PaymentServer) Capture(
+	ctx context.Context,
+	hr *pb.HoldRequest,
+) (*pb.TransactionResponse, error) {
+
+	holdRequest, err := mapHoldRequest(hr)
+	if err != nil {
+		return nil, err
+	}
+
+	code, _ := ps.authorizationService.Capture(holdRequest)
+
+	return mapCodeResponse(code), nil
+}
+
+func (ps *PaymentServer) Void(
+	ctx context.Context,
+	hr *pb.HoldRequest,
+) (*pb.TransactionResponse, error) {
+
+	holdRequest, err := mapHoldRequest(hr)
+	if err != nil {
+		return nil, err
+	}
+
+	code, _ := ps.authorizationService.Void(holdRequest)
+
+	return mapCodeResponse(code), nil
+}
+
+func (ps *PaymentServer) ListTransactions(
+	ctx context.Context,
+	thr *pb.TransactionHistoryRequest,
+) (*pb.TransactionHistoryResponse, error) {
+
+	historyRequest, err := mapTransactionHistoryRequest(thr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	history, err := ps.historyService.List(historyRequest)
+	if errors.Is(err, port.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return mapTransactionHistoryResponse(history), nil
+}
+
+func (ps *PaymentServer) GetBalance(
+	ctx context.Context,
+	br *pb.BalanceRequest,
+) (*pb.BalanceResponse, error) {
+
+	accountUID, err := uuid.Parse(br.Account)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	balance, err := ps.balanceService.Get(
+		port.BalanceRequest{
+			AccountUID:   accountUID,
+			IncludeHolds: br.IncludeHolds,
+		},
+	)
+	if errors.Is(err, port.ErrAccountNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return mapBalanceResponse(balance), nil
+}
+
+func (ps *PaymentServer) Credit(
+	ctx context.Context,
+	cr *pb.CreditRequest,
+) (*pb.TransactionResponse, error) {
+
+	creditRequest, err := mapCreditRequest(cr)
+	if err != nil {
+		return nil, err
+	}
+
+	code, _ := ps.creditService.Execute(creditRequest)
+
+	return mapCodeResponse(code), nil
+}
+
+/*
+  - The whole stream is read before processing, so a malformed credit rejects the
+    batch before any account is credited. Credits without a transaction UID get a new one.
+*/
+func (ps *PaymentServer) CreditBatch(stream pb.Payment_CreditBatchServer) error {
+	creditRequests := []port.TransactionCreditRequest{}
+
+	for {
+		cr, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		if len(creditRequests) == port.CREDIT_BATCH_MAX_SIZE {
+			return status.Error(codes.InvalidArgument, port.ErrCreditBatchTooLarge.Error())
+		}
+
+		if cr.Transaction == "" {
+			cr.Transaction = uuid.NewString()
+		}
+
+		creditRequest, err := mapCreditRequest(cr)
+		if err != nil {
+			return status.Error(
+				codes.InvalidArgument,
+				fmt.Sprintf("invalid credit at index %d: %s", len(creditRequests), err.Error()),
+			)
+		}
+
+		creditRequests = append(creditRequests, creditRequest)
+	}
+
+	batch, err := ps.creditService.ExecuteBatch(creditRequests)
+	if errors.Is(err, port.ErrCreditBatchTooLarge) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return stream.SendAndClose(mapCreditBatchResponse(batch))
+}
+
+func mapTransactionResponse(response port.TransactionPaymentResponse) *pb.TransactionResponse {
+	categories := make([]*pb.CategoryAttempt, 0, len(response.Categories))
+	for _, category := range response.Categories {
+		categories = append(categories, &pb.CategoryAttempt{
+			Category: category.Category,
+			Rule:     category.Rule,
+			Amount:   category.Amount.String(),
+			Currency: category.Currency,
+		})
+	}
+
+	return &pb.TransactionResponse{
+		Code:       response.Code,
+		Reason:     response.Reason,
+		Categories: categories,
+	}
+}
+
+func mapCodeResponse(code string) *pb.TransactionResponse {
+	return &pb.TransactionResponse{
+		Code:   code,
+		Reason: port.ResponseReason(code),
+	}
+}
+
+func mapCreditRequest(cr *pb.CreditRequest) (port.TransactionCreditRequest, error) {
+	accountUID, err := uuid.Parse(cr.Account)
+	if err != nil {
+		return port.TransactionCreditRequest{}, err
+	}
+
+	transactionUID, err := uuid.Parse(cr.Transaction)
+	if err != nil {
+		return port.TransactionCreditRequest{}, err
+	}
+
+	totalAmount, err := decimal.NewFromString(cr.TotalAmount)
+	if err != nil {
+		return port.TransactionCreditRequest{}, err
+	}
+
+	return port.TransactionCreditRequest{
+		AccountUID:     accountUID,
+		TransactionUID: transactionUID,
+		Category:       cr.Category,
+		TotalAmount:    totalAmount,
+	}, nil
+}
+
+func mapCreditBatchResponse(batch port.TransactionCreditBatchResponse) *pb.CreditBatchResponse {
+	rejections := make([]*pb.CreditRejection, 0, len(batch.Rejections))
+	for _, rejection := range batch.Rejections {
+		rejections = append(rejections, &pb.CreditRejection{
+			Index:       int32(rejection.Index),
+			Account:     rejection.AccountUID,
+			Transaction: rejection.TransactionUID,
+			Code:        rejection.Code,
+			Reason:      rejection.Reason,
+		})
+	}
+
+	return &pb.CreditBatchResponse{
+		Approved:   int32(batch.Approved),
+		Rejected:   int32(batch.Rejected),
+		Rejections: rejections,
+	}
+}
+
+func mapHoldRequest(hr *pb.HoldRequest) (port.TransactionHoldRequest, error) {
+	accountUID, err := uuid.Parse(hr.Account)
+	if err != nil {
+		return port.TransactionHoldRequest{}, err
+	}
+
+	transactionUID, err := uuid.Parse(hr.Transaction)
+	if err != nil {
+		return port.TransactionHoldRequest{}, err
+	}
+
+	return port.TransactionHoldRequest{
+		AccountUID:     accountUID,
+		TransactionUID: transactionUID,
+	}, nil
+}
+
+func mapTransactionHistoryRequest(thr *pb.TransactionHistoryRequest) (port.TransactionHistoryRequest, error) {
+	accountUID, err := uuid.Parse(thr.Account)
+	if err != nil {
+		return port.TransactionHistoryRequest{}, err
+	}
+
+	historyRequest := port.TransactionHistoryRequest{
+		AccountUID: accountUID,
+		Cursor:     thr.Cursor,
+		Limit:      int(thr.Limit),
+		Category:   thr.Category,
+		MCC:        thr.Mcc,
+		Merchant:   thr.Merchant,
+	}
+
+	if thr.From != "" {
+		historyRequest.From, err = time.Parse(time.RFC3339, thr.From)
+		if err != nil {
+			return port.TransactionHistoryRequest{}, err
+		}
+	}
+
+	if thr.To != "" {
+		historyRequest.To, err = time.Parse(time.RFC3339, thr.To)
+		if err != nil {
+			return port.TransactionHistoryRequest{}, err
+		}
+	}
+
+	return historyRequest, nil
+}
+
+func mapTransactionHistoryResponse(history port.TransactionHistoryResponse) *pb.TransactionHistoryResponse {
+	entries := make([]*pb.TransactionHistoryEntry, 0, len(history.Transactions))
+	for _, item := range history.Transactions {
+		entries = append(entries, &pb.TransactionHistoryEntry{
+			Transaction: item.TransactionUID,
+			Original:    item.OriginalUID,
+			Category:    item.Category,
+			Operation:   item.Operation,
+			Amount:      item.Amount.String(),
+			Currency:    item.Currency,
+			Balance:     item.Balance.String(),
+			Mcc:         item.MCC,
+			Merchant:    item.Merchant,
+			CreatedAt:   item.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return &pb.TransactionHistoryResponse{
+		Transactions: entries,
+		NextCursor:   history.NextCursor,
+	}
+}
+
+func mapBalanceResponse(balance port.BalanceResponse) *pb.BalanceResponse {
+	categories := make([]*pb.CategoryBalance, 0, len(balance.Categories))
+	for _, category := range balance.Categories {
+		categoryBalance := &pb.CategoryBalance{
+			Name:     category.Name,
+			Priority: int32(category.Priority),
+			Mccs:     category.MCCs,
+			Amount:   category.Amount.String(),
+			Currency: category.Currency,
+		}
+
+		if category.AmountHeld != nil {
+			categoryBalance.AmountHeld = category.AmountHeld.String()
+		}
+
+		categories = append(categories, categoryBalance)
+	}
+
+	balanceResponse := &pb.BalanceResponse{
+		Account:     balance.AccountUID,
+		AmountTotal: balance.AmountTotal.String(),
+		Categories:  categories,
+	}
+
+	if balance.AmountHeld != nil {
+		balanceResponse.AmountHeld = balance.AmountHeld.String()
+	}
+
+	return balanceResponse
 }
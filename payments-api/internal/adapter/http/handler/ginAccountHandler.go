@@ -0,0 +1,222 @@
+package ginHandler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jtonynet/go-payments-api/bootstrap"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/support/logger"
+
+	pb "github.com/jtonynet/go-payments-api/internal/adapter/gRPC/pb"
+)
+
+// @Summary Account Transactions History
+// @Description Lists the transactions of an account from the newest to the oldest, with the merchant, MCC, category debited or credited and the resulting category balance. Use **nextCursor** of the response as **cursor** to retrieve the next page.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the account"
+// @Param cursor query string false "Cursor returned by the previous page"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param from query string false "RFC3339 inclusive lower bound of the transaction date"
+// @Param to query string false "RFC3339 exclusive upper bound of the transaction date"
+// @Param category query string false "Category name"
+// @Param mcc query string false "Merchant Category Code"
+// @Param merchant query string false "Merchant name, partial match"
+// @Router /accounts/{uid}/transactions [get]
+// @Success 200 {object} port.TransactionHistoryResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AccountTransactions(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+
+	requestCtx := context.Background()
+	requestCtx = context.WithValue(requestCtx, logger.CtxAccountUIDKey, ctx.Param("uid"))
+
+	accountUID, err := uuid.Parse(ctx.Param("uid"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid account uid: %s", err.Error()))
+		return
+	}
+
+	var historyRequest port.TransactionHistoryRequest
+	if err := ctx.ShouldBindQuery(&historyRequest); err != nil {
+		badRequest(ctx, app, requestCtx, err.Error())
+		return
+	}
+
+	validationErrors, ok := dtoIsValid(historyRequest)
+	if !ok {
+		badRequest(ctx, app, requestCtx, validationErrors)
+		return
+	}
+
+	thr := &pb.TransactionHistoryRequest{
+		Account:  accountUID.String(),
+		Cursor:   historyRequest.Cursor,
+		Limit:    int32(historyRequest.Limit),
+		Category: historyRequest.Category,
+		Mcc:      historyRequest.MCC,
+		Merchant: historyRequest.Merchant,
+	}
+
+	if !historyRequest.From.IsZero() {
+		thr.From = historyRequest.From.Format(time.RFC3339)
+	}
+
+	if !historyRequest.To.IsZero() {
+		thr.To = historyRequest.To.Format(time.RFC3339)
+	}
+
+	result, err := app.GRPCpayment.ListTransactions(context.Background(), thr)
+	if status.Code(err) == codes.InvalidArgument {
+		badRequest(ctx, app, requestCtx, status.Convert(err).Message())
+		return
+	} else if err != nil {
+		app.Logger.Error(requestCtx, err.Error())
+		ctx.JSON(http.StatusInternalServerError, port.APIerrorResponse{
+			Message: "failed to retrieve account transactions",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapTransactionHistoryResponse(result))
+}
+
+// @Summary Account Balance
+// @Description Returns the available balance of each category of the account, with its priority and MCCs, and the total. With **includeHolds** the amounts reserved by pending authorizations are also returned.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the account"
+// @Param includeHolds query bool false "Include amounts reserved by pending authorization holds"
+// @Router /accounts/{uid}/balance [get]
+// @Success 200 {object} port.BalanceResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AccountBalance(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+
+	requestCtx := context.Background()
+	requestCtx = context.WithValue(requestCtx, logger.CtxAccountUIDKey, ctx.Param("uid"))
+
+	accountUID, err := uuid.Parse(ctx.Param("uid"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid account uid: %s", err.Error()))
+		return
+	}
+
+	var balanceRequest port.BalanceRequest
+	if err := ctx.ShouldBindQuery(&balanceRequest); err != nil {
+		badRequest(ctx, app, requestCtx, err.Error())
+		return
+	}
+
+	result, err := app.GRPCpayment.GetBalance(
+		context.Background(),
+		&pb.BalanceRequest{
+			Account:      accountUID.String(),
+			IncludeHolds: balanceRequest.IncludeHolds,
+		},
+	)
+	if status.Code(err) == codes.NotFound {
+		app.Logger.Warn(requestCtx, err.Error())
+		ctx.JSON(http.StatusNotFound, port.APIerrorResponse{
+			Message: "account not found",
+		})
+		return
+	} else if err != nil {
+		app.Logger.Error(requestCtx, err.Error())
+		ctx.JSON(http.StatusInternalServerError, port.APIerrorResponse{
+			Message: "failed to retrieve account balance",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapBalanceResponse(result))
+}
+
+func badRequest(ctx *gin.Context, app bootstrap.RESTApp, requestCtx context.Context, message string) {
+	app.Logger.Warn(requestCtx, message)
+
+	ctx.JSON(http.StatusBadRequest, port.APIerrorResponse{
+		Message: message,
+	})
+}
+
+func mapTransactionHistoryResponse(thr *pb.TransactionHistoryResponse) port.TransactionHistoryResponse {
+	items := []port.TransactionHistoryItemResponse{}
+	for _, entry := range thr.Transactions {
+		amount, _ := decimal.NewFromString(entry.Amount)
+		balance, _ := decimal.NewFromString(entry.Balance)
+		createdAt, _ := time.Parse(time.RFC3339, entry.CreatedAt)
+
+		items = append(items, port.TransactionHistoryItemResponse{
+			TransactionUID: entry.Transaction,
+			OriginalUID:    entry.Original,
+			Category:       entry.Category,
+			Operation:      entry.Operation,
+			Amount:         amount,
+			Currency:       entry.Currency,
+			Balance:        balance,
+			MCC:            entry.Mcc,
+			Merchant:       entry.Merchant,
+			CreatedAt:      createdAt,
+		})
+	}
+
+	return port.TransactionHistoryResponse{
+		Transactions: items,
+		NextCursor:   thr.NextCursor,
+	}
+}
+
+func mapBalanceResponse(br *pb.BalanceResponse) port.BalanceResponse {
+	categories := []port.BalanceCategoryResponse{}
+	for _, cb := range br.Categories {
+		amount, _ := decimal.NewFromString(cb.Amount)
+
+		category := port.BalanceCategoryResponse{
+			Name:     cb.Name,
+			Priority: int(cb.Priority),
+			MCCs:     cb.Mccs,
+			Amount:   amount,
+			Currency: cb.Currency,
+		}
+
+		if cb.AmountHeld != "" {
+			amountHeld, _ := decimal.NewFromString(cb.AmountHeld)
+			category.AmountHeld = &amountHeld
+		}
+
+		if category.MCCs == nil {
+			category.MCCs = []string{}
+		}
+
+		categories = append(categories, category)
+	}
+
+	amountTotal, _ := decimal.NewFromString(br.AmountTotal)
+	balance := port.BalanceResponse{
+		AccountUID:  br.Account,
+		AmountTotal: amountTotal,
+		Categories:  categories,
+	}
+
+	if br.AmountHeld != "" {
+		amountHeld, _ := decimal.NewFromString(br.AmountHeld)
+		balance.AmountHeld = &amountHeld
+	}
+
+	return balance
+}
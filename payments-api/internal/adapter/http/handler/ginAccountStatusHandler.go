@@ -0,0 +1,160 @@
+package ginHandler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+
+	"github.com/jtonynet/go-payments-api/bootstrap"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+
+	pb "github.com/jtonynet/go-payments-api/internal/adapter/gRPC/pb"
+)
+
+// @Summary Admin Block Account
+// @Description Blocks an **ACTIVE** account. Payments and authorizations of a blocked account are **rejected by account blocked** (code **62**), while credits and refunds still reach it. The body is optional, its **reason** is kept in the audit trail of the account.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the account"
+// @Param request body port.AccountStatusRequest false "Request body for Account Status"
+// @Router /admin/accounts/{uid}/block [post]
+// @Success 200 {object} port.AccountStatusChangeResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 409 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminBlockAccount(ctx *gin.Context) {
+	changeAccountStatus(ctx, port.ACCOUNT_ACTION_BLOCK)
+}
+
+// @Summary Admin Unblock Account
+// @Description Unblocks a **BLOCKED** account, back to **ACTIVE**. The body is optional, its **reason** is kept in the audit trail of the account.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the account"
+// @Param request body port.AccountStatusRequest false "Request body for Account Status"
+// @Router /admin/accounts/{uid}/unblock [post]
+// @Success 200 {object} port.AccountStatusChangeResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 409 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminUnblockAccount(ctx *gin.Context) {
+	changeAccountStatus(ctx, port.ACCOUNT_ACTION_UNBLOCK)
+}
+
+// @Summary Admin Cancel Account
+// @Description Cancels an **ACTIVE** or **BLOCKED** account, a cancellation is final. Every transaction of a cancelled account is **rejected by account cancelled** (code **46**). The body is optional, its **reason** is kept in the audit trail of the account.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the account"
+// @Param request body port.AccountStatusRequest false "Request body for Account Status"
+// @Router /admin/accounts/{uid}/cancel [post]
+// @Success 200 {object} port.AccountStatusChangeResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 409 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminCancelAccount(ctx *gin.Context) {
+	changeAccountStatus(ctx, port.ACCOUNT_ACTION_CANCEL)
+}
+
+// @Summary Admin List Account Status Changes
+// @Description Lists the audit trail of the account status changes, oldest first.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the account"
+// @Router /admin/accounts/{uid}/status-changes [get]
+// @Success 200 {object} port.AccountStatusChangeListResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminListAccountStatusChanges(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	accountUID, err := uuid.Parse(ctx.Param("uid"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid account uid: %s", err.Error()))
+		return
+	}
+
+	result, err := app.GRPCadmin.ListAccountStatusChanges(
+		context.Background(),
+		&pb.AccountRequest{Account: accountUID.String()},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to list account status changes")
+		return
+	}
+
+	changes := []port.AccountStatusChangeResponse{}
+	for _, change := range result.Changes {
+		changes = append(changes, mapAdminAccountStatusChangeResponse(change))
+	}
+
+	ctx.JSON(http.StatusOK, port.AccountStatusChangeListResponse{
+		Changes: changes,
+	})
+}
+
+func changeAccountStatus(ctx *gin.Context, action string) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	accountUID, err := uuid.Parse(ctx.Param("uid"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid account uid: %s", err.Error()))
+		return
+	}
+
+	var accountStatusRequest port.AccountStatusRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindBodyWith(&accountStatusRequest, binding.JSON); err != nil {
+			badRequest(ctx, app, requestCtx, err.Error())
+			return
+		}
+	}
+
+	validationErrors, ok := dtoIsValid(accountStatusRequest)
+	if !ok {
+		badRequest(ctx, app, requestCtx, validationErrors)
+		return
+	}
+
+	result, err := app.GRPCadmin.ChangeAccountStatus(
+		context.Background(),
+		&pb.AccountStatusRequest{
+			Account: accountUID.String(),
+			Action:  action,
+			Reason:  accountStatusRequest.Reason,
+		},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to change account status")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapAdminAccountStatusChangeResponse(result))
+}
+
+func mapAdminAccountStatusChangeResponse(ascr *pb.AccountStatusChangeResponse) port.AccountStatusChangeResponse {
+	createdAt, _ := time.Parse(time.RFC3339, ascr.CreatedAt)
+
+	return port.AccountStatusChangeResponse{
+		AccountUID:     ascr.Account,
+		PreviousStatus: ascr.PreviousStatus,
+		Status:         ascr.Status,
+		Reason:         ascr.Reason,
+		CreatedAt:      createdAt,
+	}
+}
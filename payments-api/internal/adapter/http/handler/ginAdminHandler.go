@@ -0,0 +1,418 @@
+package ginHandler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jtonynet/go-payments-api/bootstrap"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/support/logger"
+
+	pb "github.com/jtonynet/go-payments-api/internal/adapter/gRPC/pb"
+)
+
+// @Summary Admin Create Account
+// @Description Creates an account without categories. Attach categories to it to allow payments.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body port.AccountCreateRequest true "Request body for Account creation"
+// @Router /admin/accounts [post]
+// @Success 201 {object} port.AccountResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminCreateAccount(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	var accountRequest port.AccountCreateRequest
+	if err := ctx.ShouldBindBodyWith(&accountRequest, binding.JSON); err != nil {
+		badRequest(ctx, app, requestCtx, err.Error())
+		return
+	}
+
+	validationErrors, ok := dtoIsValid(accountRequest)
+	if !ok {
+		badRequest(ctx, app, requestCtx, validationErrors)
+		return
+	}
+
+	result, err := app.GRPCadmin.CreateAccount(
+		context.Background(),
+		&pb.CreateAccountRequest{
+			Name:               accountRequest.Name,
+			CurrencyConversion: accountRequest.CurrencyConversion,
+		},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to create account")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, mapAdminAccountResponse(result))
+}
+
+// @Summary Admin List Accounts
+// @Description Lists the active accounts with their categories and MCCs, from the oldest to the newest. Use **nextCursor** of the response as **cursor** to retrieve the next page.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param cursor query string false "Cursor returned by the previous page"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Router /admin/accounts [get]
+// @Success 200 {object} port.AccountListResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminListAccounts(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	var listRequest port.AccountListRequest
+	if err := ctx.ShouldBindQuery(&listRequest); err != nil {
+		badRequest(ctx, app, requestCtx, err.Error())
+		return
+	}
+
+	validationErrors, ok := dtoIsValid(listRequest)
+	if !ok {
+		badRequest(ctx, app, requestCtx, validationErrors)
+		return
+	}
+
+	result, err := app.GRPCadmin.ListAccounts(
+		context.Background(),
+		&pb.ListAccountsRequest{
+			Cursor: listRequest.Cursor,
+			Limit:  int32(listRequest.Limit),
+		},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to list accounts")
+		return
+	}
+
+	accounts := []port.AccountResponse{}
+	for _, account := range result.Accounts {
+		accounts = append(accounts, mapAdminAccountResponse(account))
+	}
+
+	ctx.JSON(http.StatusOK, port.AccountListResponse{
+		Accounts:   accounts,
+		NextCursor: result.NextCursor,
+	})
+}
+
+// @Summary Admin Delete Account
+// @Description Soft deletes the account and the links to its categories. The transactions history is kept.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the account"
+// @Router /admin/accounts/{uid} [delete]
+// @Success 204
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminDeleteAccount(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+
+	requestCtx := context.Background()
+	requestCtx = context.WithValue(requestCtx, logger.CtxAccountUIDKey, ctx.Param("uid"))
+
+	accountUID, err := uuid.Parse(ctx.Param("uid"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid account uid: %s", err.Error()))
+		return
+	}
+
+	_, err = app.GRPCadmin.DeleteAccount(
+		context.Background(),
+		&pb.AccountRequest{Account: accountUID.String()},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to delete account")
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary Admin Get Account Lock Queue
+// @Description Returns how many transactions of the account are waiting for its distributed lock. Waiters are granted the lock in arrival order.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the account"
+// @Router /admin/accounts/{uid}/lock-queue [get]
+// @Success 200 {object} port.MemoryLockQueueResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminGetLockQueue(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+
+	requestCtx := context.Background()
+	requestCtx = context.WithValue(requestCtx, logger.CtxAccountUIDKey, ctx.Param("uid"))
+
+	accountUID, err := uuid.Parse(ctx.Param("uid"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid account uid: %s", err.Error()))
+		return
+	}
+
+	result, err := app.GRPCadmin.GetLockQueue(
+		context.Background(),
+		&pb.AccountRequest{Account: accountUID.String()},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to get account lock queue")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, port.MemoryLockQueueResponse{
+		AccountUID: result.Account,
+		Depth:      result.Depth,
+	})
+}
+
+// @Summary Admin Attach Category
+// @Description Attaches a category to the account. A zero balance is opened for the category when the account never had one.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the account"
+// @Param categoryUID path string true "UUID of the category"
+// @Router /admin/accounts/{uid}/categories/{categoryUID} [post]
+// @Success 204
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 409 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminAttachCategory(ctx *gin.Context) {
+	accountCategoryCall(ctx, "failed to attach category", func(app bootstrap.RESTApp, acr *pb.AccountCategoryRequest) error {
+		_, err := app.GRPCadmin.AttachCategory(context.Background(), acr)
+		return err
+	})
+}
+
+// @Summary Admin Detach Category
+// @Description Detaches a category from the account. The category balance is kept and restored if the category is attached again.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the account"
+// @Param categoryUID path string true "UUID of the category"
+// @Router /admin/accounts/{uid}/categories/{categoryUID} [delete]
+// @Success 204
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminDetachCategory(ctx *gin.Context) {
+	accountCategoryCall(ctx, "failed to detach category", func(app bootstrap.RESTApp, acr *pb.AccountCategoryRequest) error {
+		_, err := app.GRPCadmin.DetachCategory(context.Background(), acr)
+		return err
+	})
+}
+
+// @Summary Admin Create Category
+// @Description Creates a category. Categories with lower priority are debited first.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body port.CategoryCreateRequest true "Request body for Category creation"
+// @Router /admin/categories [post]
+// @Success 201 {object} port.CategoryResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminCreateCategory(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	var categoryRequest port.CategoryCreateRequest
+	if err := ctx.ShouldBindBodyWith(&categoryRequest, binding.JSON); err != nil {
+		badRequest(ctx, app, requestCtx, err.Error())
+		return
+	}
+
+	validationErrors, ok := dtoIsValid(categoryRequest)
+	if !ok {
+		badRequest(ctx, app, requestCtx, validationErrors)
+		return
+	}
+
+	result, err := app.GRPCadmin.CreateCategory(
+		context.Background(),
+		&pb.CreateCategoryRequest{
+			Name:     categoryRequest.Name,
+			Priority: int32(categoryRequest.Priority),
+			Currency: categoryRequest.Currency,
+
+			FallbackExcluded: categoryRequest.FallbackExcluded,
+		},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to create category")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, mapAdminCategoryResponse(result))
+}
+
+// @Summary Admin Assign MCC
+// @Description Assigns a Merchant Category Code to the category. An MCC belongs to a single active category.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the category"
+// @Param request body port.MCCAssignRequest true "Request body for MCC assignment"
+// @Router /admin/categories/{uid}/mccs [post]
+// @Success 201 {object} port.MCCResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 409 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminAssignMCC(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	categoryUID, err := uuid.Parse(ctx.Param("uid"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid category uid: %s", err.Error()))
+		return
+	}
+
+	var mccRequest port.MCCAssignRequest
+	if err := ctx.ShouldBindBodyWith(&mccRequest, binding.JSON); err != nil {
+		badRequest(ctx, app, requestCtx, err.Error())
+		return
+	}
+
+	validationErrors, ok := dtoIsValid(mccRequest)
+	if !ok {
+		badRequest(ctx, app, requestCtx, validationErrors)
+		return
+	}
+
+	result, err := app.GRPCadmin.AssignMCC(
+		context.Background(),
+		&pb.AssignMCCRequest{
+			Category: categoryUID.String(),
+			Mcc:      mccRequest.MCC,
+		},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to assign mcc")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, port.MCCResponse{
+		UID:         result.MccUid,
+		CategoryUID: result.Category,
+		MCC:         result.Mcc,
+	})
+}
+
+func accountCategoryCall(
+	ctx *gin.Context,
+	failureMessage string,
+	call func(bootstrap.RESTApp, *pb.AccountCategoryRequest) error,
+) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+
+	requestCtx := context.Background()
+	requestCtx = context.WithValue(requestCtx, logger.CtxAccountUIDKey, ctx.Param("uid"))
+
+	accountUID, err := uuid.Parse(ctx.Param("uid"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid account uid: %s", err.Error()))
+		return
+	}
+
+	categoryUID, err := uuid.Parse(ctx.Param("categoryUID"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid category uid: %s", err.Error()))
+		return
+	}
+
+	err = call(
+		app,
+		&pb.AccountCategoryRequest{
+			Account:  accountUID.String(),
+			Category: categoryUID.String(),
+		},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, failureMessage)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+/*
+  - Client errors carry the message of the processor, internal
+    failures are only logged and answered with a generic message
+*/
+func adminFailure(ctx *gin.Context, app bootstrap.RESTApp, requestCtx context.Context, err error, message string) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		badRequest(ctx, app, requestCtx, status.Convert(err).Message())
+	case codes.NotFound:
+		app.Logger.Warn(requestCtx, err.Error())
+		ctx.JSON(http.StatusNotFound, port.APIerrorResponse{
+			Message: status.Convert(err).Message(),
+		})
+	case codes.AlreadyExists, codes.FailedPrecondition:
+		app.Logger.Warn(requestCtx, err.Error())
+		ctx.JSON(http.StatusConflict, port.APIerrorResponse{
+			Message: status.Convert(err).Message(),
+		})
+	default:
+		app.Logger.Error(requestCtx, err.Error())
+		ctx.JSON(http.StatusInternalServerError, port.APIerrorResponse{
+			Message: message,
+		})
+	}
+}
+
+func mapAdminAccountResponse(ar *pb.AccountResponse) port.AccountResponse {
+	categories := []port.CategoryResponse{}
+	for _, category := range ar.Categories {
+		categories = append(categories, mapAdminCategoryResponse(category))
+	}
+
+	createdAt, _ := time.Parse(time.RFC3339, ar.CreatedAt)
+
+	return port.AccountResponse{
+		UID:                ar.Account,
+		Name:               ar.Name,
+		CurrencyConversion: ar.CurrencyConversion,
+		Status:             ar.Status,
+		Categories:         categories,
+		CreatedAt:          createdAt,
+	}
+}
+
+func mapAdminCategoryResponse(cr *pb.CategoryResponse) port.CategoryResponse {
+	mccs := cr.Mccs
+	if mccs == nil {
+		mccs = []string{}
+	}
+
+	return port.CategoryResponse{
+		UID:      cr.Category,
+		Name:     cr.Name,
+		Priority: int(cr.Priority),
+		MCCs:     mccs,
+		Currency: cr.Currency,
+
+		FallbackExcluded: cr.FallbackExcluded,
+	}
+}
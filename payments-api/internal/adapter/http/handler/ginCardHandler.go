@@ -0,0 +1,182 @@
+package ginHandler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/jtonynet/go-payments-api/bootstrap"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+
+	pb "github.com/jtonynet/go-payments-api/internal/adapter/gRPC/pb"
+)
+
+// @Summary Admin Create Card
+// @Description Registers a tokenized card of the account. Payments may send the card **token** instead of the account UUID. The **maskedPan** keeps only the first six and last four digits, the **expiry** is the MM/YY printed on the card, and a zero cap is not enforced. Payments with a blocked or cancelled card are **rejected by card not permitted** (code **57**), with an expired card **rejected by expired card** (code **54**) and breaching a card cap **rejected by limit exceeded** (code **61**).
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the account"
+// @Param request body port.CardCreateRequest true "Request body for Create Card"
+// @Router /admin/accounts/{uid}/cards [post]
+// @Success 201 {object} port.CardResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 409 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminCreateCard(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	accountUID, err := uuid.Parse(ctx.Param("uid"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid account uid: %s", err.Error()))
+		return
+	}
+
+	var cardCreateRequest port.CardCreateRequest
+	if err := ctx.ShouldBindBodyWith(&cardCreateRequest, binding.JSON); err != nil {
+		badRequest(ctx, app, requestCtx, err.Error())
+		return
+	}
+
+	validationErrors, ok := dtoIsValid(cardCreateRequest)
+	if !ok {
+		badRequest(ctx, app, requestCtx, validationErrors)
+		return
+	}
+
+	result, err := app.GRPCadmin.CreateCard(
+		context.Background(),
+		&pb.CreateCardRequest{
+			Account:           accountUID.String(),
+			Token:             cardCreateRequest.Token,
+			MaskedPan:         cardCreateRequest.MaskedPAN,
+			Expiry:            cardCreateRequest.Expiry,
+			TransactionAmount: cardCreateRequest.TransactionAmount.String(),
+			DailyAmount:       cardCreateRequest.DailyAmount.String(),
+		},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to create card")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, mapAdminCardResponse(result))
+}
+
+// @Summary Admin List Cards
+// @Description Lists the cards of the account, oldest first. The card tokens are never returned.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the account"
+// @Router /admin/accounts/{uid}/cards [get]
+// @Success 200 {object} port.CardListResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminListCards(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	accountUID, err := uuid.Parse(ctx.Param("uid"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid account uid: %s", err.Error()))
+		return
+	}
+
+	result, err := app.GRPCadmin.ListCards(
+		context.Background(),
+		&pb.AccountRequest{Account: accountUID.String()},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to list cards")
+		return
+	}
+
+	cards := []port.CardResponse{}
+	for _, card := range result.Cards {
+		cards = append(cards, mapAdminCardResponse(card))
+	}
+
+	ctx.JSON(http.StatusOK, port.CardListResponse{
+		Cards: cards,
+	})
+}
+
+// @Summary Admin Update Card
+// @Description Replaces the **status** (ACTIVE, BLOCKED or CANCELLED), the **expiry** and the caps of the card. A cancelled card can't be changed.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the card"
+// @Param request body port.CardUpdateRequest true "Request body for Update Card"
+// @Router /admin/cards/{uid} [put]
+// @Success 200 {object} port.CardResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 409 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminUpdateCard(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	cardUID, err := uuid.Parse(ctx.Param("uid"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid card uid: %s", err.Error()))
+		return
+	}
+
+	var cardUpdateRequest port.CardUpdateRequest
+	if err := ctx.ShouldBindBodyWith(&cardUpdateRequest, binding.JSON); err != nil {
+		badRequest(ctx, app, requestCtx, err.Error())
+		return
+	}
+
+	validationErrors, ok := dtoIsValid(cardUpdateRequest)
+	if !ok {
+		badRequest(ctx, app, requestCtx, validationErrors)
+		return
+	}
+
+	result, err := app.GRPCadmin.UpdateCard(
+		context.Background(),
+		&pb.UpdateCardRequest{
+			Card:              cardUID.String(),
+			Status:            cardUpdateRequest.Status,
+			Expiry:            cardUpdateRequest.Expiry,
+			TransactionAmount: cardUpdateRequest.TransactionAmount.String(),
+			DailyAmount:       cardUpdateRequest.DailyAmount.String(),
+		},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to update card")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapAdminCardResponse(result))
+}
+
+func mapAdminCardResponse(cr *pb.CardResponse) port.CardResponse {
+	transactionAmount, _ := decimal.NewFromString(cr.TransactionAmount)
+	dailyAmount, _ := decimal.NewFromString(cr.DailyAmount)
+	createdAt, _ := time.Parse(time.RFC3339, cr.CreatedAt)
+
+	return port.CardResponse{
+		UID:               cr.Card,
+		AccountUID:        cr.Account,
+		MaskedPAN:         cr.MaskedPan,
+		Status:            cr.Status,
+		Expiry:            cr.Expiry,
+		TransactionAmount: transactionAmount,
+		DailyAmount:       dailyAmount,
+		CreatedAt:         createdAt,
+	}
+}
@@ -0,0 +1,121 @@
+package ginHandler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+
+	"github.com/jtonynet/go-payments-api/bootstrap"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+
+	pb "github.com/jtonynet/go-payments-api/internal/adapter/gRPC/pb"
+)
+
+// @Summary Admin Set Category Rule
+// @Description Replaces the ordered fallback chain tried once the category matched by the MCC runs out of funds, e.g. **MEAL → FOOD → CASH**. Without **category** the chain applies to transactions whose MCC matches no category. Without **account** the chain is the default of every account, otherwise an override of the account. Categories created with **fallbackExcluded** are never accepted as fallbacks. An empty **fallbacks** removes the chain.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body port.CategoryRuleRequest true "Request body for Category Rule"
+// @Router /admin/category-rules [put]
+// @Success 200 {object} port.CategoryRuleResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminSetCategoryRule(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	var categoryRuleRequest port.CategoryRuleRequest
+	if err := ctx.ShouldBindBodyWith(&categoryRuleRequest, binding.JSON); err != nil {
+		badRequest(ctx, app, requestCtx, err.Error())
+		return
+	}
+
+	validationErrors, ok := dtoIsValid(categoryRuleRequest)
+	if !ok {
+		badRequest(ctx, app, requestCtx, validationErrors)
+		return
+	}
+
+	result, err := app.GRPCadmin.SetCategoryRule(
+		context.Background(),
+		&pb.CategoryRuleRequest{
+			Account:   categoryRuleRequest.AccountUID,
+			Category:  categoryRuleRequest.CategoryUID,
+			Fallbacks: categoryRuleRequest.Fallbacks,
+		},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to set category rule")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapAdminCategoryRuleResponse(result))
+}
+
+// @Summary Admin List Category Rules
+// @Description Lists the default category rules, along with the overrides of the account when given. Each rule lists its fallback categories in the order they are tried.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param account query string false "UUID of the account whose overrides are listed"
+// @Router /admin/category-rules [get]
+// @Success 200 {object} port.CategoryRuleListResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminListCategoryRules(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	accountUID := ""
+	if account := ctx.Query("account"); account != "" {
+		parsedUID, err := uuid.Parse(account)
+		if err != nil {
+			badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid account uid: %s", err.Error()))
+			return
+		}
+
+		accountUID = parsedUID.String()
+	}
+
+	result, err := app.GRPCadmin.ListCategoryRules(
+		context.Background(),
+		&pb.ListCategoryRulesRequest{
+			Account: accountUID,
+		},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to list category rules")
+		return
+	}
+
+	rules := []port.CategoryRuleResponse{}
+	for _, rule := range result.Rules {
+		rules = append(rules, mapAdminCategoryRuleResponse(rule))
+	}
+
+	ctx.JSON(http.StatusOK, port.CategoryRuleListResponse{
+		Rules: rules,
+	})
+}
+
+func mapAdminCategoryRuleResponse(crr *pb.CategoryRuleResponse) port.CategoryRuleResponse {
+	fallbacks := []port.CategoryRuleFallbackResponse{}
+	for _, fallback := range crr.Fallbacks {
+		fallbacks = append(fallbacks, port.CategoryRuleFallbackResponse{
+			UID:  fallback.Category,
+			Name: fallback.Name,
+		})
+	}
+
+	return port.CategoryRuleResponse{
+		AccountUID:  crr.Account,
+		CategoryUID: crr.Category,
+		Fallbacks:   fallbacks,
+	}
+}
@@ -0,0 +1,206 @@
+package ginHandler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jtonynet/go-payments-api/bootstrap"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/support/logger"
+
+	pb "github.com/jtonynet/go-payments-api/internal/adapter/gRPC/pb"
+)
+
+// @Summary Credit Transaction
+// @Description Credits an amount into a category attached to the account. The HTTP status is always 200. The credit can be **approved** (code **00**), **rejected invalid transaction** (code **12**) when the category is not attached to the account, **rejected invalid amount** (code **13**), **rejected invalid account** (code **14**), **rejected by account cancelled** (code **46**), **rejected by system timeout** (code **91**), **rejected as duplicate transaction** (code **94**) or **rejected generally** (code **07**).
+// @Tags Credit
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Client UUID of the credit, retries with the same key replay the original response code"
+// @Param request body port.TransactionCreditRequest true "Request body for Credit Transaction"
+// @Router /credit [post]
+// @Success 200 {object} port.TransactionPaymentResponse
+func CreditExecution(ctx *gin.Context) {
+	startTime := time.Now()
+	code := port.CODE_REJECTED_GENERIC
+
+	transactionUID := ctx.GetHeader(IDEMPOTENCY_KEY_HEADER)
+	if transactionUID == "" {
+		transactionUID = uuid.NewString()
+	}
+
+	requestCtx := context.Background()
+	requestCtx = context.WithValue(requestCtx, logger.CtxTransactionUIDKey, transactionUID)
+
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+
+	app.Logger.Info(requestCtx, "Credit Initialized")
+
+	defer func() {
+		requestCtx = context.WithValue(requestCtx, logger.CtxExecutionTimeKey, time.Since(startTime))
+		requestCtx = context.WithValue(requestCtx, logger.CtxResponseCodeKey, code)
+		app.Logger.Info(requestCtx, "Credit Finished")
+	}()
+
+	if _, err := uuid.Parse(transactionUID); err != nil {
+		app.Logger.Error(
+			requestCtx,
+			fmt.Sprintf("rejected: %s, invalid %s header, error:%s\n", port.CODE_REJECTED_GENERIC, IDEMPOTENCY_KEY_HEADER, err.Error()),
+		)
+
+		ctx.JSON(http.StatusOK, codeResponse(port.CODE_REJECTED_GENERIC))
+
+		return
+	}
+
+	var creditRequest port.TransactionCreditRequest
+	if err := ctx.ShouldBindBodyWith(&creditRequest, binding.JSON); err != nil {
+		code = bodyRejectionCode(ctx)
+		app.Logger.Error(
+			requestCtx,
+			fmt.Sprintf("rejected: %s, error:%s\n", code, err.Error()),
+		)
+
+		ctx.JSON(http.StatusOK, codeResponse(code))
+
+		return
+	}
+	accountUID := creditRequest.AccountUID.String()
+	requestCtx = context.WithValue(requestCtx, logger.CtxAccountUIDKey, accountUID)
+
+	validationErrors, ok := dtoIsValid(creditRequest)
+	if !ok {
+		code = bodyRejectionCode(ctx)
+		app.Logger.Error(requestCtx, validationErrors)
+
+		ctx.JSON(http.StatusOK, codeResponse(code))
+
+		return
+	}
+
+	result, err := app.GRPCpayment.Credit(
+		context.Background(),
+		&pb.CreditRequest{
+			Account:     accountUID,
+			Transaction: transactionUID,
+			Category:    creditRequest.Category,
+			TotalAmount: creditRequest.TotalAmount.String(),
+		},
+	)
+	if err != nil {
+		code = callRejectionCode(err)
+		app.Logger.Error(requestCtx, err.Error())
+
+		ctx.JSON(http.StatusOK, codeResponse(code))
+
+		return
+	}
+
+	code = result.Code
+	ctx.JSON(http.StatusOK, mapTransactionPaymentResponse(result))
+}
+
+// @Summary Credit Batch
+// @Description Credits a payroll file of up to 100000 credits in a single call. Credits of the same account are applied in the file order. Each **transaction** is the idempotency key of its line, so the file can be resent after a partial failure; lines without it get a new UUID. The response counts the approved and rejected credits and lists the rejections by their **index** in the file, with the **code** and **reason** of each.
+// @Tags Credit
+// @Accept json
+// @Produce json
+// @Param request body port.TransactionCreditBatchRequest true "Request body for Credit Batch"
+// @Router /credit/batch [post]
+// @Success 200 {object} port.TransactionCreditBatchResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func CreditBatch(ctx *gin.Context) {
+	startTime := time.Now()
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	var batchRequest port.TransactionCreditBatchRequest
+	if err := ctx.ShouldBindBodyWith(&batchRequest, binding.JSON); err != nil {
+		badRequest(ctx, app, requestCtx, err.Error())
+		return
+	}
+
+	validationErrors, ok := dtoIsValid(batchRequest)
+	if !ok {
+		badRequest(ctx, app, requestCtx, validationErrors)
+		return
+	}
+
+	app.Logger.Info(requestCtx, fmt.Sprintf("Credit Batch Initialized with %d credits", len(batchRequest.Credits)))
+
+	stream, err := app.GRPCpayment.CreditBatch(context.Background())
+	if err != nil {
+		creditBatchFailure(ctx, app, requestCtx, err)
+		return
+	}
+
+	for _, credit := range batchRequest.Credits {
+		transactionUID := ""
+		if credit.TransactionUID != uuid.Nil {
+			transactionUID = credit.TransactionUID.String()
+		}
+
+		err = stream.Send(&pb.CreditRequest{
+			Account:     credit.AccountUID.String(),
+			Transaction: transactionUID,
+			Category:    credit.Category,
+			TotalAmount: credit.TotalAmount.String(),
+		})
+		if err != nil {
+			break
+		}
+	}
+
+	/*
+		A failed Send only reports io.EOF, the stream status comes from CloseAndRecv
+	*/
+	result, err := stream.CloseAndRecv()
+	if err != nil {
+		creditBatchFailure(ctx, app, requestCtx, err)
+		return
+	}
+
+	rejections := []port.TransactionCreditRejectionResponse{}
+	for _, rejection := range result.Rejections {
+		rejections = append(rejections, port.TransactionCreditRejectionResponse{
+			Index:          int(rejection.Index),
+			AccountUID:     rejection.Account,
+			TransactionUID: rejection.Transaction,
+			Code:           rejection.Code,
+			Reason:         rejection.Reason,
+		})
+	}
+
+	requestCtx = context.WithValue(requestCtx, logger.CtxExecutionTimeKey, time.Since(startTime))
+	app.Logger.Info(
+		requestCtx,
+		fmt.Sprintf("Credit Batch Finished: %d approved, %d rejected", result.Approved, result.Rejected),
+	)
+
+	ctx.JSON(http.StatusOK, port.TransactionCreditBatchResponse{
+		Approved:   int(result.Approved),
+		Rejected:   int(result.Rejected),
+		Rejections: rejections,
+	})
+}
+
+func creditBatchFailure(ctx *gin.Context, app bootstrap.RESTApp, requestCtx context.Context, err error) {
+	if status.Code(err) == codes.InvalidArgument {
+		badRequest(ctx, app, requestCtx, status.Convert(err).Message())
+		return
+	}
+
+	app.Logger.Error(requestCtx, err.Error())
+	ctx.JSON(http.StatusInternalServerError, port.APIerrorResponse{
+		Message: "failed to process credit batch",
+	})
+}
@@ -0,0 +1,93 @@
+package ginHandler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/jtonynet/go-payments-api/bootstrap"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+
+	pb "github.com/jtonynet/go-payments-api/internal/adapter/gRPC/pb"
+)
+
+// @Summary Admin Check Ledger Consistency
+// @Description Recomputes the category balances of a page of accounts from their ledger entries, the credits and debits of each movement. Reports the balances that differ from the recorded ones, or whose entries have a **balance before** different from the **balance after** of the previous entry. Use **nextCursor** of the response as **cursor** to check the next page.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param account query string false "UUID of the account, every account when empty"
+// @Param cursor query string false "Cursor returned by the previous page"
+// @Param limit query int false "Accounts per page, 50 by default and at most 500"
+// @Router /admin/ledger/consistency [get]
+// @Success 200 {object} port.LedgerConsistencyResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminCheckLedger(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	var ledgerRequest port.LedgerConsistencyRequest
+	if err := ctx.ShouldBindQuery(&ledgerRequest); err != nil {
+		badRequest(ctx, app, requestCtx, err.Error())
+		return
+	}
+
+	if account := ctx.Query("account"); account != "" {
+		accountUID, err := uuid.Parse(account)
+		if err != nil {
+			badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid account uid: %s", err.Error()))
+			return
+		}
+
+		ledgerRequest.AccountUID = accountUID
+	}
+
+	validationErrors, ok := dtoIsValid(ledgerRequest)
+	if !ok {
+		badRequest(ctx, app, requestCtx, validationErrors)
+		return
+	}
+
+	accountUID := ""
+	if ledgerRequest.AccountUID != uuid.Nil {
+		accountUID = ledgerRequest.AccountUID.String()
+	}
+
+	result, err := app.GRPCadmin.CheckLedger(
+		context.Background(),
+		&pb.LedgerConsistencyRequest{
+			Account: accountUID,
+			Cursor:  ledgerRequest.Cursor,
+			Limit:   int32(ledgerRequest.Limit),
+		},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to check ledger consistency")
+		return
+	}
+
+	inconsistencies := []port.LedgerBalanceResponse{}
+	for _, lb := range result.Inconsistencies {
+		balance, _ := decimal.NewFromString(lb.Balance)
+		ledgerBalance, _ := decimal.NewFromString(lb.LedgerBalance)
+
+		inconsistencies = append(inconsistencies, port.LedgerBalanceResponse{
+			AccountUID:    lb.Account,
+			Category:      lb.Category,
+			Balance:       balance,
+			LedgerBalance: ledgerBalance,
+			BrokenEntries: int(lb.BrokenEntries),
+		})
+	}
+
+	ctx.JSON(http.StatusOK, port.LedgerConsistencyResponse{
+		Checked:         int(result.Checked),
+		Inconsistencies: inconsistencies,
+		NextCursor:      result.NextCursor,
+	})
+}
@@ -0,0 +1,248 @@
+package ginHandler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+
+	"github.com/jtonynet/go-payments-api/bootstrap"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+
+	pb "github.com/jtonynet/go-payments-api/internal/adapter/gRPC/pb"
+)
+
+// @Summary Admin Create Merchant
+// @Description Registers a merchant to correct the MCC of the transactions sent with its name. The MCC must be assigned to a category. Names that do not match exactly are normalized (casing, padding, city and country columns) and matched against the merchants and their **aliases**; an alias ending with `*` is a prefix rule, such as `PAG*`.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body port.MerchantCreateRequest true "Request body for Merchant creation"
+// @Router /admin/merchants [post]
+// @Success 201 {object} port.MerchantResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 409 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminCreateMerchant(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	var merchantRequest port.MerchantCreateRequest
+	if err := ctx.ShouldBindBodyWith(&merchantRequest, binding.JSON); err != nil {
+		badRequest(ctx, app, requestCtx, err.Error())
+		return
+	}
+
+	validationErrors, ok := dtoIsValid(merchantRequest)
+	if !ok {
+		badRequest(ctx, app, requestCtx, validationErrors)
+		return
+	}
+
+	result, err := app.GRPCadmin.CreateMerchant(
+		context.Background(),
+		&pb.CreateMerchantRequest{
+			Name:    merchantRequest.Name,
+			Mcc:     merchantRequest.MCC,
+			Aliases: merchantRequest.Aliases,
+		},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to create merchant")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, mapAdminMerchantResponse(result))
+}
+
+// @Summary Admin List Merchants
+// @Description Lists the active merchants, from the oldest to the newest. Use **nextCursor** of the response as **cursor** to retrieve the next page.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param cursor query string false "Cursor returned by the previous page"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Router /admin/merchants [get]
+// @Success 200 {object} port.MerchantListResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminListMerchants(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	var listRequest port.MerchantListRequest
+	if err := ctx.ShouldBindQuery(&listRequest); err != nil {
+		badRequest(ctx, app, requestCtx, err.Error())
+		return
+	}
+
+	validationErrors, ok := dtoIsValid(listRequest)
+	if !ok {
+		badRequest(ctx, app, requestCtx, validationErrors)
+		return
+	}
+
+	result, err := app.GRPCadmin.ListMerchants(
+		context.Background(),
+		&pb.ListMerchantsRequest{
+			Cursor: listRequest.Cursor,
+			Limit:  int32(listRequest.Limit),
+		},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to list merchants")
+		return
+	}
+
+	merchants := []port.MerchantResponse{}
+	for _, merchant := range result.Merchants {
+		merchants = append(merchants, mapAdminMerchantResponse(merchant))
+	}
+
+	ctx.JSON(http.StatusOK, port.MerchantListResponse{
+		Merchants:  merchants,
+		NextCursor: result.NextCursor,
+	})
+}
+
+// @Summary Admin Get Merchant
+// @Description Retrieves an active merchant.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the merchant"
+// @Router /admin/merchants/{uid} [get]
+// @Success 200 {object} port.MerchantResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminGetMerchant(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	merchantUID, err := uuid.Parse(ctx.Param("uid"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid merchant uid: %s", err.Error()))
+		return
+	}
+
+	result, err := app.GRPCadmin.GetMerchant(
+		context.Background(),
+		&pb.MerchantRequest{Merchant: merchantUID.String()},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to retrieve merchant")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapAdminMerchantResponse(result))
+}
+
+// @Summary Admin Update Merchant
+// @Description Replaces the name and the MCC of the merchant. The cached MCC of both the previous and the new name are evicted.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the merchant"
+// @Param request body port.MerchantUpdateRequest true "Request body for Merchant update"
+// @Router /admin/merchants/{uid} [put]
+// @Success 200 {object} port.MerchantResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 409 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminUpdateMerchant(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	merchantUID, err := uuid.Parse(ctx.Param("uid"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid merchant uid: %s", err.Error()))
+		return
+	}
+
+	var merchantRequest port.MerchantUpdateRequest
+	if err := ctx.ShouldBindBodyWith(&merchantRequest, binding.JSON); err != nil {
+		badRequest(ctx, app, requestCtx, err.Error())
+		return
+	}
+
+	validationErrors, ok := dtoIsValid(merchantRequest)
+	if !ok {
+		badRequest(ctx, app, requestCtx, validationErrors)
+		return
+	}
+
+	result, err := app.GRPCadmin.UpdateMerchant(
+		context.Background(),
+		&pb.UpdateMerchantRequest{
+			Merchant: merchantUID.String(),
+			Name:     merchantRequest.Name,
+			Mcc:      merchantRequest.MCC,
+			Aliases:  merchantRequest.Aliases,
+		},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to update merchant")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapAdminMerchantResponse(result))
+}
+
+// @Summary Admin Delete Merchant
+// @Description Soft deletes the merchant. Its transactions are no longer corrected and keep the MCC they are sent with.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the merchant"
+// @Router /admin/merchants/{uid} [delete]
+// @Success 204
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminDeleteMerchant(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	merchantUID, err := uuid.Parse(ctx.Param("uid"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid merchant uid: %s", err.Error()))
+		return
+	}
+
+	_, err = app.GRPCadmin.DeleteMerchant(
+		context.Background(),
+		&pb.MerchantRequest{Merchant: merchantUID.String()},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to delete merchant")
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func mapAdminMerchantResponse(mr *pb.MerchantResponse) port.MerchantResponse {
+	createdAt, _ := time.Parse(time.RFC3339, mr.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, mr.UpdatedAt)
+
+	aliases := mr.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	return port.MerchantResponse{
+		UID:       mr.Merchant,
+		Name:      mr.Name,
+		MCC:       mr.Mcc,
+		Aliases:   aliases,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+}
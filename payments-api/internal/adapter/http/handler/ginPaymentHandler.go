@@ -2,6 +2,7 @@ package ginHandler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -11,6 +12,9 @@ import (
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/jtonynet/go-payments-api/bootstrap"
 	"github.com/jtonynet/go-payments-api/internal/core/port"
@@ -19,18 +23,100 @@ import (
 	pb "github.com/jtonynet/go-payments-api/internal/adapter/gRPC/pb"
 )
 
+/*
+  - Optional client supplied UUID of the transaction. A retry with the same key
+    replays the original response code instead of debiting the account again.
+*/
+const IDEMPOTENCY_KEY_HEADER = "Idempotency-Key"
+
 // @Summary Payment Execute Transaction
-// @Description Payment executes a transaction  based on the request body json data. The HTTP status is always 200. The transaction can be **approved** (code **00**), **rejected insufficient balance** (code **51**), or **rejected generally** (code **07**). [See more here](https://github.com/jtonynet/go-payments-api/tree/main?tab=readme-ov-file#about)
+// @Description Payment executes a transaction  based on the request body json data. The HTTP status is always 200. The transaction can be **approved** (code **00**), **rejected invalid transaction** (code **12**) when its currency is not supported or cannot be converted, **rejected invalid amount** (code **13**), **rejected invalid account** (code **14**), **rejected by account cancelled** (code **46**), **rejected insufficient balance** (code **51**), **rejected by expired card** (code **54**), **rejected by card not permitted** (code **57**), **rejected as suspected fraud** by the fraud rules (code **59**), **rejected by limit exceeded** (code **61**), **rejected by account blocked** (code **62**), **rejected by system timeout** (code **91**), **rejected as duplicate transaction** (code **94**), or **rejected generally** (code **07**). The **reason** carries the machine-readable name of the code. The account can be identified by a registered **card** token instead of its UUID, the card is then checked for its status, expiry and limits and recorded on the ledger rows. [See more here](https://github.com/jtonynet/go-payments-api/tree/main?tab=readme-ov-file#about)
 // @Tags Payment
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Client UUID of the transaction, retries with the same key replay the original response code"
 // @Param request body port.TransactionPaymentRequest true "Request body for Execute Transaction Payment"
 // @Router /payment [post]
 // @Success 200 {object} port.TransactionPaymentResponse
 func PaymentExecution(ctx *gin.Context) {
+	paymentTransaction(
+		ctx,
+		"Transaction",
+		func(app bootstrap.RESTApp, tr *pb.TransactionRequest) (*pb.TransactionResponse, error) {
+			return app.GRPCpayment.Execute(context.Background(), tr)
+		},
+	)
+}
+
+// @Summary Payment Authorize Transaction
+// @Description Payment authorizes a transaction based on the request body json data, reserving the funds per category without posting it. The hold must be captured or voided before it expires. The HTTP status is always 200. The authorization can be **approved** (code **00**), **rejected invalid transaction** (code **12**) when its currency is not supported or cannot be converted, **rejected invalid amount** (code **13**), **rejected invalid account** (code **14**), **rejected by account cancelled** (code **46**), **rejected insufficient balance** (code **51**), **rejected by expired card** (code **54**), **rejected by card not permitted** (code **57**), **rejected as suspected fraud** by the fraud rules (code **59**), **rejected by limit exceeded** (code **61**), **rejected by account blocked** (code **62**), **rejected by system timeout** (code **91**), **rejected as duplicate transaction** (code **94**), or **rejected generally** (code **07**). The **reason** carries the machine-readable name of the code. The account can be identified by a registered **card** token instead of its UUID, as in the payment.
+// @Tags Payment
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Client UUID of the transaction, retries with the same key replay the original response code"
+// @Param request body port.TransactionPaymentRequest true "Request body for Authorize Transaction Payment"
+// @Router /payment/authorize [post]
+// @Success 200 {object} port.TransactionPaymentResponse
+func PaymentAuthorization(ctx *gin.Context) {
+	paymentTransaction(
+		ctx,
+		"Authorization",
+		func(app bootstrap.RESTApp, tr *pb.TransactionRequest) (*pb.TransactionResponse, error) {
+			return app.GRPCpayment.Authorize(context.Background(), tr)
+		},
+	)
+}
+
+// @Summary Payment Capture Authorization
+// @Description Payment captures an authorization hold, posting the reserved amounts. The HTTP status is always 200. The capture can be **approved** (code **00**), **rejected invalid transaction** (code **12**) when the hold expired, **rejected invalid account** (code **14**), **rejected record not found** (code **25**) when the hold is not found, **rejected by limit exceeded** (code **61**), **rejected by system timeout** (code **91**), **rejected as duplicate transaction** (code **94**) or **rejected generally** (code **07**).
+// @Tags Payment
+// @Accept json
+// @Produce json
+// @Param transactionUID path string true "UUID of the authorized transaction"
+// @Param request body port.TransactionHoldRequest true "Request body for Capture Authorization"
+// @Router /payment/{transactionUID}/capture [post]
+// @Success 200 {object} port.TransactionPaymentResponse
+func PaymentCapture(ctx *gin.Context) {
+	holdTransaction(
+		ctx,
+		"Capture",
+		func(app bootstrap.RESTApp, hr *pb.HoldRequest) (*pb.TransactionResponse, error) {
+			return app.GRPCpayment.Capture(context.Background(), hr)
+		},
+	)
+}
+
+// @Summary Payment Void Authorization
+// @Description Payment voids an authorization hold, releasing the reserved amounts. The HTTP status is always 200. The void can be **approved** (code **00**), **rejected record not found** (code **25**) when the hold is not found, **rejected by system timeout** (code **91**) or **rejected generally** (code **07**).
+// @Tags Payment
+// @Accept json
+// @Produce json
+// @Param transactionUID path string true "UUID of the authorized transaction"
+// @Param request body port.TransactionHoldRequest true "Request body for Void Authorization"
+// @Router /payment/{transactionUID}/void [post]
+// @Success 200 {object} port.TransactionPaymentResponse
+func PaymentVoid(ctx *gin.Context) {
+	holdTransaction(
+		ctx,
+		"Void",
+		func(app bootstrap.RESTApp, hr *pb.HoldRequest) (*pb.TransactionResponse, error) {
+			return app.GRPCpayment.Void(context.Background(), hr)
+		},
+	)
+}
+
+func paymentTransaction(
+	ctx *gin.Context,
+	operation string,
+	call func(app bootstrap.RESTApp, tr *pb.TransactionRequest) (*pb.TransactionResponse, error),
+) {
 	startTime := time.Now()
 	code := port.CODE_REJECTED_GENERIC
-	transactionUID := uuid.NewString()
+
+	transactionUID := ctx.GetHeader(IDEMPOTENCY_KEY_HEADER)
+	if transactionUID == "" {
+		transactionUID = uuid.NewString()
+	}
 
 	requestCtx := context.Background()
 	requestCtx = context.WithValue(requestCtx, logger.CtxTransactionUIDKey, transactionUID)
@@ -39,7 +125,7 @@ func PaymentExecution(ctx *gin.Context) {
 
 	app.Logger.Info(
 		requestCtx,
-		"Transaction Initialized",
+		fmt.Sprintf("%s Initialized", operation),
 	)
 
 	defer func() {
@@ -47,62 +133,336 @@ func PaymentExecution(ctx *gin.Context) {
 		requestCtx = context.WithValue(requestCtx, logger.CtxResponseCodeKey, code)
 		app.Logger.Info(
 			requestCtx,
-			"Transaction Finished",
+			fmt.Sprintf("%s Finished", operation),
 		)
 	}()
 
+	if _, err := uuid.Parse(transactionUID); err != nil {
+		app.Logger.Error(
+			requestCtx,
+			fmt.Sprintf("rejected: %s, invalid %s header, error:%s\n", port.CODE_REJECTED_GENERIC, IDEMPOTENCY_KEY_HEADER, err.Error()),
+		)
+
+		ctx.JSON(http.StatusOK, codeResponse(port.CODE_REJECTED_GENERIC))
+
+		return
+	}
+
 	var transactionRequest port.TransactionPaymentRequest
 	if err := ctx.ShouldBindBodyWith(&transactionRequest, binding.JSON); err != nil {
+		code = bodyRejectionCode(ctx)
 		app.Logger.Error(
 			requestCtx,
-			fmt.Sprintf("rejected: %s, error:%s ms\n", port.CODE_REJECTED_GENERIC, err.Error()),
+			fmt.Sprintf("rejected: %s, error:%s ms\n", code, err.Error()),
 		)
 
-		ctx.JSON(http.StatusOK, port.TransactionPaymentResponse{
-			Code: port.CODE_REJECTED_GENERIC,
-		})
+		ctx.JSON(http.StatusOK, codeResponse(code))
 
 		return
 	}
-	accountUID := transactionRequest.AccountUID.String()
+	// Payments sent with a card token may omit the account, resolved from the card
+	accountUID := ""
+	if transactionRequest.AccountUID != uuid.Nil {
+		accountUID = transactionRequest.AccountUID.String()
+	}
 	requestCtx = context.WithValue(requestCtx, logger.CtxAccountUIDKey, accountUID)
 
 	validationErrors, ok := dtoIsValid(transactionRequest)
 	if !ok {
+		code = bodyRejectionCode(ctx)
 		app.Logger.Error(requestCtx, validationErrors)
 
-		ctx.JSON(http.StatusOK, port.TransactionPaymentResponse{
-			Code: port.CODE_REJECTED_GENERIC,
-		})
+		ctx.JSON(http.StatusOK, codeResponse(code))
 
 		return
 	}
 
-	result, err := app.GRPCpayment.Execute(
-		context.Background(),
+	result, err := call(
+		app,
 		&pb.TransactionRequest{
 			Transaction: transactionUID,
 			Account:     accountUID,
 			Mcc:         transactionRequest.MCC,
 			Merchant:    transactionRequest.Merchant,
 			TotalAmount: transactionRequest.TotalAmount.String(),
+			Currency:    transactionRequest.Currency,
+			Card:        transactionRequest.CardToken,
 		},
 	)
 
 	if err != nil {
+		code = callRejectionCode(err)
 		app.Logger.Error(requestCtx, err.Error())
 
-		ctx.JSON(http.StatusOK, port.TransactionPaymentResponse{
-			Code: port.CODE_REJECTED_GENERIC,
+		ctx.JSON(http.StatusOK, codeResponse(code))
+
+		return
+	}
+
+	code = result.Code
+	ctx.JSON(http.StatusOK, mapTransactionPaymentResponse(result))
+}
+
+func mapTransactionPaymentResponse(tr *pb.TransactionResponse) port.TransactionPaymentResponse {
+	response := port.TransactionPaymentResponse{
+		Code:   tr.Code,
+		Reason: tr.Reason,
+	}
+
+	for _, category := range tr.Categories {
+		amount, _ := decimal.NewFromString(category.Amount)
+
+		response.Categories = append(response.Categories, port.CategoryAttemptResponse{
+			Category: category.Category,
+			Rule:     category.Rule,
+			Amount:   amount,
+			Currency: category.Currency,
 		})
+	}
+
+	return response
+}
+
+func holdTransaction(
+	ctx *gin.Context,
+	operation string,
+	call func(app bootstrap.RESTApp, hr *pb.HoldRequest) (*pb.TransactionResponse, error),
+) {
+	startTime := time.Now()
+	code := port.CODE_REJECTED_GENERIC
+	transactionUIDParam := ctx.Param("transactionUID")
+
+	requestCtx := context.Background()
+	requestCtx = context.WithValue(requestCtx, logger.CtxTransactionUIDKey, transactionUIDParam)
+
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+
+	app.Logger.Info(
+		requestCtx,
+		fmt.Sprintf("%s Initialized", operation),
+	)
+
+	defer func() {
+		requestCtx = context.WithValue(requestCtx, logger.CtxExecutionTimeKey, time.Since(startTime))
+		requestCtx = context.WithValue(requestCtx, logger.CtxResponseCodeKey, code)
+		app.Logger.Info(
+			requestCtx,
+			fmt.Sprintf("%s Finished", operation),
+		)
+	}()
+
+	transactionUID, err := uuid.Parse(transactionUIDParam)
+	if err != nil {
+		app.Logger.Error(
+			requestCtx,
+			fmt.Sprintf("rejected: %s, error:%s\n", port.CODE_REJECTED_GENERIC, err.Error()),
+		)
+
+		ctx.JSON(http.StatusOK, codeResponse(port.CODE_REJECTED_GENERIC))
+
+		return
+	}
+
+	var holdRequest port.TransactionHoldRequest
+	if err := ctx.ShouldBindBodyWith(&holdRequest, binding.JSON); err != nil {
+		code = bodyRejectionCode(ctx)
+		app.Logger.Error(
+			requestCtx,
+			fmt.Sprintf("rejected: %s, error:%s\n", code, err.Error()),
+		)
+
+		ctx.JSON(http.StatusOK, codeResponse(code))
+
+		return
+	}
+	accountUID := holdRequest.AccountUID.String()
+	requestCtx = context.WithValue(requestCtx, logger.CtxAccountUIDKey, accountUID)
+
+	validationErrors, ok := dtoIsValid(holdRequest)
+	if !ok {
+		code = bodyRejectionCode(ctx)
+		app.Logger.Error(requestCtx, validationErrors)
+
+		ctx.JSON(http.StatusOK, codeResponse(code))
+
+		return
+	}
+
+	result, err := call(
+		app,
+		&pb.HoldRequest{
+			Account:     accountUID,
+			Transaction: transactionUID.String(),
+		},
+	)
+
+	if err != nil {
+		code = callRejectionCode(err)
+		app.Logger.Error(requestCtx, err.Error())
+
+		ctx.JSON(http.StatusOK, codeResponse(code))
 
 		return
 	}
 
 	code = result.Code
-	ctx.JSON(http.StatusOK, port.TransactionPaymentResponse{
-		Code: code,
-	})
+	ctx.JSON(http.StatusOK, mapTransactionPaymentResponse(result))
+}
+
+// @Summary Payment Refund Transaction
+// @Description Payment refunds, totally or partially, a previously approved transaction, restoring the amounts to the categories debited. The **totalAmount** is in the currency of the original payment, converted with the rate the payment was converted with. The HTTP status is always 200. The refund can be **approved** (code **00**), **rejected invalid amount** (code **13**), e.g. when the amount exceeds what was captured, **rejected invalid account** (code **14**), **rejected record not found** (code **25**) when the original transaction is not found, **rejected by account cancelled** (code **46**), **rejected by system timeout** (code **91**), **rejected as duplicate transaction** (code **94**) or **rejected generally** (code **07**).
+// @Tags Payment
+// @Accept json
+// @Produce json
+// @Param transactionUID path string true "UUID of the original transaction"
+// @Param Idempotency-Key header string false "Client UUID of the refund, retries with the same key replay the original response code"
+// @Param request body port.TransactionRefundRequest true "Request body for Refund Transaction Payment"
+// @Router /payment/{transactionUID}/refund [post]
+// @Success 200 {object} port.TransactionPaymentResponse
+func PaymentRefund(ctx *gin.Context) {
+	startTime := time.Now()
+	code := port.CODE_REJECTED_GENERIC
+
+	refundUID := ctx.GetHeader(IDEMPOTENCY_KEY_HEADER)
+	if refundUID == "" {
+		refundUID = uuid.NewString()
+	}
+
+	requestCtx := context.Background()
+	requestCtx = context.WithValue(requestCtx, logger.CtxTransactionUIDKey, refundUID)
+
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+
+	app.Logger.Info(
+		requestCtx,
+		"Refund Initialized",
+	)
+
+	defer func() {
+		requestCtx = context.WithValue(requestCtx, logger.CtxExecutionTimeKey, time.Since(startTime))
+		requestCtx = context.WithValue(requestCtx, logger.CtxResponseCodeKey, code)
+		app.Logger.Info(
+			requestCtx,
+			"Refund Finished",
+		)
+	}()
+
+	if _, err := uuid.Parse(refundUID); err != nil {
+		app.Logger.Error(
+			requestCtx,
+			fmt.Sprintf("rejected: %s, invalid %s header, error:%s\n", port.CODE_REJECTED_GENERIC, IDEMPOTENCY_KEY_HEADER, err.Error()),
+		)
+
+		ctx.JSON(http.StatusOK, codeResponse(port.CODE_REJECTED_GENERIC))
+
+		return
+	}
+
+	transactionUID, err := uuid.Parse(ctx.Param("transactionUID"))
+	if err != nil {
+		app.Logger.Error(
+			requestCtx,
+			fmt.Sprintf("rejected: %s, error:%s\n", port.CODE_REJECTED_GENERIC, err.Error()),
+		)
+
+		ctx.JSON(http.StatusOK, codeResponse(port.CODE_REJECTED_GENERIC))
+
+		return
+	}
+
+	var refundRequest port.TransactionRefundRequest
+	if err := ctx.ShouldBindBodyWith(&refundRequest, binding.JSON); err != nil {
+		code = bodyRejectionCode(ctx)
+		app.Logger.Error(
+			requestCtx,
+			fmt.Sprintf("rejected: %s, error:%s\n", code, err.Error()),
+		)
+
+		ctx.JSON(http.StatusOK, codeResponse(code))
+
+		return
+	}
+	accountUID := refundRequest.AccountUID.String()
+	requestCtx = context.WithValue(requestCtx, logger.CtxAccountUIDKey, accountUID)
+
+	validationErrors, ok := dtoIsValid(refundRequest)
+	if !ok {
+		code = bodyRejectionCode(ctx)
+		app.Logger.Error(requestCtx, validationErrors)
+
+		ctx.JSON(http.StatusOK, codeResponse(code))
+
+		return
+	}
+
+	result, err := app.GRPCpayment.Refund(
+		context.Background(),
+		&pb.RefundRequest{
+			Account:     accountUID,
+			Transaction: transactionUID.String(),
+			Refund:      refundUID,
+			TotalAmount: refundRequest.TotalAmount.String(),
+		},
+	)
+
+	if err != nil {
+		code = callRejectionCode(err)
+		app.Logger.Error(requestCtx, err.Error())
+
+		ctx.JSON(http.StatusOK, codeResponse(code))
+
+		return
+	}
+
+	code = result.Code
+	ctx.JSON(http.StatusOK, mapTransactionPaymentResponse(result))
+}
+
+func codeResponse(code string) port.TransactionPaymentResponse {
+	return port.TransactionPaymentResponse{
+		Code:   code,
+		Reason: port.ResponseReason(code),
+	}
+}
+
+/*
+  - Response code of a body rejected before reaching the gRPC server: an account
+    that is not a UUID or an amount that is not a positive number have codes of
+    their own, any other field is rejected generally
+*/
+func bodyRejectionCode(ctx *gin.Context) string {
+	body, _ := ctx.Get(gin.BodyBytesKey)
+	bodyBytes, _ := body.([]byte)
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bodyBytes, &fields); err != nil {
+		return port.CODE_REJECTED_GENERIC
+	}
+
+	_, hasAccount := fields["account"]
+	if _, hasCard := fields["card"]; hasAccount || !hasCard {
+		var accountUID uuid.UUID
+		if err := json.Unmarshal(fields["account"], &accountUID); err != nil || accountUID == uuid.Nil {
+			return port.CODE_REJECTED_INVALID_ACCOUNT
+		}
+	}
+
+	if rawAmount, ok := fields["totalAmount"]; ok {
+		var totalAmount decimal.Decimal
+		if err := json.Unmarshal(rawAmount, &totalAmount); err != nil || !totalAmount.IsPositive() {
+			return port.CODE_REJECTED_INVALID_AMOUNT
+		}
+	}
+
+	return port.CODE_REJECTED_GENERIC
+}
+
+func callRejectionCode(err error) string {
+	if status.Code(err) == codes.DeadlineExceeded {
+		return port.CODE_REJECTED_SYSTEM_TIMEOUT
+	}
+
+	return port.CODE_REJECTED_GENERIC
 }
 
 func validateUUID(fl validator.FieldLevel) bool {
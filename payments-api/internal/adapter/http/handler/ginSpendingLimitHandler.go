@@ -0,0 +1,126 @@
+package ginHandler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/jtonynet/go-payments-api/bootstrap"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+
+	pb "github.com/jtonynet/go-payments-api/internal/adapter/gRPC/pb"
+)
+
+// @Summary Admin Set Spending Limits
+// @Description Replaces the spending limits of the account, or of one of its categories when **category** is given: daily and monthly amount caps, a single transaction amount cap and a cap of transactions per hour. The days, months and hours are UTC windows, and a zero cap is not enforced. All caps zero remove the limits. Payments and authorizations breaching a limit are **rejected by limit exceeded** (code **61**).
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the account"
+// @Param request body port.SpendingLimitRequest true "Request body for Spending Limits"
+// @Router /admin/accounts/{uid}/limits [put]
+// @Success 200 {object} port.SpendingLimitResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminSetSpendingLimit(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	accountUID, err := uuid.Parse(ctx.Param("uid"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid account uid: %s", err.Error()))
+		return
+	}
+
+	var spendingLimitRequest port.SpendingLimitRequest
+	if err := ctx.ShouldBindBodyWith(&spendingLimitRequest, binding.JSON); err != nil {
+		badRequest(ctx, app, requestCtx, err.Error())
+		return
+	}
+
+	validationErrors, ok := dtoIsValid(spendingLimitRequest)
+	if !ok {
+		badRequest(ctx, app, requestCtx, validationErrors)
+		return
+	}
+
+	result, err := app.GRPCadmin.SetSpendingLimit(
+		context.Background(),
+		&pb.SpendingLimitRequest{
+			Account:            accountUID.String(),
+			Category:           spendingLimitRequest.CategoryUID,
+			DailyAmount:        spendingLimitRequest.DailyAmount.String(),
+			MonthlyAmount:      spendingLimitRequest.MonthlyAmount.String(),
+			TransactionAmount:  spendingLimitRequest.TransactionAmount.String(),
+			HourlyTransactions: int32(spendingLimitRequest.HourlyTransactions),
+		},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to set spending limit")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapAdminSpendingLimitResponse(result))
+}
+
+// @Summary Admin List Spending Limits
+// @Description Lists the spending limits of the account, the limits of the whole account first and then the limits of its categories.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param uid path string true "UUID of the account"
+// @Router /admin/accounts/{uid}/limits [get]
+// @Success 200 {object} port.SpendingLimitListResponse
+// @Failure 400 {object} port.APIerrorResponse
+// @Failure 404 {object} port.APIerrorResponse
+// @Failure 500 {object} port.APIerrorResponse
+func AdminListSpendingLimits(ctx *gin.Context) {
+	app := ctx.MustGet("app").(bootstrap.RESTApp)
+	requestCtx := context.Background()
+
+	accountUID, err := uuid.Parse(ctx.Param("uid"))
+	if err != nil {
+		badRequest(ctx, app, requestCtx, fmt.Sprintf("invalid account uid: %s", err.Error()))
+		return
+	}
+
+	result, err := app.GRPCadmin.ListSpendingLimits(
+		context.Background(),
+		&pb.AccountRequest{Account: accountUID.String()},
+	)
+	if err != nil {
+		adminFailure(ctx, app, requestCtx, err, "failed to list spending limits")
+		return
+	}
+
+	limits := []port.SpendingLimitResponse{}
+	for _, limit := range result.Limits {
+		limits = append(limits, mapAdminSpendingLimitResponse(limit))
+	}
+
+	ctx.JSON(http.StatusOK, port.SpendingLimitListResponse{
+		Limits: limits,
+	})
+}
+
+func mapAdminSpendingLimitResponse(slr *pb.SpendingLimitResponse) port.SpendingLimitResponse {
+	dailyAmount, _ := decimal.NewFromString(slr.DailyAmount)
+	monthlyAmount, _ := decimal.NewFromString(slr.MonthlyAmount)
+	transactionAmount, _ := decimal.NewFromString(slr.TransactionAmount)
+
+	return port.SpendingLimitResponse{
+		AccountUID:         slr.Account,
+		CategoryUID:        slr.Category,
+		CategoryName:       slr.CategoryName,
+		DailyAmount:        dailyAmount,
+		MonthlyAmount:      monthlyAmount,
+		TransactionAmount:  transactionAmount,
+		HourlyTransactions: int(slr.HourlyTransactions),
+	}
+}
@@ -2,7 +2,9 @@ package ginMiddleware
 
 import (
 	"bytes"
+	"crypto/subtle"
 	"encoding/json"
+	"net/http"
 	"runtime"
 	"strconv"
 	"strings"
@@ -269,3 +271,22 @@ func ConfigInject(cfg config.API) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+/*
+  - Admin requests carry the `Authorization: Bearer <token>` header, compared
+    in constant time. With no token configured every admin request is rejected
+*/
+func AdminAuth(cfg config.API) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if cfg.AdminToken == "" || !found ||
+			subtle.ConstantTimeCompare([]byte(token), []byte(cfg.AdminToken)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, port.APIerrorResponse{
+				Message: "missing or invalid admin token",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
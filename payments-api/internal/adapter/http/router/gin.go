@@ -40,6 +40,44 @@ func (gr Gin) HandleRequests(_ context.Context, cfg config.API) error {
 
 	v1.GET("/liveness", ginHandler.Liveness)
 	v1.POST("/payment", ginHandler.PaymentExecution)
+	v1.POST("/payment/authorize", ginHandler.PaymentAuthorization)
+	v1.POST("/payment/:transactionUID/refund", ginHandler.PaymentRefund)
+	v1.POST("/payment/:transactionUID/capture", ginHandler.PaymentCapture)
+	v1.POST("/payment/:transactionUID/void", ginHandler.PaymentVoid)
+
+	credit := v1.Group("/credit", ginMiddleware.AdminAuth(cfg))
+	credit.POST("", ginHandler.CreditExecution)
+	credit.POST("/batch", ginHandler.CreditBatch)
+
+	v1.GET("/accounts/:uid/transactions", ginHandler.AccountTransactions)
+	v1.GET("/accounts/:uid/balance", ginHandler.AccountBalance)
+
+	admin := v1.Group("/admin", ginMiddleware.AdminAuth(cfg))
+	admin.POST("/accounts", ginHandler.AdminCreateAccount)
+	admin.GET("/accounts", ginHandler.AdminListAccounts)
+	admin.DELETE("/accounts/:uid", ginHandler.AdminDeleteAccount)
+	admin.GET("/accounts/:uid/lock-queue", ginHandler.AdminGetLockQueue)
+	admin.PUT("/accounts/:uid/limits", ginHandler.AdminSetSpendingLimit)
+	admin.GET("/accounts/:uid/limits", ginHandler.AdminListSpendingLimits)
+	admin.POST("/accounts/:uid/block", ginHandler.AdminBlockAccount)
+	admin.POST("/accounts/:uid/unblock", ginHandler.AdminUnblockAccount)
+	admin.POST("/accounts/:uid/cancel", ginHandler.AdminCancelAccount)
+	admin.GET("/accounts/:uid/status-changes", ginHandler.AdminListAccountStatusChanges)
+	admin.POST("/accounts/:uid/cards", ginHandler.AdminCreateCard)
+	admin.GET("/accounts/:uid/cards", ginHandler.AdminListCards)
+	admin.PUT("/cards/:uid", ginHandler.AdminUpdateCard)
+	admin.POST("/accounts/:uid/categories/:categoryUID", ginHandler.AdminAttachCategory)
+	admin.DELETE("/accounts/:uid/categories/:categoryUID", ginHandler.AdminDetachCategory)
+	admin.POST("/categories", ginHandler.AdminCreateCategory)
+	admin.POST("/categories/:uid/mccs", ginHandler.AdminAssignMCC)
+	admin.PUT("/category-rules", ginHandler.AdminSetCategoryRule)
+	admin.GET("/category-rules", ginHandler.AdminListCategoryRules)
+	admin.POST("/merchants", ginHandler.AdminCreateMerchant)
+	admin.GET("/merchants", ginHandler.AdminListMerchants)
+	admin.GET("/merchants/:uid", ginHandler.AdminGetMerchant)
+	admin.PUT("/merchants/:uid", ginHandler.AdminUpdateMerchant)
+	admin.DELETE("/merchants/:uid", ginHandler.AdminDeleteMerchant)
+	admin.GET("/ledger/consistency", ginHandler.AdminCheckLedger)
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
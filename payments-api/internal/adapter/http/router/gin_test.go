@@ -14,6 +14,8 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/tidwall/gjson"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -28,6 +30,8 @@ import (
 
 var (
 	accountUID, _ = uuid.Parse("123e4567-e89b-12d3-a456-426614174000")
+	cardUID, _    = uuid.Parse("5f0c3a4e-8d2b-4c1a-9e7f-2b6d8a1c3e5f")
+	cardToken     = "tok_4f9a2c7e1b3d5a60"
 
 	amountFoodTransaction = decimal.NewFromFloat(100.10)
 )
@@ -51,20 +55,574 @@ func (ps *PaymentServerFake) Execute(
 		return nil, err
 	}
 
-	code := "00"
+	code, reason := "00", "APPROVED"
+
+	if tr.Account == "" && tr.Card != cardToken {
+		return &pb.TransactionResponse{Code: "14", Reason: "INVALID_ACCOUNT"}, nil
+	}
+
+	if totalAmount.GreaterThan(amountFoodTransaction) {
+		code, reason = "51", "INSUFICIENT_FUNDS"
+	}
+
+	return &pb.TransactionResponse{
+		Code:   code,
+		Reason: reason,
+		Categories: []*pb.CategoryAttempt{
+			{Category: "FOOD", Rule: "MCC", Amount: tr.TotalAmount, Currency: "BRL"},
+		},
+	}, nil
+}
+
+func (ps *PaymentServerFake) Refund(
+	ctx context.Context,
+	rr *pb.RefundRequest,
+	opts ...grpc.CallOption,
+) (*pb.TransactionResponse, error) {
+
+	totalAmount, err := decimal.NewFromString(rr.TotalAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	code, reason := "00", "APPROVED"
 
 	if totalAmount.GreaterThan(amountFoodTransaction) {
-		code = "51"
+		code, reason = "13", "INVALID_AMOUNT"
+	}
+
+	return &pb.TransactionResponse{Code: code, Reason: reason}, nil
+}
+
+func (ps *PaymentServerFake) Authorize(
+	ctx context.Context,
+	tr *pb.TransactionRequest,
+	opts ...grpc.CallOption,
+) (*pb.TransactionResponse, error) {
+	return ps.Execute(ctx, tr, opts...)
+}
+
+func (ps *PaymentServerFake) Capture(
+	ctx context.Context,
+	hr *pb.HoldRequest,
+	opts ...grpc.CallOption,
+) (*pb.TransactionResponse, error) {
+	return &pb.TransactionResponse{Code: "00"}, nil
+}
+
+func (ps *PaymentServerFake) Void(
+	ctx context.Context,
+	hr *pb.HoldRequest,
+	opts ...grpc.CallOption,
+) (*pb.TransactionResponse, error) {
+	return &pb.TransactionResponse{Code: "00"}, nil
+}
+
+func (ps *PaymentServerFake) ListTransactions(
+	ctx context.Context,
+	thr *pb.TransactionHistoryRequest,
+	opts ...grpc.CallOption,
+) (*pb.TransactionHistoryResponse, error) {
+	if thr.Cursor == "invalid" {
+		return nil, status.Error(codes.InvalidArgument, "invalid transaction history cursor")
+	}
+
+	return &pb.TransactionHistoryResponse{
+		Transactions: []*pb.TransactionHistoryEntry{
+			{
+				Transaction: uuid.NewString(),
+				Category:    "FOOD",
+				Operation:   "AUTHORIZATION",
+				Amount:      "-100.09",
+				Balance:     "105.02",
+				Mcc:         "5411",
+				Merchant:    "PADARIA DO ZE              SAO PAULO BR",
+				CreatedAt:   "2024-12-04T21:50:21Z",
+			},
+		},
+		NextCursor: "MQ",
+	}, nil
+}
+
+func (ps *PaymentServerFake) GetBalance(
+	ctx context.Context,
+	br *pb.BalanceRequest,
+	opts ...grpc.CallOption,
+) (*pb.BalanceResponse, error) {
+	if br.Account != accountUID.String() {
+		return nil, status.Error(codes.NotFound, "account not found")
+	}
+
+	balance := &pb.BalanceResponse{
+		Account:     br.Account,
+		AmountTotal: "930.66",
+		Categories: []*pb.CategoryBalance{
+			{Name: "FOOD", Priority: 1, Mccs: []string{"5411", "5412"}, Amount: "205.11"},
+			{Name: "MEAL", Priority: 2, Mccs: []string{"5811", "5812"}, Amount: "310.22"},
+			{Name: "CASH", Priority: 3, Amount: "415.33"},
+		},
+	}
+
+	if br.IncludeHolds {
+		balance.AmountHeld = "10.00"
+		for _, category := range balance.Categories {
+			category.AmountHeld = "0"
+		}
+		balance.Categories[0].AmountHeld = "10.00"
+	}
+
+	return balance, nil
+}
+
+func (ps *PaymentServerFake) Credit(
+	ctx context.Context,
+	cr *pb.CreditRequest,
+	opts ...grpc.CallOption,
+) (*pb.TransactionResponse, error) {
+	if cr.Category != "FOOD" {
+		return &pb.TransactionResponse{Code: "12", Reason: "INVALID_TRANSACTION"}, nil
+	}
+
+	return &pb.TransactionResponse{Code: "00"}, nil
+}
+
+func (ps *PaymentServerFake) CreditBatch(
+	ctx context.Context,
+	opts ...grpc.CallOption,
+) (grpc.ClientStreamingClient[pb.CreditRequest, pb.CreditBatchResponse], error) {
+	return &CreditBatchStreamFake{}, nil
+}
+
+type CreditBatchStreamFake struct {
+	grpc.ClientStream
+
+	credits []*pb.CreditRequest
+}
+
+func (cbs *CreditBatchStreamFake) Send(cr *pb.CreditRequest) error {
+	cbs.credits = append(cbs.credits, cr)
+	return nil
+}
+
+func (cbs *CreditBatchStreamFake) CloseAndRecv() (*pb.CreditBatchResponse, error) {
+	response := &pb.CreditBatchResponse{}
+
+	for index, credit := range cbs.credits {
+		if credit.Category == "FOOD" {
+			response.Approved++
+			continue
+		}
+
+		response.Rejected++
+		response.Rejections = append(response.Rejections, &pb.CreditRejection{
+			Index:       int32(index),
+			Account:     credit.Account,
+			Transaction: credit.Transaction,
+			Code:        "12",
+			Reason:      "INVALID_TRANSACTION",
+		})
+	}
+
+	return response, nil
+}
+
+type AdminServerFake struct {
+	pb.UnimplementedAdminServer
+}
+
+func NewAdminClientFake() (pb.AdminClient, error) {
+	return &AdminServerFake{}, nil
+}
+
+var (
+	categoryUID, _ = uuid.Parse("809d8fa8-b726-4ddc-92da-b565fdcad75a")
+	merchantUID, _ = uuid.Parse("0b0364a1-4955-48b6-8c63-8a446b918682")
+)
+
+func (as *AdminServerFake) CreateAccount(
+	ctx context.Context,
+	car *pb.CreateAccountRequest,
+	opts ...grpc.CallOption,
+) (*pb.AccountResponse, error) {
+	return &pb.AccountResponse{
+		Account:   accountUID.String(),
+		Name:      car.Name,
+		CreatedAt: "2024-12-04T21:50:21Z",
+	}, nil
+}
+
+func (as *AdminServerFake) DeleteAccount(
+	ctx context.Context,
+	ar *pb.AccountRequest,
+	opts ...grpc.CallOption,
+) (*pb.AdminResponse, error) {
+	if ar.Account != accountUID.String() {
+		return nil, status.Error(codes.NotFound, "account not found")
+	}
+
+	return &pb.AdminResponse{}, nil
+}
+
+func (as *AdminServerFake) ListAccounts(
+	ctx context.Context,
+	lar *pb.ListAccountsRequest,
+	opts ...grpc.CallOption,
+) (*pb.ListAccountsResponse, error) {
+	if lar.Cursor == "invalid" {
+		return nil, status.Error(codes.InvalidArgument, "invalid pagination cursor")
+	}
+
+	return &pb.ListAccountsResponse{
+		Accounts: []*pb.AccountResponse{
+			{
+				Account: accountUID.String(),
+				Name:    "Jonh Doe",
+				Categories: []*pb.CategoryResponse{
+					{Category: categoryUID.String(), Name: "FOOD", Priority: 1, Mccs: []string{"5411", "5412"}},
+				},
+				CreatedAt: "2024-12-04T21:50:21Z",
+			},
+		},
+		NextCursor: "MQ",
+	}, nil
+}
+
+func (as *AdminServerFake) AttachCategory(
+	ctx context.Context,
+	acr *pb.AccountCategoryRequest,
+	opts ...grpc.CallOption,
+) (*pb.AdminResponse, error) {
+	if acr.Category != categoryUID.String() {
+		return nil, status.Error(codes.NotFound, "category not found")
+	}
+
+	return nil, status.Error(codes.AlreadyExists, "category already attached to account")
+}
+
+func (as *AdminServerFake) DetachCategory(
+	ctx context.Context,
+	acr *pb.AccountCategoryRequest,
+	opts ...grpc.CallOption,
+) (*pb.AdminResponse, error) {
+	return &pb.AdminResponse{}, nil
+}
+
+func (as *AdminServerFake) CreateCategory(
+	ctx context.Context,
+	ccr *pb.CreateCategoryRequest,
+	opts ...grpc.CallOption,
+) (*pb.CategoryResponse, error) {
+	return &pb.CategoryResponse{
+		Category: categoryUID.String(),
+		Name:     ccr.Name,
+		Priority: ccr.Priority,
+	}, nil
+}
+
+func (as *AdminServerFake) AssignMCC(
+	ctx context.Context,
+	amr *pb.AssignMCCRequest,
+	opts ...grpc.CallOption,
+) (*pb.MCCResponse, error) {
+	if amr.Mcc == "5411" {
+		return nil, status.Error(codes.AlreadyExists, "mcc already assigned to a category")
+	}
+
+	return &pb.MCCResponse{
+		MccUid:   uuid.NewString(),
+		Category: amr.Category,
+		Mcc:      amr.Mcc,
+	}, nil
+}
+
+func (as *AdminServerFake) SetCategoryRule(
+	ctx context.Context,
+	crr *pb.CategoryRuleRequest,
+	opts ...grpc.CallOption,
+) (*pb.CategoryRuleResponse, error) {
+	fallbacks := []*pb.CategoryRuleFallback{}
+	for _, fallback := range crr.Fallbacks {
+		if _, err := uuid.Parse(fallback); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid fallback uid")
+		}
+
+		if fallback != categoryUID.String() {
+			return nil, status.Error(codes.NotFound, "category not found")
+		}
+
+		fallbacks = append(fallbacks, &pb.CategoryRuleFallback{Category: fallback, Name: "FOOD"})
+	}
+
+	return &pb.CategoryRuleResponse{
+		Account:   crr.Account,
+		Category:  crr.Category,
+		Fallbacks: fallbacks,
+	}, nil
+}
+
+func (as *AdminServerFake) ListCategoryRules(
+	ctx context.Context,
+	lcr *pb.ListCategoryRulesRequest,
+	opts ...grpc.CallOption,
+) (*pb.ListCategoryRulesResponse, error) {
+	return &pb.ListCategoryRulesResponse{
+		Rules: []*pb.CategoryRuleResponse{
+			{
+				Account:   lcr.Account,
+				Fallbacks: []*pb.CategoryRuleFallback{{Category: categoryUID.String(), Name: "FOOD"}},
+			},
+		},
+	}, nil
+}
+
+func (as *AdminServerFake) SetSpendingLimit(
+	ctx context.Context,
+	slr *pb.SpendingLimitRequest,
+	opts ...grpc.CallOption,
+) (*pb.SpendingLimitResponse, error) {
+	if slr.Account != accountUID.String() {
+		return nil, status.Error(codes.NotFound, "account not found")
+	}
+
+	return &pb.SpendingLimitResponse{
+		Account:            slr.Account,
+		Category:           slr.Category,
+		DailyAmount:        slr.DailyAmount,
+		MonthlyAmount:      slr.MonthlyAmount,
+		TransactionAmount:  slr.TransactionAmount,
+		HourlyTransactions: slr.HourlyTransactions,
+	}, nil
+}
+
+func (as *AdminServerFake) ListSpendingLimits(
+	ctx context.Context,
+	ar *pb.AccountRequest,
+	opts ...grpc.CallOption,
+) (*pb.ListSpendingLimitsResponse, error) {
+	return &pb.ListSpendingLimitsResponse{
+		Limits: []*pb.SpendingLimitResponse{
+			{Account: ar.Account, DailyAmount: "150", MonthlyAmount: "0", TransactionAmount: "0", HourlyTransactions: 5},
+			{Account: ar.Account, Category: categoryUID.String(), CategoryName: "FOOD", DailyAmount: "0", MonthlyAmount: "0", TransactionAmount: "80"},
+		},
+	}, nil
+}
+
+func (as *AdminServerFake) ChangeAccountStatus(
+	ctx context.Context,
+	asr *pb.AccountStatusRequest,
+	opts ...grpc.CallOption,
+) (*pb.AccountStatusChangeResponse, error) {
+	if asr.Account != accountUID.String() {
+		return nil, status.Error(codes.NotFound, "account not found")
+	}
+
+	statuses := map[string]string{"BLOCK": "BLOCKED", "CANCEL": "CANCELLED"}
+	nextStatus, ok := statuses[asr.Action]
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "account status transition not allowed")
+	}
+
+	return &pb.AccountStatusChangeResponse{
+		Account:        asr.Account,
+		PreviousStatus: "ACTIVE",
+		Status:         nextStatus,
+		Reason:         asr.Reason,
+		CreatedAt:      "2024-12-04T21:50:21Z",
+	}, nil
+}
+
+func (as *AdminServerFake) CreateCard(
+	ctx context.Context,
+	ccr *pb.CreateCardRequest,
+	opts ...grpc.CallOption,
+) (*pb.CardResponse, error) {
+	if ccr.Account != accountUID.String() {
+		return nil, status.Error(codes.NotFound, "account not found")
+	}
+
+	if ccr.Token == cardToken {
+		return nil, status.Error(codes.AlreadyExists, "card token already registered")
+	}
+
+	return &pb.CardResponse{
+		Card:              cardUID.String(),
+		Account:           ccr.Account,
+		MaskedPan:         ccr.MaskedPan,
+		Status:            "ACTIVE",
+		Expiry:            ccr.Expiry,
+		TransactionAmount: ccr.TransactionAmount,
+		DailyAmount:       ccr.DailyAmount,
+		CreatedAt:         "2024-12-04T21:50:21Z",
+	}, nil
+}
+
+func (as *AdminServerFake) ListCards(
+	ctx context.Context,
+	ar *pb.AccountRequest,
+	opts ...grpc.CallOption,
+) (*pb.ListCardsResponse, error) {
+	return &pb.ListCardsResponse{
+		Cards: []*pb.CardResponse{
+			{
+				Card:              cardUID.String(),
+				Account:           ar.Account,
+				MaskedPan:         "411111******1111",
+				Status:            "ACTIVE",
+				Expiry:            "12/30",
+				TransactionAmount: "80",
+				DailyAmount:       "0",
+				CreatedAt:         "2024-12-04T21:50:21Z",
+			},
+		},
+	}, nil
+}
+
+func (as *AdminServerFake) UpdateCard(
+	ctx context.Context,
+	ucr *pb.UpdateCardRequest,
+	opts ...grpc.CallOption,
+) (*pb.CardResponse, error) {
+	if ucr.Card != cardUID.String() {
+		return nil, status.Error(codes.NotFound, "card not found")
+	}
+
+	return &pb.CardResponse{
+		Card:              ucr.Card,
+		Account:           accountUID.String(),
+		MaskedPan:         "411111******1111",
+		Status:            ucr.Status,
+		Expiry:            ucr.Expiry,
+		TransactionAmount: ucr.TransactionAmount,
+		DailyAmount:       ucr.DailyAmount,
+		CreatedAt:         "2024-12-04T21:50:21Z",
+	}, nil
+}
+
+func (as *AdminServerFake) ListAccountStatusChanges(
+	ctx context.Context,
+	ar *pb.AccountRequest,
+	opts ...grpc.CallOption,
+) (*pb.ListAccountStatusChangesResponse, error) {
+	return &pb.ListAccountStatusChangesResponse{
+		Changes: []*pb.AccountStatusChangeResponse{
+			{Account: ar.Account, PreviousStatus: "ACTIVE", Status: "BLOCKED", Reason: "card reported stolen", CreatedAt: "2024-12-04T21:50:21Z"},
+			{Account: ar.Account, PreviousStatus: "BLOCKED", Status: "ACTIVE", CreatedAt: "2024-12-05T10:00:00Z"},
+		},
+	}, nil
+}
+
+func (as *AdminServerFake) CreateMerchant(
+	ctx context.Context,
+	cmr *pb.CreateMerchantRequest,
+	opts ...grpc.CallOption,
+) (*pb.MerchantResponse, error) {
+	if cmr.Mcc == "9999" {
+		return nil, status.Error(codes.NotFound, "mcc not found")
+	}
+
+	return &pb.MerchantResponse{
+		Merchant:  merchantUID.String(),
+		Name:      cmr.Name,
+		Mcc:       cmr.Mcc,
+		Aliases:   cmr.Aliases,
+		CreatedAt: "2024-12-04T21:50:21Z",
+		UpdatedAt: "2024-12-04T21:50:21Z",
+	}, nil
+}
+
+func (as *AdminServerFake) GetMerchant(
+	ctx context.Context,
+	mr *pb.MerchantRequest,
+	opts ...grpc.CallOption,
+) (*pb.MerchantResponse, error) {
+	if mr.Merchant != merchantUID.String() {
+		return nil, status.Error(codes.NotFound, "merchant not found")
+	}
+
+	return &pb.MerchantResponse{
+		Merchant:  merchantUID.String(),
+		Name:      "UBER EATS                   SAO PAULO BR",
+		Mcc:       "5412",
+		CreatedAt: "2024-12-04T21:50:21Z",
+		UpdatedAt: "2024-12-04T21:50:21Z",
+	}, nil
+}
+
+func (as *AdminServerFake) ListMerchants(
+	ctx context.Context,
+	lmr *pb.ListMerchantsRequest,
+	opts ...grpc.CallOption,
+) (*pb.ListMerchantsResponse, error) {
+	return &pb.ListMerchantsResponse{
+		Merchants: []*pb.MerchantResponse{
+			{
+				Merchant:  merchantUID.String(),
+				Name:      "UBER EATS                   SAO PAULO BR",
+				Mcc:       "5412",
+				CreatedAt: "2024-12-04T21:50:21Z",
+				UpdatedAt: "2024-12-04T21:50:21Z",
+			},
+		},
+	}, nil
+}
+
+func (as *AdminServerFake) UpdateMerchant(
+	ctx context.Context,
+	umr *pb.UpdateMerchantRequest,
+	opts ...grpc.CallOption,
+) (*pb.MerchantResponse, error) {
+	return nil, status.Error(codes.AlreadyExists, "merchant already exists")
+}
+
+func (as *AdminServerFake) DeleteMerchant(
+	ctx context.Context,
+	mr *pb.MerchantRequest,
+	opts ...grpc.CallOption,
+) (*pb.AdminResponse, error) {
+	if mr.Merchant != merchantUID.String() {
+		return nil, status.Error(codes.NotFound, "merchant not found")
+	}
+
+	return &pb.AdminResponse{}, nil
+}
+
+func (as *AdminServerFake) CheckLedger(
+	ctx context.Context,
+	lcr *pb.LedgerConsistencyRequest,
+	opts ...grpc.CallOption,
+) (*pb.LedgerConsistencyResponse, error) {
+	if lcr.Cursor == "invalid" {
+		return nil, status.Error(codes.InvalidArgument, "invalid cursor")
 	}
 
-	return &pb.TransactionResponse{Code: code}, nil
+	return &pb.LedgerConsistencyResponse{
+		Checked: 3,
+		Inconsistencies: []*pb.LedgerBalance{
+			{
+				Account:       accountUID.String(),
+				Category:      "FOOD",
+				Balance:       "105.02",
+				LedgerBalance: "100.02",
+				BrokenEntries: 1,
+			},
+		},
+	}, nil
+}
+
+func (as *AdminServerFake) GetLockQueue(
+	ctx context.Context,
+	ar *pb.AccountRequest,
+	opts ...grpc.CallOption,
+) (*pb.LockQueueResponse, error) {
+	return &pb.LockQueueResponse{Account: ar.Account, Depth: 3}, nil
 }
 
 type GinRouterSuite struct {
 	suite.Suite
 
-	router   *gin.Engine
-	apiGroup *gin.RouterGroup
+	router     *gin.Engine
+	apiGroup   *gin.RouterGroup
+	adminToken string
 }
 
 func (suite *GinRouterSuite) SetupSuite() {
@@ -82,9 +640,49 @@ func (suite *GinRouterSuite) SetupSuite() {
 	gRPCpayment, _ := NewPaymentClientFake()
 	app.GRPCpayment = gRPCpayment
 
+	gRPCadmin, _ := NewAdminClientFake()
+	app.GRPCadmin = gRPCadmin
+
 	suite.router, suite.apiGroup = setupRouterAndGroup(cfg.API, *app)
+	suite.adminToken = cfg.API.AdminToken
 
 	suite.apiGroup.POST("/payment", ginHandler.PaymentExecution)
+	suite.apiGroup.POST("/payment/authorize", ginHandler.PaymentAuthorization)
+	suite.apiGroup.POST("/payment/:transactionUID/refund", ginHandler.PaymentRefund)
+	suite.apiGroup.POST("/payment/:transactionUID/capture", ginHandler.PaymentCapture)
+	suite.apiGroup.POST("/payment/:transactionUID/void", ginHandler.PaymentVoid)
+	creditGroup := suite.apiGroup.Group("/credit", ginMiddleware.AdminAuth(cfg.API))
+	creditGroup.POST("", ginHandler.CreditExecution)
+	creditGroup.POST("/batch", ginHandler.CreditBatch)
+	suite.apiGroup.GET("/accounts/:uid/transactions", ginHandler.AccountTransactions)
+	suite.apiGroup.GET("/accounts/:uid/balance", ginHandler.AccountBalance)
+
+	adminGroup := suite.apiGroup.Group("/admin", ginMiddleware.AdminAuth(cfg.API))
+	adminGroup.POST("/accounts", ginHandler.AdminCreateAccount)
+	adminGroup.GET("/accounts", ginHandler.AdminListAccounts)
+	adminGroup.DELETE("/accounts/:uid", ginHandler.AdminDeleteAccount)
+	adminGroup.POST("/accounts/:uid/categories/:categoryUID", ginHandler.AdminAttachCategory)
+	adminGroup.DELETE("/accounts/:uid/categories/:categoryUID", ginHandler.AdminDetachCategory)
+	adminGroup.POST("/categories", ginHandler.AdminCreateCategory)
+	adminGroup.POST("/categories/:uid/mccs", ginHandler.AdminAssignMCC)
+	adminGroup.PUT("/category-rules", ginHandler.AdminSetCategoryRule)
+	adminGroup.GET("/category-rules", ginHandler.AdminListCategoryRules)
+	adminGroup.POST("/merchants", ginHandler.AdminCreateMerchant)
+	adminGroup.GET("/merchants", ginHandler.AdminListMerchants)
+	adminGroup.GET("/merchants/:uid", ginHandler.AdminGetMerchant)
+	adminGroup.PUT("/merchants/:uid", ginHandler.AdminUpdateMerchant)
+	adminGroup.DELETE("/merchants/:uid", ginHandler.AdminDeleteMerchant)
+	adminGroup.GET("/ledger/consistency", ginHandler.AdminCheckLedger)
+	adminGroup.GET("/accounts/:uid/lock-queue", ginHandler.AdminGetLockQueue)
+	adminGroup.PUT("/accounts/:uid/limits", ginHandler.AdminSetSpendingLimit)
+	adminGroup.GET("/accounts/:uid/limits", ginHandler.AdminListSpendingLimits)
+	adminGroup.POST("/accounts/:uid/block", ginHandler.AdminBlockAccount)
+	adminGroup.POST("/accounts/:uid/unblock", ginHandler.AdminUnblockAccount)
+	adminGroup.POST("/accounts/:uid/cancel", ginHandler.AdminCancelAccount)
+	adminGroup.GET("/accounts/:uid/status-changes", ginHandler.AdminListAccountStatusChanges)
+	adminGroup.POST("/accounts/:uid/cards", ginHandler.AdminCreateCard)
+	adminGroup.GET("/accounts/:uid/cards", ginHandler.AdminListCards)
+	adminGroup.PUT("/cards/:uid", ginHandler.AdminUpdateCard)
 }
 
 func setupRouterAndGroup(cfg config.API, app bootstrap.RESTApp) (*gin.Engine, *gin.RouterGroup) {
@@ -120,6 +718,27 @@ func (suite *GinRouterSuite) TestPaymentExecuteTransactionApproved() {
 	suite.paymentExecuteTransactionTest(transactionJSON, codeApproved)
 }
 
+func (suite *GinRouterSuite) TestPaymentExecuteTransactionListsCategoriesTried() {
+	transactionJSON := fmt.Sprintf(
+		`{
+	  			"account": "%s",
+	  			"mcc": "5411",
+	  			"merchant": "PADARIA DO ZE              SAO PAULO BR",
+	  			"totalAmount": %v
+			}`,
+		accountUID,
+		amountFoodTransaction,
+	)
+
+	resp := suite.adminRequestTest("POST", "/payment", transactionJSON, http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "reason").String(), "APPROVED")
+	assert.Equal(suite.T(), gjson.Get(resp, "categories.#").Int(), int64(1))
+	assert.Equal(suite.T(), gjson.Get(resp, "categories.0.category").String(), "FOOD")
+	assert.Equal(suite.T(), gjson.Get(resp, "categories.0.rule").String(), "MCC")
+	assert.Equal(suite.T(), gjson.Get(resp, "categories.0.amount").String(), amountFoodTransaction.String())
+}
+
 func (suite *GinRouterSuite) TestPaymentExecuteTransactionRejectedInsufficientFunds() {
 	codeRejectedInsufficientFunds := "51" // domain.CODE_REJECTED_INSUFICIENT_FUNDS
 
@@ -137,7 +756,7 @@ func (suite *GinRouterSuite) TestPaymentExecuteTransactionRejectedInsufficientFu
 }
 
 func (suite *GinRouterSuite) TestPaymentExecuteTransactionRejectedInvalidAccountUID() {
-	codeRejectedInsufficientFunds := "07" // domain.CODE_REJECTED_GENERIC
+	codeRejectedInsufficientFunds := "14" // domain.CODE_REJECTED_INVALID_ACCOUNT
 
 	transactionJSON :=
 		`{
@@ -150,6 +769,52 @@ func (suite *GinRouterSuite) TestPaymentExecuteTransactionRejectedInvalidAccount
 	suite.paymentExecuteTransactionTest(transactionJSON, codeRejectedInsufficientFunds)
 }
 
+func (suite *GinRouterSuite) TestPaymentExecuteTransactionWithCardTokenApproved() {
+	codeApproved := "00" // domain.CODE_APPROVED
+
+	transactionJSON := fmt.Sprintf(
+		`{
+	  			"card": "%s",
+	  			"mcc": "5411",
+	  			"merchant": "PADARIA DO ZE              SAO PAULO BR",
+	  			"totalAmount": %v
+			}`,
+		cardToken,
+		amountFoodTransaction,
+	)
+
+	suite.paymentExecuteTransactionTest(transactionJSON, codeApproved)
+}
+
+func (suite *GinRouterSuite) TestPaymentExecuteTransactionWithUnknownCardTokenRejected() {
+	codeRejectedInvalidAccount := "14" // domain.CODE_REJECTED_INVALID_ACCOUNT
+
+	transactionJSON := fmt.Sprintf(
+		`{
+	  			"card": "tok_unknown00000",
+	  			"mcc": "5411",
+	  			"merchant": "PADARIA DO ZE              SAO PAULO BR",
+	  			"totalAmount": %v
+			}`,
+		amountFoodTransaction,
+	)
+
+	suite.paymentExecuteTransactionTest(transactionJSON, codeRejectedInvalidAccount)
+}
+
+func (suite *GinRouterSuite) TestPaymentExecuteTransactionWithoutAccountAndCardRejected() {
+	codeRejectedInvalidAccount := "14" // domain.CODE_REJECTED_INVALID_ACCOUNT
+
+	transactionJSON :=
+		`{
+	  			"mcc": "5411",
+	  			"merchant": "PADARIA DO ZE              SAO PAULO BR",
+	  			"totalAmount": 0.01
+			}`
+
+	suite.paymentExecuteTransactionTest(transactionJSON, codeRejectedInvalidAccount)
+}
+
 func (suite *GinRouterSuite) TestPaymentExecuteTransactionRejectedInvalidMCC() {
 	codeRejectedInsufficientFunds := "07" // domain.CODE_REJECTED_GENERIC
 
@@ -198,6 +863,774 @@ func (suite *GinRouterSuite) TestPaymentExecuteTransactionRejectedInvalidAmount(
 	suite.paymentExecuteTransactionTest(transactionJSON, codeRejectedInsufficientFunds)
 }
 
+func (suite *GinRouterSuite) TestPaymentExecuteTransactionRejectedUnparsableAmountWithReason() {
+	transactionJSON := fmt.Sprintf(
+		`{
+  			"account": "%s",
+  			"mcc": "5411",
+  			"merchant": "PADARIA DO ZE              SAO PAULO BR",
+  			"totalAmount": "abc"
+		}`,
+		accountUID,
+	)
+
+	resp := suite.adminRequestTest("POST", "/payment", transactionJSON, http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "code").String(), "13") // domain.CODE_REJECTED_INVALID_AMOUNT
+	assert.Equal(suite.T(), gjson.Get(resp, "reason").String(), "INVALID_AMOUNT")
+}
+
+func (suite *GinRouterSuite) TestPaymentRefundTransactionApproved() {
+	codeApproved := "00" // domain.CODE_APPROVED
+
+	refundJSON := fmt.Sprintf(
+		`{
+				"account": "%s",
+				"totalAmount": %v
+			}`,
+		accountUID,
+		amountFoodTransaction,
+	)
+
+	suite.paymentRefundTransactionTest(uuid.NewString(), refundJSON, codeApproved)
+}
+
+func (suite *GinRouterSuite) TestPaymentRefundTransactionRejectedExceedsCaptured() {
+	codeRejected := "13" // domain.CODE_REJECTED_INVALID_AMOUNT
+
+	refundJSON := fmt.Sprintf(
+		`{
+				"account": "%s",
+				"totalAmount": 9999.99
+			}`,
+		accountUID,
+	)
+
+	suite.paymentRefundTransactionTest(uuid.NewString(), refundJSON, codeRejected)
+}
+
+func (suite *GinRouterSuite) TestPaymentRefundTransactionRejectedInvalidTransactionUID() {
+	codeRejected := "07" // domain.CODE_REJECTED_GENERIC
+
+	refundJSON := fmt.Sprintf(
+		`{
+				"account": "%s",
+				"totalAmount": 0.01
+			}`,
+		accountUID,
+	)
+
+	suite.paymentRefundTransactionTest("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx", refundJSON, codeRejected)
+}
+
+func (suite *GinRouterSuite) TestPaymentRefundTransactionWithIdempotencyKeyApproved() {
+	codeApproved := "00" // domain.CODE_APPROVED
+
+	refundJSON := fmt.Sprintf(
+		`{
+				"account": "%s",
+				"totalAmount": %v
+			}`,
+		accountUID,
+		amountFoodTransaction,
+	)
+
+	suite.paymentRefundIdempotencyKeyRequestTest(uuid.NewString(), refundJSON, codeApproved)
+}
+
+func (suite *GinRouterSuite) TestPaymentRefundTransactionWithInvalidIdempotencyKeyRejected() {
+	codeRejected := "07" // domain.CODE_REJECTED_GENERIC
+
+	refundJSON := fmt.Sprintf(
+		`{
+				"account": "%s",
+				"totalAmount": %v
+			}`,
+		accountUID,
+		amountFoodTransaction,
+	)
+
+	suite.paymentRefundIdempotencyKeyRequestTest("not-a-uuid", refundJSON, codeRejected)
+}
+
+func (suite *GinRouterSuite) paymentRefundIdempotencyKeyRequestTest(idempotencyKey, reqBody string, returnCode string) {
+	path := fmt.Sprintf("/payment/%s/refund", uuid.NewString())
+	req, err := http.NewRequest("POST", path, bytes.NewBuffer([]byte(reqBody)))
+	assert.NoError(suite.T(), err)
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	resp := httptest.NewRecorder()
+	suite.router.ServeHTTP(resp, req)
+	assert.Equal(suite.T(), http.StatusOK, resp.Code)
+
+	assert.Equal(suite.T(), gjson.Get(resp.Body.String(), "code").String(), returnCode)
+}
+
+func (suite *GinRouterSuite) TestPaymentExecuteTransactionWithIdempotencyKeyApproved() {
+	codeApproved := "00" // domain.CODE_APPROVED
+
+	transactionJSON := fmt.Sprintf(
+		`{
+				"account": "%s",
+				"mcc": "5411",
+				"merchant": "PADARIA DO ZE              SAO PAULO BR",
+				"totalAmount": %v
+			}`,
+		accountUID,
+		amountFoodTransaction,
+	)
+
+	suite.paymentIdempotencyKeyRequestTest(uuid.NewString(), transactionJSON, codeApproved)
+}
+
+func (suite *GinRouterSuite) TestPaymentExecuteTransactionWithInvalidIdempotencyKeyRejected() {
+	codeRejected := "07" // domain.CODE_REJECTED_GENERIC
+
+	transactionJSON := fmt.Sprintf(
+		`{
+				"account": "%s",
+				"mcc": "5411",
+				"merchant": "PADARIA DO ZE              SAO PAULO BR",
+				"totalAmount": %v
+			}`,
+		accountUID,
+		amountFoodTransaction,
+	)
+
+	suite.paymentIdempotencyKeyRequestTest("not-a-uuid", transactionJSON, codeRejected)
+}
+
+func (suite *GinRouterSuite) paymentIdempotencyKeyRequestTest(idempotencyKey, reqBody string, returnCode string) {
+	req, err := http.NewRequest("POST", "/payment", bytes.NewBuffer([]byte(reqBody)))
+	assert.NoError(suite.T(), err)
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	resp := httptest.NewRecorder()
+	suite.router.ServeHTTP(resp, req)
+	assert.Equal(suite.T(), http.StatusOK, resp.Code)
+
+	assert.Equal(suite.T(), gjson.Get(resp.Body.String(), "code").String(), returnCode)
+}
+
+func (suite *GinRouterSuite) TestPaymentAuthorizeTransactionApproved() {
+	codeApproved := "00" // domain.CODE_APPROVED
+
+	transactionJSON := fmt.Sprintf(
+		`{
+				"account": "%s",
+				"mcc": "5411",
+				"merchant": "PADARIA DO ZE              SAO PAULO BR",
+				"totalAmount": %v
+			}`,
+		accountUID,
+		amountFoodTransaction,
+	)
+
+	suite.paymentRequestTest("/payment/authorize", transactionJSON, codeApproved)
+}
+
+func (suite *GinRouterSuite) TestPaymentAuthorizeTransactionRejectedInsufficientFunds() {
+	codeRejectedInsufficientFunds := "51" // domain.CODE_REJECTED_INSUFICIENT_FUNDS
+
+	transactionJSON := fmt.Sprintf(
+		`{
+				"account": "%s",
+				"mcc": "5411",
+				"merchant": "PADARIA DO ZE              SAO PAULO BR",
+				"totalAmount": 9999.99
+			}`,
+		accountUID,
+	)
+
+	suite.paymentRequestTest("/payment/authorize", transactionJSON, codeRejectedInsufficientFunds)
+}
+
+func (suite *GinRouterSuite) TestPaymentCaptureAuthorizationApproved() {
+	codeApproved := "00" // domain.CODE_APPROVED
+
+	holdJSON := fmt.Sprintf(`{"account": "%s"}`, accountUID)
+	path := fmt.Sprintf("/payment/%s/capture", uuid.NewString())
+
+	suite.paymentRequestTest(path, holdJSON, codeApproved)
+}
+
+func (suite *GinRouterSuite) TestPaymentVoidAuthorizationApproved() {
+	codeApproved := "00" // domain.CODE_APPROVED
+
+	holdJSON := fmt.Sprintf(`{"account": "%s"}`, accountUID)
+	path := fmt.Sprintf("/payment/%s/void", uuid.NewString())
+
+	suite.paymentRequestTest(path, holdJSON, codeApproved)
+}
+
+func (suite *GinRouterSuite) TestPaymentVoidAuthorizationRejectedInvalidTransactionUID() {
+	codeRejected := "07" // domain.CODE_REJECTED_GENERIC
+
+	holdJSON := fmt.Sprintf(`{"account": "%s"}`, accountUID)
+
+	suite.paymentRequestTest("/payment/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx/void", holdJSON, codeRejected)
+}
+
+func (suite *GinRouterSuite) TestCreditExecuteTransactionApproved() {
+	codeApproved := "00" // domain.CODE_APPROVED
+
+	creditJSON := fmt.Sprintf(`{"account": "%s", "category": "FOOD", "totalAmount": 500.00}`, accountUID)
+
+	suite.creditRequestTest(creditJSON, codeApproved)
+}
+
+func (suite *GinRouterSuite) TestCreditExecuteTransactionRejectedCategoryNotAttached() {
+	codeRejected := "12" // domain.CODE_REJECTED_INVALID_TRANSACTION
+
+	creditJSON := fmt.Sprintf(`{"account": "%s", "category": "MOBILITY", "totalAmount": 500.00}`, accountUID)
+
+	suite.creditRequestTest(creditJSON, codeRejected)
+}
+
+func (suite *GinRouterSuite) TestCreditExecuteTransactionRejectedInvalidAmount() {
+	codeRejected := "13" // domain.CODE_REJECTED_INVALID_AMOUNT
+
+	creditJSON := fmt.Sprintf(`{"account": "%s", "category": "FOOD", "totalAmount": "abc"}`, accountUID)
+
+	suite.creditRequestTest(creditJSON, codeRejected)
+}
+
+func (suite *GinRouterSuite) TestCreditExecuteTransactionWithoutTokenUnauthorized() {
+	creditJSON := fmt.Sprintf(`{"account": "%s", "category": "FOOD", "totalAmount": 500.00}`, accountUID)
+
+	suite.adminRequestWithTokenTest("POST", "/credit", creditJSON, "", http.StatusUnauthorized)
+}
+
+func (suite *GinRouterSuite) TestCreditBatchWithInvalidTokenUnauthorized() {
+	batchJSON := fmt.Sprintf(`{"credits": [{"account": "%s", "category": "FOOD", "totalAmount": 500.00}]}`, accountUID)
+
+	suite.adminRequestWithTokenTest("POST", "/credit/batch", batchJSON, "not-the-admin-token", http.StatusUnauthorized)
+}
+
+func (suite *GinRouterSuite) TestCreditBatchReportsRejections() {
+	transactionUID := uuid.NewString()
+
+	batchJSON := fmt.Sprintf(`{"credits": [
+		{"account": "%s", "category": "FOOD", "totalAmount": 500.00},
+		{"transaction": "%s", "account": "%s", "category": "MOBILITY", "totalAmount": 50.00}
+	]}`, accountUID, transactionUID, accountUID)
+
+	resp := suite.adminRequestTest("POST", "/credit/batch", batchJSON, http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "approved").Int(), int64(1))
+	assert.Equal(suite.T(), gjson.Get(resp, "rejected").Int(), int64(1))
+	assert.Equal(suite.T(), gjson.Get(resp, "rejections.0.index").Int(), int64(1))
+	assert.Equal(suite.T(), gjson.Get(resp, "rejections.0.transaction").String(), transactionUID)
+	assert.Equal(suite.T(), gjson.Get(resp, "rejections.0.code").String(), "12")
+	assert.Equal(suite.T(), gjson.Get(resp, "rejections.0.reason").String(), "INVALID_TRANSACTION")
+}
+
+func (suite *GinRouterSuite) TestCreditBatchEmptyBadRequest() {
+	suite.adminRequestTest("POST", "/credit/batch", `{"credits": []}`, http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestCreditBatchInvalidTransactionBadRequest() {
+	batchJSON := fmt.Sprintf(`{"credits": [
+		{"transaction": "xxxxxxxx", "account": "%s", "category": "FOOD", "totalAmount": 500.00}
+	]}`, accountUID)
+
+	suite.adminRequestTest("POST", "/credit/batch", batchJSON, http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAccountTransactionsSuccess() {
+	path := fmt.Sprintf("/accounts/%s/transactions?limit=1&mcc=5411&from=2024-12-01T00:00:00Z", accountUID)
+
+	resp := suite.accountRequestTest(path, http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "transactions.#").Int(), int64(1))
+	assert.Equal(suite.T(), gjson.Get(resp, "transactions.0.category").String(), "FOOD")
+	assert.Equal(suite.T(), gjson.Get(resp, "transactions.0.operation").String(), "AUTHORIZATION")
+	assert.Equal(suite.T(), gjson.Get(resp, "transactions.0.amount").String(), "-100.09")
+	assert.Equal(suite.T(), gjson.Get(resp, "transactions.0.balance").String(), "105.02")
+	assert.Equal(suite.T(), gjson.Get(resp, "nextCursor").String(), "MQ")
+}
+
+func (suite *GinRouterSuite) TestAccountTransactionsInvalidAccountUIDBadRequest() {
+	suite.accountRequestTest("/accounts/xxxxxxxx/transactions", http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAccountTransactionsInvalidLimitBadRequest() {
+	path := fmt.Sprintf("/accounts/%s/transactions?limit=501", accountUID)
+
+	suite.accountRequestTest(path, http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAccountTransactionsInvalidDateBadRequest() {
+	path := fmt.Sprintf("/accounts/%s/transactions?from=yesterday", accountUID)
+
+	suite.accountRequestTest(path, http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAccountTransactionsInvalidCursorBadRequest() {
+	path := fmt.Sprintf("/accounts/%s/transactions?cursor=invalid", accountUID)
+
+	suite.accountRequestTest(path, http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAccountBalanceSuccess() {
+	path := fmt.Sprintf("/accounts/%s/balance", accountUID)
+
+	resp := suite.accountRequestTest(path, http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "account").String(), accountUID.String())
+	assert.Equal(suite.T(), gjson.Get(resp, "amountTotal").String(), "930.66")
+	assert.Equal(suite.T(), gjson.Get(resp, "categories.#").Int(), int64(3))
+	assert.Equal(suite.T(), gjson.Get(resp, "categories.0.name").String(), "FOOD")
+	assert.Equal(suite.T(), gjson.Get(resp, "categories.0.mccs.#").Int(), int64(2))
+	assert.False(suite.T(), gjson.Get(resp, "amountHeld").Exists())
+	assert.False(suite.T(), gjson.Get(resp, "categories.0.amountHeld").Exists())
+}
+
+func (suite *GinRouterSuite) TestAccountBalanceIncludeHoldsSuccess() {
+	path := fmt.Sprintf("/accounts/%s/balance?includeHolds=true", accountUID)
+
+	resp := suite.accountRequestTest(path, http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "amountHeld").String(), "10")
+	assert.Equal(suite.T(), gjson.Get(resp, "categories.0.amountHeld").String(), "10")
+	assert.Equal(suite.T(), gjson.Get(resp, "categories.1.amountHeld").String(), "0")
+}
+
+func (suite *GinRouterSuite) TestAccountBalanceNotFound() {
+	path := fmt.Sprintf("/accounts/%s/balance", uuid.NewString())
+
+	suite.accountRequestTest(path, http.StatusNotFound)
+}
+
+func (suite *GinRouterSuite) TestAccountBalanceInvalidAccountUIDBadRequest() {
+	suite.accountRequestTest("/accounts/xxxxxxxx/balance", http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminCreateAccountSuccess() {
+	resp := suite.adminRequestTest("POST", "/admin/accounts", `{"name": "Jonh Doe"}`, http.StatusCreated)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "uid").String(), accountUID.String())
+	assert.Equal(suite.T(), gjson.Get(resp, "name").String(), "Jonh Doe")
+	assert.Equal(suite.T(), gjson.Get(resp, "categories.#").Int(), int64(0))
+}
+
+func (suite *GinRouterSuite) TestAdminCreateAccountWithoutTokenUnauthorized() {
+	suite.adminRequestWithTokenTest("POST", "/admin/accounts", `{"name": "Jonh Doe"}`, "", http.StatusUnauthorized)
+}
+
+func (suite *GinRouterSuite) TestAdminListAccountsWithInvalidTokenUnauthorized() {
+	suite.adminRequestWithTokenTest("GET", "/admin/accounts", "", "not-the-admin-token", http.StatusUnauthorized)
+}
+
+func (suite *GinRouterSuite) TestAdminCreateAccountInvalidNameBadRequest() {
+	suite.adminRequestTest("POST", "/admin/accounts", `{"name": ""}`, http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminListAccountsSuccess() {
+	resp := suite.adminRequestTest("GET", "/admin/accounts?limit=1", "", http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "accounts.#").Int(), int64(1))
+	assert.Equal(suite.T(), gjson.Get(resp, "accounts.0.categories.0.name").String(), "FOOD")
+	assert.Equal(suite.T(), gjson.Get(resp, "accounts.0.categories.0.mccs.#").Int(), int64(2))
+	assert.Equal(suite.T(), gjson.Get(resp, "nextCursor").String(), "MQ")
+}
+
+func (suite *GinRouterSuite) TestAdminListAccountsInvalidCursorBadRequest() {
+	suite.adminRequestTest("GET", "/admin/accounts?cursor=invalid", "", http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminDeleteAccountSuccess() {
+	path := fmt.Sprintf("/admin/accounts/%s", accountUID)
+
+	suite.adminRequestTest("DELETE", path, "", http.StatusNoContent)
+}
+
+func (suite *GinRouterSuite) TestAdminDeleteAccountNotFound() {
+	path := fmt.Sprintf("/admin/accounts/%s", uuid.NewString())
+
+	suite.adminRequestTest("DELETE", path, "", http.StatusNotFound)
+}
+
+func (suite *GinRouterSuite) TestAdminAttachCategoryAlreadyAttachedConflict() {
+	path := fmt.Sprintf("/admin/accounts/%s/categories/%s", accountUID, categoryUID)
+
+	suite.adminRequestTest("POST", path, "", http.StatusConflict)
+}
+
+func (suite *GinRouterSuite) TestAdminAttachCategoryNotFound() {
+	path := fmt.Sprintf("/admin/accounts/%s/categories/%s", accountUID, uuid.NewString())
+
+	suite.adminRequestTest("POST", path, "", http.StatusNotFound)
+}
+
+func (suite *GinRouterSuite) TestAdminDetachCategorySuccess() {
+	path := fmt.Sprintf("/admin/accounts/%s/categories/%s", accountUID, categoryUID)
+
+	suite.adminRequestTest("DELETE", path, "", http.StatusNoContent)
+}
+
+func (suite *GinRouterSuite) TestAdminDetachCategoryInvalidCategoryUIDBadRequest() {
+	path := fmt.Sprintf("/admin/accounts/%s/categories/xxxxxxxx", accountUID)
+
+	suite.adminRequestTest("DELETE", path, "", http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminCreateCategorySuccess() {
+	resp := suite.adminRequestTest("POST", "/admin/categories", `{"name": "MOBILITY", "priority": 4}`, http.StatusCreated)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "uid").String(), categoryUID.String())
+	assert.Equal(suite.T(), gjson.Get(resp, "priority").Int(), int64(4))
+	assert.Equal(suite.T(), gjson.Get(resp, "mccs.#").Int(), int64(0))
+}
+
+func (suite *GinRouterSuite) TestAdminCreateCategoryInvalidPriorityBadRequest() {
+	suite.adminRequestTest("POST", "/admin/categories", `{"name": "MOBILITY", "priority": 0}`, http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminAssignMCCSuccess() {
+	path := fmt.Sprintf("/admin/categories/%s/mccs", categoryUID)
+
+	resp := suite.adminRequestTest("POST", path, `{"mcc": "4121"}`, http.StatusCreated)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "category").String(), categoryUID.String())
+	assert.Equal(suite.T(), gjson.Get(resp, "mcc").String(), "4121")
+}
+
+func (suite *GinRouterSuite) TestAdminAssignMCCAlreadyAssignedConflict() {
+	path := fmt.Sprintf("/admin/categories/%s/mccs", categoryUID)
+
+	suite.adminRequestTest("POST", path, `{"mcc": "5411"}`, http.StatusConflict)
+}
+
+func (suite *GinRouterSuite) TestAdminAssignMCCInvalidMCCBadRequest() {
+	path := fmt.Sprintf("/admin/categories/%s/mccs", categoryUID)
+
+	suite.adminRequestTest("POST", path, `{"mcc": "41"}`, http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminSetCategoryRuleSuccess() {
+	reqBody := fmt.Sprintf(`{"account": "%s", "fallbacks": ["%s"]}`, accountUID, categoryUID)
+
+	resp := suite.adminRequestTest("PUT", "/admin/category-rules", reqBody, http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "account").String(), accountUID.String())
+	assert.Equal(suite.T(), gjson.Get(resp, "category").Exists(), false)
+	assert.Equal(suite.T(), gjson.Get(resp, "fallbacks.0.uid").String(), categoryUID.String())
+	assert.Equal(suite.T(), gjson.Get(resp, "fallbacks.0.name").String(), "FOOD")
+}
+
+func (suite *GinRouterSuite) TestAdminSetCategoryRuleInvalidFallbackBadRequest() {
+	suite.adminRequestTest("PUT", "/admin/category-rules", `{"fallbacks": ["xxxxxxxx"]}`, http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminSetCategoryRuleFallbackNotFound() {
+	reqBody := fmt.Sprintf(`{"fallbacks": ["%s"]}`, uuid.NewString())
+
+	suite.adminRequestTest("PUT", "/admin/category-rules", reqBody, http.StatusNotFound)
+}
+
+func (suite *GinRouterSuite) TestAdminListCategoryRulesSuccess() {
+	path := fmt.Sprintf("/admin/category-rules?account=%s", accountUID)
+
+	resp := suite.adminRequestTest("GET", path, "", http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "rules.#").Int(), int64(1))
+	assert.Equal(suite.T(), gjson.Get(resp, "rules.0.fallbacks.0.name").String(), "FOOD")
+}
+
+func (suite *GinRouterSuite) TestAdminListCategoryRulesInvalidAccountBadRequest() {
+	suite.adminRequestTest("GET", "/admin/category-rules?account=xxxxxxxx", "", http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminSetSpendingLimitSuccess() {
+	path := fmt.Sprintf("/admin/accounts/%s/limits", accountUID)
+	reqBody := `{"dailyAmount": "150.00", "hourlyTransactions": 5}`
+
+	resp := suite.adminRequestTest("PUT", path, reqBody, http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "account").String(), accountUID.String())
+	assert.Equal(suite.T(), gjson.Get(resp, "category").Exists(), false)
+	assert.Equal(suite.T(), gjson.Get(resp, "dailyAmount").String(), "150")
+	assert.Equal(suite.T(), gjson.Get(resp, "monthlyAmount").String(), "0")
+	assert.Equal(suite.T(), gjson.Get(resp, "hourlyTransactions").Int(), int64(5))
+}
+
+func (suite *GinRouterSuite) TestAdminSetSpendingLimitNegativeHourlyTransactionsBadRequest() {
+	path := fmt.Sprintf("/admin/accounts/%s/limits", accountUID)
+
+	suite.adminRequestTest("PUT", path, `{"hourlyTransactions": -1}`, http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminSetSpendingLimitAccountNotFound() {
+	path := fmt.Sprintf("/admin/accounts/%s/limits", uuid.NewString())
+
+	suite.adminRequestTest("PUT", path, `{"transactionAmount": "80.00"}`, http.StatusNotFound)
+}
+
+func (suite *GinRouterSuite) TestAdminListSpendingLimitsSuccess() {
+	path := fmt.Sprintf("/admin/accounts/%s/limits", accountUID)
+
+	resp := suite.adminRequestTest("GET", path, "", http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "limits.#").Int(), int64(2))
+	assert.Equal(suite.T(), gjson.Get(resp, "limits.0.dailyAmount").String(), "150")
+	assert.Equal(suite.T(), gjson.Get(resp, "limits.1.categoryName").String(), "FOOD")
+	assert.Equal(suite.T(), gjson.Get(resp, "limits.1.transactionAmount").String(), "80")
+}
+
+func (suite *GinRouterSuite) TestAdminListSpendingLimitsInvalidAccountBadRequest() {
+	suite.adminRequestTest("GET", "/admin/accounts/xxxxxxxx/limits", "", http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminBlockAccountSuccess() {
+	path := fmt.Sprintf("/admin/accounts/%s/block", accountUID)
+
+	resp := suite.adminRequestTest("POST", path, `{"reason": "card reported stolen"}`, http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "account").String(), accountUID.String())
+	assert.Equal(suite.T(), gjson.Get(resp, "previousStatus").String(), "ACTIVE")
+	assert.Equal(suite.T(), gjson.Get(resp, "status").String(), "BLOCKED")
+	assert.Equal(suite.T(), gjson.Get(resp, "reason").String(), "card reported stolen")
+}
+
+func (suite *GinRouterSuite) TestAdminCancelAccountWithoutBodySuccess() {
+	path := fmt.Sprintf("/admin/accounts/%s/cancel", accountUID)
+
+	resp := suite.adminRequestTest("POST", path, "", http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "status").String(), "CANCELLED")
+}
+
+func (suite *GinRouterSuite) TestAdminUnblockActiveAccountConflict() {
+	path := fmt.Sprintf("/admin/accounts/%s/unblock", accountUID)
+
+	suite.adminRequestTest("POST", path, "", http.StatusConflict)
+}
+
+func (suite *GinRouterSuite) TestAdminBlockAccountNotFound() {
+	path := fmt.Sprintf("/admin/accounts/%s/block", uuid.NewString())
+
+	suite.adminRequestTest("POST", path, "", http.StatusNotFound)
+}
+
+func (suite *GinRouterSuite) TestAdminBlockAccountInvalidAccountBadRequest() {
+	suite.adminRequestTest("POST", "/admin/accounts/xxxxxxxx/block", "", http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminListAccountStatusChangesSuccess() {
+	path := fmt.Sprintf("/admin/accounts/%s/status-changes", accountUID)
+
+	resp := suite.adminRequestTest("GET", path, "", http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "changes.#").Int(), int64(2))
+	assert.Equal(suite.T(), gjson.Get(resp, "changes.0.status").String(), "BLOCKED")
+	assert.Equal(suite.T(), gjson.Get(resp, "changes.0.reason").String(), "card reported stolen")
+	assert.Equal(suite.T(), gjson.Get(resp, "changes.1.status").String(), "ACTIVE")
+}
+
+func (suite *GinRouterSuite) TestAdminCreateCardSuccess() {
+	path := fmt.Sprintf("/admin/accounts/%s/cards", accountUID)
+	reqBody := `{"token": "tok_9b8c7d6e5f4a3b21", "maskedPan": "411111******1111", "expiry": "12/30", "transactionAmount": 80}`
+
+	resp := suite.adminRequestTest("POST", path, reqBody, http.StatusCreated)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "uid").String(), cardUID.String())
+	assert.Equal(suite.T(), gjson.Get(resp, "account").String(), accountUID.String())
+	assert.Equal(suite.T(), gjson.Get(resp, "status").String(), "ACTIVE")
+	assert.Equal(suite.T(), gjson.Get(resp, "expiry").String(), "12/30")
+	assert.Equal(suite.T(), gjson.Get(resp, "token").Exists(), false)
+}
+
+func (suite *GinRouterSuite) TestAdminCreateCardTokenTakenConflict() {
+	path := fmt.Sprintf("/admin/accounts/%s/cards", accountUID)
+	reqBody := fmt.Sprintf(`{"token": "%s", "maskedPan": "411111******1111", "expiry": "12/30"}`, cardToken)
+
+	suite.adminRequestTest("POST", path, reqBody, http.StatusConflict)
+}
+
+func (suite *GinRouterSuite) TestAdminCreateCardInvalidExpiryBadRequest() {
+	path := fmt.Sprintf("/admin/accounts/%s/cards", accountUID)
+	reqBody := `{"token": "tok_9b8c7d6e5f4a3b21", "maskedPan": "411111******1111", "expiry": "12/2030"}`
+
+	suite.adminRequestTest("POST", path, reqBody, http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminListCardsSuccess() {
+	path := fmt.Sprintf("/admin/accounts/%s/cards", accountUID)
+
+	resp := suite.adminRequestTest("GET", path, "", http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "cards.#").Int(), int64(1))
+	assert.Equal(suite.T(), gjson.Get(resp, "cards.0.maskedPan").String(), "411111******1111")
+}
+
+func (suite *GinRouterSuite) TestAdminUpdateCardSuccess() {
+	path := fmt.Sprintf("/admin/cards/%s", cardUID)
+
+	resp := suite.adminRequestTest("PUT", path, `{"status": "BLOCKED", "expiry": "12/30"}`, http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "status").String(), "BLOCKED")
+}
+
+func (suite *GinRouterSuite) TestAdminUpdateCardNotFound() {
+	path := fmt.Sprintf("/admin/cards/%s", uuid.NewString())
+
+	suite.adminRequestTest("PUT", path, `{"status": "BLOCKED", "expiry": "12/30"}`, http.StatusNotFound)
+}
+
+func (suite *GinRouterSuite) TestAdminCreateMerchantSuccess() {
+	reqBody := `{"name": "UBER EATS                   SAO PAULO BR", "mcc": "5412", "aliases": ["UBER*"]}`
+
+	resp := suite.adminRequestTest("POST", "/admin/merchants", reqBody, http.StatusCreated)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "uid").String(), merchantUID.String())
+	assert.Equal(suite.T(), gjson.Get(resp, "name").String(), "UBER EATS                   SAO PAULO BR")
+	assert.Equal(suite.T(), gjson.Get(resp, "mcc").String(), "5412")
+	assert.Equal(suite.T(), gjson.Get(resp, "aliases.0").String(), "UBER*")
+}
+
+func (suite *GinRouterSuite) TestAdminCreateMerchantInvalidAliasBadRequest() {
+	suite.adminRequestTest("POST", "/admin/merchants", `{"name": "PADARIA DO ZE", "mcc": "5411", "aliases": ["P*"]}`, http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminCreateMerchantInvalidMCCBadRequest() {
+	suite.adminRequestTest("POST", "/admin/merchants", `{"name": "PADARIA DO ZE", "mcc": "54"}`, http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminCreateMerchantMCCNotFound() {
+	suite.adminRequestTest("POST", "/admin/merchants", `{"name": "PADARIA DO ZE", "mcc": "9999"}`, http.StatusNotFound)
+}
+
+func (suite *GinRouterSuite) TestAdminListMerchantsSuccess() {
+	resp := suite.adminRequestTest("GET", "/admin/merchants", "", http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "merchants.#").Int(), int64(1))
+	assert.Equal(suite.T(), gjson.Get(resp, "merchants.0.mcc").String(), "5412")
+	assert.Equal(suite.T(), gjson.Get(resp, "nextCursor").Exists(), false)
+}
+
+func (suite *GinRouterSuite) TestAdminGetMerchantSuccess() {
+	path := fmt.Sprintf("/admin/merchants/%s", merchantUID)
+
+	resp := suite.adminRequestTest("GET", path, "", http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "uid").String(), merchantUID.String())
+}
+
+func (suite *GinRouterSuite) TestAdminGetMerchantNotFound() {
+	path := fmt.Sprintf("/admin/merchants/%s", uuid.NewString())
+
+	suite.adminRequestTest("GET", path, "", http.StatusNotFound)
+}
+
+func (suite *GinRouterSuite) TestAdminUpdateMerchantAlreadyExistsConflict() {
+	path := fmt.Sprintf("/admin/merchants/%s", merchantUID)
+
+	suite.adminRequestTest("PUT", path, `{"name": "PADARIA DO ZE", "mcc": "5411"}`, http.StatusConflict)
+}
+
+func (suite *GinRouterSuite) TestAdminDeleteMerchantSuccess() {
+	path := fmt.Sprintf("/admin/merchants/%s", merchantUID)
+
+	suite.adminRequestTest("DELETE", path, "", http.StatusNoContent)
+}
+
+func (suite *GinRouterSuite) TestAdminDeleteMerchantInvalidUIDBadRequest() {
+	suite.adminRequestTest("DELETE", "/admin/merchants/xxxxxxxx", "", http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminCheckLedgerReportsInconsistencies() {
+	path := fmt.Sprintf("/admin/ledger/consistency?account=%s", accountUID)
+
+	resp := suite.adminRequestTest("GET", path, "", http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "checked").Int(), int64(3))
+	assert.Equal(suite.T(), gjson.Get(resp, "inconsistencies.#").Int(), int64(1))
+	assert.Equal(suite.T(), gjson.Get(resp, "inconsistencies.0.ledgerBalance").String(), "100.02")
+	assert.Equal(suite.T(), gjson.Get(resp, "inconsistencies.0.brokenEntries").Int(), int64(1))
+}
+
+func (suite *GinRouterSuite) TestAdminCheckLedgerInvalidAccountUIDBadRequest() {
+	suite.adminRequestTest("GET", "/admin/ledger/consistency?account=xxxxxxxx", "", http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminCheckLedgerInvalidCursorBadRequest() {
+	suite.adminRequestTest("GET", "/admin/ledger/consistency?cursor=invalid", "", http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) TestAdminGetLockQueueReportsDepth() {
+	path := fmt.Sprintf("/admin/accounts/%s/lock-queue", accountUID)
+
+	resp := suite.adminRequestTest("GET", path, "", http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "account").String(), accountUID.String())
+	assert.Equal(suite.T(), gjson.Get(resp, "depth").Int(), int64(3))
+}
+
+func (suite *GinRouterSuite) TestAdminGetLockQueueInvalidUIDBadRequest() {
+	suite.adminRequestTest("GET", "/admin/accounts/xxxxxxxx/lock-queue", "", http.StatusBadRequest)
+}
+
+func (suite *GinRouterSuite) adminRequestTest(method, path, reqBody string, httpStatus int) string {
+	return suite.adminRequestWithTokenTest(method, path, reqBody, suite.adminToken, httpStatus)
+}
+
+func (suite *GinRouterSuite) adminRequestWithTokenTest(method, path, reqBody, token string, httpStatus int) string {
+	req, err := http.NewRequest(method, path, bytes.NewBuffer([]byte(reqBody)))
+	assert.NoError(suite.T(), err)
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp := httptest.NewRecorder()
+	suite.router.ServeHTTP(resp, req)
+	assert.Equal(suite.T(), httpStatus, resp.Code)
+
+	return resp.Body.String()
+}
+
+func (suite *GinRouterSuite) accountRequestTest(path string, httpStatus int) string {
+	req, err := http.NewRequest("GET", path, nil)
+	assert.NoError(suite.T(), err)
+
+	resp := httptest.NewRecorder()
+	suite.router.ServeHTTP(resp, req)
+	assert.Equal(suite.T(), httpStatus, resp.Code)
+
+	return resp.Body.String()
+}
+
+func (suite *GinRouterSuite) paymentRequestTest(path, reqBody string, returnCode string) {
+	req, err := http.NewRequest("POST", path, bytes.NewBuffer([]byte(reqBody)))
+	assert.NoError(suite.T(), err)
+
+	resp := httptest.NewRecorder()
+	suite.router.ServeHTTP(resp, req)
+	assert.Equal(suite.T(), http.StatusOK, resp.Code)
+
+	assert.Equal(suite.T(), gjson.Get(resp.Body.String(), "code").String(), returnCode)
+}
+
+func (suite *GinRouterSuite) creditRequestTest(reqBody string, returnCode string) {
+	resp := suite.adminRequestTest("POST", "/credit", reqBody, http.StatusOK)
+
+	assert.Equal(suite.T(), gjson.Get(resp, "code").String(), returnCode)
+}
+
+func (suite *GinRouterSuite) paymentRefundTransactionTest(transactionUID, reqBody string, returnCode string) {
+	path := fmt.Sprintf("/payment/%s/refund", transactionUID)
+	reqPaymentRefund, err := http.NewRequest("POST", path, bytes.NewBuffer([]byte(reqBody)))
+	assert.NoError(suite.T(), err)
+
+	respPaymentRefund := httptest.NewRecorder()
+	suite.router.ServeHTTP(respPaymentRefund, reqPaymentRefund)
+	assert.Equal(suite.T(), http.StatusOK, respPaymentRefund.Code)
+
+	bodyRespPaymentRefund := respPaymentRefund.Body.String()
+	assert.Equal(suite.T(), gjson.Get(bodyRespPaymentRefund, "code").String(), returnCode)
+}
+
 func (suite *GinRouterSuite) paymentExecuteTransactionTest(reqBody string, returnCode string) {
 	reqPaymentExecution, err := http.NewRequest("POST", "/payment", bytes.NewBuffer([]byte(reqBody)))
 	assert.NoError(suite.T(), err)
@@ -7,8 +7,13 @@ import (
 type Account struct {
 	BaseModel `swaggerignore:"true"`
 
-	UID  uuid.UUID `json:"uid" example:"123e4567-e89b-12d3-a456-426614174000"gorm:"type:uuid;uniqueIndex"`
+	UID  uuid.UUID `json:"uid" example:"123e4567-e89b-12d3-a456-426614174000" gorm:"type:uuid;uniqueIndex"`
 	Name string    `json:"name" binding:"required" example:"Jonh Doe" gorm:"type:varchar(255)"`
 
+	CurrencyConversion bool   `json:"currency_conversion" example:"false" gorm:"not null;default:false"`
+	Currency           string `json:"currency" example:"BRL" gorm:"type:varchar(3);not null;default:'BRL'"`
+	Status             string `json:"status" example:"ACTIVE" gorm:"type:varchar(10);not null;default:ACTIVE"`
+	FencingToken       int64  `json:"-" gorm:"not null;default:0"`
+
 	AccountCategories []AccountCategory `gorm:"foreignKey:AccountID"`
 }
@@ -6,5 +6,6 @@ type AccountCategory struct {
 	AccountID  uint `json:"account_id" binding:"required" example:"1"`
 	CategoryID uint `json:"category_id" binding:"required" example:"1"`
 
-	Account Account `gorm:"foreignKey:AccountID"`
+	Account  Account  `gorm:"foreignKey:AccountID"`
+	Category Category `gorm:"foreignKey:CategoryID"`
 }
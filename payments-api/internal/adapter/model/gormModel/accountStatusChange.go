@@ -0,0 +1,12 @@
+package gormModel
+
+type AccountStatusChange struct {
+	BaseModel `swaggerignore:"true"`
+
+	AccountID      uint   `json:"account_id" binding:"required" example:"1"`
+	PreviousStatus string `json:"previous_status" binding:"required" example:"ACTIVE" gorm:"type:varchar(10)"`
+	Status         string `json:"status" binding:"required" example:"BLOCKED" gorm:"type:varchar(10)"`
+	Reason         string `json:"reason" example:"card reported stolen" gorm:"type:varchar(255);not null;default:''"`
+
+	Account Account `gorm:"foreignKey:AccountID"`
+}
@@ -0,0 +1,23 @@
+package gormModel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type Card struct {
+	BaseModel `swaggerignore:"true"`
+
+	UID               uuid.UUID       `json:"uid" binding:"required" example:"4a7f3c2e-8b1d-4e5f-9a6c-0d2e3f4a5b6c" gorm:"type:uuid;uniqueIndex"`
+	AccountID         uint            `json:"account_id" binding:"required" example:"1"`
+	Token             string          `json:"token" binding:"required" example:"tok_4f9a1c2e7b3d" gorm:"type:varchar(64);not null"`
+	MaskedPAN         string          `json:"masked_pan" binding:"required" example:"516292******1234" gorm:"type:varchar(19);column:masked_pan;not null"`
+	Status            string          `json:"status" example:"ACTIVE" gorm:"type:varchar(10);not null;default:'ACTIVE'"`
+	ExpiresAt         time.Time       `json:"expires_at" binding:"required" example:"2029-01-01T00:00:00Z" gorm:"not null"`
+	TransactionAmount decimal.Decimal `json:"transaction_amount" example:"80.00" gorm:"type:numeric(20,2);not null;default:0"`
+	DailyAmount       decimal.Decimal `json:"daily_amount" example:"150.00" gorm:"type:numeric(20,2);not null;default:0"`
+
+	Account Account `gorm:"foreignKey:AccountID"`
+}
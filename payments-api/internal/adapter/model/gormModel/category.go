@@ -10,6 +10,9 @@ type Category struct {
 	UID      uuid.UUID `json:"uid" binding:"required" example:"18e408ce-560a-48a1-b70c-2aa6408b8443" gorm:"type:uuid;uniqueIndex"`
 	Name     string    `json:"name" binding:"required" example:"CASH" gorm:"type:varchar(255)"`
 	Priority int       `json:"priority" binding:"required" example:"1"`
+	Currency string    `json:"currency" example:"BRL" gorm:"type:varchar(3);not null;default:'BRL'"`
+
+	FallbackExcluded bool `json:"fallback_excluded" example:"false" gorm:"not null;default:false"`
 
 	MCCs              []MCC             `gorm:"foreignKey:CategoryID"`
 	Transactions      []Transaction     `gorm:"foreignKey:CategoryID"`
@@ -0,0 +1,18 @@
+package gormModel
+
+import (
+	"database/sql"
+)
+
+type CategoryRule struct {
+	BaseModel `swaggerignore:"true"`
+
+	AccountID          sql.NullInt64 `json:"account_id" example:"1"`
+	CategoryID         sql.NullInt64 `json:"category_id" example:"2"`
+	FallbackCategoryID uint          `json:"fallback_category_id" binding:"required" example:"1"`
+	Position           int           `json:"position" binding:"required" example:"1"`
+
+	Account          Account  `gorm:"foreignKey:AccountID"`
+	Category         Category `gorm:"foreignKey:CategoryID"`
+	FallbackCategory Category `gorm:"foreignKey:FallbackCategoryID"`
+}
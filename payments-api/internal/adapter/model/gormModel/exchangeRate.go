@@ -0,0 +1,13 @@
+package gormModel
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+type ExchangeRate struct {
+	BaseModel `swaggerignore:"true"`
+
+	FromCurrency string          `json:"from_currency" binding:"required" example:"USD" gorm:"type:varchar(3);not null"`
+	ToCurrency   string          `json:"to_currency" binding:"required" example:"BRL" gorm:"type:varchar(3);not null"`
+	Rate         decimal.Decimal `json:"rate" binding:"required" example:"5.0000000000" gorm:"type:numeric(20,10);not null"`
+}
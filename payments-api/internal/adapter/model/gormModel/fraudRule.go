@@ -0,0 +1,18 @@
+package gormModel
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+type FraudRule struct {
+	BaseModel `swaggerignore:"true"`
+
+	Name          string          `json:"name" binding:"required" example:"rapid-repeats-same-merchant" gorm:"type:varchar(255)"`
+	Kind          string          `json:"kind" binding:"required" example:"RAPID_REPEAT" gorm:"type:varchar(30)"`
+	Decision      string          `json:"decision" binding:"required" example:"DECLINE" gorm:"type:varchar(10)"`
+	MCCs          string          `json:"mccs" example:"7995,7801" gorm:"column:mccs;type:varchar(255);not null;default:''"`
+	Amount        decimal.Decimal `json:"amount" example:"1000.00" gorm:"type:numeric(20,2);not null;default:0"`
+	Count         int             `json:"count" example:"3" gorm:"not null;default:0"`
+	WindowSeconds int             `json:"window_seconds" example:"60" gorm:"not null;default:0"`
+	Enabled       bool            `json:"enabled" example:"true" gorm:"not null;default:true"`
+}
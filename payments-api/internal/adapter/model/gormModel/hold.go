@@ -0,0 +1,31 @@
+package gormModel
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type Hold struct {
+	BaseModel `swaggerignore:"true"`
+
+	UID          uuid.UUID       `json:"uid" binding:"required" example:"91ee2159-f59f-4c89-a543-81987d563d7a" gorm:"type:uuid;index"`
+	AccountID    uint            `json:"account_id" binding:"required" example:"1"`
+	CategoryID   uint            `json:"category_id" binding:"required" example:"1"`
+	Amount       decimal.Decimal `json:"amount" binding:"required" example:"110.22" gorm:"type:numeric(20,2);"`
+	MCC          string          `json:"mcc" binding:"required" example:"5411" gorm:"type:varchar(5);column:mcc"`
+	MerchantName string          `json:"merchant_name" binding:"required" example:"PADARIA DO ZE              SAO PAULO BR" gorm:"type:varchar(255)"`
+	Status       string          `json:"status" binding:"required" example:"AUTHORIZED" gorm:"type:varchar(20)"`
+	ExpiresAt    time.Time       `json:"expires_at" binding:"required" example:"2024-12-04T21:50:21Z"`
+	CardID       sql.NullInt64   `json:"card_id" example:"1"`
+
+	Currency         string              `json:"currency" example:"BRL" gorm:"type:varchar(3);not null;default:'BRL'"`
+	OriginalAmount   decimal.NullDecimal `json:"original_amount" example:"22.04" gorm:"type:numeric(20,2)"`
+	OriginalCurrency sql.NullString      `json:"original_currency" example:"USD" gorm:"type:varchar(3)"`
+	ExchangeRate     decimal.NullDecimal `json:"exchange_rate" example:"5.0000000000" gorm:"type:numeric(20,10)"`
+
+	Category Category `gorm:"foreignKey:CategoryID"`
+	Account  Account  `gorm:"foreignKey:AccountID"`
+}
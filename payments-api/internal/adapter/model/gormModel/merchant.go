@@ -10,5 +10,6 @@ type Merchant struct {
 	Name      string    `json:"name" binding:"required" example:"UBER EATS   SAO PAULO BR" gorm:"type:varchar(255);uniqueIndex"`
 	MccID     uint      `json:"mcc_id" binding:"required" example:"1"`
 
-	MCC MCC `gorm:"foreignKey:MccID"`
+	MCC     MCC             `gorm:"foreignKey:MccID"`
+	Aliases []MerchantAlias `gorm:"foreignKey:MerchantID"`
 }
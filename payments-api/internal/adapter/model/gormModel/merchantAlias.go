@@ -0,0 +1,8 @@
+package gormModel
+
+type MerchantAlias struct {
+	BaseModel `swaggerignore:"true"`
+
+	MerchantID uint   `json:"merchant_id" binding:"required" example:"1" gorm:"index"`
+	Alias      string `json:"alias" binding:"required" example:"UBER*" gorm:"type:varchar(255)"`
+}
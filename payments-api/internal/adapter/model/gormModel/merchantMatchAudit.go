@@ -0,0 +1,20 @@
+package gormModel
+
+import (
+	"github.com/google/uuid"
+)
+
+type MerchantMatchAudit struct {
+	BaseModel `swaggerignore:"true"`
+
+	TransactionUID  uuid.UUID `json:"transaction_uid" binding:"required" example:"91ee2159-f59f-4c89-a543-81987d563d7a" gorm:"type:uuid;index"`
+	AccountID       uint      `json:"account_id" binding:"required" example:"1"`
+	MerchantName    string    `json:"merchant_name" binding:"required" example:"UBER EATS                   SAO PAULO BR" gorm:"type:varchar(255)"`
+	MatchedMerchant string    `json:"matched_merchant" binding:"required" example:"UBER EATS" gorm:"type:varchar(255)"`
+	MatchRule       string    `json:"match_rule" binding:"required" example:"PREFIX" gorm:"type:varchar(20)"`
+	MatchPattern    string    `json:"match_pattern" binding:"required" example:"UBER*" gorm:"type:varchar(255)"`
+	OriginalMCC     string    `json:"original_mcc" binding:"required" example:"5411" gorm:"type:varchar(5);column:original_mcc"`
+	MCC             string    `json:"mcc" binding:"required" example:"5812" gorm:"type:varchar(5);column:mcc"`
+
+	Account Account `gorm:"foreignKey:AccountID"`
+}
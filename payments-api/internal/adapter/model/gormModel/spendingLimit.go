@@ -0,0 +1,21 @@
+package gormModel
+
+import (
+	"database/sql"
+
+	"github.com/shopspring/decimal"
+)
+
+type SpendingLimit struct {
+	BaseModel `swaggerignore:"true"`
+
+	AccountID          uint            `json:"account_id" binding:"required" example:"1"`
+	CategoryID         sql.NullInt64   `json:"category_id" example:"2"`
+	DailyAmount        decimal.Decimal `json:"daily_amount" example:"150.00" gorm:"type:numeric(20,2);not null;default:0"`
+	MonthlyAmount      decimal.Decimal `json:"monthly_amount" example:"1500.00" gorm:"type:numeric(20,2);not null;default:0"`
+	TransactionAmount  decimal.Decimal `json:"transaction_amount" example:"80.00" gorm:"type:numeric(20,2);not null;default:0"`
+	HourlyTransactions int             `json:"hourly_transactions" example:"5" gorm:"not null;default:0"`
+
+	Account  Account  `gorm:"foreignKey:AccountID"`
+	Category Category `gorm:"foreignKey:CategoryID"`
+}
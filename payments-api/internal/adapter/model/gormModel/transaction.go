@@ -1,6 +1,8 @@
 package gormModel
 
 import (
+	"database/sql"
+
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
@@ -8,12 +10,24 @@ import (
 type Transaction struct {
 	BaseModel `swaggerignore:"true"`
 
-	UID          uuid.UUID       `json:"uid" binding:"required" example:"91ee2159-f59f-4c89-a543-81987d563d7a" gorm:"type:uuid;unique"`
+	UID          uuid.UUID       `json:"uid" binding:"required" example:"91ee2159-f59f-4c89-a543-81987d563d7a" gorm:"type:uuid;uniqueIndex:idx_transactions_uid_category_id"`
 	AccountID    uint            `json:"account_id" binding:"required" example:"1" gorm:"index:idx_transaction_composite"`
-	CategoryID   uint            `json:"category_id" binding:"required" example:"1" gorm:"index:idx_transaction_composite"`
+	CategoryID   uint            `json:"category_id" binding:"required" example:"1" gorm:"index:idx_transaction_composite;uniqueIndex:idx_transactions_uid_category_id"`
 	Amount       decimal.Decimal `json:"amount" binding:"required" example:"110.22" gorm:"type:numeric(20,2);"`
 	MCC          string          `json:"mcc" binding:"required" example:"5411" gorm:"type:varchar(5);column:mcc"`
 	MerchantName string          `json:"merchant_name" binding:"required" example:"Jonh Doe" gorm:"type:varchar(255)"`
+	OriginalUID  uuid.NullUUID   `json:"original_uid" example:"91ee2159-f59f-4c89-a543-81987d563d7a" gorm:"type:uuid;index"`
+	CardID       sql.NullInt64   `json:"card_id" example:"1"`
+
+	Operation     string          `json:"operation" binding:"required" example:"AUTHORIZATION" gorm:"type:varchar(20);not null"`
+	EntryType     string          `json:"entry_type" binding:"required" example:"DEBIT" gorm:"type:varchar(6);not null"`
+	BalanceBefore decimal.Decimal `json:"balance_before" example:"210.33" gorm:"type:numeric(20,2);not null"`
+	BalanceAfter  decimal.Decimal `json:"balance_after" example:"100.11" gorm:"type:numeric(20,2);->"`
+
+	Currency         string              `json:"currency" example:"BRL" gorm:"type:varchar(3);not null;default:'BRL'"`
+	OriginalAmount   decimal.NullDecimal `json:"original_amount" example:"22.04" gorm:"type:numeric(20,2)"`
+	OriginalCurrency sql.NullString      `json:"original_currency" example:"USD" gorm:"type:varchar(3)"`
+	ExchangeRate     decimal.NullDecimal `json:"exchange_rate" example:"5.0000000000" gorm:"type:numeric(20,10)"`
 
 	Category Category `gorm:"foreignKey:CategoryID"`
 	Account  Account  `gorm:"foreignKey:AccountID"`
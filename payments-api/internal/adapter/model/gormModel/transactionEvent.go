@@ -0,0 +1,25 @@
+package gormModel
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type TransactionEvent struct {
+	BaseModel `swaggerignore:"true"`
+
+	UID            uuid.UUID       `json:"uid" binding:"required" example:"0b5c8f3e-2d4a-4f6b-9c1e-7a8d3b2f6e4c" gorm:"type:uuid;uniqueIndex"`
+	AccountID      sql.NullInt64   `json:"account_id" example:"1"`
+	AccountUID     uuid.NullUUID   `json:"account_uid" example:"123e4567-e89b-12d3-a456-426614174000" gorm:"type:uuid"`
+	EventType      string          `json:"event_type" binding:"required" example:"TRANSACTION_APPROVED" gorm:"type:varchar(20);not null"`
+	TransactionUID uuid.UUID       `json:"transaction_uid" binding:"required" example:"91ee2159-f59f-4c89-a543-81987d563d7a" gorm:"type:uuid;not null"`
+	OriginalUID    uuid.NullUUID   `json:"original_uid" example:"91ee2159-f59f-4c89-a543-81987d563d7a" gorm:"type:uuid"`
+	Amount         decimal.Decimal `json:"amount" example:"100.10" gorm:"type:numeric(20,2);not null;default:0"`
+	Currency       string          `json:"currency" example:"BRL" gorm:"type:varchar(3);not null;default:'BRL'"`
+	Code           string          `json:"code" binding:"required" example:"00" gorm:"type:varchar(2);not null"`
+	PublishedAt    sql.NullTime    `json:"published_at" example:"2024-12-04T21:50:22Z"`
+
+	Account Account `gorm:"foreignKey:AccountID"`
+}
@@ -0,0 +1,15 @@
+package gormModel
+
+import (
+	"github.com/google/uuid"
+)
+
+type TransactionOutcome struct {
+	BaseModel `swaggerignore:"true"`
+
+	UID       uuid.UUID `json:"uid" binding:"required" example:"91ee2159-f59f-4c89-a543-81987d563d7a" gorm:"type:uuid;uniqueIndex"`
+	AccountID uint      `json:"account_id" binding:"required" example:"1"`
+	Code      string    `json:"code" binding:"required" example:"00" gorm:"type:varchar(2)"`
+
+	Account Account `gorm:"foreignKey:AccountID"`
+}
@@ -0,0 +1,130 @@
+package pubSub
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/jtonynet/go-payments-api/config"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+)
+
+/*
+  - In process implementation of `PubSub` for single node and test mode, listening
+    to the expired keys of the in process database with the same `DB` number and
+    to the release topics published in the process
+*/
+type MemoryPubSub struct {
+	expired     <-chan string
+	unsubscribe func()
+
+	// Guards the subscriptions from changing while dispatching
+	mu            sync.RWMutex
+	subscriptions sync.Map
+	strategy      string
+	db            int
+}
+
+func NewMemoryPubSub(cfg config.PubSub) (*MemoryPubSub, error) {
+	expired, unsubscribe := database.SharedMemoryClient(cfg.DB).SubscribeExpired()
+
+	mps := &MemoryPubSub{
+		expired:       expired,
+		unsubscribe:   unsubscribe,
+		subscriptions: sync.Map{},
+		strategy:      cfg.Strategy,
+		db:            cfg.DB,
+	}
+
+	go mps.listen()
+
+	return mps, nil
+}
+
+func (m *MemoryPubSub) Subscribe(_ context.Context, key Key) (<-chan string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	listenerBufferSize := 1
+
+	transactionsSubscriptions, _ := m.subscriptions.LoadOrStore(key.Account, &sync.Map{})
+	transactionMap := transactionsSubscriptions.(*sync.Map)
+
+	subscription, _ := transactionMap.LoadOrStore(key.Transaction, make(chan string, listenerBufferSize))
+	return subscription.(chan string), nil
+}
+
+func (m *MemoryPubSub) UnSubscribe(_ context.Context, key Key) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if transactionsSubscriptions, ok := m.subscriptions.Load(key.Account); ok {
+		transactionMap := transactionsSubscriptions.(*sync.Map)
+
+		if subscription, exists := transactionMap.LoadAndDelete(key.Transaction); exists {
+			close(subscription.(chan string))
+
+			hasRemaining := false
+			transactionMap.Range(func(_, _ interface{}) bool {
+				hasRemaining = true
+				return false
+			})
+
+			if !hasRemaining {
+				m.subscriptions.Delete(key.Account)
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+  - Only the expired keyevent channel of its database and the release topics are
+    delivered, as they are the only channels subscribed by the Redis implementation
+*/
+func (m *MemoryPubSub) Publish(_ context.Context, topic, message string) error {
+	if topic == m.expiredChannel() || strings.HasPrefix(topic, releaseTopicPrefix) {
+		m.dispatch(message)
+	}
+
+	return nil
+}
+
+func (m *MemoryPubSub) Close() error {
+	m.unsubscribe()
+	return nil
+}
+
+func (m *MemoryPubSub) GetStrategy(_ context.Context) (string, error) {
+	return m.strategy, nil
+}
+
+func (m *MemoryPubSub) listen() {
+	for accountUID := range m.expired {
+		m.dispatch(accountUID)
+	}
+}
+
+func (m *MemoryPubSub) dispatch(accountUID string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if transactionsSubscriptions, ok := m.subscriptions.Load(accountUID); ok {
+		transactionMap := transactionsSubscriptions.(*sync.Map)
+
+		transactionMap.Range(func(_, sub interface{}) bool {
+			subscription := sub.(chan string)
+			select {
+			case subscription <- accountUID:
+			default:
+			}
+			return true
+		})
+	}
+}
+
+func (m *MemoryPubSub) expiredChannel() string {
+	return fmt.Sprintf("__keyevent@%d__:expired", m.db)
+}
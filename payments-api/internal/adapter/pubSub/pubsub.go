@@ -15,6 +15,12 @@ type PubSub interface {
 	Close() error
 }
 
+/*
+  - Accounts are released on their own channel by the memory lock owner, while the
+    expired keyevent channel remains as the fallback for owners that never unlock
+*/
+const releaseTopicPrefix = "memory_lock:released:"
+
 type Key struct {
 	Account     string
 	Transaction string
@@ -24,7 +30,13 @@ func New(cfg config.PubSub) (PubSub, error) {
 	switch cfg.Strategy {
 	case "redis":
 		return NewRedisPubSub(cfg)
+	case "memory":
+		return NewMemoryPubSub(cfg)
 	default:
 		return nil, fmt.Errorf("pubsub strategy not suported: %s", cfg.Strategy)
 	}
 }
+
+func ReleaseTopic(account string) string {
+	return releaseTopicPrefix + account
+}
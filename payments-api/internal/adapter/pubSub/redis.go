@@ -4,36 +4,53 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/jtonynet/go-payments-api/config"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
 	"github.com/redis/go-redis/v9"
 )
 
+/*
+- Interval to look for master shards added or replaced in a cluster, by failover or resharding
+*/
+const clusterTopologyRefreshInterval = 5 * time.Second
+
 type RedisPubSub struct {
-	client *redis.Client
-	pubsub *redis.PubSub
+	client redis.UniversalClient
+
+	pubsubsMu sync.Mutex
+	pubsubs   []*redis.PubSub
+
+	// Expired keyevents subscription of each master shard of a cluster, by its address
+	shardsMu      sync.Mutex
+	shards        map[string]*redis.PubSub
+	stopTopology  chan struct{}
+	closeTopology sync.Once
 
-	subscriptions sync.Map
-	strategy      string
+	// Guards the subscriptions from changing while dispatching
+	subscriptionsMu sync.RWMutex
+	subscriptions   sync.Map
+	strategy        string
+	db              int
 }
 
 func NewRedisPubSub(cfg config.PubSub) (*RedisPubSub, error) {
-	strAddr := fmt.Sprintf("%s:%s", cfg.Host, cfg.Port)
-
-	client := redis.NewClient(&redis.Options{
-		Addr:     strAddr,
-		Password: cfg.Pass,
-		DB:       cfg.DB,
-		Protocol: cfg.Protocol,
-	})
+	client, err := database.NewRedisUniversalClient(cfg.ToInMemoryDatabase())
+	if err != nil {
+		return &RedisPubSub{}, err
+	}
 
 	rps := &RedisPubSub{
 		client:        client,
+		shards:        make(map[string]*redis.PubSub),
+		stopTopology:  make(chan struct{}),
 		strategy:      cfg.Strategy,
 		subscriptions: sync.Map{},
+		db:            cfg.DB,
 	}
 
-	err := rps.subscribe(context.Background())
+	err = rps.subscribe(context.Background())
 	if err != nil {
 		return &RedisPubSub{}, err
 	}
@@ -42,35 +59,26 @@ func NewRedisPubSub(cfg config.PubSub) (*RedisPubSub, error) {
 }
 
 func (r *RedisPubSub) Subscribe(_ context.Context, key Key) (<-chan string, error) {
-	listenerBufferSize := 1
+	r.subscriptionsMu.Lock()
+	defer r.subscriptionsMu.Unlock()
 
-	if transactionsSubscriptions, ok := r.subscriptions.Load(key.Account); ok {
-		transactionMap, _ := transactionsSubscriptions.(*sync.Map)
-
-		if subscription, exists := transactionMap.Load(key.Transaction); exists {
-			return subscription.(chan string), nil
-		}
-
-		subscription := make(chan string, listenerBufferSize)
-		transactionMap.Store(key.Transaction, subscription)
-		r.subscriptions.Store(key.Account, transactionMap)
-		return subscription, nil
-	}
+	listenerBufferSize := 1
 
-	subscription := make(chan string, listenerBufferSize)
-	transactionMap := &sync.Map{}
-	transactionMap.Store(key.Transaction, subscription)
+	transactionsSubscriptions, _ := r.subscriptions.LoadOrStore(key.Account, &sync.Map{})
+	transactionMap := transactionsSubscriptions.(*sync.Map)
 
-	r.subscriptions.Store(key.Account, transactionMap)
-	return subscription, nil
+	subscription, _ := transactionMap.LoadOrStore(key.Transaction, make(chan string, listenerBufferSize))
+	return subscription.(chan string), nil
 }
 
 func (r *RedisPubSub) UnSubscribe(_ context.Context, key Key) error {
+	r.subscriptionsMu.Lock()
+	defer r.subscriptionsMu.Unlock()
+
 	if transactionsSubscriptions, ok := r.subscriptions.Load(key.Account); ok {
-		transactionMap, _ := transactionsSubscriptions.(*sync.Map)
+		transactionMap := transactionsSubscriptions.(*sync.Map)
 
-		if subscription, exists := transactionMap.Load(key.Transaction); exists {
-			transactionMap.Delete(key.Transaction)
+		if subscription, exists := transactionMap.LoadAndDelete(key.Transaction); exists {
 			close(subscription.(chan string))
 
 			hasRemaining := false
@@ -88,40 +96,173 @@ func (r *RedisPubSub) UnSubscribe(_ context.Context, key Key) error {
 	return nil
 }
 
+/*
+  - Expired keyevents are only delivered by the node where the key expired, so in
+    a cluster every master shard is subscribed, while the release topics published
+    to any node reach the whole cluster through a single subscription
+  - The masters of a cluster are looked up again on `clusterTopologyRefreshInterval`,
+    subscribing the new ones and dropping those no longer masters
+*/
 func (r *RedisPubSub) subscribe(ctx context.Context) error {
-	keyspaceChannel := fmt.Sprintf("__keyevent@%d__:expired", r.client.Options().DB)
-	r.pubsub = r.client.Subscribe(ctx, keyspaceChannel)
+	releasePattern := releaseTopicPrefix + "*"
+
+	cluster, ok := r.client.(*redis.ClusterClient)
+	if !ok {
+		pubsub := r.client.Subscribe(ctx, r.keyspaceChannel())
+		r.listen(ctx, pubsub)
+
+		err := pubsub.PSubscribe(ctx, releasePattern)
+		if err != nil {
+			r.Close()
+			return fmt.Errorf("failed to subscribe to release topics: %w", err)
+		}
+
+		return nil
+	}
+
+	err := r.subscribeShards(ctx, cluster)
+	if err != nil {
+		r.Close()
+		return err
+	}
+
+	pubsub := cluster.PSubscribe(ctx)
+	r.listen(ctx, pubsub)
+
+	err = pubsub.PSubscribe(ctx, releasePattern)
+	if err != nil {
+		r.Close()
+		return fmt.Errorf("failed to subscribe to release topics: %w", err)
+	}
+
+	go r.watchTopology(cluster)
+
+	return nil
+}
+
+/*
+  - The cluster state is reloaded in the background, so a change is subscribed by
+    the lookup that follows the reload
+*/
+func (r *RedisPubSub) subscribeShards(ctx context.Context, cluster *redis.ClusterClient) error {
+	cluster.ReloadState(ctx)
+
+	masters := make(map[string]bool)
+	var mastersMu sync.Mutex
+
+	err := cluster.ForEachMaster(ctx, func(ctx context.Context, shard *redis.Client) error {
+		addr := shard.Options().Addr
+
+		mastersMu.Lock()
+		masters[addr] = true
+		mastersMu.Unlock()
+
+		r.shardsMu.Lock()
+		_, subscribed := r.shards[addr]
+		r.shardsMu.Unlock()
+		if subscribed {
+			return nil
+		}
 
+		pubsub := shard.Subscribe(ctx)
+		if err := pubsub.Subscribe(ctx, r.keyspaceChannel()); err != nil {
+			pubsub.Close()
+			return err
+		}
+
+		r.shardsMu.Lock()
+		r.shards[addr] = pubsub
+		r.shardsMu.Unlock()
+
+		r.dispatch(context.Background(), pubsub)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to expired keyevents of cluster shards: %w", err)
+	}
+
+	r.shardsMu.Lock()
+	defer r.shardsMu.Unlock()
+
+	for addr, pubsub := range r.shards {
+		if !masters[addr] {
+			pubsub.Close()
+			delete(r.shards, addr)
+		}
+	}
+
+	return nil
+}
+
+/*
+- A failed lookup keeps the current subscriptions until the next interval
+*/
+func (r *RedisPubSub) watchTopology(cluster *redis.ClusterClient) {
+	ticker := time.NewTicker(clusterTopologyRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopTopology:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), clusterTopologyRefreshInterval)
+			_ = r.subscribeShards(ctx, cluster)
+			cancel()
+		}
+	}
+}
+
+func (r *RedisPubSub) keyspaceChannel() string {
+	return fmt.Sprintf("__keyevent@%d__:expired", r.db)
+}
+
+func (r *RedisPubSub) listen(ctx context.Context, pubsub *redis.PubSub) {
+	r.pubsubsMu.Lock()
+	r.pubsubs = append(r.pubsubs, pubsub)
+	r.pubsubsMu.Unlock()
+
+	r.dispatch(ctx, pubsub)
+}
+
+/*
+- Dispatches the account of each message to the transactions subscribed to it
+*/
+func (r *RedisPubSub) dispatch(ctx context.Context, pubsub *redis.PubSub) {
 	go func() {
-		defer r.pubsub.Close()
+		defer pubsub.Close()
 
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case msg, ok := <-r.pubsub.Channel():
+			case msg, ok := <-pubsub.Channel():
 				if !ok {
 					return
 				}
 
-				accountUID := msg.Payload
-				if transactionsSubscriptions, ok := r.subscriptions.Load(accountUID); ok {
-					transactionMap := transactionsSubscriptions.(*sync.Map)
-
-					transactionMap.Range(func(_, sub interface{}) bool {
-						subscription := sub.(chan string)
-						select {
-						case subscription <- accountUID:
-						default:
-						}
-						return true
-					})
-				}
+				r.notify(msg.Payload)
 			}
 		}
 	}()
+}
 
-	return nil
+func (r *RedisPubSub) notify(accountUID string) {
+	r.subscriptionsMu.RLock()
+	defer r.subscriptionsMu.RUnlock()
+
+	if transactionsSubscriptions, ok := r.subscriptions.Load(accountUID); ok {
+		transactionMap := transactionsSubscriptions.(*sync.Map)
+
+		transactionMap.Range(func(_, sub interface{}) bool {
+			subscription := sub.(chan string)
+			select {
+			case subscription <- accountUID:
+			default:
+			}
+			return true
+		})
+	}
 }
 
 func (r *RedisPubSub) Publish(ctx context.Context, topic, message string) error {
@@ -129,8 +270,22 @@ func (r *RedisPubSub) Publish(ctx context.Context, topic, message string) error
 }
 
 func (r *RedisPubSub) Close() error {
-	if r.pubsub != nil {
-		return r.pubsub.Close()
+	r.closeTopology.Do(func() { close(r.stopTopology) })
+
+	r.shardsMu.Lock()
+	for addr, pubsub := range r.shards {
+		pubsub.Close()
+		delete(r.shards, addr)
+	}
+	r.shardsMu.Unlock()
+
+	r.pubsubsMu.Lock()
+	defer r.pubsubsMu.Unlock()
+
+	for _, pubsub := range r.pubsubs {
+		if err := pubsub.Close(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
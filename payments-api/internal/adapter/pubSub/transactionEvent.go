@@ -0,0 +1,98 @@
+package pubSub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jtonynet/go-payments-api/config"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultTransactionEventsTopic = "transaction_events"
+
+/*
+  - The `pubsub` strategy publishes on the topic of the pub/sub client, reaching
+    only the subscribers connected at the time. The `memory` pub/sub has no
+    subscribers out of the process, so its events are discarded
+  - The `stream` strategy appends to a Redis Stream on the pub/sub connection,
+    kept for consumers that are offline or replaying
+*/
+func NewTransactionEventPublisher(
+	cfg config.Events,
+	pubSubCfg config.PubSub,
+	pubsub PubSub,
+) (port.TransactionEventPublisher, error) {
+	topic := cfg.Topic
+	if topic == "" {
+		topic = defaultTransactionEventsTopic
+	}
+
+	switch cfg.Strategy {
+	case "pubsub":
+		return &PubSubTransactionEventPublisher{
+			pubsub: pubsub,
+			topic:  topic,
+		}, nil
+	case "stream":
+		if pubSubCfg.Strategy != "redis" {
+			return nil, fmt.Errorf("transaction events stream strategy requires redis pubsub strategy, got: %s", pubSubCfg.Strategy)
+		}
+
+		client, err := database.NewRedisUniversalClient(pubSubCfg.ToInMemoryDatabase())
+		if err != nil {
+			return nil, err
+		}
+
+		return &StreamTransactionEventPublisher{
+			client: client,
+			stream: topic,
+			maxLen: cfg.StreamMaxLen,
+		}, nil
+	default:
+		return nil, fmt.Errorf("transaction events strategy not suported: %s", cfg.Strategy)
+	}
+}
+
+type PubSubTransactionEventPublisher struct {
+	pubsub PubSub
+	topic  string
+}
+
+func (p *PubSubTransactionEventPublisher) Publish(ctx context.Context, message port.TransactionEventMessage) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transaction event: %w", err)
+	}
+
+	return p.pubsub.Publish(ctx, p.topic, string(payload))
+}
+
+type StreamTransactionEventPublisher struct {
+	client redis.UniversalClient
+	stream string
+	maxLen int64
+}
+
+/*
+- The stream is trimmed to about maxLen entries, a maxLen of 0 keeps every entry
+*/
+func (s *StreamTransactionEventPublisher) Publish(ctx context.Context, message port.TransactionEventMessage) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transaction event: %w", err)
+	}
+
+	return s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.stream,
+		MaxLen: s.maxLen,
+		Approx: s.maxLen > 0,
+		Values: map[string]interface{}{
+			"event":   string(payload),
+			"account": message.AccountUID,
+			"type":    message.Type,
+		},
+	}).Err()
+}
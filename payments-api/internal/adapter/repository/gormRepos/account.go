@@ -3,8 +3,10 @@ package gormRepos
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jtonynet/go-payments-api/internal/adapter/database"
@@ -38,15 +40,22 @@ func NewGormAccount(conn database.Conn) (port.AccountRepository, error) {
 }
 
 type accountResult struct {
-	AccountID      uint
-	AccountUID     uuid.UUID
-	TransactionID  uint
-	TransactionUID uuid.UUID
-	Amount         decimal.Decimal
-	CategoryID     uint
-	CategoryName   string
-	Priority       int
-	Codes          sql.NullString
+	AccountID          uint
+	AccountUID         uuid.UUID
+	Status             string
+	CurrencyConversion bool
+	AccountCurrency    string
+	TransactionID      uint
+	TransactionUID     uuid.UUID
+	Amount             decimal.Decimal
+	AmountHeld         decimal.Decimal
+	HoldsExpireAt      sql.NullTime
+	CategoryID         uint
+	CategoryName       string
+	Currency           string
+	FallbackExcluded   bool
+	Priority           int
+	Codes              sql.NullString
 }
 
 func (a *Account) FindByUID(ctx context.Context, uid uuid.UUID) (port.AccountEntity, error) {
@@ -64,16 +73,29 @@ func (a *Account) FindByUID(ctx context.Context, uid uuid.UUID) (port.AccountEnt
 		Table("accounts as a").
 		Select(`
 			a.id as account_id, 
+			a.status as status, 
+			a.currency_conversion as currency_conversion, 
+			a.currency as account_currency, 
 			lt.transactions_latest_id as transaction_id, 
-			lt.amount as amount, 
+			lt.amount - COALESCE(h.amount, 0) as amount, 
+			COALESCE(h.amount, 0) as amount_held, 
+			h.expires_at as holds_expire_at, 
 			c.id as category_id, 
 			c.name as category_name, 
+			c.currency as currency, 
+			c.fallback_excluded as fallback_excluded, 
 			c.priority as priority,
 			STRING_AGG(mc.mcc, ',') AS codes
 		`).
 		Joins("JOIN account_categories as ac ON ac.account_id = a.id").
 		Joins("JOIN categories as c ON c.id = ac.category_id").
 		Joins("JOIN transactions_latest as lt ON lt.account_id = a.id AND lt.category_id = c.id").
+		Joins(`LEFT JOIN (
+			SELECT account_id, category_id, SUM(amount) as amount, MIN(expires_at) as expires_at
+			FROM holds
+			WHERE status = ? AND expires_at > NOW() AND deleted_at IS NULL
+			GROUP BY account_id, category_id
+		) as h ON h.account_id = a.id AND h.category_id = c.id`, port.HOLD_STATUS_AUTHORIZED).
 		Joins("LEFT JOIN mccs as mc ON mc.category_id = c.id").
 		Where("a.uid = ?", uid).
 		Where(`
@@ -81,7 +103,7 @@ func (a *Account) FindByUID(ctx context.Context, uid uuid.UUID) (port.AccountEnt
 			AND ac.deleted_at IS NULL
 			AND c.deleted_at IS NULL
 		`).
-		Group("a.id, lt.transactions_latest_id, lt.amount, c.id, c.name, c.priority").
+		Group("a.id, a.status, a.currency_conversion, a.currency, lt.transactions_latest_id, lt.amount, h.amount, h.expires_at, c.id, c.name, c.currency, c.fallback_excluded, c.priority").
 		Scan(&results).Error
 
 	if err != nil {
@@ -99,20 +121,32 @@ func (a *Account) FindByUID(ctx context.Context, uid uuid.UUID) (port.AccountEnt
 
 			amountTotal = amountTotal.Add(result.Amount)
 
+			if result.HoldsExpireAt.Valid &&
+				(balance.HoldsExpireAt.IsZero() || result.HoldsExpireAt.Time.Before(balance.HoldsExpireAt)) {
+				balance.HoldsExpireAt = result.HoldsExpireAt.Time
+			}
+
 			if !firstFound {
 				firstFound = true
 				account.ID = result.AccountID
 				account.UID = uid
+				account.Status = result.Status
+				account.CurrencyConversion = result.CurrencyConversion
+				account.Currency = result.AccountCurrency
 			}
 
 			transactionsByCategories[int(result.TransactionID)] = port.TransactionByCategoryEntity{
-				ID:     result.TransactionID,
-				Amount: result.Amount,
+				ID:         result.TransactionID,
+				Amount:     result.Amount,
+				AmountHeld: result.AmountHeld,
 				Category: port.CategoryEntity{
 					ID:       result.CategoryID,
 					Name:     result.CategoryName,
+					Currency: result.Currency,
 					Priority: result.Priority,
 					MCCs:     mccs,
+
+					FallbackExcluded: result.FallbackExcluded,
 				},
 			}
 		}
@@ -126,11 +160,259 @@ func (a *Account) FindByUID(ctx context.Context, uid uuid.UUID) (port.AccountEnt
 	return account, nil
 }
 
-func (a *Account) SaveTransactions(ctx context.Context, transactions map[int]port.TransactionEntity) error {
+type transactionCapturedResult struct {
+	TransactionUID   uuid.UUID
+	AccountID        uint
+	AccountUID       uuid.UUID
+	CategoryID       uint
+	Priority         int
+	AmountCaptured   decimal.Decimal
+	AmountRefunded   decimal.Decimal
+	OriginalAmount   decimal.Decimal
+	OriginalRefunded decimal.Decimal
+	OriginalCurrency string
+	ExchangeRate     decimal.Decimal
+}
+
+/*
+  - The captured amount is the debit of the transaction in each category and the
+    refunded amount the sum of the credits of its refunds, which point to the
+    original transaction through `original_uid`.
+  - The original amounts are their shares in the currency of the transaction.
+    Rows written before currencies were not converted.
+*/
+func (a *Account) FindTransactionsByUID(ctx context.Context, uid uuid.UUID) (map[int]port.TransactionCapturedEntity, error) {
+	var results []transactionCapturedResult
+	transactionsCaptured := make(map[int]port.TransactionCapturedEntity)
+
+	err := a.db.WithContext(ctx).Raw(`
+		SELECT
+			t.uid as transaction_uid,
+			t.account_id as account_id,
+			a.uid as account_uid,
+			t.category_id as category_id,
+			c.priority as priority,
+			t.amount as amount_captured,
+			COALESCE((
+				SELECT SUM(r.amount)
+				FROM transactions as r
+				WHERE r.original_uid = t.uid
+				AND r.category_id = t.category_id
+				AND r.entry_type = ?
+				AND r.deleted_at IS NULL
+			), 0) as amount_refunded,
+			COALESCE(t.original_amount, t.amount) as original_amount,
+			COALESCE((
+				SELECT SUM(COALESCE(r.original_amount, r.amount))
+				FROM transactions as r
+				WHERE r.original_uid = t.uid
+				AND r.category_id = t.category_id
+				AND r.entry_type = ?
+				AND r.deleted_at IS NULL
+			), 0) as original_refunded,
+			COALESCE(t.original_currency, t.currency) as original_currency,
+			COALESCE(t.exchange_rate, 1) as exchange_rate
+		FROM transactions as t
+		JOIN accounts as a ON a.id = t.account_id
+		JOIN categories as c ON c.id = t.category_id
+		WHERE t.uid = ?
+		AND t.original_uid IS NULL
+		AND t.entry_type = ?
+		AND t.deleted_at IS NULL
+	`, port.TRANSACTION_ENTRY_CREDIT, port.TRANSACTION_ENTRY_CREDIT, uid, port.TRANSACTION_ENTRY_DEBIT).Scan(&results).Error
+
+	if err != nil {
+		return transactionsCaptured, fmt.Errorf("error retrying transactions:%s  err: %w", uid, err)
+	}
+
+	for _, result := range results {
+		transactionsCaptured[result.Priority] = port.TransactionCapturedEntity{
+			UID:              result.TransactionUID,
+			AccountID:        result.AccountID,
+			AccountUID:       result.AccountUID,
+			CategoryID:       result.CategoryID,
+			Priority:         result.Priority,
+			AmountCaptured:   result.AmountCaptured,
+			AmountRefunded:   result.AmountRefunded,
+			OriginalAmount:   result.OriginalAmount,
+			OriginalRefunded: result.OriginalRefunded,
+			OriginalCurrency: result.OriginalCurrency,
+			ExchangeRate:     result.ExchangeRate,
+		}
+	}
+
+	return transactionsCaptured, nil
+}
+
+type transactionHistoryResult struct {
+	ID           uint
+	UID          uuid.NullUUID
+	OriginalUID  uuid.NullUUID
+	CategoryID   uint
+	CategoryName string
+	Operation    string
+	Amount       decimal.Decimal
+	Balance      decimal.Decimal
+	Currency     string
+	MCC          sql.NullString
+	MerchantName sql.NullString
+	CreatedAt    time.Time
+}
+
+/*
+  - Debits are reported as negative amounts, next to the category balance after
+    the movement. Pages are ordered from the newest to the oldest transaction.
+*/
+func (a *Account) FindTransactionsByAccountUID(ctx context.Context, filter port.TransactionHistoryFilterEntity) ([]port.TransactionHistoryEntity, error) {
+	var results []transactionHistoryResult
+	transactions := []port.TransactionHistoryEntity{}
+
+	query := a.db.WithContext(ctx).
+		Table("transactions as t").
+		Select(`
+			t.id,
+			t.uid,
+			t.original_uid,
+			t.category_id,
+			c.name as category_name,
+			t.operation,
+			CASE WHEN t.entry_type = ? THEN -t.amount ELSE t.amount END as amount,
+			t.balance_after as balance,
+			t.currency,
+			t.mcc,
+			t.merchant_name,
+			t.created_at
+		`, port.TRANSACTION_ENTRY_DEBIT).
+		Joins("JOIN accounts as a ON a.id = t.account_id").
+		Joins("JOIN categories as c ON c.id = t.category_id").
+		Where("a.uid = ?", filter.AccountUID).
+		Where("t.deleted_at IS NULL AND a.deleted_at IS NULL")
+
+	if filter.CursorID > 0 {
+		query = query.Where("t.id < ?", filter.CursorID)
+	}
+
+	if !filter.From.IsZero() {
+		query = query.Where("t.created_at >= ?", filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		query = query.Where("t.created_at < ?", filter.To)
+	}
+
+	if filter.CategoryName != "" {
+		query = query.Where("UPPER(c.name) = UPPER(?)", filter.CategoryName)
+	}
+
+	if filter.MCC != "" {
+		query = query.Where("t.mcc = ?", filter.MCC)
+	}
+
+	if filter.MerchantName != "" {
+		query = query.Where("t.merchant_name ILIKE ?", "%"+filter.MerchantName+"%")
+	}
+
+	err := query.
+		Order("t.id DESC").
+		Limit(filter.Limit).
+		Scan(&results).Error
+
+	if err != nil {
+		return transactions, fmt.Errorf("error retrying transactions of account:%s  err: %w", filter.AccountUID, err)
+	}
+
+	for _, result := range results {
+		transactions = append(transactions, port.TransactionHistoryEntity{
+			ID:           result.ID,
+			UID:          result.UID.UUID,
+			OriginalUID:  result.OriginalUID.UUID,
+			CategoryID:   result.CategoryID,
+			CategoryName: result.CategoryName,
+			Operation:    result.Operation,
+			Amount:       result.Amount,
+			Balance:      result.Balance,
+			Currency:     result.Currency,
+			MCC:          result.MCC.String,
+			MerchantName: result.MerchantName.String,
+			CreatedAt:    result.CreatedAt,
+		})
+	}
+
+	return transactions, nil
+}
+
+func (a *Account) SaveTransactions(ctx context.Context, transactions map[int]port.TransactionEntity, fencingToken int64) error {
 	if len(transactions) == 0 {
 		return fmt.Errorf("no transactions to save")
 	}
 
+	tSlice := mapTransactionEntitiesToModels(transactions)
+
+	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := verifyFencingToken(tx, transactions, fencingToken); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&tSlice).Error; err != nil {
+			if isDuplicatedKey(tx, err) {
+				return fmt.Errorf("%w: %s", port.ErrDuplicateTransaction, err.Error())
+			}
+
+			return fmt.Errorf("failed to save transactions: %w", err)
+		}
+
+		return createTransactionEvents(tx, transactions)
+	})
+}
+
+/*
+  - Whether the error violates a unique constraint, such as the one on the
+    transaction uid and category. Translated by the dialector, since the
+    connection is not opened with `TranslateError`
+*/
+func isDuplicatedKey(tx *gorm.DB, err error) bool {
+	if translator, ok := tx.Dialector.(gorm.ErrorTranslator); ok {
+		err = translator.Translate(err)
+	}
+
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
+
+/*
+  - Advances the `fencing_token` of each account written by the transactions,
+    rejecting the write when a newer lock holder has already written on it
+*/
+func verifyFencingToken(tx *gorm.DB, transactions map[int]port.TransactionEntity, fencingToken int64) error {
+	verified := make(map[uint]bool)
+
+	for _, transaction := range transactions {
+		if verified[transaction.AccountID] {
+			continue
+		}
+
+		result := tx.Model(&gormModel.Account{}).
+			Where("id = ? AND fencing_token <= ?", transaction.AccountID, fencingToken).
+			UpdateColumn("fencing_token", fencingToken)
+
+		if result.Error != nil {
+			return fmt.Errorf("failed to verify fencing token: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: %d on account %d", port.ErrStaleFencingToken, fencingToken, transaction.AccountID)
+		}
+
+		verified[transaction.AccountID] = true
+	}
+
+	return nil
+}
+
+/*
+  - `balance_after` is generated by the database from the balance before and
+    the signed amount of the entry
+*/
+func mapTransactionEntitiesToModels(transactions map[int]port.TransactionEntity) []gormModel.Transaction {
 	var tSlice []gormModel.Transaction
 
 	for _, transaction := range transactions {
@@ -141,13 +423,34 @@ func (a *Account) SaveTransactions(ctx context.Context, transactions map[int]por
 			Amount:       transaction.Amount,
 			MCC:          transaction.MCC,
 			MerchantName: transaction.MerchantName,
+			OriginalUID: uuid.NullUUID{
+				UUID:  transaction.OriginalUID,
+				Valid: transaction.OriginalUID != uuid.Nil,
+			},
+			CardID: sql.NullInt64{
+				Int64: int64(transaction.CardID),
+				Valid: transaction.CardID != 0,
+			},
+			Operation:        transaction.Operation,
+			EntryType:        transaction.EntryType,
+			BalanceBefore:    transaction.BalanceBefore,
+			Currency:         transaction.Currency,
+			OriginalAmount:   nullDecimal(transaction.OriginalAmount, transaction.OriginalCurrency),
+			OriginalCurrency: nullString(transaction.OriginalCurrency),
+			ExchangeRate:     nullDecimal(transaction.ExchangeRate, transaction.OriginalCurrency),
 		})
 	}
 
-	err := a.db.WithContext(ctx).Create(&tSlice).Error
-	if err != nil {
-		return fmt.Errorf("failed to save transactions: %w", err)
-	}
+	return tSlice
+}
 
-	return nil
+/*
+- Original amounts and rates are only recorded along with their original currency
+*/
+func nullDecimal(value decimal.Decimal, currency string) decimal.NullDecimal {
+	return decimal.NullDecimal{Decimal: value, Valid: currency != ""}
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
 }
@@ -0,0 +1,389 @@
+package gormRepos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/adapter/model/gormModel"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/shopspring/decimal"
+
+	"gorm.io/gorm"
+)
+
+type Admin struct {
+	gormConn database.Conn
+	db       *gorm.DB
+}
+
+func NewAdmin(conn database.Conn) (port.AdminRepository, error) {
+	db, err := conn.GetDB(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("admin repository failure on conn.GetDB()")
+	}
+
+	dbGorm, ok := db.(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("admin repository failure to cast conn.GetDB() as gorm.DB")
+	}
+
+	return &Admin{
+		gormConn: conn,
+		db:       dbGorm,
+	}, nil
+}
+
+func (ad *Admin) CreateAccount(ctx context.Context, account port.AccountAdminEntity) (port.AccountAdminEntity, error) {
+	accountModel := gormModel.Account{
+		UID:                account.UID,
+		Name:               account.Name,
+		CurrencyConversion: account.CurrencyConversion,
+		Status:             account.Status,
+	}
+
+	err := ad.db.WithContext(ctx).Create(&accountModel).Error
+	if err != nil {
+		return port.AccountAdminEntity{}, fmt.Errorf("failed to create account: %w", err)
+	}
+
+	return mapAccountModelToAdminEntity(accountModel), nil
+}
+
+func (ad *Admin) DeleteAccount(ctx context.Context, uid uuid.UUID) error {
+	return ad.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		accountModel, err := findAccountModel(tx, uid)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where(&gormModel.AccountCategory{AccountID: accountModel.ID}).Delete(&gormModel.AccountCategory{}).Error
+		if err != nil {
+			return fmt.Errorf("failed to delete account %s categories: %w", uid, err)
+		}
+
+		err = tx.Delete(&accountModel).Error
+		if err != nil {
+			return fmt.Errorf("failed to delete account %s: %w", uid, err)
+		}
+
+		return nil
+	})
+}
+
+func (ad *Admin) FindAccounts(ctx context.Context, filter port.AccountListFilterEntity) ([]port.AccountAdminEntity, error) {
+	var accountModels []gormModel.Account
+	accounts := []port.AccountAdminEntity{}
+
+	query := ad.db.WithContext(ctx).
+		Preload("AccountCategories.Category.MCCs")
+
+	if filter.CursorID > 0 {
+		query = query.Where("id > ?", filter.CursorID)
+	}
+
+	err := query.
+		Order("id ASC").
+		Limit(filter.Limit).
+		Find(&accountModels).Error
+
+	if err != nil {
+		return accounts, fmt.Errorf("error retrying accounts: %w", err)
+	}
+
+	for _, accountModel := range accountModels {
+		accounts = append(accounts, mapAccountModelToAdminEntity(accountModel))
+	}
+
+	return accounts, nil
+}
+
+func (ad *Admin) FindAccountStatus(ctx context.Context, uid uuid.UUID) (string, error) {
+	accountModel, err := findAccountModel(ad.db.WithContext(ctx), uid)
+	if err != nil {
+		return "", err
+	}
+
+	return accountModel.Status, nil
+}
+
+/*
+  - The status is only updated while the account is still in PreviousStatus, so
+    concurrent changes can't both apply. The audit row is written in the same
+    transaction as the update
+*/
+func (ad *Admin) ChangeAccountStatus(ctx context.Context, change port.AccountStatusChangeEntity) (port.AccountStatusChangeEntity, error) {
+	err := ad.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		accountModel, err := findAccountModel(tx, change.AccountUID)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&gormModel.Account{}).
+			Where("id = ? AND status = ?", accountModel.ID, change.PreviousStatus).
+			Update("status", change.Status)
+		if result.Error != nil {
+			return fmt.Errorf("failed to change account %s status: %w", change.AccountUID, result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return fmt.Errorf(
+				"%w: account %s is no longer %s",
+				port.ErrAccountStatusTransition,
+				change.AccountUID,
+				change.PreviousStatus,
+			)
+		}
+
+		changeModel := gormModel.AccountStatusChange{
+			AccountID:      accountModel.ID,
+			PreviousStatus: change.PreviousStatus,
+			Status:         change.Status,
+			Reason:         change.Reason,
+		}
+
+		err = tx.Create(&changeModel).Error
+		if err != nil {
+			return fmt.Errorf("failed to save account %s status change: %w", change.AccountUID, err)
+		}
+
+		change.CreatedAt = changeModel.CreatedAt
+
+		return nil
+	})
+	if err != nil {
+		return port.AccountStatusChangeEntity{}, err
+	}
+
+	return change, nil
+}
+
+func (ad *Admin) FindAccountStatusChanges(ctx context.Context, uid uuid.UUID) ([]port.AccountStatusChangeEntity, error) {
+	changes := []port.AccountStatusChangeEntity{}
+
+	accountModel, err := findAccountModel(ad.db.WithContext(ctx), uid)
+	if err != nil {
+		return changes, err
+	}
+
+	var changeModels []gormModel.AccountStatusChange
+	err = ad.db.WithContext(ctx).
+		Where(&gormModel.AccountStatusChange{AccountID: accountModel.ID}).
+		Order("id ASC").
+		Find(&changeModels).Error
+	if err != nil {
+		return changes, fmt.Errorf("error retrying account %s status changes: %w", uid, err)
+	}
+
+	for _, changeModel := range changeModels {
+		changes = append(changes, port.AccountStatusChangeEntity{
+			AccountUID:     uid,
+			PreviousStatus: changeModel.PreviousStatus,
+			Status:         changeModel.Status,
+			Reason:         changeModel.Reason,
+			CreatedAt:      changeModel.CreatedAt,
+		})
+	}
+
+	return changes, nil
+}
+
+func (ad *Admin) AttachCategory(ctx context.Context, accountUID, categoryUID uuid.UUID) error {
+	return ad.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		accountModel, err := findAccountModel(tx, accountUID)
+		if err != nil {
+			return err
+		}
+
+		categoryModel, err := findCategoryModel(tx, categoryUID)
+		if err != nil {
+			return err
+		}
+
+		var attached int64
+		err = tx.Model(&gormModel.AccountCategory{}).
+			Where(&gormModel.AccountCategory{AccountID: accountModel.ID, CategoryID: categoryModel.ID}).
+			Count(&attached).Error
+		if err != nil {
+			return fmt.Errorf("failed to retrieve account %s categories: %w", accountUID, err)
+		}
+
+		if attached > 0 {
+			return fmt.Errorf("%w: %s", port.ErrCategoryAlreadyAttached, categoryUID)
+		}
+
+		err = tx.Create(&gormModel.AccountCategory{AccountID: accountModel.ID, CategoryID: categoryModel.ID}).Error
+		if err != nil {
+			return fmt.Errorf("failed to attach category %s: %w", categoryUID, err)
+		}
+
+		var opened int64
+		err = tx.Table("transactions_latest").
+			Where("account_id = ? AND category_id = ?", accountModel.ID, categoryModel.ID).
+			Count(&opened).Error
+		if err != nil {
+			return fmt.Errorf("failed to retrieve category %s balance: %w", categoryUID, err)
+		}
+
+		if opened > 0 {
+			return nil
+		}
+
+		/*
+			Same as the balance charges of the seeds: an adjustment without transaction UID
+		*/
+		err = tx.Omit("UID").Create(&gormModel.Transaction{
+			AccountID:     accountModel.ID,
+			CategoryID:    categoryModel.ID,
+			Amount:        decimal.Zero,
+			Operation:     port.TRANSACTION_OPERATION_ADJUSTMENT,
+			EntryType:     port.TRANSACTION_ENTRY_CREDIT,
+			BalanceBefore: decimal.Zero,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to open category %s balance: %w", categoryUID, err)
+		}
+
+		return nil
+	})
+}
+
+func (ad *Admin) DetachCategory(ctx context.Context, accountUID, categoryUID uuid.UUID) error {
+	return ad.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		accountModel, err := findAccountModel(tx, accountUID)
+		if err != nil {
+			return err
+		}
+
+		categoryModel, err := findCategoryModel(tx, categoryUID)
+		if err != nil {
+			return err
+		}
+
+		result := tx.
+			Where(&gormModel.AccountCategory{AccountID: accountModel.ID, CategoryID: categoryModel.ID}).
+			Delete(&gormModel.AccountCategory{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to detach category %s: %w", categoryUID, result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: %s", port.ErrCategoryNotAttached, categoryUID)
+		}
+
+		return nil
+	})
+}
+
+func (ad *Admin) CreateCategory(ctx context.Context, category port.CategoryAdminEntity) (port.CategoryAdminEntity, error) {
+	categoryModel := gormModel.Category{
+		UID:      category.UID,
+		Name:     category.Name,
+		Priority: category.Priority,
+		Currency: category.Currency,
+
+		FallbackExcluded: category.FallbackExcluded,
+	}
+
+	err := ad.db.WithContext(ctx).Create(&categoryModel).Error
+	if err != nil {
+		return port.CategoryAdminEntity{}, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return mapCategoryModelToAdminEntity(categoryModel), nil
+}
+
+func (ad *Admin) AssignMCC(ctx context.Context, mcc port.MCCAdminEntity) (port.MCCAdminEntity, error) {
+	err := ad.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		categoryModel, err := findCategoryModel(tx, mcc.CategoryUID)
+		if err != nil {
+			return err
+		}
+
+		var assigned int64
+		err = tx.Model(&gormModel.MCC{}).Where(&gormModel.MCC{MCC: mcc.MCC}).Count(&assigned).Error
+		if err != nil {
+			return fmt.Errorf("failed to retrieve mcc %s: %w", mcc.MCC, err)
+		}
+
+		if assigned > 0 {
+			return fmt.Errorf("%w: %s", port.ErrMCCAlreadyAssigned, mcc.MCC)
+		}
+
+		err = tx.Create(&gormModel.MCC{UID: mcc.UID, CategoryID: categoryModel.ID, MCC: mcc.MCC}).Error
+		if err != nil {
+			return fmt.Errorf("failed to assign mcc %s: %w", mcc.MCC, err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return port.MCCAdminEntity{}, err
+	}
+
+	return mcc, nil
+}
+
+func findAccountModel(tx *gorm.DB, uid uuid.UUID) (gormModel.Account, error) {
+	accountModel := gormModel.Account{}
+
+	result := tx.Where(&gormModel.Account{UID: uid}).First(&accountModel)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return accountModel, fmt.Errorf("%w: %s", port.ErrAccountNotFound, uid)
+	} else if result.Error != nil {
+		return accountModel, fmt.Errorf("error retrying account:%s  err: %w", uid, result.Error)
+	}
+
+	return accountModel, nil
+}
+
+func findCategoryModel(tx *gorm.DB, uid uuid.UUID) (gormModel.Category, error) {
+	categoryModel := gormModel.Category{}
+
+	result := tx.Where(&gormModel.Category{UID: uid}).First(&categoryModel)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return categoryModel, fmt.Errorf("%w: %s", port.ErrCategoryNotFound, uid)
+	} else if result.Error != nil {
+		return categoryModel, fmt.Errorf("error retrying category:%s  err: %w", uid, result.Error)
+	}
+
+	return categoryModel, nil
+}
+
+func mapAccountModelToAdminEntity(accountModel gormModel.Account) port.AccountAdminEntity {
+	categories := []port.CategoryAdminEntity{}
+	for _, accountCategory := range accountModel.AccountCategories {
+		categories = append(categories, mapCategoryModelToAdminEntity(accountCategory.Category))
+	}
+
+	return port.AccountAdminEntity{
+		ID:                 accountModel.ID,
+		UID:                accountModel.UID,
+		Name:               accountModel.Name,
+		CurrencyConversion: accountModel.CurrencyConversion,
+		Status:             accountModel.Status,
+		Categories:         categories,
+		CreatedAt:          accountModel.CreatedAt,
+	}
+}
+
+func mapCategoryModelToAdminEntity(categoryModel gormModel.Category) port.CategoryAdminEntity {
+	mccs := []string{}
+	for _, mccModel := range categoryModel.MCCs {
+		mccs = append(mccs, mccModel.MCC)
+	}
+
+	return port.CategoryAdminEntity{
+		ID:       categoryModel.ID,
+		UID:      categoryModel.UID,
+		Name:     categoryModel.Name,
+		Priority: categoryModel.Priority,
+		Currency: categoryModel.Currency,
+		MCCs:     mccs,
+
+		FallbackExcluded: categoryModel.FallbackExcluded,
+	}
+}
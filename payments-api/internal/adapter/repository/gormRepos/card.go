@@ -0,0 +1,213 @@
+package gormRepos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/adapter/model/gormModel"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/shopspring/decimal"
+
+	"gorm.io/gorm"
+)
+
+type Card struct {
+	gormConn database.Conn
+	db       *gorm.DB
+}
+
+func NewCard(conn database.Conn) (port.CardRepository, error) {
+	db, err := conn.GetDB(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("card repository failure on conn.GetDB()")
+	}
+
+	dbGorm, ok := db.(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("card repository failure to cast conn.GetDB() as gorm.DB")
+	}
+
+	return &Card{
+		gormConn: conn,
+		db:       dbGorm,
+	}, nil
+}
+
+type cardResult struct {
+	gormModel.Card
+	AccountUID uuid.UUID
+}
+
+func (c *Card) cardQuery(ctx context.Context) *gorm.DB {
+	return c.db.WithContext(ctx).
+		Table("cards").
+		Select("cards.*, a.uid as account_uid").
+		Joins("JOIN accounts as a ON a.id = cards.account_id").
+		Where("cards.deleted_at IS NULL AND a.deleted_at IS NULL")
+}
+
+func (c *Card) FindByToken(ctx context.Context, token string) (port.CardEntity, error) {
+	var results []cardResult
+
+	err := c.cardQuery(ctx).
+		Where("cards.token = ?", token).
+		Limit(1).
+		Scan(&results).Error
+	if err != nil {
+		return port.CardEntity{}, fmt.Errorf("error retrying card by token  err: %w", err)
+	}
+
+	if len(results) == 0 {
+		return port.CardEntity{}, port.ErrCardNotFound
+	}
+
+	return mapCardResultToEntity(results[0]), nil
+}
+
+func (c *Card) FindByUID(ctx context.Context, uid uuid.UUID) (port.CardEntity, error) {
+	var results []cardResult
+
+	err := c.cardQuery(ctx).
+		Where("cards.uid = ?", uid).
+		Limit(1).
+		Scan(&results).Error
+	if err != nil {
+		return port.CardEntity{}, fmt.Errorf("error retrying card:%s  err: %w", uid, err)
+	}
+
+	if len(results) == 0 {
+		return port.CardEntity{}, fmt.Errorf("%w: %s", port.ErrCardNotFound, uid)
+	}
+
+	return mapCardResultToEntity(results[0]), nil
+}
+
+func (c *Card) FindByAccountUID(ctx context.Context, accountUID uuid.UUID) ([]port.CardEntity, error) {
+	accountModel, err := findAccountModel(c.db.WithContext(ctx), accountUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []cardResult
+
+	err = c.cardQuery(ctx).
+		Where("cards.account_id = ?", accountModel.ID).
+		Order("cards.id").
+		Scan(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("error retrying cards of account:%s  err: %w", accountUID, err)
+	}
+
+	cEntities := make([]port.CardEntity, 0, len(results))
+	for _, result := range results {
+		cEntities = append(cEntities, mapCardResultToEntity(result))
+	}
+
+	return cEntities, nil
+}
+
+/*
+  - Sums the AUTHORIZATION debits paid with the card since the start of the UTC
+    day of at
+*/
+func (c *Card) FindDailyUsage(ctx context.Context, cardID uint, at time.Time) (decimal.Decimal, error) {
+	_, dayStart, _ := spendingWindows(at)
+
+	var dailyAmount decimal.Decimal
+	err := c.db.WithContext(ctx).Raw(`
+		SELECT COALESCE(SUM(amount), 0)
+		FROM transactions
+		WHERE deleted_at IS NULL
+			AND card_id = ?
+			AND operation = 'AUTHORIZATION'
+			AND entry_type = 'DEBIT'
+			AND created_at >= ?`,
+		cardID, dayStart,
+	).Scan(&dailyAmount).Error
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("error retrying daily usage of card:%d  err: %w", cardID, err)
+	}
+
+	return dailyAmount, nil
+}
+
+func (c *Card) Create(ctx context.Context, card port.CardEntity) (port.CardEntity, error) {
+	err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		accountModel, err := findAccountModel(tx, card.AccountUID)
+		if err != nil {
+			return err
+		}
+
+		cardModel := gormModel.Card{
+			UID:               card.UID,
+			AccountID:         accountModel.ID,
+			Token:             card.Token,
+			MaskedPAN:         card.MaskedPAN,
+			Status:            card.Status,
+			ExpiresAt:         card.ExpiresAt,
+			TransactionAmount: card.TransactionAmount,
+			DailyAmount:       card.DailyAmount,
+		}
+
+		err = tx.Create(&cardModel).Error
+		if err != nil {
+			if isDuplicatedKey(tx, err) {
+				return port.ErrCardTokenTaken
+			}
+
+			return fmt.Errorf("failed to create card: %w", err)
+		}
+
+		card.ID = cardModel.ID
+		card.AccountID = accountModel.ID
+		card.CreatedAt = cardModel.CreatedAt
+
+		return nil
+	})
+
+	if err != nil {
+		return port.CardEntity{}, err
+	}
+
+	return card, nil
+}
+
+func (c *Card) Update(ctx context.Context, card port.CardEntity) (port.CardEntity, error) {
+	result := c.db.WithContext(ctx).
+		Model(&gormModel.Card{}).
+		Where("id = ?", card.ID).
+		Updates(map[string]interface{}{
+			"status":             card.Status,
+			"expires_at":         card.ExpiresAt,
+			"transaction_amount": card.TransactionAmount,
+			"daily_amount":       card.DailyAmount,
+		})
+	if result.Error != nil {
+		return port.CardEntity{}, fmt.Errorf("failed to update card %s: %w", card.UID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return port.CardEntity{}, fmt.Errorf("%w: %s", port.ErrCardNotFound, card.UID)
+	}
+
+	return card, nil
+}
+
+func mapCardResultToEntity(result cardResult) port.CardEntity {
+	return port.CardEntity{
+		ID:                result.ID,
+		UID:               result.UID,
+		AccountID:         result.AccountID,
+		AccountUID:        result.AccountUID,
+		Token:             result.Token,
+		MaskedPAN:         result.MaskedPAN,
+		Status:            result.Status,
+		ExpiresAt:         result.ExpiresAt,
+		TransactionAmount: result.TransactionAmount,
+		DailyAmount:       result.DailyAmount,
+		CreatedAt:         result.CreatedAt,
+	}
+}
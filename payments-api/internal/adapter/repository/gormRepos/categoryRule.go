@@ -0,0 +1,196 @@
+package gormRepos
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/adapter/model/gormModel"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+
+	"gorm.io/gorm"
+)
+
+type CategoryRule struct {
+	gormConn database.Conn
+	db       *gorm.DB
+}
+
+func NewCategoryRule(conn database.Conn) (port.CategoryRuleRepository, error) {
+	db, err := conn.GetDB(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("category rule repository failure on conn.GetDB()")
+	}
+
+	dbGorm, ok := db.(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("category rule repository failure to cast conn.GetDB() as gorm.DB")
+	}
+
+	return &CategoryRule{
+		gormConn: conn,
+		db:       dbGorm,
+	}, nil
+}
+
+func (cr *CategoryRule) FindByAccountID(ctx context.Context, accountID uint) ([]port.CategoryRuleEntity, error) {
+	var ruleModels []gormModel.CategoryRule
+
+	err := cr.db.WithContext(ctx).
+		Where("account_id IS NULL OR account_id = ?", accountID).
+		Order("position").
+		Find(&ruleModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("error retrying category rules of account:%d  err: %w", accountID, err)
+	}
+
+	crEntities := make([]port.CategoryRuleEntity, 0, len(ruleModels))
+	for _, ruleModel := range ruleModels {
+		crEntities = append(crEntities, port.CategoryRuleEntity{
+			AccountID:          uint(ruleModel.AccountID.Int64),
+			CategoryID:         uint(ruleModel.CategoryID.Int64),
+			FallbackCategoryID: ruleModel.FallbackCategoryID,
+			Position:           ruleModel.Position,
+		})
+	}
+
+	return crEntities, nil
+}
+
+func (cr *CategoryRule) SaveChain(ctx context.Context, chain port.CategoryRuleChainEntity) (port.CategoryRuleChainEntity, error) {
+	err := cr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		accountID := sql.NullInt64{}
+		if chain.AccountUID != uuid.Nil {
+			accountModel, err := findAccountModel(tx, chain.AccountUID)
+			if err != nil {
+				return err
+			}
+
+			accountID = sql.NullInt64{Int64: int64(accountModel.ID), Valid: true}
+		}
+
+		categoryID := sql.NullInt64{}
+		if chain.CategoryUID != uuid.Nil {
+			categoryModel, err := findCategoryModel(tx, chain.CategoryUID)
+			if err != nil {
+				return err
+			}
+
+			categoryID = sql.NullInt64{Int64: int64(categoryModel.ID), Valid: true}
+		}
+
+		err := chainScope(tx, accountID, categoryID).Delete(&gormModel.CategoryRule{}).Error
+		if err != nil {
+			return fmt.Errorf("failed to remove category rule: %w", err)
+		}
+
+		for index, fallback := range chain.Fallbacks {
+			fallbackModel, err := findCategoryModel(tx, fallback.UID)
+			if err != nil {
+				return err
+			}
+
+			if fallbackModel.FallbackExcluded {
+				return fmt.Errorf("%w: %s", port.ErrCategoryFallbackExcluded, fallbackModel.Name)
+			}
+
+			err = tx.Create(&gormModel.CategoryRule{
+				AccountID:          accountID,
+				CategoryID:         categoryID,
+				FallbackCategoryID: fallbackModel.ID,
+				Position:           index + 1,
+			}).Error
+			if err != nil {
+				return fmt.Errorf("failed to create category rule: %w", err)
+			}
+
+			chain.Fallbacks[index] = mapCategoryModelToAdminEntity(fallbackModel)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return port.CategoryRuleChainEntity{}, err
+	}
+
+	return chain, nil
+}
+
+type categoryRuleResult struct {
+	AccountUID   uuid.NullUUID
+	CategoryUID  uuid.NullUUID
+	FallbackUID  uuid.UUID
+	FallbackName string
+}
+
+/*
+- Chains ordered with the default ones first, then by category and fallback position
+*/
+func (cr *CategoryRule) FindChains(ctx context.Context, accountUID uuid.UUID) ([]port.CategoryRuleChainEntity, error) {
+	var results []categoryRuleResult
+
+	query := cr.db.WithContext(ctx).
+		Table("category_rules as cr").
+		Select(`
+			a.uid as account_uid, 
+			c.uid as category_uid, 
+			fc.uid as fallback_uid, 
+			fc.name as fallback_name
+		`).
+		Joins("LEFT JOIN accounts as a ON a.id = cr.account_id").
+		Joins("LEFT JOIN categories as c ON c.id = cr.category_id").
+		Joins("JOIN categories as fc ON fc.id = cr.fallback_category_id").
+		Where("cr.deleted_at IS NULL")
+
+	if accountUID != uuid.Nil {
+		query = query.Where("cr.account_id IS NULL OR a.uid = ?", accountUID)
+	} else {
+		query = query.Where("cr.account_id IS NULL")
+	}
+
+	err := query.
+		Order("cr.account_id NULLS FIRST, cr.category_id NULLS FIRST, cr.position").
+		Scan(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("error retrying category rules err: %w", err)
+	}
+
+	chains := []port.CategoryRuleChainEntity{}
+	for _, result := range results {
+		last := len(chains) - 1
+		if last < 0 ||
+			chains[last].AccountUID != result.AccountUID.UUID ||
+			chains[last].CategoryUID != result.CategoryUID.UUID {
+			chains = append(chains, port.CategoryRuleChainEntity{
+				AccountUID:  result.AccountUID.UUID,
+				CategoryUID: result.CategoryUID.UUID,
+				Fallbacks:   []port.CategoryAdminEntity{},
+			})
+			last++
+		}
+
+		chains[last].Fallbacks = append(chains[last].Fallbacks, port.CategoryAdminEntity{
+			UID:  result.FallbackUID,
+			Name: result.FallbackName,
+		})
+	}
+
+	return chains, nil
+}
+
+func chainScope(tx *gorm.DB, accountID, categoryID sql.NullInt64) *gorm.DB {
+	if accountID.Valid {
+		tx = tx.Where("account_id = ?", accountID.Int64)
+	} else {
+		tx = tx.Where("account_id IS NULL")
+	}
+
+	if categoryID.Valid {
+		return tx.Where("category_id = ?", categoryID.Int64)
+	}
+
+	return tx.Where("category_id IS NULL")
+}
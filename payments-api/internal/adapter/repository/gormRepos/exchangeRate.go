@@ -0,0 +1,57 @@
+package gormRepos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/adapter/model/gormModel"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+
+	"gorm.io/gorm"
+)
+
+/*
+- Rates maintained in the `exchange_rates` table, one active rate per pair of currencies
+*/
+type ExchangeRate struct {
+	gormConn database.Conn
+	db       *gorm.DB
+}
+
+func NewExchangeRate(conn database.Conn) (port.ExchangeRateRepository, error) {
+	db, err := conn.GetDB(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("exchange rate repository failure on conn.GetDB()")
+	}
+
+	dbGorm, ok := db.(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("exchange rate repository failure to cast conn.GetDB() as gorm.DB")
+	}
+
+	return &ExchangeRate{
+		gormConn: conn,
+		db:       dbGorm,
+	}, nil
+}
+
+func (er *ExchangeRate) FindRate(ctx context.Context, fromCurrency, toCurrency string) (port.ExchangeRateEntity, error) {
+	rateModel := gormModel.ExchangeRate{}
+
+	result := er.db.WithContext(ctx).
+		Where(&gormModel.ExchangeRate{FromCurrency: fromCurrency, ToCurrency: toCurrency}).
+		First(&rateModel)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return port.ExchangeRateEntity{}, fmt.Errorf("%w: %s to %s", port.ErrExchangeRateNotFound, fromCurrency, toCurrency)
+	} else if result.Error != nil {
+		return port.ExchangeRateEntity{}, fmt.Errorf("error retrying exchange rate:%s to %s  err: %w", fromCurrency, toCurrency, result.Error)
+	}
+
+	return port.ExchangeRateEntity{
+		FromCurrency: rateModel.FromCurrency,
+		ToCurrency:   rateModel.ToCurrency,
+		Rate:         rateModel.Rate,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package gormRepos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/adapter/model/gormModel"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+
+	"gorm.io/gorm"
+)
+
+type FencingToken struct {
+	gormConn database.Conn
+	db       *gorm.DB
+}
+
+func NewFencingToken(conn database.Conn) (port.FencingTokenRepository, error) {
+	db, err := conn.GetDB(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("fencing token repository failure on conn.GetDB()")
+	}
+
+	dbGorm, ok := db.(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("fencing token repository failure to cast conn.GetDB() as gorm.DB")
+	}
+
+	return &FencingToken{
+		gormConn: conn,
+		db:       dbGorm,
+	}, nil
+}
+
+/*
+- A key other than an account UID has no token written
+*/
+func (ft *FencingToken) FindFencingToken(ctx context.Context, key string) (int64, error) {
+	accountUID, err := uuid.Parse(key)
+	if err != nil {
+		return 0, nil
+	}
+
+	var fencingTokens []int64
+	err = ft.db.WithContext(ctx).
+		Model(&gormModel.Account{}).
+		Where("uid = ?", accountUID).
+		Pluck("fencing_token", &fencingTokens).Error
+	if err != nil {
+		return 0, fmt.Errorf("error retrying fencing token of key %s: %w", key, err)
+	}
+
+	if len(fencingTokens) == 0 {
+		return 0, nil
+	}
+
+	return fencingTokens[0], nil
+}
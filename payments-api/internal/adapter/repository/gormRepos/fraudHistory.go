@@ -0,0 +1,110 @@
+package gormRepos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/shopspring/decimal"
+
+	"gorm.io/gorm"
+)
+
+type FraudHistory struct {
+	gormConn database.Conn
+	db       *gorm.DB
+}
+
+func NewFraudHistory(conn database.Conn) (port.FraudHistoryRepository, error) {
+	db, err := conn.GetDB(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("fraud history repository failure on conn.GetDB()")
+	}
+
+	dbGorm, ok := db.(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("fraud history repository failure to cast conn.GetDB() as gorm.DB")
+	}
+
+	return &FraudHistory{
+		gormConn: conn,
+		db:       dbGorm,
+	}, nil
+}
+
+type fraudPaymentResult struct {
+	UID          uuid.UUID
+	MCC          string
+	MerchantName string
+	Amount       decimal.Decimal
+	CreatedAt    time.Time
+}
+
+/*
+  - Groups the AUTHORIZATION debits by transaction, newest first, summing the
+    categories debited by each one
+*/
+func (fh *FraudHistory) FindPayments(ctx context.Context, accountID uint, since time.Time) ([]port.FraudPaymentEntity, error) {
+	var results []fraudPaymentResult
+
+	err := fh.db.WithContext(ctx).Raw(`
+		SELECT
+			uid,
+			COALESCE(MAX(mcc), '') as mcc,
+			COALESCE(MAX(merchant_name), '') as merchant_name,
+			SUM(amount) as amount,
+			MIN(created_at) as created_at
+		FROM transactions
+		WHERE deleted_at IS NULL
+			AND account_id = ?
+			AND operation = 'AUTHORIZATION'
+			AND entry_type = 'DEBIT'
+			AND created_at >= ?
+		GROUP BY uid
+		ORDER BY MIN(created_at) DESC`,
+		accountID,
+		since,
+	).Scan(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("error retrying fraud history of account:%d  err: %w", accountID, err)
+	}
+
+	fpEntities := make([]port.FraudPaymentEntity, 0, len(results))
+	for _, result := range results {
+		fpEntities = append(fpEntities, port.FraudPaymentEntity{
+			UID:          result.UID,
+			MCC:          result.MCC,
+			MerchantName: result.MerchantName,
+			Amount:       result.Amount,
+			CreatedAt:    result.CreatedAt,
+		})
+	}
+
+	return fpEntities, nil
+}
+
+func (fh *FraudHistory) MerchantSeen(ctx context.Context, accountID uint, merchantName string) (bool, error) {
+	var seen bool
+
+	err := fh.db.WithContext(ctx).Raw(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM transactions
+			WHERE deleted_at IS NULL
+				AND account_id = ?
+				AND merchant_name = ?
+				AND operation = 'AUTHORIZATION'
+				AND entry_type = 'DEBIT'
+		)`,
+		accountID,
+		merchantName,
+	).Scan(&seen).Error
+	if err != nil {
+		return false, fmt.Errorf("error retrying fraud history of account:%d merchant:%s  err: %w", accountID, merchantName, err)
+	}
+
+	return seen, nil
+}
@@ -0,0 +1,75 @@
+package gormRepos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/adapter/model/gormModel"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+
+	"gorm.io/gorm"
+)
+
+type FraudRule struct {
+	gormConn database.Conn
+	db       *gorm.DB
+}
+
+func NewFraudRule(conn database.Conn) (port.FraudRuleRepository, error) {
+	db, err := conn.GetDB(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("fraud rule repository failure on conn.GetDB()")
+	}
+
+	dbGorm, ok := db.(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("fraud rule repository failure to cast conn.GetDB() as gorm.DB")
+	}
+
+	return &FraudRule{
+		gormConn: conn,
+		db:       dbGorm,
+	}, nil
+}
+
+func (fr *FraudRule) FindEnabled(ctx context.Context) ([]port.FraudRuleEntity, error) {
+	var ruleModels []gormModel.FraudRule
+
+	err := fr.db.WithContext(ctx).
+		Where("enabled").
+		Order("id").
+		Find(&ruleModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("error retrying enabled fraud rules err: %w", err)
+	}
+
+	frEntities := make([]port.FraudRuleEntity, 0, len(ruleModels))
+	for _, ruleModel := range ruleModels {
+		frEntities = append(frEntities, port.FraudRuleEntity{
+			ID:       ruleModel.ID,
+			Name:     ruleModel.Name,
+			Kind:     ruleModel.Kind,
+			Decision: ruleModel.Decision,
+			MCCs:     splitFraudRuleMCCs(ruleModel.MCCs),
+			Amount:   ruleModel.Amount,
+			Count:    ruleModel.Count,
+			Window:   time.Duration(ruleModel.WindowSeconds) * time.Second,
+		})
+	}
+
+	return frEntities, nil
+}
+
+func splitFraudRuleMCCs(mccs string) []string {
+	splitted := []string{}
+	for _, mcc := range strings.Split(mccs, ",") {
+		if mcc = strings.TrimSpace(mcc); mcc != "" {
+			splitted = append(splitted, mcc)
+		}
+	}
+
+	return splitted
+}
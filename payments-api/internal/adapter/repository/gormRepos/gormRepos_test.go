@@ -5,11 +5,13 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jtonynet/go-payments-api/config"
 	"github.com/jtonynet/go-payments-api/internal/adapter/database"
 	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/support/logger"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 
@@ -32,11 +34,30 @@ var (
 	merchantCategoryToMap   = uint(2)
 )
 
+type FakeLog struct{}
+
+func newFakeLog() logger.Logger {
+	return &FakeLog{}
+}
+
+func (fl FakeLog) Info(ctx context.Context, msg string, args ...interface{})  {}
+func (fl FakeLog) Debug(ctx context.Context, msg string, args ...interface{}) {}
+func (fl FakeLog) Warn(ctx context.Context, msg string, args ...interface{})  {}
+func (fl FakeLog) Error(ctx context.Context, msg string, args ...interface{}) {}
+
 type RepositoriesSuite struct {
 	suite.Suite
 
-	AccountRepo  port.AccountRepository
-	MerchantRepo port.MerchantRepository
+	AccountRepo            port.AccountRepository
+	MerchantRepo           port.MerchantRepository
+	TransactionOutcomeRepo port.TransactionOutcomeRepository
+	AdminRepo              port.AdminRepository
+	MerchantRegistryRepo   port.MerchantRegistryRepository
+	MerchantMatchAuditRepo port.MerchantMatchAuditRepository
+	LedgerRepo             port.LedgerRepository
+	MemoryLockRepo         port.MemoryLockRepository
+	TransactionEventRepo   port.TransactionEventRepository
+	FencingTokenRepo       port.FencingTokenRepository
 
 	AccountEntity port.AccountEntity
 	BalanceEntity port.BalanceEntity
@@ -69,8 +90,56 @@ func (suite *RepositoriesSuite) SetupSuite() {
 		log.Fatalf("error when instantiating merchant repository: %v", err)
 	}
 
+	transactionOutcome, err := NewTransactionOutcome(conn)
+	if err != nil {
+		log.Fatalf("error when instantiating transaction outcome repository: %v", err)
+	}
+
+	admin, err := NewAdmin(conn)
+	if err != nil {
+		log.Fatalf("error when instantiating admin repository: %v", err)
+	}
+
+	merchantRegistry, err := NewMerchantRegistry(conn)
+	if err != nil {
+		log.Fatalf("error when instantiating merchant registry repository: %v", err)
+	}
+
+	merchantMatchAudit, err := NewMerchantMatchAudit(conn)
+	if err != nil {
+		log.Fatalf("error when instantiating merchant match audit repository: %v", err)
+	}
+
+	ledger, err := NewLedger(conn)
+	if err != nil {
+		log.Fatalf("error when instantiating ledger repository: %v", err)
+	}
+
 	suite.AccountRepo = account
 	suite.MerchantRepo = merchant
+	suite.TransactionOutcomeRepo = transactionOutcome
+	suite.AdminRepo = admin
+	suite.MerchantRegistryRepo = merchantRegistry
+	suite.MerchantMatchAuditRepo = merchantMatchAudit
+	suite.LedgerRepo = ledger
+
+	transactionEvent, err := NewTransactionEvent(conn)
+	if err != nil {
+		log.Fatalf("error when instantiating transaction event repository: %v", err)
+	}
+	suite.TransactionEventRepo = transactionEvent
+
+	fencingToken, err := NewFencingToken(conn)
+	if err != nil {
+		log.Fatalf("error when instantiating fencing token repository: %v", err)
+	}
+	suite.FencingTokenRepo = fencingToken
+
+	memoryLock, err := NewMemoryLock(conn, conn, newFakeLog())
+	if err != nil {
+		log.Fatalf("error when instantiating memory lock repository: %v", err)
+	}
+	suite.MemoryLockRepo = memoryLock
 
 	suite.loadDBtestData(conn)
 }
@@ -146,15 +215,110 @@ func (suite *RepositoriesSuite) AccountRepositorySaveTransactionsSuccess() {
 	transactionEntities := make(map[int]port.TransactionEntity)
 
 	transactionEntities[1] = port.TransactionEntity{
-		AccountID:    1,
-		Amount:       decimal.NewFromFloat(100.00),
-		MCC:          merchantCorrectMccToMap,
-		MerchantName: merchantNameToMap,
-		CategoryID:   merchantCategoryToMap,
+		AccountID:     1,
+		Amount:        decimal.NewFromFloat(10.22),
+		MCC:           merchantCorrectMccToMap,
+		MerchantName:  merchantNameToMap,
+		CategoryID:    merchantCategoryToMap,
+		Operation:     "AUTHORIZATION",
+		EntryType:     port.TRANSACTION_ENTRY_DEBIT,
+		BalanceBefore: decimal.NewFromFloat(110.22),
+	}
+
+	err := suite.AccountRepo.SaveTransactions(context.Background(), transactionEntities, 1)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RepositoriesSuite) AccountRepositorySaveTransactionsStaleFencingToken() {
+	transactionEntities := make(map[int]port.TransactionEntity)
+
+	transactionEntities[1] = port.TransactionEntity{
+		AccountID:     1,
+		Amount:        decimal.NewFromFloat(10.22),
+		MCC:           merchantCorrectMccToMap,
+		MerchantName:  merchantNameToMap,
+		CategoryID:    merchantCategoryToMap,
+		Operation:     "AUTHORIZATION",
+		EntryType:     port.TRANSACTION_ENTRY_DEBIT,
+		BalanceBefore: decimal.NewFromFloat(100.00),
+	}
+
+	err := suite.AccountRepo.SaveTransactions(context.Background(), transactionEntities, 0)
+	assert.ErrorIs(suite.T(), err, port.ErrStaleFencingToken)
+}
+
+func (suite *RepositoriesSuite) FencingTokenRepositoryFindFencingTokenSuccess() {
+	fencingToken, err := suite.FencingTokenRepo.FindFencingToken(context.Background(), accountUID.String())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), fencingToken)
+
+	fencingToken, err = suite.FencingTokenRepo.FindFencingToken(context.Background(), uuid.New().String())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(0), fencingToken)
+}
+
+func (suite *RepositoriesSuite) AccountRepositoryFindTransactionsByUIDSuccess() {
+	transactionUID := uuid.New()
+	transactionEntities := make(map[int]port.TransactionEntity)
+
+	transactionEntities[1] = port.TransactionEntity{
+		UID:           transactionUID,
+		AccountID:     1,
+		Amount:        decimal.NewFromFloat(10.00),
+		MCC:           merchantCorrectMccToMap,
+		MerchantName:  merchantNameToMap,
+		CategoryID:    merchantCategoryToMap,
+		Operation:     "AUTHORIZATION",
+		EntryType:     port.TRANSACTION_ENTRY_DEBIT,
+		BalanceBefore: decimal.NewFromFloat(100.00),
 	}
 
-	err := suite.AccountRepo.SaveTransactions(context.Background(), transactionEntities)
+	err := suite.AccountRepo.SaveTransactions(context.Background(), transactionEntities, 1)
+	assert.NoError(suite.T(), err)
+
+	transactionsCaptured, err := suite.AccountRepo.FindTransactionsByUID(context.Background(), transactionUID)
 	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), transactionsCaptured, 1)
+
+	for _, transactionCaptured := range transactionsCaptured {
+		assert.Equal(suite.T(), transactionCaptured.AccountUID, accountUID)
+		assert.Equal(suite.T(), transactionCaptured.CategoryID, merchantCategoryToMap)
+		assert.True(suite.T(), transactionCaptured.AmountCaptured.Equal(decimal.NewFromFloat(10.00)))
+		assert.True(suite.T(), transactionCaptured.AmountRefunded.IsZero())
+	}
+}
+
+func (suite *RepositoriesSuite) AccountRepositoryFindTransactionsByAccountUIDSuccess() {
+	firstPage, err := suite.AccountRepo.FindTransactionsByAccountUID(
+		context.Background(),
+		port.TransactionHistoryFilterEntity{AccountUID: accountUID, Limit: 2},
+	)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), firstPage, 2)
+	assert.Greater(suite.T(), firstPage[0].ID, firstPage[1].ID)
+	assert.True(suite.T(), firstPage[0].Amount.Equal(decimal.NewFromFloat(-10.00)))
+	assert.True(suite.T(), firstPage[0].Balance.Equal(decimal.NewFromFloat(90.00)))
+	assert.Equal(suite.T(), firstPage[0].Operation, "AUTHORIZATION")
+
+	nextPage, err := suite.AccountRepo.FindTransactionsByAccountUID(
+		context.Background(),
+		port.TransactionHistoryFilterEntity{AccountUID: accountUID, Limit: 2, CursorID: firstPage[1].ID},
+	)
+	assert.NoError(suite.T(), err)
+	for _, transaction := range nextPage {
+		assert.Less(suite.T(), transaction.ID, firstPage[1].ID)
+	}
+
+	filtered, err := suite.AccountRepo.FindTransactionsByAccountUID(
+		context.Background(),
+		port.TransactionHistoryFilterEntity{AccountUID: accountUID, Limit: 10, MCC: merchantCorrectMccToMap},
+	)
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), filtered)
+	for _, transaction := range filtered {
+		assert.Equal(suite.T(), transaction.MCC, merchantCorrectMccToMap)
+		assert.Equal(suite.T(), transaction.CategoryID, merchantCategoryToMap)
+	}
 }
 
 func (suite *RepositoriesSuite) MerchantRepositoryFindByNameSuccess() {
@@ -163,10 +327,260 @@ func (suite *RepositoriesSuite) MerchantRepositoryFindByNameSuccess() {
 	assert.NoError(suite.T(), err)
 }
 
+func (suite *RepositoriesSuite) TransactionOutcomeRepositorySaveAndFindByUIDSuccess() {
+	transactionUID := uuid.New()
+
+	outcomeEntity, err := suite.TransactionOutcomeRepo.FindByUID(context.Background(), transactionUID)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), outcomeEntity)
+
+	err = suite.TransactionOutcomeRepo.Save(
+		context.Background(),
+		port.TransactionOutcomeEntity{UID: transactionUID, AccountID: 1, Code: "00"},
+	)
+	assert.NoError(suite.T(), err)
+
+	outcomeEntity, err = suite.TransactionOutcomeRepo.FindByUID(context.Background(), transactionUID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), outcomeEntity.AccountUID, accountUID)
+	assert.Equal(suite.T(), outcomeEntity.Code, "00")
+
+	err = suite.TransactionOutcomeRepo.Save(
+		context.Background(),
+		port.TransactionOutcomeEntity{UID: transactionUID, AccountID: 1, Code: "51"},
+	)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *RepositoriesSuite) TransactionEventRepositoryRelayPendingSuccess() {
+	ctx := context.Background()
+	declinedUID := uuid.New()
+
+	err := suite.TransactionOutcomeRepo.Save(
+		ctx,
+		port.TransactionOutcomeEntity{
+			UID:       declinedUID,
+			AccountID: 1,
+			Code:      "51",
+			Amount:    decimal.NewFromFloat(500.00),
+			Currency:  "BRL",
+		},
+	)
+	assert.NoError(suite.T(), err)
+
+	var relayed []port.TransactionEventEntity
+	published, err := suite.TransactionEventRepo.RelayPending(
+		ctx,
+		100,
+		func(_ context.Context, events []port.TransactionEventEntity) []uint {
+			relayed = events
+
+			ids := []uint{}
+			for _, event := range events {
+				ids = append(ids, event.ID)
+			}
+			return ids
+		},
+	)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), published, len(relayed))
+
+	approved, declined := 0, 0
+	for i, event := range relayed {
+		if i > 0 {
+			assert.Greater(suite.T(), event.ID, relayed[i-1].ID)
+		}
+		assert.Equal(suite.T(), event.AccountUID, accountUID)
+
+		switch event.Type {
+		case port.TRANSACTION_EVENT_APPROVED:
+			approved++
+			assert.Equal(suite.T(), event.Code, "00")
+		case port.TRANSACTION_EVENT_DECLINED:
+			declined++
+			assert.Equal(suite.T(), event.TransactionUID, declinedUID)
+			assert.Equal(suite.T(), event.Code, "51")
+			assert.True(suite.T(), event.Amount.Equal(decimal.NewFromFloat(500.00)))
+		}
+	}
+	assert.Greater(suite.T(), approved, 0)
+	assert.Equal(suite.T(), declined, 1)
+
+	published, err = suite.TransactionEventRepo.RelayPending(
+		ctx,
+		100,
+		func(_ context.Context, events []port.TransactionEventEntity) []uint {
+			assert.Empty(suite.T(), events)
+			return nil
+		},
+	)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), published, 0)
+}
+
+func (suite *RepositoriesSuite) AdminRepositoryManageAccountCategoriesSuccess() {
+	ctx := context.Background()
+
+	accountEntity, err := suite.AdminRepo.CreateAccount(ctx, port.AccountAdminEntity{UID: uuid.New(), Name: "Jonh Doe"})
+	assert.NoError(suite.T(), err)
+	assert.NotZero(suite.T(), accountEntity.ID)
+
+	categoryEntity, err := suite.AdminRepo.CreateCategory(ctx, port.CategoryAdminEntity{UID: uuid.New(), Name: "MOBILITY", Priority: 4})
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.AdminRepo.AssignMCC(ctx, port.MCCAdminEntity{UID: uuid.New(), CategoryUID: categoryEntity.UID, MCC: merchantCorrectMccToMap})
+	assert.ErrorIs(suite.T(), err, port.ErrMCCAlreadyAssigned)
+
+	_, err = suite.AdminRepo.AssignMCC(ctx, port.MCCAdminEntity{UID: uuid.New(), CategoryUID: categoryEntity.UID, MCC: "4121"})
+	assert.NoError(suite.T(), err)
+
+	err = suite.AdminRepo.AttachCategory(ctx, accountEntity.UID, categoryEntity.UID)
+	assert.NoError(suite.T(), err)
+
+	err = suite.AdminRepo.AttachCategory(ctx, accountEntity.UID, categoryEntity.UID)
+	assert.ErrorIs(suite.T(), err, port.ErrCategoryAlreadyAttached)
+
+	accountBalance, err := suite.AccountRepo.FindByUID(ctx, accountEntity.UID)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), accountBalance.Balance.Categories, 1)
+	assert.True(suite.T(), accountBalance.Balance.AmountTotal.IsZero())
+
+	accountEntities, err := suite.AdminRepo.FindAccounts(ctx, port.AccountListFilterEntity{CursorID: accountEntity.ID - 1, Limit: 1})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), accountEntities, 1)
+	assert.Equal(suite.T(), accountEntities[0].UID, accountEntity.UID)
+	assert.Len(suite.T(), accountEntities[0].Categories, 1)
+	assert.Equal(suite.T(), accountEntities[0].Categories[0].MCCs, []string{"4121"})
+
+	err = suite.AdminRepo.DetachCategory(ctx, accountEntity.UID, categoryEntity.UID)
+	assert.NoError(suite.T(), err)
+
+	err = suite.AdminRepo.DetachCategory(ctx, accountEntity.UID, categoryEntity.UID)
+	assert.ErrorIs(suite.T(), err, port.ErrCategoryNotAttached)
+
+	err = suite.AdminRepo.DeleteAccount(ctx, accountEntity.UID)
+	assert.NoError(suite.T(), err)
+
+	err = suite.AdminRepo.DeleteAccount(ctx, accountEntity.UID)
+	assert.ErrorIs(suite.T(), err, port.ErrAccountNotFound)
+}
+
+func (suite *RepositoriesSuite) MerchantRegistryRepositoryManageMerchantsSuccess() {
+	ctx := context.Background()
+	merchantName := "PADARIA DO ZE               SAO PAULO BR"
+
+	_, err := suite.MerchantRegistryRepo.CreateMerchant(ctx, port.MerchantRegistryEntity{UID: uuid.New(), Name: merchantNameToMap, MCC: "5411"})
+	assert.ErrorIs(suite.T(), err, port.ErrMerchantAlreadyExists)
+
+	_, err = suite.MerchantRegistryRepo.CreateMerchant(ctx, port.MerchantRegistryEntity{UID: uuid.New(), Name: merchantName, MCC: "0000"})
+	assert.ErrorIs(suite.T(), err, port.ErrMCCNotFound)
+
+	merchantEntity, err := suite.MerchantRegistryRepo.CreateMerchant(ctx, port.MerchantRegistryEntity{UID: uuid.New(), Name: merchantName, MCC: "5411", Aliases: []string{"PAG*PADARIA*"}})
+	assert.NoError(suite.T(), err)
+	assert.NotZero(suite.T(), merchantEntity.ID)
+	assert.Equal(suite.T(), merchantEntity.Aliases, []string{"PAG*PADARIA*"})
+
+	_, err = suite.MerchantRegistryRepo.CreateMerchant(ctx, port.MerchantRegistryEntity{UID: uuid.New(), Name: "PAGSEGURO", MCC: "5411", Aliases: []string{"PAG*PADARIA*"}})
+	assert.ErrorIs(suite.T(), err, port.ErrMerchantAliasTaken)
+
+	merchantEntity.MCC = "5412"
+	merchantEntity.Aliases = []string{"PAG*PADARIA*", "PANIFICADORA ZE"}
+	merchantUpdated, err := suite.MerchantRegistryRepo.UpdateMerchant(ctx, merchantEntity)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), merchantUpdated.MCC, "5412")
+
+	merchantCatalog, err := suite.MerchantRepo.FindAll(ctx)
+	assert.NoError(suite.T(), err)
+	for _, catalogEntity := range merchantCatalog {
+		if catalogEntity.Name == merchantName {
+			assert.ElementsMatch(suite.T(), catalogEntity.Aliases, []string{"PAG*PADARIA*", "PANIFICADORA ZE"})
+		}
+	}
+
+	mappedMerchant, err := suite.MerchantRepo.FindByName(ctx, merchantName)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), mappedMerchant.MCC, "5412")
+
+	merchantEntities, err := suite.MerchantRegistryRepo.FindMerchants(ctx, port.MerchantListFilterEntity{CursorID: merchantEntity.ID - 1, Limit: 1})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), merchantEntities, 1)
+	assert.Equal(suite.T(), merchantEntities[0].UID, merchantEntity.UID)
+
+	err = suite.MerchantRegistryRepo.DeleteMerchant(ctx, merchantEntity.UID)
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.MerchantRegistryRepo.FindMerchantByUID(ctx, merchantEntity.UID)
+	assert.ErrorIs(suite.T(), err, port.ErrMerchantNotFound)
+
+	_, err = suite.MerchantRegistryRepo.CreateMerchant(ctx, port.MerchantRegistryEntity{UID: uuid.New(), Name: merchantName, MCC: "5411"})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RepositoriesSuite) MerchantMatchAuditRepositorySaveSuccess() {
+	err := suite.MerchantMatchAuditRepo.Save(
+		context.Background(),
+		port.MerchantMatchAuditEntity{
+			TransactionUID:  uuid.New(),
+			AccountID:       1,
+			MerchantName:    "Uber Eats      OSASCO BR",
+			MatchedMerchant: merchantNameToMap,
+			MatchRule:       "NORMALIZED",
+			MatchPattern:    merchantNameToMap,
+			OriginalMCC:     "5411",
+			MCC:             merchantCorrectMccToMap,
+		},
+	)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RepositoriesSuite) LedgerRepositoryFindLedgerBalancesSuccess() {
+	balances, err := suite.LedgerRepo.FindLedgerBalances(
+		context.Background(),
+		port.LedgerFilterEntity{AccountUID: accountUID, Limit: 1},
+	)
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), balances)
+
+	for _, balance := range balances {
+		assert.Equal(suite.T(), balance.AccountUID, accountUID)
+		assert.True(suite.T(), balance.Balance.Equal(balance.LedgerBalance))
+		assert.Equal(suite.T(), balance.BrokenEntries, 0)
+
+		if balance.CategoryID == merchantCategoryToMap {
+			assert.True(suite.T(), balance.LedgerBalance.Equal(decimal.NewFromFloat(90.00)))
+		}
+	}
+}
+
 func TestRepositoriesSuite(t *testing.T) {
 	suite.Run(t, new(RepositoriesSuite))
 }
 
+func (suite *RepositoriesSuite) MemoryLockRepositoryLockAndUnlockSuccess() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	owner := port.MemoryLockEntity{Key: accountUID.String(), Transcation: uuid.New().String()}
+	locked, err := suite.MemoryLockRepo.Lock(ctx, owner)
+	assert.NoError(suite.T(), err)
+
+	intruder := port.MemoryLockEntity{Key: accountUID.String(), Transcation: uuid.New().String()}
+	assert.ErrorIs(suite.T(), suite.MemoryLockRepo.Unlock(ctx, intruder), port.ErrMemoryLockNotOwned)
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer waitCancel()
+
+	_, err = suite.MemoryLockRepo.Lock(waitCtx, intruder)
+	assert.Error(suite.T(), err)
+
+	assert.NoError(suite.T(), suite.MemoryLockRepo.Unlock(ctx, locked))
+
+	relocked, err := suite.MemoryLockRepo.Lock(ctx, intruder)
+	assert.NoError(suite.T(), err)
+	assert.Greater(suite.T(), relocked.FencingToken, locked.FencingToken)
+	assert.NoError(suite.T(), suite.MemoryLockRepo.Unlock(ctx, relocked))
+}
+
 func (suite *RepositoriesSuite) TestCases() {
 	suite.T().Run("TestAccountRepositoryFindByUIDSuccess", func(t *testing.T) {
 		suite.AccountRepositoryFindByUIDsuccess()
@@ -176,9 +590,53 @@ func (suite *RepositoriesSuite) TestCases() {
 		suite.AccountRepositorySaveTransactionsSuccess()
 	})
 
+	suite.T().Run("TestAccountRepositorySaveTransactionsStaleFencingToken", func(t *testing.T) {
+		suite.AccountRepositorySaveTransactionsStaleFencingToken()
+	})
+
+	suite.T().Run("TestFencingTokenRepositoryFindFencingTokenSuccess", func(t *testing.T) {
+		suite.FencingTokenRepositoryFindFencingTokenSuccess()
+	})
+
+	suite.T().Run("TestAccountRepositoryFindTransactionsByUIDSuccess", func(t *testing.T) {
+		suite.AccountRepositoryFindTransactionsByUIDSuccess()
+	})
+
+	suite.T().Run("TestAccountRepositoryFindTransactionsByAccountUIDSuccess", func(t *testing.T) {
+		suite.AccountRepositoryFindTransactionsByAccountUIDSuccess()
+	})
+
+	suite.T().Run("TestLedgerRepositoryFindLedgerBalancesSuccess", func(t *testing.T) {
+		suite.LedgerRepositoryFindLedgerBalancesSuccess()
+	})
+
+	suite.T().Run("TestMemoryLockRepositoryLockAndUnlockSuccess", func(t *testing.T) {
+		suite.MemoryLockRepositoryLockAndUnlockSuccess()
+	})
+
 	suite.T().Run("TestMerchantRepositoryFindByNameSuccess", func(t *testing.T) {
 		suite.MerchantRepositoryFindByNameSuccess()
 	})
+
+	suite.T().Run("TestTransactionOutcomeRepositorySaveAndFindByUIDSuccess", func(t *testing.T) {
+		suite.TransactionOutcomeRepositorySaveAndFindByUIDSuccess()
+	})
+
+	suite.T().Run("TestTransactionEventRepositoryRelayPendingSuccess", func(t *testing.T) {
+		suite.TransactionEventRepositoryRelayPendingSuccess()
+	})
+
+	suite.T().Run("TestAdminRepositoryManageAccountCategoriesSuccess", func(t *testing.T) {
+		suite.AdminRepositoryManageAccountCategoriesSuccess()
+	})
+
+	suite.T().Run("TestMerchantRegistryRepositoryManageMerchantsSuccess", func(t *testing.T) {
+		suite.MerchantRegistryRepositoryManageMerchantsSuccess()
+	})
+
+	suite.T().Run("TestMerchantMatchAuditRepositorySaveSuccess", func(t *testing.T) {
+		suite.MerchantMatchAuditRepositorySaveSuccess()
+	})
 }
 
 func (suite *RepositoriesSuite) TearDownSuite() {
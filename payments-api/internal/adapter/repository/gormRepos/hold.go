@@ -0,0 +1,208 @@
+package gormRepos
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/adapter/model/gormModel"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/shopspring/decimal"
+
+	"gorm.io/gorm"
+)
+
+type Hold struct {
+	gormConn database.Conn
+	db       *gorm.DB
+}
+
+func NewHold(conn database.Conn) (port.HoldRepository, error) {
+	db, err := conn.GetDB(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("hold repository failure on conn.GetDB()")
+	}
+
+	dbGorm, ok := db.(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("hold repository failure to cast conn.GetDB() as gorm.DB")
+	}
+
+	return &Hold{
+		gormConn: conn,
+		db:       dbGorm,
+	}, nil
+}
+
+func (h *Hold) SaveHolds(ctx context.Context, holds map[int]port.HoldEntity) error {
+	if len(holds) == 0 {
+		return fmt.Errorf("no holds to save")
+	}
+
+	var hSlice []gormModel.Hold
+
+	for _, hold := range holds {
+		hSlice = append(hSlice, gormModel.Hold{
+			UID:          hold.UID,
+			AccountID:    hold.AccountID,
+			CategoryID:   hold.CategoryID,
+			Amount:       hold.Amount,
+			MCC:          hold.MCC,
+			MerchantName: hold.MerchantName,
+			Status:       hold.Status,
+			ExpiresAt:    hold.ExpiresAt,
+			CardID: sql.NullInt64{
+				Int64: int64(hold.CardID),
+				Valid: hold.CardID != 0,
+			},
+
+			Currency:         hold.Currency,
+			OriginalAmount:   nullDecimal(hold.OriginalAmount, hold.OriginalCurrency),
+			OriginalCurrency: nullString(hold.OriginalCurrency),
+			ExchangeRate:     nullDecimal(hold.ExchangeRate, hold.OriginalCurrency),
+		})
+	}
+
+	db := h.db.WithContext(ctx)
+
+	err := db.Create(&hSlice).Error
+	if err != nil {
+		if isDuplicatedKey(db, err) {
+			return fmt.Errorf("%w: %s", port.ErrDuplicateTransaction, err.Error())
+		}
+
+		return fmt.Errorf("failed to save holds: %w", err)
+	}
+
+	return nil
+}
+
+type holdResult struct {
+	ID           uint
+	UID          uuid.UUID
+	AccountID    uint
+	AccountUID   uuid.UUID
+	CategoryID   uint
+	Priority     int
+	Amount       decimal.Decimal
+	MCC          string
+	MerchantName string
+	Status       string
+	ExpiresAt    time.Time
+	CardID       sql.NullInt64
+
+	Currency         string
+	OriginalAmount   decimal.NullDecimal
+	OriginalCurrency sql.NullString
+	ExchangeRate     decimal.NullDecimal
+}
+
+func (h *Hold) FindByUID(ctx context.Context, uid uuid.UUID) (map[int]port.HoldEntity, error) {
+	var results []holdResult
+	holds := make(map[int]port.HoldEntity)
+
+	err := h.db.WithContext(ctx).
+		Table("holds as h").
+		Select(`
+			h.id as id,
+			h.uid as uid,
+			h.account_id as account_id,
+			a.uid as account_uid,
+			h.category_id as category_id,
+			c.priority as priority,
+			h.amount as amount,
+			h.mcc as mcc,
+			h.merchant_name as merchant_name,
+			h.status as status,
+			h.expires_at as expires_at,
+			h.card_id as card_id,
+			h.currency as currency,
+			h.original_amount as original_amount,
+			h.original_currency as original_currency,
+			h.exchange_rate as exchange_rate
+		`).
+		Joins("JOIN accounts as a ON a.id = h.account_id").
+		Joins("JOIN categories as c ON c.id = h.category_id").
+		Where("h.uid = ?", uid).
+		Where("h.status = ?", port.HOLD_STATUS_AUTHORIZED).
+		Where("h.deleted_at IS NULL").
+		Scan(&results).Error
+
+	if err != nil {
+		return holds, fmt.Errorf("error retrying holds:%s  err: %w", uid, err)
+	}
+
+	for _, result := range results {
+		holds[result.Priority] = port.HoldEntity{
+			ID:           result.ID,
+			UID:          result.UID,
+			AccountID:    result.AccountID,
+			AccountUID:   result.AccountUID,
+			CategoryID:   result.CategoryID,
+			Amount:       result.Amount,
+			MCC:          result.MCC,
+			MerchantName: result.MerchantName,
+			Status:       result.Status,
+			ExpiresAt:    result.ExpiresAt,
+			CardID:       uint(result.CardID.Int64),
+
+			Currency:         result.Currency,
+			OriginalAmount:   result.OriginalAmount.Decimal,
+			OriginalCurrency: result.OriginalCurrency.String,
+			ExchangeRate:     result.ExchangeRate.Decimal,
+		}
+	}
+
+	return holds, nil
+}
+
+func (h *Hold) Capture(ctx context.Context, uid uuid.UUID, transactions map[int]port.TransactionEntity, fencingToken int64) error {
+	if len(transactions) == 0 {
+		return fmt.Errorf("no transactions to capture")
+	}
+
+	tSlice := mapTransactionEntitiesToModels(transactions)
+
+	return h.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := verifyFencingToken(tx, transactions, fencingToken); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&tSlice).Error; err != nil {
+			if isDuplicatedKey(tx, err) {
+				return fmt.Errorf("%w: %s", port.ErrDuplicateTransaction, err.Error())
+			}
+
+			return fmt.Errorf("failed to save captured transactions: %w", err)
+		}
+
+		if err := createTransactionEvents(tx, transactions); err != nil {
+			return err
+		}
+
+		return h.updateStatus(tx, uid, port.HOLD_STATUS_CAPTURED)
+	})
+}
+
+func (h *Hold) Void(ctx context.Context, uid uuid.UUID) error {
+	return h.updateStatus(h.db.WithContext(ctx), uid, port.HOLD_STATUS_VOIDED)
+}
+
+func (h *Hold) updateStatus(tx *gorm.DB, uid uuid.UUID, status string) error {
+	result := tx.Model(&gormModel.Hold{}).
+		Where("uid = ? AND status = ?", uid, port.HOLD_STATUS_AUTHORIZED).
+		Update("status", status)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to update holds %s to %s: %w", uid, status, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("no authorized holds %s to update to %s", uid, status)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package gormRepos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/shopspring/decimal"
+
+	"gorm.io/gorm"
+)
+
+type Ledger struct {
+	gormConn database.Conn
+	db       *gorm.DB
+}
+
+func NewLedger(conn database.Conn) (port.LedgerRepository, error) {
+	db, err := conn.GetDB(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("ledger repository failure on conn.GetDB()")
+	}
+
+	dbGorm, ok := db.(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("ledger repository failure to cast conn.GetDB() as gorm.DB")
+	}
+
+	return &Ledger{
+		gormConn: conn,
+		db:       dbGorm,
+	}, nil
+}
+
+type ledgerBalanceResult struct {
+	AccountID     uint
+	AccountUID    uuid.UUID
+	CategoryID    uint
+	CategoryName  string
+	Balance       decimal.Decimal
+	LedgerBalance decimal.Decimal
+	BrokenEntries int
+}
+
+/*
+  - `transactions_latest` is kept by trigger with the balance after of the last
+    entry, so it's compared against the sum of the signed entry amounts. Every
+    entry is considered, as the trigger does.
+*/
+func (l *Ledger) FindLedgerBalances(ctx context.Context, filter port.LedgerFilterEntity) ([]port.LedgerBalanceEntity, error) {
+	var results []ledgerBalanceResult
+	balances := []port.LedgerBalanceEntity{}
+
+	accounts := l.db.
+		Table("accounts").
+		Select("id, uid").
+		Where("id > ? AND deleted_at IS NULL", filter.CursorID).
+		Order("id").
+		Limit(filter.Limit)
+
+	if filter.AccountUID != uuid.Nil {
+		accounts = accounts.Where("uid = ?", filter.AccountUID)
+	}
+
+	entries := l.db.
+		Table("transactions as t").
+		Select(`
+			t.id,
+			t.account_id,
+			t.category_id,
+			CASE WHEN t.entry_type = ? THEN -t.amount ELSE t.amount END as amount,
+			t.balance_before,
+			LAG(t.balance_after, 1, 0) OVER (PARTITION BY t.account_id, t.category_id ORDER BY t.id) as previous_balance
+		`, port.TRANSACTION_ENTRY_DEBIT).
+		Where("t.account_id IN (?)", l.db.Table("(?) as p", accounts).Select("p.id"))
+
+	err := l.db.WithContext(ctx).
+		Table("(?) as e", entries).
+		Select(`
+			p.id as account_id,
+			p.uid as account_uid,
+			e.category_id,
+			c.name as category_name,
+			COALESCE(lt.amount, 0) as balance,
+			SUM(e.amount) as ledger_balance,
+			COUNT(*) FILTER (WHERE e.balance_before <> e.previous_balance) as broken_entries
+		`).
+		Joins("JOIN (?) as p ON p.id = e.account_id", accounts).
+		Joins("JOIN categories as c ON c.id = e.category_id").
+		Joins("LEFT JOIN transactions_latest as lt ON lt.account_id = e.account_id AND lt.category_id = e.category_id").
+		Group("p.id, p.uid, e.category_id, c.name, lt.amount").
+		Order("p.id, e.category_id").
+		Scan(&results).Error
+
+	if err != nil {
+		return balances, fmt.Errorf("error recomputing ledger balances: %w", err)
+	}
+
+	for _, result := range results {
+		balances = append(balances, port.LedgerBalanceEntity{
+			AccountID:     result.AccountID,
+			AccountUID:    result.AccountUID,
+			CategoryID:    result.CategoryID,
+			CategoryName:  result.CategoryName,
+			Balance:       result.Balance,
+			LedgerBalance: result.LedgerBalance,
+			BrokenEntries: result.BrokenEntries,
+		})
+	}
+
+	return balances, nil
+}
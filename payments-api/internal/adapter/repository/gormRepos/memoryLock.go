@@ -0,0 +1,166 @@
+package gormRepos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/support/logger"
+
+	"gorm.io/gorm"
+)
+
+/*
+  - The lock is a `pg_advisory_xact_lock` held by a database transaction opened
+    with the context of the locking transaction: waiting for it is cancelled on the
+    context deadline, and it is released on `Unlock` or as soon as the context is done
+  - Postgres grants an advisory lock to its waiters in arrival order. Each held
+    lock and each waiter keeps a connection busy, taken from `lockConn`, a pool
+    dedicated to the locks so they never starve the repositories. Once its
+    connections are in use, a new transaction waits for one until its deadline
+  - The fencing tokens and the queue depth run on the pool of the repositories,
+    so a holder never waits for a second connection of the locks pool
+*/
+type MemoryLock struct {
+	gormConn database.Conn
+	db       *gorm.DB
+	lockDB   *gorm.DB
+	locks    sync.Map
+	log      logger.Logger
+}
+
+func NewMemoryLock(conn, lockConn database.Conn, log logger.Logger) (port.MemoryLockRepository, error) {
+	dbGorm, err := memoryLockDB(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	lockDBGorm, err := memoryLockDB(lockConn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemoryLock{
+		gormConn: conn,
+		db:       dbGorm,
+		lockDB:   lockDBGorm,
+		log:      log,
+	}, nil
+}
+
+func (ml *MemoryLock) Lock(ctx context.Context, mle port.MemoryLockEntity) (port.MemoryLockEntity, error) {
+	tx := ml.lockDB.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		if lockTimedOut(ctx, tx.Error) {
+			return port.MemoryLockEntity{}, fmt.Errorf("%w on key %s: %w", port.ErrMemoryLockTimeout, mle.Key, tx.Error)
+		}
+		return port.MemoryLockEntity{}, fmt.Errorf("failed to begin memory lock transaction: %w", tx.Error)
+	}
+
+	err := tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", mle.Key).Error
+	if err != nil {
+		tx.Rollback()
+		if lockTimedOut(ctx, err) {
+			return port.MemoryLockEntity{}, fmt.Errorf("%w on key %s: %w", port.ErrMemoryLockTimeout, mle.Key, err)
+		}
+		return port.MemoryLockEntity{}, fmt.Errorf("failed to lock memory lock key %s: %w", mle.Key, err)
+	}
+
+	fencingToken, err := ml.nextFencingToken(ctx, mle.Key)
+	if err != nil {
+		tx.Rollback()
+		return port.MemoryLockEntity{}, err
+	}
+
+	ml.log.Debug(ctx, "Locked in database memory lock")
+
+	mle.FencingToken = fencingToken
+	ml.locks.Store(lockKey(mle), tx)
+
+	return mle, nil
+}
+
+func (ml *MemoryLock) Unlock(ctx context.Context, mle port.MemoryLockEntity) error {
+	tx, ok := ml.locks.LoadAndDelete(lockKey(mle))
+	if !ok {
+		return fmt.Errorf("%w: %s on key %s", port.ErrMemoryLockNotOwned, mle.Transcation, mle.Key)
+	}
+
+	// Ends the transaction that holds the advisory lock, already ended when its context is done
+	tx.(*gorm.DB).Rollback()
+
+	ml.log.Debug(ctx, "Unlocked in database memory lock")
+	return nil
+}
+
+func (ml *MemoryLock) QueueDepth(ctx context.Context, key string) (int64, error) {
+	var depth int64
+
+	err := ml.db.WithContext(ctx).Raw(`
+		SELECT COUNT(*)
+		FROM pg_locks
+		WHERE locktype = 'advisory'
+		  AND NOT granted
+		  AND objsubid = 1
+		  AND ((classid::int8 << 32) | objid::int8) = hashtextextended(?, 0)
+	`, key).Scan(&depth).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count memory lock waiters on key %s: %w", key, err)
+	}
+
+	return depth, nil
+}
+
+/*
+  - Runs outside of the lock transaction, so the token handed out is kept even
+    when the lock transaction is rolled back
+  - A key without tokens starts after the last token written on its account,
+    issued by another lock strategy
+*/
+func (ml *MemoryLock) nextFencingToken(ctx context.Context, key string) (int64, error) {
+	var fencingToken int64
+
+	// A key that is not an account UID matches no account
+	accountUID, _ := uuid.Parse(key)
+
+	err := ml.db.WithContext(ctx).Raw(`
+		INSERT INTO memory_lock_fencing_tokens ("key", fencing_token)
+		VALUES (?, COALESCE((SELECT fencing_token FROM accounts WHERE uid = ?), 0) + 1)
+		ON CONFLICT ("key") DO UPDATE SET fencing_token = memory_lock_fencing_tokens.fencing_token + 1
+		RETURNING fencing_token
+	`, key, accountUID).Scan(&fencingToken).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to hand out fencing token on key %s: %w", key, err)
+	}
+
+	return fencingToken, nil
+}
+
+func memoryLockDB(conn database.Conn) (*gorm.DB, error) {
+	db, err := conn.GetDB(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("memory lock repository failure on conn.GetDB()")
+	}
+
+	dbGorm, ok := db.(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("memory lock repository failure to cast conn.GetDB() as gorm.DB")
+	}
+
+	return dbGorm, nil
+}
+
+func lockKey(mle port.MemoryLockEntity) string {
+	return fmt.Sprintf("%s:%s", mle.Key, mle.Transcation)
+}
+
+/*
+- Only the wait ended by the context is a timeout, other failures are returned as they are
+*/
+func lockTimedOut(ctx context.Context, err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil
+}
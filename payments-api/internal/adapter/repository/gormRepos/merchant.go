@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jtonynet/go-payments-api/internal/adapter/database"
 	"github.com/jtonynet/go-payments-api/internal/adapter/model/gormModel"
 	"github.com/jtonynet/go-payments-api/internal/core/port"
@@ -17,6 +18,14 @@ type Merchant struct {
 }
 
 func NewMerchant(conn database.Conn) (port.MerchantRepository, error) {
+	return newMerchant(conn)
+}
+
+func NewMerchantRegistry(conn database.Conn) (port.MerchantRegistryRepository, error) {
+	return newMerchant(conn)
+}
+
+func newMerchant(conn database.Conn) (*Merchant, error) {
 	db, err := conn.GetDB(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("merchant repository failure on conn.GetDB()")
@@ -48,3 +57,291 @@ func (m *Merchant) FindByName(ctx context.Context, name string) (*port.MerchantE
 		MCC:  merchantModel.MCC.MCC,
 	}, nil
 }
+
+func (m *Merchant) FindAll(ctx context.Context) ([]port.MerchantEntity, error) {
+	var merchantModels []gormModel.Merchant
+	merchants := []port.MerchantEntity{}
+
+	err := m.db.WithContext(ctx).
+		Preload("MCC").
+		Preload("Aliases").
+		Order("id ASC").
+		Find(&merchantModels).Error
+
+	if err != nil {
+		return merchants, fmt.Errorf("error retrying merchants: %w", err)
+	}
+
+	for _, merchantModel := range merchantModels {
+		merchants = append(merchants, port.MerchantEntity{
+			Name:    merchantModel.Name,
+			MCC:     merchantModel.MCC.MCC,
+			Aliases: mapMerchantAliasModelsToStrings(merchantModel.Aliases),
+		})
+	}
+
+	return merchants, nil
+}
+
+func (m *Merchant) CreateMerchant(ctx context.Context, merchant port.MerchantRegistryEntity) (port.MerchantRegistryEntity, error) {
+	merchantModel := gormModel.Merchant{
+		UID:  merchant.UID,
+		Name: merchant.Name,
+	}
+
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := checkMerchantNameAvailable(tx, merchant.Name, merchant.UID)
+		if err != nil {
+			return err
+		}
+
+		mccModel, err := findMCCModel(tx, merchant.MCC)
+		if err != nil {
+			return err
+		}
+
+		err = checkMerchantAliasesAvailable(tx, merchant.Aliases, 0)
+		if err != nil {
+			return err
+		}
+
+		merchantModel.MccID = mccModel.ID
+		merchantModel.MCC = mccModel
+
+		err = tx.Omit("MCC", "Aliases").Create(&merchantModel).Error
+		if err != nil {
+			return fmt.Errorf("failed to create merchant: %w", err)
+		}
+
+		merchantModel.Aliases, err = createMerchantAliases(tx, merchantModel.ID, merchant.Aliases)
+
+		return err
+	})
+
+	if err != nil {
+		return port.MerchantRegistryEntity{}, err
+	}
+
+	return mapMerchantModelToRegistryEntity(merchantModel), nil
+}
+
+func (m *Merchant) FindMerchantByUID(ctx context.Context, uid uuid.UUID) (port.MerchantRegistryEntity, error) {
+	merchantModel, err := findMerchantModel(m.db.WithContext(ctx), uid)
+	if err != nil {
+		return port.MerchantRegistryEntity{}, err
+	}
+
+	return mapMerchantModelToRegistryEntity(merchantModel), nil
+}
+
+func (m *Merchant) FindMerchants(ctx context.Context, filter port.MerchantListFilterEntity) ([]port.MerchantRegistryEntity, error) {
+	var merchantModels []gormModel.Merchant
+	merchants := []port.MerchantRegistryEntity{}
+
+	query := m.db.WithContext(ctx).Preload("MCC")
+
+	if filter.CursorID > 0 {
+		query = query.Where("id > ?", filter.CursorID)
+	}
+
+	err := query.
+		Order("id ASC").
+		Limit(filter.Limit).
+		Find(&merchantModels).Error
+
+	if err != nil {
+		return merchants, fmt.Errorf("error retrying merchants: %w", err)
+	}
+
+	for _, merchantModel := range merchantModels {
+		merchants = append(merchants, mapMerchantModelToRegistryEntity(merchantModel))
+	}
+
+	return merchants, nil
+}
+
+func (m *Merchant) UpdateMerchant(ctx context.Context, merchant port.MerchantRegistryEntity) (port.MerchantRegistryEntity, error) {
+	var merchantModel gormModel.Merchant
+
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+
+		merchantModel, err = findMerchantModel(tx, merchant.UID)
+		if err != nil {
+			return err
+		}
+
+		err = checkMerchantNameAvailable(tx, merchant.Name, merchant.UID)
+		if err != nil {
+			return err
+		}
+
+		mccModel, err := findMCCModel(tx, merchant.MCC)
+		if err != nil {
+			return err
+		}
+
+		err = checkMerchantAliasesAvailable(tx, merchant.Aliases, merchantModel.ID)
+		if err != nil {
+			return err
+		}
+
+		merchantModel.Name = merchant.Name
+		merchantModel.MccID = mccModel.ID
+		merchantModel.MCC = mccModel
+
+		err = tx.Omit("MCC", "Aliases").Save(&merchantModel).Error
+		if err != nil {
+			return fmt.Errorf("failed to update merchant %s: %w", merchant.UID, err)
+		}
+
+		err = deleteMerchantAliases(tx, merchantModel.ID)
+		if err != nil {
+			return err
+		}
+
+		merchantModel.Aliases, err = createMerchantAliases(tx, merchantModel.ID, merchant.Aliases)
+
+		return err
+	})
+
+	if err != nil {
+		return port.MerchantRegistryEntity{}, err
+	}
+
+	return mapMerchantModelToRegistryEntity(merchantModel), nil
+}
+
+func (m *Merchant) DeleteMerchant(ctx context.Context, uid uuid.UUID) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		merchantModel, err := findMerchantModel(tx, uid)
+		if err != nil {
+			return err
+		}
+
+		err = deleteMerchantAliases(tx, merchantModel.ID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Delete(&merchantModel).Error
+		if err != nil {
+			return fmt.Errorf("failed to delete merchant %s: %w", uid, err)
+		}
+
+		return nil
+	})
+}
+
+func findMerchantModel(tx *gorm.DB, uid uuid.UUID) (gormModel.Merchant, error) {
+	merchantModel := gormModel.Merchant{}
+
+	result := tx.Preload("MCC").Preload("Aliases").Where(&gormModel.Merchant{UID: uid}).First(&merchantModel)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return merchantModel, fmt.Errorf("%w: %s", port.ErrMerchantNotFound, uid)
+	} else if result.Error != nil {
+		return merchantModel, fmt.Errorf("error retrying merchant:%s  err: %w", uid, result.Error)
+	}
+
+	return merchantModel, nil
+}
+
+func findMCCModel(tx *gorm.DB, mcc string) (gormModel.MCC, error) {
+	mccModel := gormModel.MCC{}
+
+	result := tx.Where(&gormModel.MCC{MCC: mcc}).First(&mccModel)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return mccModel, fmt.Errorf("%w: %s", port.ErrMCCNotFound, mcc)
+	} else if result.Error != nil {
+		return mccModel, fmt.Errorf("error retrying mcc:%s  err: %w", mcc, result.Error)
+	}
+
+	return mccModel, nil
+}
+
+func checkMerchantNameAvailable(tx *gorm.DB, name string, uid uuid.UUID) error {
+	var taken int64
+
+	err := tx.Model(&gormModel.Merchant{}).
+		Where("name = ? AND uid <> ?", name, uid).
+		Count(&taken).Error
+	if err != nil {
+		return fmt.Errorf("failed to retrieve merchant %s: %w", name, err)
+	}
+
+	if taken > 0 {
+		return fmt.Errorf("%w: %s", port.ErrMerchantAlreadyExists, name)
+	}
+
+	return nil
+}
+
+/*
+- merchantID is the merchant being updated, its own aliases do not conflict
+*/
+func checkMerchantAliasesAvailable(tx *gorm.DB, aliases []string, merchantID uint) error {
+	if len(aliases) == 0 {
+		return nil
+	}
+
+	var taken []gormModel.MerchantAlias
+
+	err := tx.Where("alias IN ? AND merchant_id <> ?", aliases, merchantID).Find(&taken).Error
+	if err != nil {
+		return fmt.Errorf("failed to retrieve merchant aliases: %w", err)
+	}
+
+	if len(taken) > 0 {
+		return fmt.Errorf("%w: %s", port.ErrMerchantAliasTaken, taken[0].Alias)
+	}
+
+	return nil
+}
+
+func createMerchantAliases(tx *gorm.DB, merchantID uint, aliases []string) ([]gormModel.MerchantAlias, error) {
+	aliasModels := []gormModel.MerchantAlias{}
+	for _, alias := range aliases {
+		aliasModels = append(aliasModels, gormModel.MerchantAlias{MerchantID: merchantID, Alias: alias})
+	}
+
+	if len(aliasModels) == 0 {
+		return aliasModels, nil
+	}
+
+	err := tx.Create(&aliasModels).Error
+	if err != nil {
+		return aliasModels, fmt.Errorf("failed to create merchant aliases: %w", err)
+	}
+
+	return aliasModels, nil
+}
+
+func deleteMerchantAliases(tx *gorm.DB, merchantID uint) error {
+	err := tx.Where(&gormModel.MerchantAlias{MerchantID: merchantID}).Delete(&gormModel.MerchantAlias{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete merchant aliases: %w", err)
+	}
+
+	return nil
+}
+
+func mapMerchantAliasModelsToStrings(aliasModels []gormModel.MerchantAlias) []string {
+	aliases := []string{}
+	for _, aliasModel := range aliasModels {
+		aliases = append(aliases, aliasModel.Alias)
+	}
+
+	return aliases
+}
+
+func mapMerchantModelToRegistryEntity(merchantModel gormModel.Merchant) port.MerchantRegistryEntity {
+	return port.MerchantRegistryEntity{
+		ID:        merchantModel.ID,
+		UID:       merchantModel.UID,
+		Name:      merchantModel.Name,
+		MCC:       merchantModel.MCC.MCC,
+		Aliases:   mapMerchantAliasModelsToStrings(merchantModel.Aliases),
+		CreatedAt: merchantModel.CreatedAt,
+		UpdatedAt: merchantModel.UpdatedAt,
+	}
+}
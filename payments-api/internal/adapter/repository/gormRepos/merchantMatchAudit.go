@@ -0,0 +1,54 @@
+package gormRepos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/adapter/model/gormModel"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+
+	"gorm.io/gorm"
+)
+
+type MerchantMatchAudit struct {
+	gormConn database.Conn
+	db       *gorm.DB
+}
+
+func NewMerchantMatchAudit(conn database.Conn) (port.MerchantMatchAuditRepository, error) {
+	db, err := conn.GetDB(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("merchant match audit repository failure on conn.GetDB()")
+	}
+
+	dbGorm, ok := db.(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("merchant match audit repository failure to cast conn.GetDB() as gorm.DB")
+	}
+
+	return &MerchantMatchAudit{
+		gormConn: conn,
+		db:       dbGorm,
+	}, nil
+}
+
+func (ma *MerchantMatchAudit) Save(ctx context.Context, audit port.MerchantMatchAuditEntity) error {
+	auditModel := gormModel.MerchantMatchAudit{
+		TransactionUID:  audit.TransactionUID,
+		AccountID:       audit.AccountID,
+		MerchantName:    audit.MerchantName,
+		MatchedMerchant: audit.MatchedMerchant,
+		MatchRule:       audit.MatchRule,
+		MatchPattern:    audit.MatchPattern,
+		OriginalMCC:     audit.OriginalMCC,
+		MCC:             audit.MCC,
+	}
+
+	err := ma.db.WithContext(ctx).Create(&auditModel).Error
+	if err != nil {
+		return fmt.Errorf("failed to save merchant match audit: %w", err)
+	}
+
+	return nil
+}
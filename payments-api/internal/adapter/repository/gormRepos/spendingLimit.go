@@ -0,0 +1,169 @@
+package gormRepos
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/adapter/model/gormModel"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/shopspring/decimal"
+
+	"gorm.io/gorm"
+)
+
+type SpendingLimit struct {
+	gormConn database.Conn
+	db       *gorm.DB
+}
+
+func NewSpendingLimit(conn database.Conn) (port.SpendingLimitRepository, error) {
+	db, err := conn.GetDB(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("spending limit repository failure on conn.GetDB()")
+	}
+
+	dbGorm, ok := db.(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("spending limit repository failure to cast conn.GetDB() as gorm.DB")
+	}
+
+	return &SpendingLimit{
+		gormConn: conn,
+		db:       dbGorm,
+	}, nil
+}
+
+type spendingLimitResult struct {
+	AccountID          uint
+	AccountUID         uuid.UUID
+	CategoryID         sql.NullInt64
+	CategoryUID        uuid.NullUUID
+	CategoryName       sql.NullString
+	DailyAmount        decimal.Decimal
+	MonthlyAmount      decimal.Decimal
+	TransactionAmount  decimal.Decimal
+	HourlyTransactions int
+}
+
+/*
+- Limits ordered with the limits of the account first, then by category
+*/
+func (sl *SpendingLimit) FindByAccountID(ctx context.Context, accountID uint) ([]port.SpendingLimitEntity, error) {
+	var results []spendingLimitResult
+
+	err := sl.db.WithContext(ctx).
+		Table("spending_limits as sl").
+		Select(`
+			sl.account_id,
+			a.uid as account_uid,
+			sl.category_id,
+			c.uid as category_uid,
+			c.name as category_name,
+			sl.daily_amount,
+			sl.monthly_amount,
+			sl.transaction_amount,
+			sl.hourly_transactions
+		`).
+		Joins("JOIN accounts as a ON a.id = sl.account_id").
+		Joins("LEFT JOIN categories as c ON c.id = sl.category_id").
+		Where("sl.deleted_at IS NULL AND sl.account_id = ?", accountID).
+		Order("sl.category_id NULLS FIRST").
+		Scan(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("error retrying spending limits of account:%d  err: %w", accountID, err)
+	}
+
+	slEntities := make([]port.SpendingLimitEntity, 0, len(results))
+	for _, result := range results {
+		slEntities = append(slEntities, port.SpendingLimitEntity{
+			AccountID:          result.AccountID,
+			AccountUID:         result.AccountUID,
+			CategoryID:         uint(result.CategoryID.Int64),
+			CategoryUID:        result.CategoryUID.UUID,
+			CategoryName:       result.CategoryName.String,
+			DailyAmount:        result.DailyAmount,
+			MonthlyAmount:      result.MonthlyAmount,
+			TransactionAmount:  result.TransactionAmount,
+			HourlyTransactions: result.HourlyTransactions,
+		})
+	}
+
+	return slEntities, nil
+}
+
+func (sl *SpendingLimit) FindByAccountUID(ctx context.Context, accountUID uuid.UUID) ([]port.SpendingLimitEntity, error) {
+	accountModel, err := findAccountModel(sl.db.WithContext(ctx), accountUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sl.FindByAccountID(ctx, accountModel.ID)
+}
+
+func (sl *SpendingLimit) Save(ctx context.Context, limit port.SpendingLimitEntity) (port.SpendingLimitEntity, error) {
+	err := sl.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		accountModel, err := findAccountModel(tx, limit.AccountUID)
+		if err != nil {
+			return err
+		}
+
+		limit.AccountID = accountModel.ID
+
+		categoryID := sql.NullInt64{}
+		if limit.CategoryUID != uuid.Nil {
+			categoryModel, err := findCategoryModel(tx, limit.CategoryUID)
+			if err != nil {
+				return err
+			}
+
+			categoryID = sql.NullInt64{Int64: int64(categoryModel.ID), Valid: true}
+			limit.CategoryID = categoryModel.ID
+			limit.CategoryName = categoryModel.Name
+		}
+
+		err = spendingLimitScope(tx, accountModel.ID, categoryID).Delete(&gormModel.SpendingLimit{}).Error
+		if err != nil {
+			return fmt.Errorf("failed to remove spending limit: %w", err)
+		}
+
+		if limit.DailyAmount.IsZero() &&
+			limit.MonthlyAmount.IsZero() &&
+			limit.TransactionAmount.IsZero() &&
+			limit.HourlyTransactions == 0 {
+			return nil
+		}
+
+		err = tx.Create(&gormModel.SpendingLimit{
+			AccountID:          accountModel.ID,
+			CategoryID:         categoryID,
+			DailyAmount:        limit.DailyAmount,
+			MonthlyAmount:      limit.MonthlyAmount,
+			TransactionAmount:  limit.TransactionAmount,
+			HourlyTransactions: limit.HourlyTransactions,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to create spending limit: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return port.SpendingLimitEntity{}, err
+	}
+
+	return limit, nil
+}
+
+func spendingLimitScope(tx *gorm.DB, accountID uint, categoryID sql.NullInt64) *gorm.DB {
+	tx = tx.Where("account_id = ?", accountID)
+
+	if categoryID.Valid {
+		return tx.Where("category_id = ?", categoryID.Int64)
+	}
+
+	return tx.Where("category_id IS NULL")
+}
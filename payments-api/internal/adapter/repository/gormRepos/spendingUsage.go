@@ -0,0 +1,141 @@
+package gormRepos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/shopspring/decimal"
+
+	"gorm.io/gorm"
+)
+
+type SpendingUsage struct {
+	gormConn database.Conn
+	db       *gorm.DB
+}
+
+func NewSpendingUsage(conn database.Conn) (port.SpendingUsageRepository, error) {
+	db, err := conn.GetDB(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("spending usage repository failure on conn.GetDB()")
+	}
+
+	dbGorm, ok := db.(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("spending usage repository failure to cast conn.GetDB() as gorm.DB")
+	}
+
+	return &SpendingUsage{
+		gormConn: conn,
+		db:       dbGorm,
+	}, nil
+}
+
+type spendingUsageResult struct {
+	DailyAmount        decimal.Decimal
+	MonthlyAmount      decimal.Decimal
+	HourlyTransactions int
+	Unconverted        int
+}
+
+/*
+  - Sums the AUTHORIZATION debits posted since the start of the UTC month of at and
+    the holds active at it, held in every window, counting each transaction once
+    even when it debits several categories
+  - Amounts are converted into the account currency with the rates of
+    `exchange_rates`, failing when a rate is missing
+*/
+func (su *SpendingUsage) FindUsage(ctx context.Context, accountID, categoryID uint, at time.Time) (port.SpendingUsageEntity, error) {
+	hourStart, dayStart, monthStart := spendingWindows(at)
+
+	categoryFilter := ""
+	if categoryID != 0 {
+		categoryFilter = " AND category_id = @categoryID"
+	}
+
+	query := `
+		SELECT
+			COALESCE(SUM(u.amount * r.rate) FILTER (WHERE u.held OR u.created_at >= @dayStart), 0) as daily_amount,
+			COALESCE(SUM(u.amount * r.rate), 0) as monthly_amount,
+			COUNT(DISTINCT u.uid) FILTER (WHERE u.created_at >= @hourStart) as hourly_transactions,
+			COUNT(*) FILTER (WHERE r.rate IS NULL) as unconverted
+		FROM (
+			SELECT uid, created_at, amount, currency, false as held
+			FROM transactions
+			WHERE deleted_at IS NULL
+				AND account_id = @accountID
+				AND operation = 'AUTHORIZATION'
+				AND entry_type = 'DEBIT'
+				AND created_at >= @monthStart` + categoryFilter + `
+			UNION ALL
+			SELECT uid, created_at, amount, currency, true as held
+			FROM holds
+			WHERE deleted_at IS NULL
+				AND account_id = @accountID
+				AND status = @holdStatus
+				AND expires_at > @at` + categoryFilter + `
+		) as u
+		JOIN accounts as a ON a.id = @accountID
+		LEFT JOIN exchange_rates as er ON er.deleted_at IS NULL
+			AND er.from_currency = u.currency
+			AND er.to_currency = a.currency
+		CROSS JOIN LATERAL (
+			SELECT CASE WHEN u.currency = a.currency THEN 1 ELSE er.rate END as rate
+		) as r`
+
+	var result spendingUsageResult
+	err := su.db.WithContext(ctx).Raw(query, map[string]interface{}{
+		"accountID":  accountID,
+		"categoryID": categoryID,
+		"holdStatus": port.HOLD_STATUS_AUTHORIZED,
+		"at":         at,
+		"hourStart":  hourStart,
+		"dayStart":   dayStart,
+		"monthStart": monthStart,
+	}).Scan(&result).Error
+	if err != nil {
+		return port.SpendingUsageEntity{}, fmt.Errorf("error retrying spending usage of account:%d  err: %w", accountID, err)
+	}
+
+	if result.Unconverted > 0 {
+		return port.SpendingUsageEntity{}, fmt.Errorf(
+			"error retrying spending usage of account:%d  err: %w for %d debits",
+			accountID,
+			port.ErrExchangeRateNotFound,
+			result.Unconverted,
+		)
+	}
+
+	return port.SpendingUsageEntity{
+		AccountID:          accountID,
+		CategoryID:         categoryID,
+		At:                 at,
+		DailyAmount:        result.DailyAmount,
+		MonthlyAmount:      result.MonthlyAmount,
+		HourlyTransactions: result.HourlyTransactions,
+	}, nil
+}
+
+/*
+- The posted transactions and the holds are already in the ledger
+*/
+func (su *SpendingUsage) AddUsage(_ context.Context, _ port.SpendingUsageEntity) error {
+	return nil
+}
+
+func (su *SpendingUsage) ResetUsage(_ context.Context, _, _ uint, _ time.Time) error {
+	return nil
+}
+
+func spendingWindows(at time.Time) (time.Time, time.Time, time.Time) {
+	at = at.UTC()
+
+	hourStart := at.Truncate(time.Hour)
+	dayStart := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	return hourStart, dayStart, monthStart
+}
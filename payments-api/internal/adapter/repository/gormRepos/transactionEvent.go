@@ -0,0 +1,273 @@
+package gormRepos
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/adapter/model/gormModel"
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/shopspring/decimal"
+
+	"gorm.io/gorm"
+)
+
+/*
+- Advisory lock held by the relay session, so a single relay publishes at a time
+*/
+const transactionEventRelayLockKey = "transaction_events:relay"
+
+type TransactionEvent struct {
+	gormConn database.Conn
+	db       *gorm.DB
+}
+
+func NewTransactionEvent(conn database.Conn) (port.TransactionEventRepository, error) {
+	db, err := conn.GetDB(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("transaction event repository failure on conn.GetDB()")
+	}
+
+	dbGorm, ok := db.(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("transaction event repository failure to cast conn.GetDB() as gorm.DB")
+	}
+
+	return &TransactionEvent{
+		gormConn: conn,
+		db:       dbGorm,
+	}, nil
+}
+
+type transactionEventResult struct {
+	ID             uint
+	UID            uuid.UUID
+	EventType      string
+	AccountID      uint
+	AccountUID     uuid.NullUUID
+	TransactionUID uuid.UUID
+	OriginalUID    uuid.NullUUID
+	Amount         decimal.Decimal
+	Currency       string
+	Code           string
+	CreatedAt      time.Time
+}
+
+/*
+  - The relay lock is a session lock of a single connection, so the events are
+    relayed outside of any database transaction and no row stays locked while
+    they are published
+  - The published events are marked after the relay. A relay that fails to mark
+    them publishes them again in the next pass
+  - The events are taken by their position in the pending backlog of their account,
+    then in the order they were written, so an account that fails to publish a
+    large backlog never starves the others out of the batch
+*/
+func (te *TransactionEvent) RelayPending(
+	ctx context.Context,
+	limit int,
+	relay func(ctx context.Context, events []port.TransactionEventEntity) []uint,
+) (int, error) {
+	published := 0
+
+	err := te.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		var locked bool
+		err := conn.Raw("SELECT pg_try_advisory_lock(hashtextextended(?, 0))", transactionEventRelayLockKey).
+			Scan(&locked).Error
+		if err != nil {
+			return fmt.Errorf("failed to lock transaction events relay: %w", err)
+		}
+
+		if !locked {
+			return nil
+		}
+
+		// The connection returns to the pool, so the lock is released even when ctx is done
+		defer conn.WithContext(context.WithoutCancel(ctx)).
+			Exec("SELECT pg_advisory_unlock(hashtextextended(?, 0))", transactionEventRelayLockKey)
+
+		var results []transactionEventResult
+		err = conn.Table(`(
+				SELECT
+					*,
+					ROW_NUMBER() OVER (PARTITION BY account_id ORDER BY id) as position
+				FROM transaction_events
+				WHERE published_at IS NULL AND deleted_at IS NULL
+			) as te`).
+			Select(`
+				te.id,
+				te.uid,
+				te.event_type,
+				COALESCE(te.account_id, 0) as account_id,
+				COALESCE(a.uid, te.account_uid) as account_uid,
+				te.transaction_uid,
+				te.original_uid,
+				te.amount,
+				te.currency,
+				te.code,
+				te.created_at
+			`).
+			Joins("LEFT JOIN accounts as a ON a.id = te.account_id").
+			Order("te.position, te.id").
+			Limit(limit).
+			Scan(&results).Error
+		if err != nil {
+			return fmt.Errorf("error retrying pending transaction events  err: %w", err)
+		}
+
+		if len(results) == 0 {
+			return nil
+		}
+
+		events := make([]port.TransactionEventEntity, 0, len(results))
+		for _, result := range results {
+			events = append(events, port.TransactionEventEntity{
+				ID:             result.ID,
+				UID:            result.UID,
+				Type:           result.EventType,
+				AccountID:      result.AccountID,
+				AccountUID:     result.AccountUID.UUID,
+				TransactionUID: result.TransactionUID,
+				OriginalUID:    result.OriginalUID.UUID,
+				Amount:         result.Amount,
+				Currency:       result.Currency,
+				Code:           result.Code,
+				CreatedAt:      result.CreatedAt,
+			})
+		}
+
+		publishedIDs := relay(ctx, events)
+		if len(publishedIDs) == 0 {
+			return nil
+		}
+
+		err = conn.Model(&gormModel.TransactionEvent{}).
+			Where("id IN ?", publishedIDs).
+			Update("published_at", time.Now()).Error
+		if err != nil {
+			return fmt.Errorf("failed to mark transaction events as published: %w", err)
+		}
+
+		published = len(publishedIDs)
+		return nil
+	})
+
+	return published, err
+}
+
+/*
+  - One event per transaction posted: AUTHORIZATION debits are approved payments
+    or captures and REFUND credits are refunds, other operations have no event
+  - The amount is the one requested, in its original currency when converted
+*/
+func createTransactionEvents(tx *gorm.DB, transactions map[int]port.TransactionEntity) error {
+	keys := make([]int, 0, len(transactions))
+	for key := range transactions {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+
+	var events []gormModel.TransactionEvent
+	eventIndexes := make(map[uuid.UUID]int)
+
+	for _, key := range keys {
+		transaction := transactions[key]
+
+		eventType := transactionEventType(transaction)
+		if eventType == "" {
+			continue
+		}
+
+		amount, currency := transaction.Amount, transaction.Currency
+		if transaction.OriginalCurrency != "" {
+			amount, currency = transaction.OriginalAmount, transaction.OriginalCurrency
+		}
+
+		if index, ok := eventIndexes[transaction.UID]; ok {
+			events[index].Amount = events[index].Amount.Add(amount)
+			continue
+		}
+
+		eventIndexes[transaction.UID] = len(events)
+		events = append(events, gormModel.TransactionEvent{
+			UID: uuid.New(),
+			AccountID: sql.NullInt64{
+				Int64: int64(transaction.AccountID),
+				Valid: true,
+			},
+			AccountUID: uuid.NullUUID{
+				UUID:  transaction.AccountUID,
+				Valid: transaction.AccountUID != uuid.Nil,
+			},
+			EventType:      eventType,
+			TransactionUID: transaction.UID,
+			OriginalUID: uuid.NullUUID{
+				UUID:  transaction.OriginalUID,
+				Valid: transaction.OriginalUID != uuid.Nil,
+			},
+			Amount:   amount,
+			Currency: currency,
+			Code:     port.CODE_APPROVED,
+		})
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	if err := tx.Create(&events).Error; err != nil {
+		return fmt.Errorf("failed to save transaction events: %w", err)
+	}
+
+	return nil
+}
+
+func transactionEventType(transaction port.TransactionEntity) string {
+	switch {
+	case transaction.Operation == port.TRANSACTION_OPERATION_AUTHORIZATION &&
+		transaction.EntryType == port.TRANSACTION_ENTRY_DEBIT:
+		return port.TRANSACTION_EVENT_APPROVED
+	case transaction.Operation == port.TRANSACTION_OPERATION_REFUND &&
+		transaction.EntryType == port.TRANSACTION_ENTRY_CREDIT:
+		return port.TRANSACTION_EVENT_REFUNDED
+	default:
+		return ""
+	}
+}
+
+/*
+- A rejection of an unknown account or card has no account, only the one requested
+*/
+func createDeclinedTransactionEvent(tx *gorm.DB, outcome port.TransactionOutcomeEntity) error {
+	currency := outcome.Currency
+	if currency == "" {
+		currency = domain.DEFAULT_CURRENCY
+	}
+
+	err := tx.Create(&gormModel.TransactionEvent{
+		UID: uuid.New(),
+		AccountID: sql.NullInt64{
+			Int64: int64(outcome.AccountID),
+			Valid: outcome.AccountID != 0,
+		},
+		AccountUID: uuid.NullUUID{
+			UUID:  outcome.AccountUID,
+			Valid: outcome.AccountUID != uuid.Nil,
+		},
+		EventType:      port.TRANSACTION_EVENT_DECLINED,
+		TransactionUID: outcome.UID,
+		Amount:         outcome.Amount,
+		Currency:       currency,
+		Code:           outcome.Code,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to save transaction event: %w", err)
+	}
+
+	return nil
+}
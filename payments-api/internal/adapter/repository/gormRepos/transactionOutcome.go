@@ -0,0 +1,79 @@
+package gormRepos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/adapter/model/gormModel"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+
+	"gorm.io/gorm"
+)
+
+type TransactionOutcome struct {
+	gormConn database.Conn
+	db       *gorm.DB
+}
+
+func NewTransactionOutcome(conn database.Conn) (port.TransactionOutcomeRepository, error) {
+	db, err := conn.GetDB(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("transaction outcome repository failure on conn.GetDB()")
+	}
+
+	dbGorm, ok := db.(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("transaction outcome repository failure to cast conn.GetDB() as gorm.DB")
+	}
+
+	return &TransactionOutcome{
+		gormConn: conn,
+		db:       dbGorm,
+	}, nil
+}
+
+func (to *TransactionOutcome) FindByUID(ctx context.Context, uid uuid.UUID) (*port.TransactionOutcomeEntity, error) {
+	outcomeModel := gormModel.TransactionOutcome{}
+
+	result := to.db.WithContext(ctx).Preload("Account").Where(&gormModel.TransactionOutcome{UID: uid}).First(&outcomeModel)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if result.Error != nil {
+		return nil, fmt.Errorf("error retrying transaction outcome:%s  err: %w", uid, result.Error)
+	}
+
+	return &port.TransactionOutcomeEntity{
+		UID:        outcomeModel.UID,
+		AccountID:  outcomeModel.AccountID,
+		AccountUID: outcomeModel.Account.UID,
+		Code:       outcomeModel.Code,
+	}, nil
+}
+
+func (to *TransactionOutcome) Save(ctx context.Context, outcome port.TransactionOutcomeEntity) error {
+	outcomeModel := gormModel.TransactionOutcome{
+		UID:       outcome.UID,
+		AccountID: outcome.AccountID,
+		Code:      outcome.Code,
+	}
+
+	return to.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&outcomeModel).Error
+		if err != nil {
+			return fmt.Errorf("failed to save transaction outcome: %w", err)
+		}
+
+		if outcome.Code == port.CODE_APPROVED {
+			return nil
+		}
+
+		return createDeclinedTransactionEvent(tx, outcome)
+	})
+}
+
+func (to *TransactionOutcome) SaveDeclinedEvent(ctx context.Context, outcome port.TransactionOutcomeEntity) error {
+	return createDeclinedTransactionEvent(to.db.WithContext(ctx), outcome)
+}
@@ -0,0 +1,54 @@
+package redisRepos
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+/*
+  - Reads are never cached here, the payment flow needs the persisted balance.
+    Saved transactions evict the cached balance of their accounts.
+*/
+type Account struct {
+	cacheConn database.InMemory
+
+	accountRepository port.AccountRepository
+}
+
+func NewRedisAccount(cacheConn database.InMemory, aRepository port.AccountRepository) (port.AccountRepository, error) {
+	return &Account{
+		cacheConn:         cacheConn,
+		accountRepository: aRepository,
+	}, nil
+}
+
+func (a *Account) FindByUID(ctx context.Context, uid uuid.UUID) (port.AccountEntity, error) {
+	return a.accountRepository.FindByUID(ctx, uid)
+}
+
+func (a *Account) FindTransactionsByUID(ctx context.Context, uid uuid.UUID) (map[int]port.TransactionCapturedEntity, error) {
+	return a.accountRepository.FindTransactionsByUID(ctx, uid)
+}
+
+func (a *Account) FindTransactionsByAccountUID(ctx context.Context, filter port.TransactionHistoryFilterEntity) ([]port.TransactionHistoryEntity, error) {
+	return a.accountRepository.FindTransactionsByAccountUID(ctx, filter)
+}
+
+func (a *Account) SaveTransactions(ctx context.Context, transactions map[int]port.TransactionEntity, fencingToken int64) error {
+	err := a.accountRepository.SaveTransactions(ctx, transactions, fencingToken)
+	if err != nil {
+		return err
+	}
+
+	accountUIDs := make(map[uuid.UUID]struct{})
+	for _, transaction := range transactions {
+		accountUIDs[transaction.AccountUID] = struct{}{}
+	}
+
+	invalidateBalances(ctx, a.cacheConn, accountUIDs)
+
+	return nil
+}
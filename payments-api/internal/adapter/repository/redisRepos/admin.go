@@ -0,0 +1,88 @@
+package redisRepos
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+/*
+  - Attaching, detaching categories and deleting accounts change the account
+    balance, so they evict its cached balance. MCC assignments are shared by many
+    accounts and rely on the cache default expiration.
+*/
+type Admin struct {
+	cacheConn database.InMemory
+
+	adminRepository port.AdminRepository
+}
+
+func NewRedisAdmin(cacheConn database.InMemory, adRepository port.AdminRepository) (port.AdminRepository, error) {
+	return &Admin{
+		cacheConn:       cacheConn,
+		adminRepository: adRepository,
+	}, nil
+}
+
+func (ad *Admin) CreateAccount(ctx context.Context, account port.AccountAdminEntity) (port.AccountAdminEntity, error) {
+	return ad.adminRepository.CreateAccount(ctx, account)
+}
+
+func (ad *Admin) DeleteAccount(ctx context.Context, uid uuid.UUID) error {
+	err := ad.adminRepository.DeleteAccount(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	invalidateBalances(ctx, ad.cacheConn, map[uuid.UUID]struct{}{uid: {}})
+
+	return nil
+}
+
+func (ad *Admin) FindAccounts(ctx context.Context, filter port.AccountListFilterEntity) ([]port.AccountAdminEntity, error) {
+	return ad.adminRepository.FindAccounts(ctx, filter)
+}
+
+func (ad *Admin) FindAccountStatus(ctx context.Context, uid uuid.UUID) (string, error) {
+	return ad.adminRepository.FindAccountStatus(ctx, uid)
+}
+
+func (ad *Admin) ChangeAccountStatus(ctx context.Context, change port.AccountStatusChangeEntity) (port.AccountStatusChangeEntity, error) {
+	return ad.adminRepository.ChangeAccountStatus(ctx, change)
+}
+
+func (ad *Admin) FindAccountStatusChanges(ctx context.Context, uid uuid.UUID) ([]port.AccountStatusChangeEntity, error) {
+	return ad.adminRepository.FindAccountStatusChanges(ctx, uid)
+}
+
+func (ad *Admin) AttachCategory(ctx context.Context, accountUID, categoryUID uuid.UUID) error {
+	err := ad.adminRepository.AttachCategory(ctx, accountUID, categoryUID)
+	if err != nil {
+		return err
+	}
+
+	invalidateBalances(ctx, ad.cacheConn, map[uuid.UUID]struct{}{accountUID: {}})
+
+	return nil
+}
+
+func (ad *Admin) DetachCategory(ctx context.Context, accountUID, categoryUID uuid.UUID) error {
+	err := ad.adminRepository.DetachCategory(ctx, accountUID, categoryUID)
+	if err != nil {
+		return err
+	}
+
+	invalidateBalances(ctx, ad.cacheConn, map[uuid.UUID]struct{}{accountUID: {}})
+
+	return nil
+}
+
+func (ad *Admin) CreateCategory(ctx context.Context, category port.CategoryAdminEntity) (port.CategoryAdminEntity, error) {
+	return ad.adminRepository.CreateCategory(ctx, category)
+}
+
+func (ad *Admin) AssignMCC(ctx context.Context, mcc port.MCCAdminEntity) (port.MCCAdminEntity, error) {
+	return ad.adminRepository.AssignMCC(ctx, mcc)
+}
@@ -0,0 +1,76 @@
+package redisRepos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+type Balance struct {
+	cacheConn database.InMemory
+
+	accountRepository port.AccountRepository
+}
+
+func NewRedisBalance(cacheConn database.InMemory, aRepository port.AccountRepository) (port.BalanceRepository, error) {
+	return &Balance{
+		cacheConn:         cacheConn,
+		accountRepository: aRepository,
+	}, nil
+}
+
+func (b *Balance) FindByAccountUID(ctx context.Context, uid uuid.UUID) (port.AccountEntity, error) {
+	var aEntity port.AccountEntity
+
+	balanceCached, err := b.cacheConn.Get(ctx, balanceCacheKey(uid))
+	if err == nil && json.Unmarshal([]byte(balanceCached), &aEntity) == nil {
+		return aEntity, nil
+	}
+
+	aEntity, err = b.accountRepository.FindByUID(ctx, uid)
+	if err != nil {
+		return aEntity, err
+	}
+
+	if aEntity.ID == 0 {
+		return aEntity, nil
+	}
+
+	expiration, err := b.cacheConn.GetDefaultExpiration(ctx)
+	if err != nil {
+		return aEntity, err
+	}
+
+	// The held amounts are released when a hold expires, with no write to invalidate the entry
+	if holdsExpireAt := aEntity.Balance.HoldsExpireAt; !holdsExpireAt.IsZero() {
+		expiration = min(expiration, time.Until(holdsExpireAt))
+		if expiration <= 0 {
+			return aEntity, nil
+		}
+	}
+
+	err = b.cacheConn.Set(ctx, balanceCacheKey(uid), aEntity, expiration)
+	if err != nil {
+		return aEntity, err
+	}
+
+	return aEntity, nil
+}
+
+func balanceCacheKey(uid uuid.UUID) string {
+	return fmt.Sprintf("balance:%s", uid.String())
+}
+
+/*
+- Failures are ignored: the entry still expires with the cache default expiration
+*/
+func invalidateBalances(ctx context.Context, cacheConn database.InMemory, uids map[uuid.UUID]struct{}) {
+	for uid := range uids {
+		_ = cacheConn.Delete(ctx, balanceCacheKey(uid))
+	}
+}
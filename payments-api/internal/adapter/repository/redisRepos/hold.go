@@ -0,0 +1,82 @@
+package redisRepos
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+/*
+  - Holds reduce the available balance, so every change evicts the cached
+    balance of the accounts involved
+*/
+type Hold struct {
+	cacheConn database.InMemory
+
+	holdRepository port.HoldRepository
+}
+
+func NewRedisHold(cacheConn database.InMemory, hRepository port.HoldRepository) (port.HoldRepository, error) {
+	return &Hold{
+		cacheConn:      cacheConn,
+		holdRepository: hRepository,
+	}, nil
+}
+
+func (h *Hold) SaveHolds(ctx context.Context, holds map[int]port.HoldEntity) error {
+	err := h.holdRepository.SaveHolds(ctx, holds)
+	if err != nil {
+		return err
+	}
+
+	invalidateBalances(ctx, h.cacheConn, holdsAccountUIDs(holds))
+
+	return nil
+}
+
+func (h *Hold) FindByUID(ctx context.Context, uid uuid.UUID) (map[int]port.HoldEntity, error) {
+	return h.holdRepository.FindByUID(ctx, uid)
+}
+
+func (h *Hold) Capture(ctx context.Context, uid uuid.UUID, transactions map[int]port.TransactionEntity, fencingToken int64) error {
+	err := h.holdRepository.Capture(ctx, uid, transactions, fencingToken)
+	if err != nil {
+		return err
+	}
+
+	accountUIDs := make(map[uuid.UUID]struct{})
+	for _, transaction := range transactions {
+		accountUIDs[transaction.AccountUID] = struct{}{}
+	}
+
+	invalidateBalances(ctx, h.cacheConn, accountUIDs)
+
+	return nil
+}
+
+func (h *Hold) Void(ctx context.Context, uid uuid.UUID) error {
+	holds, err := h.holdRepository.FindByUID(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	err = h.holdRepository.Void(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	invalidateBalances(ctx, h.cacheConn, holdsAccountUIDs(holds))
+
+	return nil
+}
+
+func holdsAccountUIDs(holds map[int]port.HoldEntity) map[uuid.UUID]struct{} {
+	accountUIDs := make(map[uuid.UUID]struct{})
+	for _, hold := range holds {
+		accountUIDs[hold.AccountUID] = struct{}{}
+	}
+
+	return accountUIDs
+}
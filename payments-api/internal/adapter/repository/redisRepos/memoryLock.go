@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strconv"
 	"time"
 
 	"github.com/jtonynet/go-payments-api/internal/adapter/database"
@@ -13,17 +12,41 @@ import (
 	"github.com/jtonynet/go-payments-api/internal/support/logger"
 )
 
+/*
+  - Waiters are woken by the releases and departures published on the account,
+    and by the expired keyevent of owners that never unlock. They only retry on
+    this interval for waiters that crashed at the head of the queue, pruned once
+    their waiting deadline lapses
+*/
+const waiterFallbackPollInterval = 250 * time.Millisecond
+
 type MemoryLock struct {
-	lockConn database.InMemory
-	pubsub   pubSub.PubSub
-	log      logger.Logger
+	lockConn               database.InMemory
+	store                  lockStore
+	watchdog               *leaseWatchdog
+	fencingTokenRepository port.FencingTokenRepository
+	pubsub                 pubSub.PubSub
+	log                    logger.Logger
 }
 
-func NewMemoryLock(lockConn database.InMemory, pubsub pubSub.PubSub, log logger.Logger) (port.MemoryLockRepository, error) {
+func NewMemoryLock(
+	lockConn database.InMemory,
+	ftRepository port.FencingTokenRepository,
+	pubsub pubSub.PubSub,
+	log logger.Logger,
+) (port.MemoryLockRepository, error) {
+	store, err := newLockStore(lockConn)
+	if err != nil {
+		return nil, err
+	}
+
 	return &MemoryLock{
-		lockConn: lockConn,
-		pubsub:   pubsub,
-		log:      log,
+		lockConn:               lockConn,
+		store:                  store,
+		watchdog:               newLeaseWatchdog(store, log),
+		fencingTokenRepository: ftRepository,
+		pubsub:                 pubsub,
+		log:                    log,
 	}, nil
 }
 
@@ -36,17 +59,20 @@ func (ml *MemoryLock) Lock(
 		return port.MemoryLockEntity{}, err
 	}
 
-	isUnlocked, _ := ml.isUnlocked(ctx, mle)
-	if isUnlocked {
-		ml.log.Debug(ctx, "Locked in distributed memory lock")
-
-		err = ml.lockConn.Set(ctx, mle.Key, mle.Timestamp, expiration)
-		if err != nil {
-			return port.MemoryLockEntity{}, err
-		}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		log.Fatalf("cannot acquire deadline from context")
+	}
 
-		return mle, nil
+	locked, err := ml.acquire(ctx, mle, expiration, time.Until(deadline))
+	if err != nil || locked.FencingToken != 0 {
+		return locked, err
 	}
+	defer func() {
+		if locked.FencingToken == 0 {
+			ml.leave(mle)
+		}
+	}()
 
 	accountTransactionKey := pubSub.Key{Account: mle.Key, Transaction: mle.Transcation}
 	unlockSubscription, err := ml.pubsub.Subscribe(context.Background(), accountTransactionKey)
@@ -57,58 +83,121 @@ func (ml *MemoryLock) Lock(
 		ml.pubsub.UnSubscribe(context.Background(), accountTransactionKey)
 	}()
 
-	deadline, ok := ctx.Deadline()
-	if !ok {
-		log.Fatalf("cannot acquire deadline from context")
+	// The lock may have been released between the first attempt and the subscription
+	locked, err = ml.acquire(ctx, mle, expiration, time.Until(deadline))
+	if err != nil || locked.FencingToken != 0 {
+		return locked, err
 	}
 
+	timeout := time.After(time.Until(deadline))
+
+	poll := time.NewTicker(waiterFallbackPollInterval)
+	defer poll.Stop()
+
 	for {
 		select {
 		case <-unlockSubscription:
-			err = ml.lockConn.Set(ctx, mle.Key, mle.Timestamp, expiration)
-			if err != nil {
-				return port.MemoryLockEntity{}, err
+			locked, err = ml.acquire(ctx, mle, expiration, time.Until(deadline))
+			if err != nil || locked.FencingToken != 0 {
+				return locked, err
 			}
-
-			ml.log.Debug(ctx, "Locked in distributed memory lock")
-			return mle, nil
-		case <-time.After(time.Until(deadline)):
-			return port.MemoryLockEntity{}, fmt.Errorf("timeout waiting for lock release on key: %s", mle.Key)
+		case <-poll.C:
+			locked, err = ml.acquire(ctx, mle, expiration, time.Until(deadline))
+			if err != nil || locked.FencingToken != 0 {
+				return locked, err
+			}
+		case <-timeout:
+			return port.MemoryLockEntity{}, fmt.Errorf("%w on key: %s", port.ErrMemoryLockTimeout, mle.Key)
 		case <-ctx.Done():
 			return port.MemoryLockEntity{}, ctx.Err()
 		}
 	}
-
 }
 
-func (ml *MemoryLock) Unlock(ctx context.Context, key string) error {
+func (ml *MemoryLock) Unlock(ctx context.Context, mle port.MemoryLockEntity) error {
+	ml.watchdog.stop(mle)
+
+	released, err := ml.store.release(ctx, mle)
+	if err != nil {
+		return err
+	}
+
+	if !released {
+		return fmt.Errorf("%w: %s on key %s", port.ErrMemoryLockNotOwned, mle.Transcation, mle.Key)
+	}
+
 	ml.log.Debug(ctx, "Unlocked in distributed memory lock")
-	return ml.lockConn.Expire(ctx, key, 0)
-}
 
-func (ml *MemoryLock) isUnlocked(ctx context.Context, mle port.MemoryLockEntity) (bool, error) {
-	locked, err := ml.get(ctx, mle.Key)
-	if err == nil {
-		elapsedTime := time.Now().UnixMilli() - locked.Timestamp
-		return false, fmt.Errorf("key is already locked by another process, held for %v ms", elapsedTime)
+	// Waiters still acquire on the expired fallback or on the slow polling when the release is not published
+	err = ml.pubsub.Publish(ctx, pubSub.ReleaseTopic(mle.Key), mle.Key)
+	if err != nil {
+		ml.log.Warn(ctx, fmt.Sprintf("failed to publish release on key %s: %v", mle.Key, err))
 	}
 
-	return true, nil
+	return nil
 }
 
-func (ml *MemoryLock) get(_ context.Context, key string) (port.MemoryLockEntity, error) {
-	timestampStr, err := ml.lockConn.Get(context.Background(), key)
+func (ml *MemoryLock) QueueDepth(ctx context.Context, key string) (int64, error) {
+	return ml.store.queueDepth(ctx, key)
+}
+
+/*
+  - Returns the entity with its `FencingToken` when acquired, or with a zero
+    `FencingToken` when the key is held by another transaction or by a waiter
+    that arrived first, keeping the transaction in the waiters queue during `wait`
+  - The lease of an acquired lock is renewed while `ctx` is alive
+  - A key whose fencing token counter is missing is acquired again with the
+    token written on its account, the floor of the counter
+*/
+func (ml *MemoryLock) acquire(
+	ctx context.Context,
+	mle port.MemoryLockEntity,
+	expiration time.Duration,
+	wait time.Duration,
+) (port.MemoryLockEntity, error) {
+	fencingToken, err := ml.store.acquire(ctx, mle, expiration, wait, unknownFencingToken)
 	if err != nil {
 		return port.MemoryLockEntity{}, err
 	}
 
-	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+	if fencingToken == unknownFencingToken {
+		floor, err := ml.fencingTokenRepository.FindFencingToken(ctx, mle.Key)
+		if err != nil {
+			return port.MemoryLockEntity{}, err
+		}
+
+		fencingToken, err = ml.store.acquire(ctx, mle, expiration, wait, floor)
+		if err != nil {
+			return port.MemoryLockEntity{}, err
+		}
+	}
+
+	if fencingToken == 0 {
+		return port.MemoryLockEntity{}, nil
+	}
+
+	ml.log.Debug(ctx, "Locked in distributed memory lock")
+
+	mle.FencingToken = fencingToken
+	ml.watchdog.watch(ctx, mle, expiration)
+
+	return mle, nil
+}
+
+/*
+  - Runs apart from the context of the transaction, that may be already done
+  - The departure is published as a release, as the waiter behind may be
+    the head of the queue of a key that is no longer held
+*/
+func (ml *MemoryLock) leave(mle port.MemoryLockEntity) {
+	err := ml.store.leave(context.Background(), mle)
 	if err != nil {
-		return port.MemoryLockEntity{}, err
+		ml.log.Warn(context.Background(), fmt.Sprintf("failed to leave waiters queue on key %s: %v", mle.Key, err))
+		return
 	}
 
-	return port.MemoryLockEntity{
-		Key:       key,
-		Timestamp: timestamp,
-	}, nil
+	err = ml.pubsub.Publish(context.Background(), pubSub.ReleaseTopic(mle.Key), mle.Key)
+	if err != nil {
+		ml.log.Warn(context.Background(), fmt.Sprintf("failed to publish departure on key %s: %v", mle.Key, err))
+	}
 }
@@ -0,0 +1,350 @@
+package redisRepos
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/redis/go-redis/v9"
+)
+
+/*
+  - Atomic steps of the memory lock over its lock source
+  - `acquire` returns the next fencing token of the key when the lock is acquired,
+    or zero when the key is held by another transaction or by a waiter that arrived
+    first, keeping the transaction in the waiters queue during `wait`
+  - The fencing token counter of the key is raised to `fencingFloor` when below
+    it. Without a known floor, `unknownFencingToken`, a key whose counter is
+    missing is not acquired and `unknownFencingToken` is returned
+  - `release` and `renew` only act on a lock owned by the transaction
+*/
+type lockStore interface {
+	acquire(ctx context.Context, mle port.MemoryLockEntity, expiration, wait time.Duration, fencingFloor int64) (int64, error)
+	release(ctx context.Context, mle port.MemoryLockEntity) (bool, error)
+	renew(ctx context.Context, mle port.MemoryLockEntity, expiration time.Duration) (bool, error)
+	leave(ctx context.Context, mle port.MemoryLockEntity) error
+	queueDepth(ctx context.Context, key string) (int64, error)
+}
+
+const unknownFencingToken = -1
+
+func newLockStore(lockConn database.InMemory) (lockStore, error) {
+	client, err := lockConn.GetClient(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	switch c := client.(type) {
+	case *database.MemoryClient:
+		return newProcessLockStore(c), nil
+	case *redis.ClusterClient:
+		return &scriptLockStore{client: c, hashTagged: true}, nil
+	case redis.Cmdable:
+		return &scriptLockStore{client: c}, nil
+	default:
+		return nil, fmt.Errorf("memory lock client not suported: %T", client)
+	}
+}
+
+/*
+  - Sets the lock owned by the transaction only when it does not exist and the
+    transaction is the first waiter of the key, handing out in the same step the
+    next fencing token of the key, above the floor `ARGV[4]` when one is known
+  - Otherwise the transaction joins the waiters queue, ordered by arrival, until
+    its waiting deadline, after which it is pruned from the head of the queue
+*/
+var acquireScript = redis.NewScript(`
+local now = redis.call('TIME')
+local nowMs = tonumber(now[1]) * 1000 + math.floor(tonumber(now[2]) / 1000)
+
+local head = redis.call('ZRANGE', KEYS[3], 0, 0)[1]
+while head and head ~= ARGV[1] and tonumber(redis.call('HGET', KEYS[4], head) or 0) < nowMs do
+	redis.call('ZREM', KEYS[3], head)
+	redis.call('HDEL', KEYS[4], head)
+	head = redis.call('ZRANGE', KEYS[3], 0, 0)[1]
+end
+
+local floor = tonumber(ARGV[4])
+if (not head or head == ARGV[1]) and floor < 0 and redis.call('EXISTS', KEYS[2]) == 0 then
+	return -1
+end
+
+if (not head or head == ARGV[1]) and redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	redis.call('ZREM', KEYS[3], ARGV[1])
+	redis.call('HDEL', KEYS[4], ARGV[1])
+	if floor > tonumber(redis.call('GET', KEYS[2]) or 0) then
+		redis.call('SET', KEYS[2], floor)
+	end
+	return redis.call('INCR', KEYS[2])
+end
+
+local wait = tonumber(ARGV[3])
+if wait > 0 and redis.call('ZADD', KEYS[3], 'NX', tonumber(now[1]) * 1000000 + tonumber(now[2]), ARGV[1]) == 1 then
+	redis.call('HSET', KEYS[4], ARGV[1], nowMs + wait)
+
+	local queueTTL = wait + tonumber(ARGV[2])
+	if redis.call('PTTL', KEYS[3]) < queueTTL then
+		redis.call('PEXPIRE', KEYS[3], queueTTL)
+		redis.call('PEXPIRE', KEYS[4], queueTTL)
+	end
+end
+
+return 0
+`)
+
+/*
+- Removes the transaction from the waiters queue when it gives up waiting
+*/
+var leaveScript = redis.NewScript(`
+redis.call('ZREM', KEYS[1], ARGV[1])
+redis.call('HDEL', KEYS[2], ARGV[1])
+return 1
+`)
+
+/*
+  - Deletes the lock only when owned by the transaction, without an expired
+    event, as the owner publishes the release to the transactions waiting for it
+*/
+var releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+/*
+- Extends the expiration of the lock only when still owned by the transaction
+*/
+var renewScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+/*
+- Runs each step as a Lua script, atomic across every instance
+*/
+type scriptLockStore struct {
+	client redis.Cmdable
+
+	// In a cluster the keys of a lock share its hash slot, as each script runs on a single shard
+	hashTagged bool
+}
+
+func (s *scriptLockStore) acquire(
+	ctx context.Context,
+	mle port.MemoryLockEntity,
+	expiration time.Duration,
+	wait time.Duration,
+	fencingFloor int64,
+) (int64, error) {
+	return acquireScript.Run(
+		ctx,
+		s.client,
+		[]string{
+			mle.Key,
+			fencingTokenKey(s.slotKey(mle.Key)),
+			waitersQueueKey(s.slotKey(mle.Key)),
+			waitersDeadlineKey(s.slotKey(mle.Key)),
+		},
+		mle.Transcation,
+		expiration.Milliseconds(),
+		wait.Milliseconds(),
+		fencingFloor,
+	).Int64()
+}
+
+func (s *scriptLockStore) release(ctx context.Context, mle port.MemoryLockEntity) (bool, error) {
+	released, err := releaseScript.Run(ctx, s.client, []string{mle.Key}, mle.Transcation).Int64()
+	return released == 1, err
+}
+
+func (s *scriptLockStore) renew(ctx context.Context, mle port.MemoryLockEntity, expiration time.Duration) (bool, error) {
+	renewed, err := renewScript.Run(
+		ctx,
+		s.client,
+		[]string{mle.Key},
+		mle.Transcation,
+		expiration.Milliseconds(),
+	).Int64()
+	return renewed == 1, err
+}
+
+func (s *scriptLockStore) leave(ctx context.Context, mle port.MemoryLockEntity) error {
+	return leaveScript.Run(
+		ctx,
+		s.client,
+		[]string{waitersQueueKey(s.slotKey(mle.Key)), waitersDeadlineKey(s.slotKey(mle.Key))},
+		mle.Transcation,
+	).Err()
+}
+
+func (s *scriptLockStore) queueDepth(ctx context.Context, key string) (int64, error) {
+	return s.client.ZCard(ctx, waitersQueueKey(s.slotKey(key))).Result()
+}
+
+/*
+  - A hash tag of the whole lock key places the derived keys in the slot of the
+    lock key, which is kept untagged so that its expired event carries the account
+*/
+func (s *scriptLockStore) slotKey(key string) string {
+	if s.hashTagged {
+		return fmt.Sprintf("{%s}", key)
+	}
+
+	return key
+}
+
+type processWaiter struct {
+	owner    string
+	deadline time.Time
+}
+
+/*
+  - Runs each step holding a mutex of the process, over the lock keys of the in
+    process database, whose expiration still notifies the waiters of crashed owners
+  - Fencing tokens and waiters queues live in the process, as the lock is only
+    shared by its transactions, and the tokens resume from the floor known on the
+    first acquisition of each key after a restart
+*/
+type processLockStore struct {
+	client *database.MemoryClient
+
+	mu            sync.Mutex
+	fencingTokens map[string]int64
+	waiters       map[string][]processWaiter
+}
+
+func newProcessLockStore(client *database.MemoryClient) *processLockStore {
+	return &processLockStore{
+		client:        client,
+		fencingTokens: make(map[string]int64),
+		waiters:       make(map[string][]processWaiter),
+	}
+}
+
+func (p *processLockStore) acquire(
+	ctx context.Context,
+	mle port.MemoryLockEntity,
+	expiration time.Duration,
+	wait time.Duration,
+	fencingFloor int64,
+) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	queue := p.waiters[mle.Key]
+	for len(queue) > 0 && queue[0].owner != mle.Transcation && queue[0].deadline.Before(now) {
+		queue = queue[1:]
+	}
+	p.waiters[mle.Key] = queue
+
+	if len(queue) == 0 || queue[0].owner == mle.Transcation {
+		if _, ok := p.fencingTokens[mle.Key]; !ok && fencingFloor < 0 {
+			return unknownFencingToken, nil
+		}
+
+		acquired, err := p.client.SetNX(ctx, mle.Key, mle.Transcation, expiration)
+		if err != nil {
+			return 0, err
+		}
+
+		if acquired {
+			p.removeWaiter(mle)
+			if fencingFloor > p.fencingTokens[mle.Key] {
+				p.fencingTokens[mle.Key] = fencingFloor
+			}
+			p.fencingTokens[mle.Key]++
+			return p.fencingTokens[mle.Key], nil
+		}
+	}
+
+	if wait > 0 && !p.isWaiting(mle) {
+		p.waiters[mle.Key] = append(p.waiters[mle.Key], processWaiter{owner: mle.Transcation, deadline: now.Add(wait)})
+	}
+
+	return 0, nil
+}
+
+func (p *processLockStore) release(ctx context.Context, mle port.MemoryLockEntity) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.isOwner(ctx, mle) {
+		return false, nil
+	}
+
+	return true, p.client.Delete(ctx, mle.Key)
+}
+
+func (p *processLockStore) renew(ctx context.Context, mle port.MemoryLockEntity, expiration time.Duration) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.isOwner(ctx, mle) {
+		return false, nil
+	}
+
+	return true, p.client.Expire(ctx, mle.Key, expiration)
+}
+
+func (p *processLockStore) leave(_ context.Context, mle port.MemoryLockEntity) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.removeWaiter(mle)
+	return nil
+}
+
+func (p *processLockStore) queueDepth(_ context.Context, key string) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return int64(len(p.waiters[key])), nil
+}
+
+func (p *processLockStore) isOwner(ctx context.Context, mle port.MemoryLockEntity) bool {
+	owner, err := p.client.Get(ctx, mle.Key)
+	return err == nil && owner == mle.Transcation
+}
+
+func (p *processLockStore) isWaiting(mle port.MemoryLockEntity) bool {
+	for _, waiter := range p.waiters[mle.Key] {
+		if waiter.owner == mle.Transcation {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *processLockStore) removeWaiter(mle port.MemoryLockEntity) {
+	queue := p.waiters[mle.Key]
+	for i, waiter := range queue {
+		if waiter.owner == mle.Transcation {
+			p.waiters[mle.Key] = append(queue[:i:i], queue[i+1:]...)
+			break
+		}
+	}
+
+	if len(p.waiters[mle.Key]) == 0 {
+		delete(p.waiters, mle.Key)
+	}
+}
+
+func fencingTokenKey(key string) string {
+	return fmt.Sprintf("%s:fencing", key)
+}
+
+func waitersQueueKey(key string) string {
+	return fmt.Sprintf("%s:waiters", key)
+}
+
+func waitersDeadlineKey(key string) string {
+	return fmt.Sprintf("%s:waiters:deadlines", key)
+}
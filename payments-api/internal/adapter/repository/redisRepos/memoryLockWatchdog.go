@@ -0,0 +1,100 @@
+package redisRepos
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/support/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// The lease is renewed three times within each expiration window
+const leaseRenewalsPerExpiration = 3
+
+var (
+	memoryLockLeaseRenewals = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "memory_lock_lease_renewals_total",
+			Help: "Total of memory lock leases extended while their transaction was running",
+		},
+	)
+
+	memoryLockLeasesLost = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "memory_lock_leases_lost_total",
+			Help: "Total of memory lock leases lost before their transaction unlocked",
+		},
+	)
+)
+
+/*
+  - Keeps the leases of the acquired locks alive while the context of their
+    transaction is alive, stopping on unlock or on context cancellation
+  - A renewal rejected because the lock is no longer owned by the transaction
+    means the lease was lost, and is reported through logs and metrics
+*/
+type leaseWatchdog struct {
+	store  lockStore
+	leases sync.Map
+	log    logger.Logger
+}
+
+func newLeaseWatchdog(store lockStore, log logger.Logger) *leaseWatchdog {
+	return &leaseWatchdog{
+		store: store,
+		log:   log,
+	}
+}
+
+func (lw *leaseWatchdog) watch(ctx context.Context, mle port.MemoryLockEntity, expiration time.Duration) {
+	watchCtx, stop := context.WithCancel(ctx)
+	lw.leases.Store(leaseKey(mle), stop)
+
+	go func() {
+		defer lw.leases.Delete(leaseKey(mle))
+		defer stop()
+
+		ticker := time.NewTicker(expiration / leaseRenewalsPerExpiration)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-watchCtx.Done():
+				return
+			case <-ticker.C:
+				renewed, err := lw.store.renew(watchCtx, mle, expiration)
+
+				if watchCtx.Err() != nil {
+					return
+				}
+
+				if err != nil {
+					lw.log.Warn(ctx, fmt.Sprintf("failed to renew memory lock lease on key %s: %v", mle.Key, err))
+					continue
+				}
+
+				if !renewed {
+					memoryLockLeasesLost.Inc()
+					lw.log.Error(ctx, fmt.Sprintf("memory lock lease lost on key %s with fencing token %d", mle.Key, mle.FencingToken))
+					return
+				}
+
+				memoryLockLeaseRenewals.Inc()
+			}
+		}
+	}()
+}
+
+func (lw *leaseWatchdog) stop(mle port.MemoryLockEntity) {
+	if stop, ok := lw.leases.LoadAndDelete(leaseKey(mle)); ok {
+		stop.(context.CancelFunc)()
+	}
+}
+
+func leaseKey(mle port.MemoryLockEntity) string {
+	return fmt.Sprintf("%s:%s", mle.Key, mle.Transcation)
+}
@@ -0,0 +1,325 @@
+package redisRepos
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/config"
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/adapter/pubSub"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+/*
+  - Runs the cached and lock repositories over the in process `memory` strategy,
+    without a Redis server
+*/
+type MemoryStrategySuite struct {
+	suite.Suite
+
+	cacheConn            database.InMemory
+	lockConn             database.InMemory
+	pubSubUnlock         pubSub.PubSub
+	fencingTokenRepo     *FencingTokenRepoFake
+	memoryLockRepository port.MemoryLockRepository
+}
+
+func (suite *MemoryStrategySuite) SetupSuite() {
+	cacheConn, err := database.NewInMemory(config.InMemoryDatabase{Strategy: "memory", DB: 0, Expiration: 50000})
+	if err != nil {
+		log.Fatalf("error: dont instantiate memory cache client: %v", err)
+	}
+
+	lockConn, err := database.NewInMemory(config.InMemoryDatabase{Strategy: "memory", DB: 1, Expiration: 100})
+	if err != nil {
+		log.Fatalf("error: dont instantiate memory lock client: %v", err)
+	}
+
+	pubSubUnlock, err := pubSub.New(config.PubSub{Strategy: "memory", DB: 1})
+	if err != nil {
+		log.Fatalf("error: dont instantiate memory pubsub client: %v", err)
+	}
+
+	fencingTokenRepo := newFencingTokenRepoFake()
+
+	memoryLockRepo, err := NewMemoryLock(lockConn, fencingTokenRepo, pubSubUnlock, newFakeLog())
+	if err != nil {
+		log.Fatalf("error: dont instantiate memory lock repository: %v", err)
+	}
+
+	suite.cacheConn = cacheConn
+	suite.lockConn = lockConn
+	suite.pubSubUnlock = pubSubUnlock
+	suite.fencingTokenRepo = fencingTokenRepo
+	suite.memoryLockRepository = memoryLockRepo
+}
+
+func (suite *MemoryStrategySuite) TestCachedMerchantFindByName() {
+	cachedMerchantRepo, err := NewRedisMerchant(suite.cacheConn, newMerchantRepoFake(newDBfake()))
+	assert.NoError(suite.T(), err)
+
+	merchant, err := cachedMerchantRepo.FindByName(context.Background(), merchantName)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "5412", merchant.MCC)
+
+	_, err = suite.cacheConn.Get(context.Background(), merchantCacheKey(merchantName))
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *MemoryStrategySuite) TestCachedMerchantFindByNameUnknownStaysMissed() {
+	cachedMerchantRepo, err := NewRedisMerchant(suite.cacheConn, newMerchantRepoFake(newDBfake()))
+	assert.NoError(suite.T(), err)
+
+	unknownName := "PADARIA DESCONHECIDA         SAO PAULO BR"
+
+	merchant, err := cachedMerchantRepo.FindByName(context.Background(), unknownName)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), merchant)
+
+	_, err = suite.cacheConn.Get(context.Background(), merchantCacheKey(unknownName))
+	assert.NoError(suite.T(), err)
+
+	merchant, err = cachedMerchantRepo.FindByName(context.Background(), unknownName)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), merchant)
+}
+
+func (suite *MemoryStrategySuite) TestCachedSpendingUsageCountsAddedUsage() {
+	spendingUsageRepoFake := &SpendingUsageRepoFake{}
+	cachedSpendingUsageRepo, err := NewRedisSpendingUsage(suite.cacheConn, spendingUsageRepoFake)
+	assert.NoError(suite.T(), err)
+
+	ctx := context.Background()
+	at := time.Now()
+
+	_, err = cachedSpendingUsageRepo.FindUsage(ctx, 1, 2, at)
+	assert.NoError(suite.T(), err)
+
+	err = cachedSpendingUsageRepo.AddUsage(ctx, port.SpendingUsageEntity{
+		AccountID:          1,
+		CategoryID:         2,
+		At:                 at,
+		DailyAmount:        decimal.NewFromFloat(10.5),
+		MonthlyAmount:      decimal.NewFromFloat(10.5),
+		HourlyTransactions: 1,
+	})
+	assert.NoError(suite.T(), err)
+
+	usage, err := cachedSpendingUsageRepo.FindUsage(ctx, 1, 2, at)
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), 1, spendingUsageRepoFake.findUsageCalls)
+	assert.Equal(suite.T(), "40.5", usage.DailyAmount.String())
+	assert.Equal(suite.T(), "130.5", usage.MonthlyAmount.String())
+	assert.Equal(suite.T(), 3, usage.HourlyTransactions)
+}
+
+func (suite *MemoryStrategySuite) TestCachedBalanceExpiresWithNearestHold() {
+	accountRepoFake := &AccountRepoFake{holdsExpireAt: time.Now().Add(200 * time.Millisecond)}
+	cachedBalanceRepo, err := NewRedisBalance(suite.cacheConn, accountRepoFake)
+	assert.NoError(suite.T(), err)
+
+	ctx := context.Background()
+	uid := uuid.New()
+
+	_, err = cachedBalanceRepo.FindByAccountUID(ctx, uid)
+	assert.NoError(suite.T(), err)
+
+	_, err = cachedBalanceRepo.FindByAccountUID(ctx, uid)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, accountRepoFake.findByUIDCalls)
+
+	time.Sleep(400 * time.Millisecond)
+
+	_, err = cachedBalanceRepo.FindByAccountUID(ctx, uid)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, accountRepoFake.findByUIDCalls)
+}
+
+func (suite *MemoryStrategySuite) TestMemoryLockOnlyOwnerUnlocks() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	key := uuid.New().String()
+
+	owner := port.MemoryLockEntity{Key: key, Transcation: uuid.New().String()}
+	locked, err := suite.memoryLockRepository.Lock(ctx, owner)
+	assert.NoError(suite.T(), err)
+
+	intruder := port.MemoryLockEntity{Key: key, Transcation: uuid.New().String()}
+	assert.ErrorIs(suite.T(), suite.memoryLockRepository.Unlock(ctx, intruder), port.ErrMemoryLockNotOwned)
+
+	assert.NoError(suite.T(), suite.memoryLockRepository.Unlock(ctx, locked))
+
+	relocked, err := suite.memoryLockRepository.Lock(ctx, intruder)
+	assert.NoError(suite.T(), err)
+	assert.Greater(suite.T(), relocked.FencingToken, locked.FencingToken)
+	assert.NoError(suite.T(), suite.memoryLockRepository.Unlock(ctx, relocked))
+}
+
+func (suite *MemoryStrategySuite) TestMemoryLockFencingTokenResumesFromAccount() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	key := uuid.New().String()
+	suite.fencingTokenRepo.fencingTokens[key] = 41
+	calls := suite.fencingTokenRepo.calls
+
+	locked, err := suite.memoryLockRepository.Lock(ctx, port.MemoryLockEntity{Key: key, Transcation: uuid.New().String()})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(42), locked.FencingToken)
+	assert.NoError(suite.T(), suite.memoryLockRepository.Unlock(ctx, locked))
+
+	relocked, err := suite.memoryLockRepository.Lock(ctx, port.MemoryLockEntity{Key: key, Transcation: uuid.New().String()})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(43), relocked.FencingToken)
+	assert.Equal(suite.T(), calls+1, suite.fencingTokenRepo.calls)
+	assert.NoError(suite.T(), suite.memoryLockRepository.Unlock(ctx, relocked))
+}
+
+func (suite *MemoryStrategySuite) TestMemoryLockUnlockPublishesRelease() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	key := uuid.New().String()
+
+	locked, err := suite.memoryLockRepository.Lock(ctx, port.MemoryLockEntity{Key: key, Transcation: uuid.New().String()})
+	assert.NoError(suite.T(), err)
+
+	observer := pubSub.Key{Account: key, Transaction: uuid.New().String()}
+	released, err := suite.pubSubUnlock.Subscribe(ctx, observer)
+	assert.NoError(suite.T(), err)
+	defer suite.pubSubUnlock.UnSubscribe(ctx, observer)
+
+	assert.NoError(suite.T(), suite.memoryLockRepository.Unlock(ctx, locked))
+
+	select {
+	case accountUID := <-released:
+		assert.Equal(suite.T(), key, accountUID)
+	case <-time.After(50 * time.Millisecond):
+		suite.T().Fatal("release not published on unlock")
+	}
+}
+
+func (suite *MemoryStrategySuite) TestMemoryLockLeavePublishesRelease() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	key := uuid.New().String()
+
+	locked, err := suite.memoryLockRepository.Lock(ctx, port.MemoryLockEntity{Key: key, Transcation: uuid.New().String()})
+	assert.NoError(suite.T(), err)
+
+	observer := pubSub.Key{Account: key, Transaction: uuid.New().String()}
+	released, err := suite.pubSubUnlock.Subscribe(ctx, observer)
+	assert.NoError(suite.T(), err)
+	defer suite.pubSubUnlock.UnSubscribe(ctx, observer)
+
+	// The waiter gives up before the owner unlocks, leaving the queue
+	waiterCtx, waiterCancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer waiterCancel()
+
+	_, err = suite.memoryLockRepository.Lock(waiterCtx, port.MemoryLockEntity{Key: key, Transcation: uuid.New().String()})
+	assert.Error(suite.T(), err)
+
+	select {
+	case accountUID := <-released:
+		assert.Equal(suite.T(), key, accountUID)
+	case <-time.After(50 * time.Millisecond):
+		suite.T().Fatal("departure not published on leave")
+	}
+
+	assert.NoError(suite.T(), suite.memoryLockRepository.Unlock(ctx, locked))
+}
+
+func (suite *MemoryStrategySuite) TestMemoryLockGrantedOnExpiredNotification() {
+	key := uuid.New().String()
+
+	// The lease of the owner is not renewed once its context is done
+	ownerCtx, ownerCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer ownerCancel()
+
+	locked, err := suite.memoryLockRepository.Lock(ownerCtx, port.MemoryLockEntity{Key: key, Transcation: uuid.New().String()})
+	assert.NoError(suite.T(), err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	waiter := port.MemoryLockEntity{Key: key, Transcation: uuid.New().String()}
+	waiterLocked, err := suite.memoryLockRepository.Lock(ctx, waiter)
+	assert.NoError(suite.T(), err)
+	assert.Greater(suite.T(), waiterLocked.FencingToken, locked.FencingToken)
+	assert.NoError(suite.T(), suite.memoryLockRepository.Unlock(ctx, waiterLocked))
+}
+
+func (suite *MemoryStrategySuite) TestMemoryLockGrantedInArrivalOrder() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	key := uuid.New().String()
+
+	locked, err := suite.memoryLockRepository.Lock(ctx, port.MemoryLockEntity{Key: key, Transcation: uuid.New().String()})
+	assert.NoError(suite.T(), err)
+
+	granted := make(chan string, 3)
+	waiters := []port.MemoryLockEntity{
+		{Key: key, Transcation: uuid.New().String()},
+		{Key: key, Transcation: uuid.New().String()},
+		{Key: key, Transcation: uuid.New().String()},
+	}
+
+	for _, waiter := range waiters {
+		go func(waiter port.MemoryLockEntity) {
+			waiterLocked, err := suite.memoryLockRepository.Lock(ctx, waiter)
+			if err != nil {
+				granted <- err.Error()
+				return
+			}
+
+			granted <- waiterLocked.Transcation
+			suite.memoryLockRepository.Unlock(ctx, waiterLocked)
+		}(waiter)
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	depth, err := suite.memoryLockRepository.QueueDepth(ctx, key)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(3), depth)
+
+	assert.NoError(suite.T(), suite.memoryLockRepository.Unlock(ctx, locked))
+
+	for _, waiter := range waiters {
+		assert.Equal(suite.T(), waiter.Transcation, <-granted)
+	}
+}
+
+func (suite *MemoryStrategySuite) TestMemoryLockLeaseRenewed() {
+	expiration, err := suite.lockConn.GetDefaultExpiration(context.Background())
+	assert.NoError(suite.T(), err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*expiration)
+	defer cancel()
+
+	key := uuid.New().String()
+
+	locked, err := suite.memoryLockRepository.Lock(ctx, port.MemoryLockEntity{Key: key, Transcation: uuid.New().String()})
+	assert.NoError(suite.T(), err)
+
+	time.Sleep(3 * expiration)
+
+	_, err = suite.lockConn.Get(context.Background(), key)
+	assert.NoError(suite.T(), err)
+
+	assert.NoError(suite.T(), suite.memoryLockRepository.Unlock(ctx, locked))
+}
+
+func TestMemoryStrategySuite(t *testing.T) {
+	suite.Run(t, new(MemoryStrategySuite))
+}
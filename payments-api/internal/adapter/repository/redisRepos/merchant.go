@@ -2,7 +2,9 @@ package redisRepos
 
 import (
 	"context"
+	"encoding/json"
 
+	"github.com/google/uuid"
 	"github.com/jtonynet/go-payments-api/internal/adapter/database"
 	"github.com/jtonynet/go-payments-api/internal/core/port"
 
@@ -25,7 +27,7 @@ func NewRedisMerchant(cacheConn database.InMemory, mRepository port.MerchantRepo
 func (m *Merchant) FindByName(_ context.Context, name string) (*port.MerchantEntity, error) {
 	var mEntity *port.MerchantEntity
 
-	merchantCached, err := m.cacheConn.Get(context.Background(), name)
+	merchantCached, err := m.cacheConn.Get(context.Background(), merchantCacheKey(name))
 	if err != nil {
 		mEntity, err = m.merchantRepository.FindByName(context.Background(), name)
 		if err != nil {
@@ -37,11 +39,14 @@ func (m *Merchant) FindByName(_ context.Context, name string) (*port.MerchantEnt
 			return mEntity, err
 		}
 
-		err = m.cacheConn.Set(context.Background(), name, mEntity, defaultExpiration)
+		err = m.cacheConn.Set(context.Background(), merchantCacheKey(name), mEntity, defaultExpiration)
 		if err != nil {
 			return mEntity, err
 		}
 
+	} else if !gjson.Parse(merchantCached).IsObject() {
+		// An unknown name is cached as null, missed again without reaching the database
+		return nil, nil
 	} else {
 		mEntity = &port.MerchantEntity{
 			Name: gjson.Get(merchantCached, "Name").String(),
@@ -52,3 +57,116 @@ func (m *Merchant) FindByName(_ context.Context, name string) (*port.MerchantEnt
 
 	return mEntity, nil
 }
+
+/*
+  - The catalog is cached as a whole, it is matched by the domain rules on
+    every name the exact lookup misses
+*/
+func (m *Merchant) FindAll(ctx context.Context) ([]port.MerchantEntity, error) {
+	var mEntities []port.MerchantEntity
+
+	catalogCached, err := m.cacheConn.Get(ctx, MERCHANT_CATALOG_CACHE_KEY)
+	if err == nil && json.Unmarshal([]byte(catalogCached), &mEntities) == nil {
+		return mEntities, nil
+	}
+
+	mEntities, err = m.merchantRepository.FindAll(ctx)
+	if err != nil {
+		return mEntities, err
+	}
+
+	defaultExpiration, err := m.cacheConn.GetDefaultExpiration(ctx)
+	if err != nil {
+		return mEntities, err
+	}
+
+	err = m.cacheConn.Set(ctx, MERCHANT_CATALOG_CACHE_KEY, mEntities, defaultExpiration)
+	if err != nil {
+		return mEntities, err
+	}
+
+	return mEntities, nil
+}
+
+const MERCHANT_CATALOG_CACHE_KEY = "merchants:catalog"
+
+func merchantCacheKey(name string) string {
+	return name
+}
+
+/*
+  - Evicts the cached lookup of every name a write touches. Unknown names are cached
+    too, so a created merchant must be evicted as well to correct the MCC right away.
+  - The merchant catalog is evicted on every write, aliases included
+*/
+type MerchantRegistry struct {
+	cacheConn database.InMemory
+
+	merchantRegistryRepository port.MerchantRegistryRepository
+}
+
+func NewRedisMerchantRegistry(cacheConn database.InMemory, mrRepository port.MerchantRegistryRepository) (port.MerchantRegistryRepository, error) {
+	return &MerchantRegistry{
+		cacheConn:                  cacheConn,
+		merchantRegistryRepository: mrRepository,
+	}, nil
+}
+
+func (mr *MerchantRegistry) CreateMerchant(ctx context.Context, merchant port.MerchantRegistryEntity) (port.MerchantRegistryEntity, error) {
+	merchantCreated, err := mr.merchantRegistryRepository.CreateMerchant(ctx, merchant)
+	if err != nil {
+		return merchantCreated, err
+	}
+
+	mr.evict(ctx, merchantCreated.Name)
+
+	return merchantCreated, nil
+}
+
+func (mr *MerchantRegistry) FindMerchantByUID(ctx context.Context, uid uuid.UUID) (port.MerchantRegistryEntity, error) {
+	return mr.merchantRegistryRepository.FindMerchantByUID(ctx, uid)
+}
+
+func (mr *MerchantRegistry) FindMerchants(ctx context.Context, filter port.MerchantListFilterEntity) ([]port.MerchantRegistryEntity, error) {
+	return mr.merchantRegistryRepository.FindMerchants(ctx, filter)
+}
+
+func (mr *MerchantRegistry) UpdateMerchant(ctx context.Context, merchant port.MerchantRegistryEntity) (port.MerchantRegistryEntity, error) {
+	merchantPrevious, err := mr.merchantRegistryRepository.FindMerchantByUID(ctx, merchant.UID)
+	if err != nil {
+		return port.MerchantRegistryEntity{}, err
+	}
+
+	merchantUpdated, err := mr.merchantRegistryRepository.UpdateMerchant(ctx, merchant)
+	if err != nil {
+		return merchantUpdated, err
+	}
+
+	mr.evict(ctx, merchantPrevious.Name, merchantUpdated.Name)
+
+	return merchantUpdated, nil
+}
+
+func (mr *MerchantRegistry) DeleteMerchant(ctx context.Context, uid uuid.UUID) error {
+	merchantPrevious, err := mr.merchantRegistryRepository.FindMerchantByUID(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	err = mr.merchantRegistryRepository.DeleteMerchant(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	mr.evict(ctx, merchantPrevious.Name)
+
+	return nil
+}
+
+func (mr *MerchantRegistry) evict(ctx context.Context, names ...string) {
+	_ = mr.cacheConn.Delete(ctx, MERCHANT_CATALOG_CACHE_KEY)
+
+	for _, name := range names {
+		_ = mr.cacheConn.Delete(ctx, merchantCacheKey(name))
+	}
+}
@@ -4,23 +4,36 @@ import (
 	"context"
 	"log"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jtonynet/go-payments-api/config"
 	"github.com/jtonynet/go-payments-api/internal/adapter/database"
 	"github.com/jtonynet/go-payments-api/internal/adapter/pubSub"
 	"github.com/jtonynet/go-payments-api/internal/core/port"
 	"github.com/jtonynet/go-payments-api/internal/support/logger"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
-var merchantName = "XYZ*TestCachedRepositoryMerchant                   PIRAPORINHA BR"
+var (
+	merchantName = "XYZ*TestCachedRepositoryMerchant                   PIRAPORINHA BR"
+
+	balanceAccountUID, _ = uuid.Parse("9c1d8a8e-8a52-4d3d-9b0f-3b0a7d1f6c11")
+	lockAccountUID, _    = uuid.Parse("5f2b7c3e-1d4a-4e8b-9c6f-2a7e8d9b0c12")
+)
 
 type RedisReposSuite struct {
 	suite.Suite
 
-	cacheConn          database.InMemory
-	cachedMerchantRepo port.MerchantRepository
+	cacheConn                    database.InMemory
+	cachedMerchantRepo           port.MerchantRepository
+	evictingMerchantRegistryRepo port.MerchantRegistryRepository
+
+	accountRepo                    *AccountRepoFake
+	cachedBalanceRepo              port.BalanceRepository
+	balanceInvalidatingAccountRepo port.AccountRepository
 
 	lockConn             database.InMemory
 	memoryLockRepository port.MemoryLockRepository
@@ -69,6 +82,15 @@ func (m *MerchantRepoFake) FindByName(_ context.Context, name string) (*port.Mer
 	return MerchantEntity, err
 }
 
+func (m *MerchantRepoFake) FindAll(_ context.Context) ([]port.MerchantEntity, error) {
+	merchants := []port.MerchantEntity{}
+	for _, merchant := range m.db.Merchant {
+		merchants = append(merchants, merchant)
+	}
+
+	return merchants, nil
+}
+
 func (dbf *DBfake) MerchantRepoFindByName(Name string) (*port.MerchantEntity, error) {
 
 	for _, m := range dbf.Merchant {
@@ -80,6 +102,109 @@ func (dbf *DBfake) MerchantRepoFindByName(Name string) (*port.MerchantEntity, er
 	return nil, nil
 }
 
+type MerchantRegistryRepoFake struct {
+	merchant port.MerchantRegistryEntity
+}
+
+func (mrf *MerchantRegistryRepoFake) CreateMerchant(_ context.Context, merchant port.MerchantRegistryEntity) (port.MerchantRegistryEntity, error) {
+	return merchant, nil
+}
+
+func (mrf *MerchantRegistryRepoFake) FindMerchantByUID(_ context.Context, _ uuid.UUID) (port.MerchantRegistryEntity, error) {
+	return mrf.merchant, nil
+}
+
+func (mrf *MerchantRegistryRepoFake) FindMerchants(_ context.Context, _ port.MerchantListFilterEntity) ([]port.MerchantRegistryEntity, error) {
+	return []port.MerchantRegistryEntity{mrf.merchant}, nil
+}
+
+func (mrf *MerchantRegistryRepoFake) UpdateMerchant(_ context.Context, merchant port.MerchantRegistryEntity) (port.MerchantRegistryEntity, error) {
+	return merchant, nil
+}
+
+func (mrf *MerchantRegistryRepoFake) DeleteMerchant(_ context.Context, _ uuid.UUID) error {
+	return nil
+}
+
+type AccountRepoFake struct {
+	findByUIDCalls int
+	holdsExpireAt  time.Time
+}
+
+func (a *AccountRepoFake) FindByUID(_ context.Context, uid uuid.UUID) (port.AccountEntity, error) {
+	a.findByUIDCalls++
+
+	return port.AccountEntity{
+		ID:  1,
+		UID: uid,
+		Balance: port.BalanceEntity{
+			AmountTotal: decimal.NewFromFloat(205.11),
+			Categories: map[int]port.TransactionByCategoryEntity{
+				1: {
+					ID:       1,
+					Amount:   decimal.NewFromFloat(205.11),
+					Category: port.CategoryEntity{ID: 1, Name: "FOOD", MCCs: []string{"5411", "5412"}, Priority: 1},
+				},
+			},
+			HoldsExpireAt: a.holdsExpireAt,
+		},
+	}, nil
+}
+
+func (a *AccountRepoFake) FindTransactionsByUID(_ context.Context, _ uuid.UUID) (map[int]port.TransactionCapturedEntity, error) {
+	return make(map[int]port.TransactionCapturedEntity), nil
+}
+
+func (a *AccountRepoFake) FindTransactionsByAccountUID(_ context.Context, _ port.TransactionHistoryFilterEntity) ([]port.TransactionHistoryEntity, error) {
+	return []port.TransactionHistoryEntity{}, nil
+}
+
+func (a *AccountRepoFake) SaveTransactions(_ context.Context, _ map[int]port.TransactionEntity, _ int64) error {
+	return nil
+}
+
+type SpendingUsageRepoFake struct {
+	findUsageCalls int
+}
+
+func (su *SpendingUsageRepoFake) FindUsage(_ context.Context, accountID, categoryID uint, at time.Time) (port.SpendingUsageEntity, error) {
+	su.findUsageCalls++
+
+	return port.SpendingUsageEntity{
+		AccountID:          accountID,
+		CategoryID:         categoryID,
+		At:                 at,
+		DailyAmount:        decimal.NewFromFloat(30),
+		MonthlyAmount:      decimal.NewFromFloat(120),
+		HourlyTransactions: 2,
+	}, nil
+}
+
+func (su *SpendingUsageRepoFake) AddUsage(_ context.Context, _ port.SpendingUsageEntity) error {
+	return nil
+}
+
+func (su *SpendingUsageRepoFake) ResetUsage(_ context.Context, _, _ uint, _ time.Time) error {
+	return nil
+}
+
+/*
+- Fencing tokens written on the accounts, by account UID
+*/
+type FencingTokenRepoFake struct {
+	fencingTokens map[string]int64
+	calls         int
+}
+
+func newFencingTokenRepoFake() *FencingTokenRepoFake {
+	return &FencingTokenRepoFake{fencingTokens: make(map[string]int64)}
+}
+
+func (ftrf *FencingTokenRepoFake) FindFencingToken(_ context.Context, key string) (int64, error) {
+	ftrf.calls++
+	return ftrf.fencingTokens[key], nil
+}
+
 func (suite *RedisReposSuite) SetupSuite() {
 	cfg, err := config.LoadConfig("./../../../../")
 	if err != nil {
@@ -96,6 +221,7 @@ func (suite *RedisReposSuite) SetupSuite() {
 	}
 
 	cacheConn.Delete(context.Background(), merchantName)
+	cacheConn.Delete(context.Background(), MERCHANT_CATALOG_CACHE_KEY)
 
 	dbFake := newDBfake()
 	merchantRepo := newMerchantRepoFake(dbFake)
@@ -105,8 +231,35 @@ func (suite *RedisReposSuite) SetupSuite() {
 		log.Fatalf("error: dont instantiate merchant cached repository: %v", err)
 	}
 
+	evictingMerchantRegistryRepo, err := NewRedisMerchantRegistry(
+		cacheConn,
+		&MerchantRegistryRepoFake{merchant: port.MerchantRegistryEntity{UID: uuid.New(), Name: merchantName, MCC: "5412"}},
+	)
+	if err != nil {
+		log.Fatalf("error: dont instantiate merchant registry evicting repository: %v", err)
+	}
+
 	suite.cacheConn = cacheConn
 	suite.cachedMerchantRepo = cachedMerchantRepo
+	suite.evictingMerchantRegistryRepo = evictingMerchantRegistryRepo
+
+	cacheConn.Delete(context.Background(), balanceCacheKey(balanceAccountUID))
+
+	accountRepo := &AccountRepoFake{}
+
+	cachedBalanceRepo, err := NewRedisBalance(cacheConn, accountRepo)
+	if err != nil {
+		log.Fatalf("error: dont instantiate balance cached repository: %v", err)
+	}
+
+	invalidatingAccountRepo, err := NewRedisAccount(cacheConn, accountRepo)
+	if err != nil {
+		log.Fatalf("error: dont instantiate balance invalidating account repository: %v", err)
+	}
+
+	suite.accountRepo = accountRepo
+	suite.cachedBalanceRepo = cachedBalanceRepo
+	suite.balanceInvalidatingAccountRepo = invalidatingAccountRepo
 
 	lockConn, err := database.NewInMemory(cfg.Lock.ToInMemoryDatabase())
 	if err != nil {
@@ -122,7 +275,7 @@ func (suite *RedisReposSuite) SetupSuite() {
 		log.Fatalf("error: dont instantiate pubsub client: %v", err)
 	}
 
-	memoryLockRepo, err := NewMemoryLock(lockConn, pubSubUnlock, newFakeLog())
+	memoryLockRepo, err := NewMemoryLock(lockConn, newFencingTokenRepoFake(), pubSubUnlock, newFakeLog())
 	if err != nil {
 		log.Fatalf("error: dont instantiate memory lock repository: %v", err)
 	}
@@ -133,6 +286,7 @@ func (suite *RedisReposSuite) SetupSuite() {
 
 func (suite *RedisReposSuite) TearDownSuite() {
 	suite.cacheConn.Delete(context.Background(), merchantName)
+	suite.cacheConn.Delete(context.Background(), balanceCacheKey(balanceAccountUID))
 }
 
 func (suite *RedisReposSuite) MerchantRepositoryFindByNameNotCached() {
@@ -156,11 +310,156 @@ func (suite *RedisReposSuite) MerchantRepositoryFindByNameCached() {
 	assert.NotNil(suite.T(), merchantEntity)
 }
 
+func (suite *RedisReposSuite) MerchantRepositoryFindAllCached() {
+	merchantEntities, err := suite.cachedMerchantRepo.FindAll(context.Background())
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), merchantEntities, 1)
+
+	_, err = suite.cacheConn.Get(context.Background(), MERCHANT_CATALOG_CACHE_KEY)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RedisReposSuite) MerchantRegistryRepositoryEvictedAfterUpdate() {
+	_, err := suite.cacheConn.Get(context.Background(), merchantCacheKey(merchantName))
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.evictingMerchantRegistryRepo.UpdateMerchant(
+		context.Background(),
+		port.MerchantRegistryEntity{UID: uuid.New(), Name: merchantName, MCC: "5411"},
+	)
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.cacheConn.Get(context.Background(), merchantCacheKey(merchantName))
+	assert.EqualError(suite.T(), err, "redis: nil")
+
+	_, err = suite.cacheConn.Get(context.Background(), MERCHANT_CATALOG_CACHE_KEY)
+	assert.EqualError(suite.T(), err, "redis: nil")
+}
+
+func (suite *RedisReposSuite) BalanceRepositoryFindByAccountUIDReadThrough() {
+	calls := suite.accountRepo.findByUIDCalls
+
+	notCached, err := suite.cachedBalanceRepo.FindByAccountUID(context.Background(), balanceAccountUID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), calls+1, suite.accountRepo.findByUIDCalls)
+
+	cached, err := suite.cachedBalanceRepo.FindByAccountUID(context.Background(), balanceAccountUID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), calls+1, suite.accountRepo.findByUIDCalls)
+	assert.True(suite.T(), cached.Balance.AmountTotal.Equal(notCached.Balance.AmountTotal))
+	assert.Equal(suite.T(), cached.Balance.Categories[1].Category.MCCs, []string{"5411", "5412"})
+}
+
+func (suite *RedisReposSuite) BalanceRepositoryInvalidatedAfterSaveTransactions() {
+	_, err := suite.cacheConn.Get(context.Background(), balanceCacheKey(balanceAccountUID))
+	assert.NoError(suite.T(), err)
+
+	err = suite.balanceInvalidatingAccountRepo.SaveTransactions(
+		context.Background(),
+		map[int]port.TransactionEntity{1: {AccountID: 1, AccountUID: balanceAccountUID, CategoryID: 1}},
+		1,
+	)
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.cacheConn.Get(context.Background(), balanceCacheKey(balanceAccountUID))
+	assert.EqualError(suite.T(), err, "redis: nil")
+}
+
 func (suite *RedisReposSuite) MemoryLockRepoLockSuccesfulLock() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	mle := port.MemoryLockEntity{Key: lockAccountUID.String(), Transcation: uuid.New().String()}
 
+	first, err := suite.memoryLockRepository.Lock(ctx, mle)
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.memoryLockRepository.Unlock(ctx, first))
+
+	second, err := suite.memoryLockRepository.Lock(ctx, mle)
+	assert.NoError(suite.T(), err)
+	assert.Greater(suite.T(), second.FencingToken, first.FencingToken)
+	assert.NoError(suite.T(), suite.memoryLockRepository.Unlock(ctx, second))
 }
 
-func (suite *RedisReposSuite) MemoryLockRepoLockNotSuccesfulLock() {}
+func (suite *RedisReposSuite) MemoryLockRepoLockNotSuccesfulLock() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	owner := port.MemoryLockEntity{Key: lockAccountUID.String(), Transcation: uuid.New().String()}
+	locked, err := suite.memoryLockRepository.Lock(ctx, owner)
+	assert.NoError(suite.T(), err)
+
+	intruder := port.MemoryLockEntity{Key: lockAccountUID.String(), Transcation: uuid.New().String()}
+	err = suite.memoryLockRepository.Unlock(ctx, intruder)
+	assert.ErrorIs(suite.T(), err, port.ErrMemoryLockNotOwned)
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer waitCancel()
+
+	_, err = suite.memoryLockRepository.Lock(waitCtx, intruder)
+	assert.Error(suite.T(), err)
+
+	assert.NoError(suite.T(), suite.memoryLockRepository.Unlock(ctx, locked))
+}
+
+func (suite *RedisReposSuite) MemoryLockRepoLockLeaseRenewed() {
+	expiration, err := suite.lockConn.GetDefaultExpiration(context.Background())
+	assert.NoError(suite.T(), err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*expiration)
+	defer cancel()
+
+	owner := port.MemoryLockEntity{Key: lockAccountUID.String(), Transcation: uuid.New().String()}
+	locked, err := suite.memoryLockRepository.Lock(ctx, owner)
+	assert.NoError(suite.T(), err)
+
+	time.Sleep(3 * expiration)
+
+	// Still held by the owner after outliving its expiration
+	_, err = suite.lockConn.Get(context.Background(), lockAccountUID.String())
+	assert.NoError(suite.T(), err)
+
+	assert.NoError(suite.T(), suite.memoryLockRepository.Unlock(ctx, locked))
+}
+
+func (suite *RedisReposSuite) MemoryLockRepoLockGrantedInArrivalOrder() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	owner := port.MemoryLockEntity{Key: lockAccountUID.String(), Transcation: uuid.New().String()}
+	locked, err := suite.memoryLockRepository.Lock(ctx, owner)
+	assert.NoError(suite.T(), err)
+
+	granted := make(chan string, 2)
+	waiters := []port.MemoryLockEntity{
+		{Key: lockAccountUID.String(), Transcation: uuid.New().String()},
+		{Key: lockAccountUID.String(), Transcation: uuid.New().String()},
+	}
+
+	for _, waiter := range waiters {
+		go func(waiter port.MemoryLockEntity) {
+			waiterLocked, err := suite.memoryLockRepository.Lock(ctx, waiter)
+			if err != nil {
+				granted <- err.Error()
+				return
+			}
+
+			granted <- waiterLocked.Transcation
+			suite.memoryLockRepository.Unlock(ctx, waiterLocked)
+		}(waiter)
+
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	depth, err := suite.memoryLockRepository.QueueDepth(ctx, lockAccountUID.String())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), depth)
+
+	assert.NoError(suite.T(), suite.memoryLockRepository.Unlock(ctx, locked))
+
+	assert.Equal(suite.T(), waiters[0].Transcation, <-granted)
+	assert.Equal(suite.T(), waiters[1].Transcation, <-granted)
+}
 
 func TestRedisReposSuite(t *testing.T) {
 	suite.Run(t, new(RedisReposSuite))
@@ -174,4 +473,36 @@ func (suite *RedisReposSuite) TestCases() {
 	suite.T().Run("TestMerchantRepositoryFindByNameCached", func(t *testing.T) {
 		suite.MerchantRepositoryFindByNameCached()
 	})
+
+	suite.T().Run("TestMerchantRepositoryFindAllCached", func(t *testing.T) {
+		suite.MerchantRepositoryFindAllCached()
+	})
+
+	suite.T().Run("TestMerchantRegistryRepositoryEvictedAfterUpdate", func(t *testing.T) {
+		suite.MerchantRegistryRepositoryEvictedAfterUpdate()
+	})
+
+	suite.T().Run("TestBalanceRepositoryFindByAccountUIDReadThrough", func(t *testing.T) {
+		suite.BalanceRepositoryFindByAccountUIDReadThrough()
+	})
+
+	suite.T().Run("TestBalanceRepositoryInvalidatedAfterSaveTransactions", func(t *testing.T) {
+		suite.BalanceRepositoryInvalidatedAfterSaveTransactions()
+	})
+
+	suite.T().Run("TestMemoryLockRepoLockSuccesfulLock", func(t *testing.T) {
+		suite.MemoryLockRepoLockSuccesfulLock()
+	})
+
+	suite.T().Run("TestMemoryLockRepoLockNotSuccesfulLock", func(t *testing.T) {
+		suite.MemoryLockRepoLockNotSuccesfulLock()
+	})
+
+	suite.T().Run("TestMemoryLockRepoLockLeaseRenewed", func(t *testing.T) {
+		suite.MemoryLockRepoLockLeaseRenewed()
+	})
+
+	suite.T().Run("TestMemoryLockRepoLockGrantedInArrivalOrder", func(t *testing.T) {
+		suite.MemoryLockRepoLockGrantedInArrivalOrder()
+	})
 }
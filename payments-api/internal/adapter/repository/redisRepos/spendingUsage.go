@@ -0,0 +1,107 @@
+package redisRepos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jtonynet/go-payments-api/internal/adapter/database"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+/*
+  - Counters of the spending usage, one entry per account, category and UTC hour
+    holding the usage of the hour, its day and month. An entry is loaded from the
+    ledger on a miss and expires at the end of its hour
+  - A hold that expires by its TTL is still counted until the end of the hour
+  - The account lock serializes the writers of an account, so a read followed by
+    a write does not lose updates
+*/
+type SpendingUsage struct {
+	cacheConn database.InMemory
+
+	spendingUsageRepository port.SpendingUsageRepository
+}
+
+func NewRedisSpendingUsage(cacheConn database.InMemory, suRepository port.SpendingUsageRepository) (port.SpendingUsageRepository, error) {
+	return &SpendingUsage{
+		cacheConn:               cacheConn,
+		spendingUsageRepository: suRepository,
+	}, nil
+}
+
+func (su *SpendingUsage) FindUsage(ctx context.Context, accountID, categoryID uint, at time.Time) (port.SpendingUsageEntity, error) {
+	suEntity, found := su.cachedUsage(ctx, accountID, categoryID, at)
+	if found {
+		return suEntity, nil
+	}
+
+	suEntity, err := su.spendingUsageRepository.FindUsage(ctx, accountID, categoryID, at)
+	if err != nil {
+		return suEntity, err
+	}
+
+	return suEntity, su.cacheUsage(ctx, suEntity)
+}
+
+/*
+- A missing entry is left to be loaded from the ledger, which already has the transaction
+*/
+func (su *SpendingUsage) AddUsage(ctx context.Context, usage port.SpendingUsageEntity) error {
+	suEntity, found := su.cachedUsage(ctx, usage.AccountID, usage.CategoryID, usage.At)
+	if !found {
+		return su.spendingUsageRepository.AddUsage(ctx, usage)
+	}
+
+	suEntity.DailyAmount = suEntity.DailyAmount.Add(usage.DailyAmount)
+	suEntity.MonthlyAmount = suEntity.MonthlyAmount.Add(usage.MonthlyAmount)
+	suEntity.HourlyTransactions += usage.HourlyTransactions
+
+	err := su.cacheUsage(ctx, suEntity)
+	if err != nil {
+		_ = su.cacheConn.Delete(ctx, spendingUsageCacheKey(usage.AccountID, usage.CategoryID, usage.At))
+		return err
+	}
+
+	return su.spendingUsageRepository.AddUsage(ctx, usage)
+}
+
+func (su *SpendingUsage) ResetUsage(ctx context.Context, accountID, categoryID uint, at time.Time) error {
+	err := su.cacheConn.Delete(ctx, spendingUsageCacheKey(accountID, categoryID, at))
+	if err != nil {
+		return err
+	}
+
+	return su.spendingUsageRepository.ResetUsage(ctx, accountID, categoryID, at)
+}
+
+func (su *SpendingUsage) cachedUsage(ctx context.Context, accountID, categoryID uint, at time.Time) (port.SpendingUsageEntity, bool) {
+	var suEntity port.SpendingUsageEntity
+
+	usageCached, err := su.cacheConn.Get(ctx, spendingUsageCacheKey(accountID, categoryID, at))
+	if err != nil || json.Unmarshal([]byte(usageCached), &suEntity) != nil {
+		return suEntity, false
+	}
+
+	suEntity.At = at
+	return suEntity, true
+}
+
+func (su *SpendingUsage) cacheUsage(ctx context.Context, suEntity port.SpendingUsageEntity) error {
+	expiration := time.Until(suEntity.At.UTC().Truncate(time.Hour).Add(time.Hour))
+	if expiration <= 0 {
+		return nil
+	}
+
+	return su.cacheConn.Set(
+		ctx,
+		spendingUsageCacheKey(suEntity.AccountID, suEntity.CategoryID, suEntity.At),
+		suEntity,
+		expiration,
+	)
+}
+
+func spendingUsageCacheKey(accountID, categoryID uint, at time.Time) string {
+	return fmt.Sprintf("spending_usage:%d:%d:%s", accountID, categoryID, at.UTC().Format("2006010215"))
+}
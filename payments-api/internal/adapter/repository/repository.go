@@ -16,6 +16,22 @@ import (
 type AllRepos struct {
 	Account  port.AccountRepository
 	Merchant port.MerchantRepository
+	Hold     port.HoldRepository
+
+	TransactionOutcome port.TransactionOutcomeRepository
+	Admin              port.AdminRepository
+	MerchantRegistry   port.MerchantRegistryRepository
+	MerchantMatchAudit port.MerchantMatchAuditRepository
+	Ledger             port.LedgerRepository
+	ExchangeRate       port.ExchangeRateRepository
+	CategoryRule       port.CategoryRuleRepository
+	SpendingLimit      port.SpendingLimitRepository
+	SpendingUsage      port.SpendingUsageRepository
+	Card               port.CardRepository
+	FraudRule          port.FraudRuleRepository
+	FraudHistory       port.FraudHistoryRepository
+	TransactionEvent   port.TransactionEventRepository
+	FencingToken       port.FencingTokenRepository
 }
 
 func GetAll(conn database.Conn) (AllRepos, error) {
@@ -40,6 +56,96 @@ func GetAll(conn database.Conn) (AllRepos, error) {
 		}
 		repos.Merchant = Merchant
 
+		hold, err := gormRepos.NewHold(conn)
+		if err != nil {
+			return AllRepos{}, fmt.Errorf("error when instantiating hold repository: %v", err)
+		}
+		repos.Hold = hold
+
+		exchangeRate, err := gormRepos.NewExchangeRate(conn)
+		if err != nil {
+			return AllRepos{}, fmt.Errorf("error when instantiating exchange rate repository: %v", err)
+		}
+		repos.ExchangeRate = exchangeRate
+
+		categoryRule, err := gormRepos.NewCategoryRule(conn)
+		if err != nil {
+			return AllRepos{}, fmt.Errorf("error when instantiating category rule repository: %v", err)
+		}
+		repos.CategoryRule = categoryRule
+
+		spendingLimit, err := gormRepos.NewSpendingLimit(conn)
+		if err != nil {
+			return AllRepos{}, fmt.Errorf("error when instantiating spending limit repository: %v", err)
+		}
+		repos.SpendingLimit = spendingLimit
+
+		spendingUsage, err := gormRepos.NewSpendingUsage(conn)
+		if err != nil {
+			return AllRepos{}, fmt.Errorf("error when instantiating spending usage repository: %v", err)
+		}
+		repos.SpendingUsage = spendingUsage
+
+		card, err := gormRepos.NewCard(conn)
+		if err != nil {
+			return AllRepos{}, fmt.Errorf("error when instantiating card repository: %v", err)
+		}
+		repos.Card = card
+
+		fraudRule, err := gormRepos.NewFraudRule(conn)
+		if err != nil {
+			return AllRepos{}, fmt.Errorf("error when instantiating fraud rule repository: %v", err)
+		}
+		repos.FraudRule = fraudRule
+
+		fraudHistory, err := gormRepos.NewFraudHistory(conn)
+		if err != nil {
+			return AllRepos{}, fmt.Errorf("error when instantiating fraud history repository: %v", err)
+		}
+		repos.FraudHistory = fraudHistory
+
+		transactionOutcome, err := gormRepos.NewTransactionOutcome(conn)
+		if err != nil {
+			return AllRepos{}, fmt.Errorf("error when instantiating transaction outcome repository: %v", err)
+		}
+		repos.TransactionOutcome = transactionOutcome
+
+		transactionEvent, err := gormRepos.NewTransactionEvent(conn)
+		if err != nil {
+			return AllRepos{}, fmt.Errorf("error when instantiating transaction event repository: %v", err)
+		}
+		repos.TransactionEvent = transactionEvent
+
+		fencingToken, err := gormRepos.NewFencingToken(conn)
+		if err != nil {
+			return AllRepos{}, fmt.Errorf("error when instantiating fencing token repository: %v", err)
+		}
+		repos.FencingToken = fencingToken
+
+		admin, err := gormRepos.NewAdmin(conn)
+		if err != nil {
+			return AllRepos{}, fmt.Errorf("error when instantiating admin repository: %v", err)
+		}
+		repos.Admin = admin
+
+		merchantRegistry, err := gormRepos.NewMerchantRegistry(conn)
+		if err != nil {
+			return AllRepos{}, fmt.Errorf("error when instantiating merchant registry repository: %v", err)
+		}
+		repos.MerchantRegistry = merchantRegistry
+
+		merchantMatchAudit, err := gormRepos.NewMerchantMatchAudit(conn)
+		if err != nil {
+			return AllRepos{}, fmt.Errorf("error when instantiating merchant match audit repository: %v", err)
+		}
+		repos.MerchantMatchAudit = merchantMatchAudit
+
+		ledger, err := gormRepos.NewLedger(conn)
+		if err != nil {
+			return AllRepos{}, fmt.Errorf("error when instantiating ledger repository: %v", err)
+		}
+		repos.Ledger = ledger
+
 		return repos, nil
 	default:
 		return AllRepos{}, errors.New("repository strategy not suported: " + strategy)
@@ -55,24 +161,135 @@ func NewCachedMerchant(cacheConn database.InMemory, mRepository port.MerchantRep
 	}
 
 	switch strategy {
-	case "redis":
+	case "redis", "memory":
 		return redisRepos.NewRedisMerchant(cacheConn, mRepository)
 	default:
 		return mr, fmt.Errorf("cached repository strategy not suported: %s", strategy)
 	}
 }
 
-func NewMemoryLock(lockConn database.InMemory, pubsub pubSub.PubSub, log logger.Logger) (port.MemoryLockRepository, error) {
-	var mlr port.MemoryLockRepository
+func NewCacheEvictingMerchantRegistry(cacheConn database.InMemory, mrRepository port.MerchantRegistryRepository) (port.MerchantRegistryRepository, error) {
+	var mrr port.MerchantRegistryRepository
 
-	strategy, err := lockConn.GetStrategy(context.Background())
+	strategy, err := cacheConn.GetStrategy(context.Background())
 	if err != nil {
-		return mlr, fmt.Errorf("error: dont retrieve memory lock strategy: %v", err)
+		return mrr, fmt.Errorf("error: dont retrieve cache strategy: %v", err)
+	}
+
+	switch strategy {
+	case "redis", "memory":
+		return redisRepos.NewRedisMerchantRegistry(cacheConn, mrRepository)
+	default:
+		return mrr, fmt.Errorf("cached repository strategy not suported: %s", strategy)
 	}
+}
+
+func NewCachedBalance(cacheConn database.InMemory, aRepository port.AccountRepository) (port.BalanceRepository, error) {
+	var br port.BalanceRepository
+
+	strategy, err := cacheConn.GetStrategy(context.Background())
+	if err != nil {
+		return br, fmt.Errorf("error: dont retrieve cache strategy: %v", err)
+	}
+
+	switch strategy {
+	case "redis", "memory":
+		return redisRepos.NewRedisBalance(cacheConn, aRepository)
+	default:
+		return br, fmt.Errorf("cached repository strategy not suported: %s", strategy)
+	}
+}
+
+func NewCachedSpendingUsage(cacheConn database.InMemory, suRepository port.SpendingUsageRepository) (port.SpendingUsageRepository, error) {
+	var sur port.SpendingUsageRepository
+
+	strategy, err := cacheConn.GetStrategy(context.Background())
+	if err != nil {
+		return sur, fmt.Errorf("error: dont retrieve cache strategy: %v", err)
+	}
+
+	switch strategy {
+	case "redis", "memory":
+		return redisRepos.NewRedisSpendingUsage(cacheConn, suRepository)
+	default:
+		return sur, fmt.Errorf("cached repository strategy not suported: %s", strategy)
+	}
+}
+
+/*
+  - Write-through wrappers: they keep the cached balances coherent with the
+    transactions and holds persisted by the wrapped repositories
+*/
+func NewBalanceInvalidatingAccount(cacheConn database.InMemory, aRepository port.AccountRepository) (port.AccountRepository, error) {
+	var ar port.AccountRepository
+
+	strategy, err := cacheConn.GetStrategy(context.Background())
+	if err != nil {
+		return ar, fmt.Errorf("error: dont retrieve cache strategy: %v", err)
+	}
+
+	switch strategy {
+	case "redis", "memory":
+		return redisRepos.NewRedisAccount(cacheConn, aRepository)
+	default:
+		return ar, fmt.Errorf("cached repository strategy not suported: %s", strategy)
+	}
+}
+
+func NewBalanceInvalidatingHold(cacheConn database.InMemory, hRepository port.HoldRepository) (port.HoldRepository, error) {
+	var hr port.HoldRepository
+
+	strategy, err := cacheConn.GetStrategy(context.Background())
+	if err != nil {
+		return hr, fmt.Errorf("error: dont retrieve cache strategy: %v", err)
+	}
+
+	switch strategy {
+	case "redis", "memory":
+		return redisRepos.NewRedisHold(cacheConn, hRepository)
+	default:
+		return hr, fmt.Errorf("cached repository strategy not suported: %s", strategy)
+	}
+}
+
+func NewBalanceInvalidatingAdmin(cacheConn database.InMemory, adRepository port.AdminRepository) (port.AdminRepository, error) {
+	var adr port.AdminRepository
+
+	strategy, err := cacheConn.GetStrategy(context.Background())
+	if err != nil {
+		return adr, fmt.Errorf("error: dont retrieve cache strategy: %v", err)
+	}
+
+	switch strategy {
+	case "redis", "memory":
+		return redisRepos.NewRedisAdmin(cacheConn, adRepository)
+	default:
+		return adr, fmt.Errorf("cached repository strategy not suported: %s", strategy)
+	}
+}
+
+/*
+  - The `postgres` strategy locks through the database connection, without
+    a lock in memory connection nor pub/sub
+  - The `redis` and `memory` strategies resume their fencing tokens from the
+    tokens written on the accounts
+*/
+func NewMemoryLock(
+	strategy string,
+	lockConn database.InMemory,
+	dbConn database.Conn,
+	lockDBConn database.Conn,
+	ftRepository port.FencingTokenRepository,
+	pubsub pubSub.PubSub,
+	log logger.Logger,
+) (port.MemoryLockRepository, error) {
+	var mlr port.MemoryLockRepository
 
 	switch strategy {
-	case "redis":
-		return redisRepos.NewMemoryLock(lockConn, pubsub, log)
+	case "redis", "memory":
+		return redisRepos.NewMemoryLock(lockConn, ftRepository, pubsub, log)
+	case "postgres":
+		return gormRepos.NewMemoryLock(dbConn, lockDBConn, log)
 	default:
 		return mlr, fmt.Errorf("memory lock repository strategy not suported: %s", strategy)
 	}
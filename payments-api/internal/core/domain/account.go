@@ -3,6 +3,8 @@ package domain
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jtonynet/go-payments-api/internal/support/logger"
@@ -10,102 +12,465 @@ import (
 )
 
 type Account struct {
-	ID  uint
-	UID uuid.UUID
+	ID     uint
+	UID    uuid.UUID
+	Status string
 
 	Balance
 
+	CurrencyConversion bool
+	ExchangeRates      ExchangeRates
+
+	CategoryRules  CategoryRules
+	SpendingLimits SpendingLimits
+
+	Currency      string
+	SpendingRates ExchangeRates
+
+	Card *Card
+
 	Log logger.Logger
 }
 
-func (a *Account) ApproveTransaction(ctx context.Context, tDomain Transaction) (map[int]Transaction, *CustomError) {
+/*
+  - Debits the categories resolved for the transaction in order, each one covering
+    what it can of the remaining amount, and reports the categories tried
+  - Amounts are debited in the currency of each category. A transaction in another
+    currency is rejected, unless the account allows the conversion, when the
+    debit is converted with the rate of the category currency
+  - An approved transaction is still rejected when its debits breach a spending
+    limit of the account or of a debited category, or a limit of the card it
+    was paid with
+*/
+func (a *Account) ApproveTransaction(ctx context.Context, tDomain Transaction) (map[int]Transaction, []CategoryAttempt, *CustomError) {
 	transactions := make(map[int]Transaction)
+	attempts := []CategoryAttempt{}
 
 	amountDebtRemaining := tDomain.Amount
 
-	categoryMCC, err := a.Balance.TransactionByCategories.GetByMCC(tDomain.MCC)
-	if err != nil {
+	for _, step := range a.resolveCategories(tDomain.MCC) {
+		category := step.category
+
+		rate, cErr := a.exchangeRate(tDomain, category)
+		if cErr != nil {
+			return make(map[int]Transaction), attempts, cErr
+		}
+
+		amountDebit := convertAmount(amountDebtRemaining, rate, category.Currency)
+		amountCovered := amountDebtRemaining
+		amountAfter := category.Amount.Sub(amountDebit)
+
+		if category.Amount.LessThan(amountDebit) {
+			amountDebit = decimal.Max(category.Amount, decimal.Zero)
+			amountCovered = decimal.Min(revertAmount(amountDebit, rate, tDomain.Currency), amountDebtRemaining)
+			amountAfter = decimal.NewFromFloat(0)
+		}
+
+		attempts = append(attempts, CategoryAttempt{
+			CategoryID: category.CategoryID,
+			Name:       category.Name,
+			Rule:       step.rule,
+			Amount:     amountDebit,
+			Currency:   category.Currency,
+		})
+
+		if !amountDebit.IsPositive() {
+			a.Log.Debug(
+				ctx,
+				fmt.Sprintf(
+					"Category '%s' (%s) has no funds, attempting the next category.",
+					category.Name,
+					step.rule,
+				),
+			)
+
+			continue
+		}
+
 		a.Log.Debug(
 			ctx,
-			"Error retrieving category by MCC, attempting fallback.",
+			fmt.Sprintf(
+				"Category '%s' (%s) covers %s of the transaction",
+				category.Name,
+				step.rule,
+				amountDebit.String(),
+			),
 		)
 
-	} else if categoryMCC.Amount.GreaterThanOrEqual(tDomain.Amount) {
-		a.Log.Debug(
-			ctx,
+		amountDebtRemaining = amountDebtRemaining.Sub(amountCovered)
+
+		category.Amount = amountAfter
+		transactions[category.Priority] = a.mapCategoryToTransaction(
+			category,
+			tDomain,
+			TRANSACTION_OPERATION_AUTHORIZATION,
+			TRANSACTION_ENTRY_DEBIT,
+			amountDebit,
+		).withOriginalAmount(tDomain.Currency, amountCovered, rate)
+
+		if !amountDebtRemaining.IsPositive() {
+			break
+		}
+	}
+
+	if amountDebtRemaining.GreaterThan(decimal.Zero) || len(transactions) == 0 {
+		return make(map[int]Transaction), attempts, NewCustomError(CODE_REJECTED_INSUFICIENT_FUNDS, "Insuficient funds for transaction")
+	}
+
+	if len(a.SpendingLimits) > 0 || a.Card != nil {
+		spending, cErr := a.SpendingByCategory(transactions)
+		if cErr != nil {
+			return make(map[int]Transaction), attempts, cErr
+		}
+
+		if cErr := a.SpendingLimits.check(spending); cErr != nil {
+			return make(map[int]Transaction), attempts, cErr
+		}
+
+		if a.Card != nil {
+			if cErr := a.Card.checkLimits(spending[0]); cErr != nil {
+				return make(map[int]Transaction), attempts, cErr
+			}
+		}
+	}
+
+	return transactions, attempts, nil
+}
+
+/*
+  - Rate from the transaction currency to the category currency, one when both
+    are the same currency
+*/
+func (a *Account) exchangeRate(tDomain Transaction, tc TransactionCategory) (decimal.Decimal, *CustomError) {
+	if tDomain.Currency == tc.Currency {
+		return decimal.NewFromInt(1), nil
+	}
+
+	if !a.CurrencyConversion {
+		return decimal.Zero, NewCustomError(
+			CODE_REJECTED_INVALID_TRANSACTION,
 			fmt.Sprintf(
-				"Sufficient funds available in category '%s'",
-				categoryMCC.Name,
+				"Transaction currency %s does not match the currency %s of category '%s'",
+				tDomain.Currency,
+				tc.Currency,
+				tc.Name,
 			),
 		)
+	}
 
-		amountDebtRemaining = decimal.NewFromFloat(0)
+	rate, ok := a.ExchangeRates[tc.Currency]
+	if !ok || !rate.IsPositive() {
+		return decimal.Zero, NewCustomError(
+			CODE_REJECTED_INVALID_TRANSACTION,
+			fmt.Sprintf("Exchange rate from %s to %s not found", tDomain.Currency, tc.Currency),
+		)
+	}
+
+	return rate, nil
+}
+
+/*
+  - Currencies of the account categories other than the transaction currency,
+    whose rates must be provided to convert the transaction
+*/
+func (a *Account) CurrenciesToConvert(currency string) []string {
+	currencies := []string{}
+	if !a.CurrencyConversion {
+		return currencies
+	}
+
+	seen := map[string]bool{currency: true}
+	for _, category := range a.Balance.TransactionByCategories.Itens {
+		if !seen[category.Currency] {
+			seen[category.Currency] = true
+			currencies = append(currencies, category.Currency)
+		}
+	}
 
-		categoryMCC.Amount = categoryMCC.Amount.Sub(tDomain.Amount)
-		transactions[categoryMCC.Priority] = a.mapCategoryToTransaction(categoryMCC, tDomain)
+	sort.Strings(currencies)
+	return currencies
+}
+
+/*
+  - Reserves funds per category following the same rules of ApproveTransaction,
+    without posting a final transaction. Each hold stores the debited amount.
+*/
+func (a *Account) AuthorizeTransaction(ctx context.Context, tDomain Transaction, expiresAt time.Time) (map[int]Hold, []CategoryAttempt, *CustomError) {
+	holds := make(map[int]Hold)
+
+	approvedTransactions, attempts, cErr := a.ApproveTransaction(ctx, tDomain)
+	if cErr != nil {
+		return holds, attempts, cErr
+	}
+
+	for key, approved := range approvedTransactions {
+		holds[key] = Hold{
+			UID:              tDomain.UID,
+			AccountID:        a.ID,
+			AccountUID:       a.UID,
+			CategoryID:       approved.CategoryID,
+			Amount:           approved.Amount,
+			Currency:         approved.Currency,
+			MCC:              tDomain.MCC,
+			MerchantName:     tDomain.MerchantName,
+			ExpiresAt:        expiresAt,
+			CardID:           approved.CardID,
+			OriginalAmount:   approved.OriginalAmount,
+			OriginalCurrency: approved.OriginalCurrency,
+			ExchangeRate:     approved.ExchangeRate,
+		}
+	}
+
+	return holds, attempts, nil
+}
+
+/*
+  - Settles the holds of an authorization, posting the reserved amounts as
+    transactions over the category balances.
+  - The spending limits are checked again, as they may have been lowered since
+    the authorization
+*/
+func (a *Account) CaptureHolds(ctx context.Context, holds map[int]Hold, now time.Time) (map[int]Transaction, *CustomError) {
+	transactions := make(map[int]Transaction)
+
+	if len(holds) == 0 {
+		return transactions, NewCustomError(CODE_REJECTED_RECORD_NOT_FOUND, "Authorization hold not found to capture")
+	}
+
+	for key, hold := range holds {
+		if hold.IsExpired(now) {
+			return make(map[int]Transaction), NewCustomError(
+				CODE_REJECTED_INVALID_TRANSACTION,
+				fmt.Sprintf("Authorization hold %s expired at %s", hold.UID.String(), hold.ExpiresAt.Format(time.RFC3339)),
+			)
+		}
+
+		categoryKey, category, err := a.Balance.TransactionByCategories.GetByCategoryID(hold.CategoryID)
+		if err != nil {
+			return make(map[int]Transaction), NewCustomError(CODE_REJECTED_INVALID_TRANSACTION, err.Error())
+		}
 
-	} else if categoryMCC.Amount.IsPositive() {
 		a.Log.Debug(
 			ctx,
 			fmt.Sprintf(
-				"Category '%s' has a positive balance but insufficient funds, attempting from fallback.",
-				categoryMCC.Name,
+				"Capturing %s held in category '%s'",
+				hold.Amount.String(),
+				category.Name,
 			),
 		)
 
-		amountDebtRemaining = amountDebtRemaining.Sub(categoryMCC.Amount)
+		category.AmountHeld = category.AmountHeld.Sub(hold.Amount)
+		a.Balance.TransactionByCategories.Itens[categoryKey] = category
+
+		transactions[key] = a.mapCategoryToTransaction(
+			category,
+			Transaction{
+				UID:          hold.UID,
+				CardID:       hold.CardID,
+				MCC:          hold.MCC,
+				MerchantName: hold.MerchantName,
+			},
+			TRANSACTION_OPERATION_AUTHORIZATION,
+			TRANSACTION_ENTRY_DEBIT,
+			hold.Amount,
+		)
 
-		categoryMCC.Amount = decimal.NewFromFloat(0)
-		transactions[categoryMCC.Priority] = a.mapCategoryToTransaction(categoryMCC, tDomain)
+		if hold.OriginalCurrency != "" {
+			transactions[key] = transactions[key].withOriginalAmount(hold.OriginalCurrency, hold.OriginalAmount, hold.ExchangeRate)
+		}
 	}
 
-	if amountDebtRemaining.GreaterThan(decimal.Zero) {
-		CategoryFallback, err := a.Balance.TransactionByCategories.GetFallback()
+	if len(a.SpendingLimits) > 0 {
+		spending, cErr := a.SpendingByCategory(transactions)
+		if cErr != nil {
+			return make(map[int]Transaction), cErr
+		}
+
+		if cErr := a.SpendingLimits.checkCaptured(spending); cErr != nil {
+			return make(map[int]Transaction), cErr
+		}
+	}
+
+	return transactions, nil
+}
+
+/*
+  - The refund amount is in the currency of the original transaction, and each
+    category is credited with its share converted with the rate the debit was
+    converted with. A category refunded in full is credited its whole refundable
+    amount, so the rounding of the rate leaves nothing behind
+*/
+func (a *Account) ApproveRefund(
+	ctx context.Context,
+	tRefund Transaction,
+	capturedTransactions map[int]TransactionCaptured,
+) (map[int]Transaction, *CustomError) {
+	transactions := make(map[int]Transaction)
+
+	if len(capturedTransactions) == 0 {
+		return transactions, NewCustomError(
+			CODE_REJECTED_RECORD_NOT_FOUND,
+			fmt.Sprintf("Original transaction %s not found to refund", tRefund.OriginalUID.String()),
+		)
+	}
+
+	amountRefundable := decimal.Zero
+	captured := make([]TransactionCaptured, 0, len(capturedTransactions))
+	for _, tCaptured := range capturedTransactions {
+		amountRefundable = amountRefundable.Add(tCaptured.RefundableOriginal())
+		captured = append(captured, tCaptured)
+	}
+
+	if tRefund.Amount.GreaterThan(amountRefundable) {
+		return transactions, NewCustomError(
+			CODE_REJECTED_INVALID_AMOUNT,
+			fmt.Sprintf(
+				"Refund amount %s exceeds the refundable amount %s",
+				tRefund.Amount.String(),
+				amountRefundable.String(),
+			),
+		)
+	}
+
+	/*
+		Restore in the reverse order of the debit: the fallback category
+		(highest priority) is the last one debited, so it's the first credited.
+	*/
+	sort.Slice(captured, func(i, j int) bool {
+		return captured[i].Priority > captured[j].Priority
+	})
+
+	amountRefundRemaining := tRefund.Amount
+	for _, tCaptured := range captured {
+		if !amountRefundRemaining.IsPositive() {
+			break
+		}
+
+		refundable := tCaptured.Refundable()
+		refundableOriginal := tCaptured.RefundableOriginal()
+		if !refundable.IsPositive() || !refundableOriginal.IsPositive() {
+			continue
+		}
+
+		key, category, err := a.Balance.TransactionByCategories.GetByCategoryID(tCaptured.CategoryID)
 		if err != nil {
-			a.Log.Debug(
-				ctx,
-				"Error retrieving fallback category.",
-			)
+			return make(map[int]Transaction), NewCustomError(CODE_REJECTED_INVALID_TRANSACTION, err.Error())
+		}
 
-			fallbackNotFoundErr := fmt.Sprintf("Category Fallback not found for :%s", tDomain.AccountUID.String())
-			return transactions, NewCustomError(CODE_REJECTED_INSUFICIENT_FUNDS, fallbackNotFoundErr)
+		rate := tCaptured.rate()
+		amountCovered := decimal.Min(refundableOriginal, amountRefundRemaining)
+		amountCredit := refundable
+		if amountCovered.LessThan(refundableOriginal) {
+			amountCredit = decimal.Min(convertAmount(amountCovered, rate, category.Currency), refundable)
+		}
 
-		} else if CategoryFallback.Amount.GreaterThanOrEqual(amountDebtRemaining) {
-			a.Log.Debug(
-				ctx,
-				"Fallback category has sufficient funds available.",
-			)
+		amountRefundRemaining = amountRefundRemaining.Sub(amountCovered)
 
-			CategoryFallback.Amount = CategoryFallback.Amount.Sub(amountDebtRemaining)
-			transactions[CategoryFallback.Priority] = a.mapCategoryToTransaction(CategoryFallback, tDomain)
+		a.Log.Debug(
+			ctx,
+			fmt.Sprintf(
+				"Refunding %s to category '%s'",
+				amountCredit.String(),
+				category.Name,
+			),
+		)
 
-			amountDebtRemaining = decimal.NewFromFloat(0)
-		} else {
-			a.Log.Debug(
-				ctx,
-				"Insufficient funds in the fallback category.",
-			)
+		category.Amount = category.Amount.Add(amountCredit)
+		a.Balance.TransactionByCategories.Itens[key] = category
+
+		transactions[tCaptured.Priority] = a.mapCategoryToTransaction(
+			category,
+			tRefund,
+			TRANSACTION_OPERATION_REFUND,
+			TRANSACTION_ENTRY_CREDIT,
+			amountCredit,
+		)
 
-			amountDebtRemaining = tDomain.Amount
-			transactions = make(map[int]Transaction)
+		if tCaptured.OriginalCurrency != "" {
+			transactions[tCaptured.Priority] = transactions[tCaptured.Priority].withOriginalAmount(tCaptured.OriginalCurrency, amountCovered, rate)
 		}
 	}
 
-	if amountDebtRemaining.GreaterThan(decimal.Zero) || len(transactions) == 0 {
-		return transactions, NewCustomError(CODE_REJECTED_INSUFICIENT_FUNDS, "Insuficient funds for transaction")
+	return transactions, nil
+}
+
+func (a *Account) ApproveCredit(ctx context.Context, tCredit Transaction, categoryName string) (map[int]Transaction, *CustomError) {
+	transactions := make(map[int]Transaction)
+
+	if !tCredit.Amount.IsPositive() {
+		return transactions, NewCustomError(
+			CODE_REJECTED_INVALID_AMOUNT,
+			fmt.Sprintf("Credit amount %s must be positive", tCredit.Amount.String()),
+		)
+	}
+
+	key, category, err := a.Balance.TransactionByCategories.GetByName(categoryName)
+	if err != nil {
+		return transactions, NewCustomError(
+			CODE_REJECTED_INVALID_TRANSACTION,
+			fmt.Sprintf("Category %s not attached to account %s", categoryName, a.UID.String()),
+		)
 	}
 
+	a.Log.Debug(
+		ctx,
+		fmt.Sprintf(
+			"Crediting %s to category '%s'",
+			tCredit.Amount.String(),
+			category.Name,
+		),
+	)
+
+	category.Amount = category.Amount.Add(tCredit.Amount)
+	a.Balance.TransactionByCategories.Itens[key] = category
+
+	transactions[category.Priority] = a.mapCategoryToTransaction(
+		category,
+		tCredit,
+		TRANSACTION_OPERATION_CREDIT,
+		TRANSACTION_ENTRY_CREDIT,
+		tCredit.Amount,
+	)
+
 	return transactions, nil
 }
 
-func (a *Account) mapCategoryToTransaction(tc TransactionCategory, t Transaction) Transaction {
+/*
+  - tc holds the category balance after the movement. The posted balance
+    includes the amounts held, so captured holds move it and new holds don't
+  - The movement is recorded in the category currency, as its own original amount
+*/
+func (a *Account) mapCategoryToTransaction(
+	tc TransactionCategory,
+	t Transaction,
+	operation string,
+	entryType string,
+	amount decimal.Decimal,
+) Transaction {
+	balanceAfter := tc.Amount.Add(tc.AmountHeld)
+
+	balanceBefore := balanceAfter.Add(amount)
+	if entryType == TRANSACTION_ENTRY_CREDIT {
+		balanceBefore = balanceAfter.Sub(amount)
+	}
+
 	return Transaction{
-		UID:          t.UID,
-		AccountID:    a.ID,
-		AccountUID:   a.UID,
-		CategoryID:   tc.CategoryID,
-		Amount:       tc.Amount,
-		MCC:          t.MCC,
-		MerchantName: t.MerchantName,
+		UID:              t.UID,
+		AccountID:        a.ID,
+		AccountUID:       a.UID,
+		CardID:           t.CardID,
+		CategoryID:       tc.CategoryID,
+		Amount:           amount,
+		Currency:         tc.Currency,
+		MCC:              t.MCC,
+		MerchantName:     t.MerchantName,
+		OriginalUID:      t.OriginalUID,
+		Operation:        operation,
+		EntryType:        entryType,
+		BalanceBefore:    balanceBefore,
+		BalanceAfter:     balanceAfter,
+		OriginalAmount:   amount,
+		OriginalCurrency: tc.Currency,
+		ExchangeRate:     decimal.NewFromInt(1),
 	}
 }
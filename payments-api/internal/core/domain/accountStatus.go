@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	ACCOUNT_STATUS_ACTIVE    = "ACTIVE"
+	ACCOUNT_STATUS_BLOCKED   = "BLOCKED"
+	ACCOUNT_STATUS_CANCELLED = "CANCELLED"
+
+	ACCOUNT_ACTION_BLOCK   = "BLOCK"
+	ACCOUNT_ACTION_UNBLOCK = "UNBLOCK"
+	ACCOUNT_ACTION_CANCEL  = "CANCEL"
+)
+
+/*
+  - Status reached by each action from the statuses it applies to. CANCELLED
+    is final, no action leaves it
+*/
+var accountStatusTransitions = map[string]map[string]string{
+	ACCOUNT_ACTION_BLOCK: {
+		ACCOUNT_STATUS_ACTIVE: ACCOUNT_STATUS_BLOCKED,
+	},
+	ACCOUNT_ACTION_UNBLOCK: {
+		ACCOUNT_STATUS_BLOCKED: ACCOUNT_STATUS_ACTIVE,
+	},
+	ACCOUNT_ACTION_CANCEL: {
+		ACCOUNT_STATUS_ACTIVE:  ACCOUNT_STATUS_CANCELLED,
+		ACCOUNT_STATUS_BLOCKED: ACCOUNT_STATUS_CANCELLED,
+	},
+}
+
+/*
+- Status the account reaches with the action, false when the action does not apply to its status
+*/
+func AccountStatusTransition(status, action string) (string, bool) {
+	next, ok := accountStatusTransitions[action][status]
+	return next, ok
+}
+
+func IsAccountAction(action string) bool {
+	_, ok := accountStatusTransitions[action]
+	return ok
+}
+
+/*
+  - Debits are declined on blocked and cancelled accounts, before their balance
+    is evaluated. An account without status predates the lifecycle and is active
+  - A debit paid with a card is also declined when the card is not active or
+    has expired at the given time
+*/
+func (a *Account) CheckDebitAllowed(at time.Time) *CustomError {
+	switch a.Status {
+	case ACCOUNT_STATUS_BLOCKED:
+		return NewCustomError(CODE_REJECTED_ACCOUNT_BLOCKED, fmt.Sprintf("account %s is blocked", a.UID))
+	case ACCOUNT_STATUS_CANCELLED:
+		return NewCustomError(CODE_REJECTED_ACCOUNT_CANCELLED, fmt.Sprintf("account %s is cancelled", a.UID))
+	}
+
+	if a.Card != nil {
+		return a.Card.checkUsable(at)
+	}
+
+	return nil
+}
+
+/*
+- Credits still reach blocked accounts, only a cancelled account refuses them
+*/
+func (a *Account) CheckCreditAllowed() *CustomError {
+	if a.Status == ACCOUNT_STATUS_CANCELLED {
+		return NewCustomError(CODE_REJECTED_ACCOUNT_CANCELLED, fmt.Sprintf("account %s is cancelled", a.UID))
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	CARD_STATUS_ACTIVE    = "ACTIVE"
+	CARD_STATUS_BLOCKED   = "BLOCKED"
+	CARD_STATUS_CANCELLED = "CANCELLED"
+
+	CARD_EXPIRY_LAYOUT = "01/06"
+)
+
+/*
+- First six and last four digits of the PAN, the ones in between masked
+*/
+var maskedPANPattern = regexp.MustCompile(`^[0-9]{6}\*{2,9}[0-9]{4}$`)
+
+/*
+  - Tokenized card of an account, identified by the token the acquirer sends. The
+    PAN itself is never kept, only its masked form
+  - The card is valid until ExpiresAt, the first instant after its expiry month
+  - A zero cap is not enforced. As the account spending limits, the caps are in
+    the currency of the debited categories
+*/
+type Card struct {
+	ID        uint
+	UID       uuid.UUID
+	MaskedPAN string
+	Status    string
+	ExpiresAt time.Time
+
+	TransactionAmount decimal.Decimal
+	DailyAmount       decimal.Decimal
+
+	DailyUsage decimal.Decimal
+}
+
+func IsCardStatus(status string) bool {
+	switch status {
+	case CARD_STATUS_ACTIVE, CARD_STATUS_BLOCKED, CARD_STATUS_CANCELLED:
+		return true
+	}
+
+	return false
+}
+
+func IsMaskedPAN(maskedPAN string) bool {
+	return maskedPANPattern.MatchString(maskedPAN)
+}
+
+/*
+- Expiry printed on the card as MM/YY, valid through the last day of that month in UTC
+*/
+func ParseCardExpiry(expiry string) (time.Time, error) {
+	month, err := time.Parse(CARD_EXPIRY_LAYOUT, expiry)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("card expiry %s must be MM/YY", expiry)
+	}
+
+	return month.AddDate(0, 1, 0), nil
+}
+
+func FormatCardExpiry(expiresAt time.Time) string {
+	if expiresAt.IsZero() {
+		return ""
+	}
+
+	return expiresAt.UTC().AddDate(0, 0, -1).Format(CARD_EXPIRY_LAYOUT)
+}
+
+/*
+- Only active cards within their expiry pay, blocked and cancelled ones are not permitted
+*/
+func (c *Card) checkUsable(at time.Time) *CustomError {
+	if c.Status != CARD_STATUS_ACTIVE {
+		return NewCustomError(CODE_REJECTED_CARD_NOT_PERMITTED, fmt.Sprintf("card %s is %s", c.UID, c.Status))
+	}
+
+	if !at.Before(c.ExpiresAt) {
+		return NewCustomError(CODE_REJECTED_EXPIRED_CARD, fmt.Sprintf("card %s expired on %s", c.UID, FormatCardExpiry(c.ExpiresAt)))
+	}
+
+	return nil
+}
+
+/*
+- amount is the total debited by the transaction, the spending of the category zero
+*/
+func (c *Card) checkLimits(amount decimal.Decimal) *CustomError {
+	breach := ""
+
+	switch {
+	case c.TransactionAmount.IsPositive() && amount.GreaterThan(c.TransactionAmount):
+		breach = "transaction amount"
+	case c.DailyAmount.IsPositive() && c.DailyUsage.Add(amount).GreaterThan(c.DailyAmount):
+		breach = "daily amount"
+	default:
+		return nil
+	}
+
+	return NewCustomError(CODE_REJECTED_LIMIT_EXCEEDED, fmt.Sprintf("%s limit exceeded for card %s", breach, c.UID))
+}
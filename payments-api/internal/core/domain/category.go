@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/shopspring/decimal"
 )
@@ -11,8 +12,12 @@ type TransactionCategory struct {
 	CategoryID uint
 	Name       string
 	Amount     decimal.Decimal
+	AmountHeld decimal.Decimal
+	Currency   string
 	MCCs       []string
 	Priority   int
+
+	FallbackExcluded bool
 }
 
 type TransactionByCategories struct {
@@ -29,13 +34,36 @@ func (tc *TransactionByCategories) GetByMCC(mcc string) (TransactionCategory, er
 	return TransactionCategory{}, fmt.Errorf("balance category with MCC %s not found", mcc)
 }
 
+func (tc *TransactionByCategories) GetByCategoryID(categoryID uint) (int, TransactionCategory, error) {
+	for key, transactionCategory := range tc.Itens {
+		if transactionCategory.CategoryID == categoryID {
+			return key, transactionCategory, nil
+		}
+	}
+
+	return 0, TransactionCategory{}, fmt.Errorf("balance category with ID %v not found", categoryID)
+}
+
+func (tc *TransactionByCategories) GetByName(name string) (int, TransactionCategory, error) {
+	for key, transactionCategory := range tc.Itens {
+		if strings.EqualFold(transactionCategory.Name, name) {
+			return key, transactionCategory, nil
+		}
+	}
+
+	return 0, TransactionCategory{}, fmt.Errorf("balance category with name %s not found", name)
+}
+
+/*
+- Default fallback, used when no category rule is configured for the category of the MCC
+*/
 func (tc *TransactionByCategories) GetFallback() (TransactionCategory, error) {
 	var categoryFallback TransactionCategory
 	found := false
 	maxKey := -1
 
 	for key, transactionCategory := range tc.Itens {
-		if key > maxKey && len(transactionCategory.MCCs) == 0 {
+		if key > maxKey && len(transactionCategory.MCCs) == 0 && !transactionCategory.FallbackExcluded {
 			maxKey = key
 			categoryFallback = transactionCategory
 			found = true
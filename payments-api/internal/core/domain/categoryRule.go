@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	CATEGORY_RULE_MCC              = "MCC"
+	CATEGORY_RULE_FALLBACK         = "FALLBACK"
+	CATEGORY_RULE_DEFAULT_FALLBACK = "DEFAULT_FALLBACK"
+)
+
+/*
+  - Fallback at a position of the chain of a category. CategoryID is the category
+    matched by the MCC, zero for the chain used when no category matches it
+  - AccountID is zero for the default rules, shared by every account
+*/
+type CategoryRule struct {
+	AccountID          uint
+	CategoryID         uint
+	FallbackCategoryID uint
+	Position           int
+}
+
+type CategoryRules []CategoryRule
+
+/*
+  - Ordered fallbacks of a category. The chain of the account overrides the default
+    chain of the same category, reporting false when none of them is configured
+*/
+func (cr CategoryRules) FallbacksOf(accountID, categoryID uint) ([]uint, bool) {
+	fallbacks := cr.chain(accountID, categoryID)
+	if len(fallbacks) > 0 {
+		return fallbacks, true
+	}
+
+	fallbacks = cr.chain(0, categoryID)
+	return fallbacks, len(fallbacks) > 0
+}
+
+func (cr CategoryRules) chain(accountID, categoryID uint) []uint {
+	rules := []CategoryRule{}
+	for _, rule := range cr {
+		if rule.AccountID == accountID && rule.CategoryID == categoryID {
+			rules = append(rules, rule)
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Position < rules[j].Position
+	})
+
+	fallbacks := make([]uint, 0, len(rules))
+	for _, rule := range rules {
+		fallbacks = append(fallbacks, rule.FallbackCategoryID)
+	}
+
+	return fallbacks
+}
+
+/*
+  - Category tried to pay a transaction, by the rule that selected it, and the
+    amount it covers in its currency, zero when it has no funds. Nothing is
+    debited when the transaction is rejected
+*/
+type CategoryAttempt struct {
+	CategoryID uint
+	Name       string
+	Rule       string
+	Amount     decimal.Decimal
+	Currency   string
+}
+
+type categoryStep struct {
+	category TransactionCategory
+	rule     string
+}
+
+/*
+  - Categories tried to pay a transaction, in order: the category of the MCC, then
+    its fallback chain from the category rules or, without rules, the default
+    fallback. Categories excluded from fallbacks, not attached to the account or
+    already tried are skipped
+*/
+func (a *Account) resolveCategories(mcc string) []categoryStep {
+	steps := []categoryStep{}
+	tried := make(map[uint]bool)
+	categoryID := uint(0)
+
+	categoryMCC, err := a.Balance.TransactionByCategories.GetByMCC(mcc)
+	if err == nil {
+		steps = append(steps, categoryStep{category: categoryMCC, rule: CATEGORY_RULE_MCC})
+		tried[categoryMCC.CategoryID] = true
+		categoryID = categoryMCC.CategoryID
+	}
+
+	fallbackIDs, ok := a.CategoryRules.FallbacksOf(a.ID, categoryID)
+	if !ok {
+		categoryFallback, err := a.Balance.TransactionByCategories.GetFallback()
+		if err == nil && !tried[categoryFallback.CategoryID] {
+			steps = append(steps, categoryStep{category: categoryFallback, rule: CATEGORY_RULE_DEFAULT_FALLBACK})
+		}
+
+		return steps
+	}
+
+	for _, fallbackID := range fallbackIDs {
+		_, categoryFallback, err := a.Balance.TransactionByCategories.GetByCategoryID(fallbackID)
+		if err != nil || categoryFallback.FallbackExcluded || tried[categoryFallback.CategoryID] {
+			continue
+		}
+
+		tried[categoryFallback.CategoryID] = true
+		steps = append(steps, categoryStep{category: categoryFallback, rule: CATEGORY_RULE_FALLBACK})
+	}
+
+	return steps
+}
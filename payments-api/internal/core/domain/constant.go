@@ -1,7 +1,29 @@
 package domain
 
 const (
-	CODE_APPROVED                   = "00"
-	CODE_REJECTED_GENERIC           = "07"
-	CODE_REJECTED_INSUFICIENT_FUNDS = "51"
+	CODE_APPROVED                       = "00"
+	CODE_REJECTED_GENERIC               = "07"
+	CODE_REJECTED_INVALID_TRANSACTION   = "12"
+	CODE_REJECTED_INVALID_AMOUNT        = "13"
+	CODE_REJECTED_INVALID_ACCOUNT       = "14"
+	CODE_REJECTED_RECORD_NOT_FOUND      = "25"
+	CODE_REJECTED_ACCOUNT_CANCELLED     = "46"
+	CODE_REJECTED_INSUFICIENT_FUNDS     = "51"
+	CODE_REJECTED_EXPIRED_CARD          = "54"
+	CODE_REJECTED_CARD_NOT_PERMITTED    = "57"
+	CODE_REJECTED_SUSPECTED_FRAUD       = "59"
+	CODE_REJECTED_LIMIT_EXCEEDED        = "61"
+	CODE_REJECTED_ACCOUNT_BLOCKED       = "62"
+	CODE_REJECTED_SYSTEM_TIMEOUT        = "91"
+	CODE_REJECTED_DUPLICATE_TRANSACTION = "94"
+)
+
+const (
+	TRANSACTION_OPERATION_AUTHORIZATION = "AUTHORIZATION"
+	TRANSACTION_OPERATION_REFUND        = "REFUND"
+	TRANSACTION_OPERATION_CREDIT        = "CREDIT"
+	TRANSACTION_OPERATION_ADJUSTMENT    = "ADJUSTMENT"
+
+	TRANSACTION_ENTRY_DEBIT  = "DEBIT"
+	TRANSACTION_ENTRY_CREDIT = "CREDIT"
 )
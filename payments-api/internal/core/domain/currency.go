@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+const DEFAULT_CURRENCY = "BRL"
+
+/*
+  - ISO-4217 minor units of the supported currencies. The ledger stores amounts
+    with two decimal places, so currencies with three minor units are not listed
+*/
+var currencyMinorUnits = map[string]int32{
+	"BRL": 2,
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"ARS": 2,
+	"MXN": 2,
+	"CLP": 0,
+	"PYG": 0,
+	"JPY": 0,
+}
+
+/*
+- An empty code is the default currency, kept for requests sent before currencies
+*/
+func NormalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return DEFAULT_CURRENCY, nil
+	}
+
+	if _, ok := currencyMinorUnits[code]; !ok {
+		return "", fmt.Errorf("currency %s not supported", code)
+	}
+
+	return code, nil
+}
+
+func CurrencyMinorUnits(code string) int32 {
+	minorUnits, ok := currencyMinorUnits[code]
+	if !ok {
+		return currencyMinorUnits[DEFAULT_CURRENCY]
+	}
+
+	return minorUnits
+}
+
+/*
+- An amount fits its currency when it has no more decimal places than its minor units
+*/
+func AmountFitsCurrency(amount decimal.Decimal, code string) bool {
+	return amount.Equal(amount.Truncate(CurrencyMinorUnits(code)))
+}
+
+/*
+  - Rates to convert an amount of the transaction currency into the currency of
+    each category, provided only for accounts that allow the conversion
+*/
+type ExchangeRates map[string]decimal.Decimal
+
+/*
+  - Amounts are converted with banker's rounding to the minor units of the
+    currency they are converted to. Amounts of the same currency are kept as sent
+*/
+func convertAmount(amount, rate decimal.Decimal, toCurrency string) decimal.Decimal {
+	if rate.Equal(decimal.NewFromInt(1)) {
+		return amount
+	}
+
+	return amount.Mul(rate).RoundBank(CurrencyMinorUnits(toCurrency))
+}
+
+func revertAmount(amount, rate decimal.Decimal, toCurrency string) decimal.Decimal {
+	if rate.Equal(decimal.NewFromInt(1)) {
+		return amount
+	}
+
+	return amount.DivRound(rate, 16).RoundBank(CurrencyMinorUnits(toCurrency))
+}
@@ -19,3 +19,7 @@ func NewCustomError(code string, message string) *CustomError {
 		Message: message,
 	}
 }
+
+func (e *CustomError) Reason() string {
+	return ResponseReason(e.Code)
+}
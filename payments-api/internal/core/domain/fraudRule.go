@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	RISK_DECISION_APPROVE = "APPROVE"
+	RISK_DECISION_REVIEW  = "REVIEW"
+	RISK_DECISION_DECLINE = "DECLINE"
+
+	FRAUD_RULE_MCC_BLOCKLIST       = "MCC_BLOCKLIST"
+	FRAUD_RULE_FIRST_SEEN_MERCHANT = "FIRST_SEEN_MERCHANT"
+	FRAUD_RULE_RAPID_REPEAT        = "RAPID_REPEAT"
+	FRAUD_RULE_IMPOSSIBLE_VELOCITY = "IMPOSSIBLE_VELOCITY"
+)
+
+var riskDecisionSeverity = map[string]int{
+	RISK_DECISION_APPROVE: 0,
+	RISK_DECISION_REVIEW:  1,
+	RISK_DECISION_DECLINE: 2,
+}
+
+/*
+- Decision is taken when the rule matches, using the parameters of its Kind
+- MCC_BLOCKLIST matches the MCCs
+- FIRST_SEEN_MERCHANT matches an Amount or higher at a merchant the account never paid
+- RAPID_REPEAT matches Count or more earlier payments at the same merchant within the Window
+- IMPOSSIBLE_VELOCITY matches a payment in another location within the Window
+*/
+type FraudRule struct {
+	Name     string
+	Kind     string
+	Decision string
+	MCCs     []string
+	Amount   decimal.Decimal
+	Count    int
+	Window   time.Duration
+}
+
+/*
+- A payment of the account, one per transaction even when it debits several categories
+*/
+type FraudPayment struct {
+	UID          uuid.UUID
+	MCC          string
+	MerchantName string
+	Amount       decimal.Decimal
+	At           time.Time
+}
+
+/*
+  - Payments of the account within the Lookback of the rules and whether it
+    has ever paid the merchant of the assessed payment
+*/
+type FraudHistory struct {
+	Payments     []FraudPayment
+	MerchantSeen bool
+}
+
+type FraudVerdict struct {
+	Rule     string
+	Decision string
+	Reason   string
+}
+
+/*
+- Decision is the most severe decision of the matched rules, APPROVE when none matches
+*/
+type FraudAssessment struct {
+	Decision string
+	Verdicts []FraudVerdict
+}
+
+type FraudRules []FraudRule
+
+func (fr FraudRules) Assess(payment FraudPayment, history FraudHistory) FraudAssessment {
+	assessment := FraudAssessment{
+		Decision: RISK_DECISION_APPROVE,
+		Verdicts: []FraudVerdict{},
+	}
+
+	for _, rule := range fr {
+		reason := rule.match(payment, history)
+		if reason == "" {
+			continue
+		}
+
+		assessment.Verdicts = append(assessment.Verdicts, FraudVerdict{
+			Rule:     rule.Name,
+			Decision: rule.Decision,
+			Reason:   reason,
+		})
+
+		if riskDecisionSeverity[rule.Decision] > riskDecisionSeverity[assessment.Decision] {
+			assessment.Decision = rule.Decision
+		}
+	}
+
+	return assessment
+}
+
+/*
+- Longest window of the rules, the history they need to be assessed
+*/
+func (fr FraudRules) Lookback() time.Duration {
+	lookback := time.Duration(0)
+	for _, rule := range fr {
+		lookback = max(lookback, rule.Window)
+	}
+
+	return lookback
+}
+
+func (fa FraudAssessment) Reasons() []string {
+	reasons := make([]string, 0, len(fa.Verdicts))
+	for _, verdict := range fa.Verdicts {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", verdict.Rule, verdict.Reason))
+	}
+
+	return reasons
+}
+
+func (r FraudRule) match(payment FraudPayment, history FraudHistory) string {
+	switch r.Kind {
+	case FRAUD_RULE_MCC_BLOCKLIST:
+		if slices.Contains(r.MCCs, payment.MCC) {
+			return fmt.Sprintf("mcc %s is blocklisted", payment.MCC)
+		}
+
+	case FRAUD_RULE_FIRST_SEEN_MERCHANT:
+		if !history.MerchantSeen && payment.Amount.GreaterThanOrEqual(r.Amount) {
+			return fmt.Sprintf(
+				"first payment at merchant %s with amount %s, at least %s",
+				NormalizeMerchantName(payment.MerchantName),
+				payment.Amount.String(),
+				r.Amount.String(),
+			)
+		}
+
+	case FRAUD_RULE_RAPID_REPEAT:
+		merchant := NormalizeMerchantName(payment.MerchantName)
+
+		repeats := 0
+		for _, previous := range r.within(payment, history) {
+			if NormalizeMerchantName(previous.MerchantName) == merchant {
+				repeats++
+			}
+		}
+
+		if repeats > 0 && repeats >= r.Count {
+			return fmt.Sprintf("%d payments at merchant %s within %s", repeats+1, merchant, r.Window)
+		}
+
+	case FRAUD_RULE_IMPOSSIBLE_VELOCITY:
+		location := MerchantLocation(payment.MerchantName)
+		if location == "" {
+			return ""
+		}
+
+		for _, previous := range r.within(payment, history) {
+			previousLocation := MerchantLocation(previous.MerchantName)
+			if previousLocation != "" && previousLocation != location {
+				return fmt.Sprintf(
+					"payment at %s %s after a payment at %s",
+					location,
+					payment.At.Sub(previous.At).Round(time.Second),
+					previousLocation,
+				)
+			}
+		}
+	}
+
+	return ""
+}
+
+func (r FraudRule) within(payment FraudPayment, history FraudHistory) []FraudPayment {
+	payments := []FraudPayment{}
+	for _, previous := range history.Payments {
+		if previous.UID == payment.UID || previous.At.After(payment.At) {
+			continue
+		}
+
+		if payment.At.Sub(previous.At) <= r.Window {
+			payments = append(payments, previous)
+		}
+	}
+
+	return payments
+}
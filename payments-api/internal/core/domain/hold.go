@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type Hold struct {
+	UID              uuid.UUID
+	AccountID        uint
+	AccountUID       uuid.UUID
+	CategoryID       uint
+	Amount           decimal.Decimal
+	Currency         string
+	MCC              string
+	MerchantName     string
+	ExpiresAt        time.Time
+	CardID           uint
+	OriginalAmount   decimal.Decimal
+	OriginalCurrency string
+	ExchangeRate     decimal.Decimal
+}
+
+func (h *Hold) IsExpired(now time.Time) bool {
+	return !h.ExpiresAt.After(now)
+}
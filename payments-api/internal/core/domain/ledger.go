@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+/*
+  - Balance is the category balance recorded by the last ledger entry and
+    LedgerBalance the one recomputed by summing every credit and debit
+  - BrokenEntries counts entries whose balance before differs from the
+    balance after of the previous entry of the same category
+*/
+type LedgerBalance struct {
+	Balance       decimal.Decimal
+	LedgerBalance decimal.Decimal
+	BrokenEntries int
+}
+
+func (lb *LedgerBalance) IsConsistent() bool {
+	return lb.Balance.Equal(lb.LedgerBalance) && lb.BrokenEntries == 0
+}
@@ -1,19 +1,44 @@
 package domain
 
 import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
+const (
+	MERCHANT_MATCH_EXACT      = "EXACT"
+	MERCHANT_MATCH_NORMALIZED = "NORMALIZED"
+	MERCHANT_MATCH_ALIAS      = "ALIAS"
+	MERCHANT_MATCH_PREFIX     = "PREFIX"
+	MERCHANT_MATCH_SIMILARITY = "SIMILARITY"
+
+	MERCHANT_ALIAS_PREFIX_WILDCARD = "*"
+)
+
+var (
+	merchantColumnGap = regexp.MustCompile(`\s{2,}`)
+	merchantSpaces    = regexp.MustCompile(`\s+`)
+	merchantWildcard  = regexp.MustCompile(`\s*\*\s*`)
+)
+
 type Merchant struct {
-	Name string
-	MCC  string
+	Name    string
+	MCC     string
+	Aliases []string
+
+	MatchRule    string
+	MatchPattern string
 }
 
 func (m *Merchant) NewTransaction(
 	transactionUID uuid.UUID,
 	mcc string,
 	totalAmount decimal.Decimal,
+	currency string,
 	name string,
 
 	account Account,
@@ -23,12 +48,209 @@ func (m *Merchant) NewTransaction(
 		correctMCC = m.MCC
 	}
 
-	return Transaction{
+	transaction := Transaction{
 		UID:          transactionUID,
 		AccountID:    account.ID,
 		AccountUID:   account.UID,
 		MCC:          correctMCC,
 		Amount:       totalAmount,
+		Currency:     currency,
 		MerchantName: name,
 	}
+
+	if account.Card != nil {
+		transaction.CardID = account.Card.ID
+	}
+
+	return transaction
+}
+
+/*
+  - Acquirers send the merchant as fixed width columns: name, city and country
+    padded with spaces. Everything after the first gap of two or more spaces is
+    the location and is dropped
+  - The remaining name is upper cased, its whitespace collapsed and the spaces
+    around the facilitator separator removed (`PAG *JOSE` is `PAG*JOSE`)
+*/
+func NormalizeMerchantName(name string) string {
+	normalized := strings.ToUpper(strings.TrimSpace(name))
+	normalized = merchantColumnGap.Split(normalized, 2)[0]
+	normalized = merchantSpaces.ReplaceAllString(normalized, " ")
+	normalized = merchantWildcard.ReplaceAllString(normalized, MERCHANT_ALIAS_PREFIX_WILDCARD)
+
+	return normalized
+}
+
+/*
+  - The city and country columns dropped by NormalizeMerchantName, upper cased
+    and with their whitespace collapsed. Empty when the name has no location
+*/
+func MerchantLocation(name string) string {
+	columns := merchantColumnGap.Split(strings.ToUpper(strings.TrimSpace(name)), 2)
+	if len(columns) < 2 {
+		return ""
+	}
+
+	return merchantSpaces.ReplaceAllString(columns[1], " ")
+}
+
+/*
+  - An alias ending with `*` is a prefix rule, `PAG*` matches every name
+    starting with `PAG`. Any other alias must match the normalized name
+*/
+func IsMerchantPrefixAlias(alias string) bool {
+	return strings.HasSuffix(alias, MERCHANT_ALIAS_PREFIX_WILDCARD)
+}
+
+/*
+  - Ratio between 0 and 1 of the Levenshtein distance over the longest name,
+    1 means equal names
+*/
+func MerchantNameSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+
+	if longest == 0 {
+		return 1
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			substitution := previous[j-1]
+			if ra[i-1] != rb[j-1] {
+				substitution++
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+
+		previous, current = current, previous
+	}
+
+	return 1 - float64(previous[len(rb)])/float64(longest)
+}
+
+type MerchantMatcher struct {
+	Merchants           []Merchant
+	SimilarityThreshold float64
+}
+
+/*
+  - Ceiling of MerchantNameSimilarity from the lengths alone, as the distance is
+    at least their difference
+*/
+func merchantLengthSimilarity(a, b string) float64 {
+	la, lb := utf8.RuneCountInString(a), utf8.RuneCountInString(b)
+
+	longest, shortest := la, lb
+	if lb > la {
+		longest, shortest = lb, la
+	}
+
+	if longest == 0 {
+		return 1
+	}
+
+	return float64(shortest) / float64(longest)
+}
+
+/*
+  - Rules are tried from the strictest to the loosest: normalized name, alias,
+    longest prefix alias and, when the threshold is above zero, the most similar
+    name or alias
+  - Only the names and aliases whose length can reach the best score so far are
+    compared by similarity
+  - The returned merchant carries the rule and the name or alias that matched
+*/
+func (mm *MerchantMatcher) Match(name string) (Merchant, bool) {
+	normalized := NormalizeMerchantName(name)
+	if normalized == "" {
+		return Merchant{}, false
+	}
+
+	for _, merchant := range mm.Merchants {
+		if NormalizeMerchantName(merchant.Name) == normalized {
+			return merchant.matchedBy(MERCHANT_MATCH_NORMALIZED, merchant.Name), true
+		}
+	}
+
+	for _, merchant := range mm.Merchants {
+		for _, alias := range merchant.Aliases {
+			if !IsMerchantPrefixAlias(alias) && alias == normalized {
+				return merchant.matchedBy(MERCHANT_MATCH_ALIAS, alias), true
+			}
+		}
+	}
+
+	var prefixMatch Merchant
+	prefixFound := false
+
+	for _, merchant := range mm.Merchants {
+		for _, alias := range merchant.Aliases {
+			prefix := strings.TrimSuffix(alias, MERCHANT_ALIAS_PREFIX_WILDCARD)
+			if !IsMerchantPrefixAlias(alias) || !strings.HasPrefix(normalized, prefix) {
+				continue
+			}
+
+			if !prefixFound || len(alias) > len(prefixMatch.MatchPattern) {
+				prefixMatch = merchant.matchedBy(MERCHANT_MATCH_PREFIX, alias)
+				prefixFound = true
+			}
+		}
+	}
+
+	if prefixFound {
+		return prefixMatch, true
+	}
+
+	if mm.SimilarityThreshold <= 0 {
+		return Merchant{}, false
+	}
+
+	var similarMatch Merchant
+	bestScore := mm.SimilarityThreshold
+
+	for _, merchant := range mm.Merchants {
+		candidates := []string{merchant.Name}
+		for _, alias := range merchant.Aliases {
+			if !IsMerchantPrefixAlias(alias) {
+				candidates = append(candidates, alias)
+			}
+		}
+
+		for _, candidate := range candidates {
+			normalizedCandidate := NormalizeMerchantName(candidate)
+			if merchantLengthSimilarity(normalized, normalizedCandidate) < bestScore {
+				continue
+			}
+
+			score := MerchantNameSimilarity(normalized, normalizedCandidate)
+			if score >= bestScore && (similarMatch.MatchRule == "" || score > bestScore) {
+				similarMatch = merchant.matchedBy(MERCHANT_MATCH_SIMILARITY, candidate)
+				bestScore = score
+			}
+		}
+	}
+
+	return similarMatch, similarMatch.MatchRule != ""
+}
+
+func (m Merchant) matchedBy(rule, pattern string) Merchant {
+	m.MatchRule = rule
+	m.MatchPattern = pattern
+
+	return m
 }
@@ -0,0 +1,52 @@
+package domain
+
+const (
+	REASON_APPROVED              = "APPROVED"
+	REASON_GENERIC_ERROR         = "GENERIC_ERROR"
+	REASON_INVALID_TRANSACTION   = "INVALID_TRANSACTION"
+	REASON_INVALID_AMOUNT        = "INVALID_AMOUNT"
+	REASON_INVALID_ACCOUNT       = "INVALID_ACCOUNT"
+	REASON_RECORD_NOT_FOUND      = "RECORD_NOT_FOUND"
+	REASON_ACCOUNT_CANCELLED     = "ACCOUNT_CANCELLED"
+	REASON_INSUFICIENT_FUNDS     = "INSUFICIENT_FUNDS"
+	REASON_EXPIRED_CARD          = "EXPIRED_CARD"
+	REASON_CARD_NOT_PERMITTED    = "CARD_NOT_PERMITTED"
+	REASON_SUSPECTED_FRAUD       = "SUSPECTED_FRAUD"
+	REASON_LIMIT_EXCEEDED        = "LIMIT_EXCEEDED"
+	REASON_ACCOUNT_BLOCKED       = "ACCOUNT_BLOCKED"
+	REASON_SYSTEM_TIMEOUT        = "SYSTEM_TIMEOUT"
+	REASON_DUPLICATE_TRANSACTION = "DUPLICATE_TRANSACTION"
+)
+
+/*
+- ISO-8583 response codes of the transactions and their machine-readable reasons
+*/
+var responseReasons = map[string]string{
+	CODE_APPROVED:                       REASON_APPROVED,
+	CODE_REJECTED_GENERIC:               REASON_GENERIC_ERROR,
+	CODE_REJECTED_INVALID_TRANSACTION:   REASON_INVALID_TRANSACTION,
+	CODE_REJECTED_INVALID_AMOUNT:        REASON_INVALID_AMOUNT,
+	CODE_REJECTED_INVALID_ACCOUNT:       REASON_INVALID_ACCOUNT,
+	CODE_REJECTED_RECORD_NOT_FOUND:      REASON_RECORD_NOT_FOUND,
+	CODE_REJECTED_ACCOUNT_CANCELLED:     REASON_ACCOUNT_CANCELLED,
+	CODE_REJECTED_INSUFICIENT_FUNDS:     REASON_INSUFICIENT_FUNDS,
+	CODE_REJECTED_EXPIRED_CARD:          REASON_EXPIRED_CARD,
+	CODE_REJECTED_CARD_NOT_PERMITTED:    REASON_CARD_NOT_PERMITTED,
+	CODE_REJECTED_SUSPECTED_FRAUD:       REASON_SUSPECTED_FRAUD,
+	CODE_REJECTED_LIMIT_EXCEEDED:        REASON_LIMIT_EXCEEDED,
+	CODE_REJECTED_ACCOUNT_BLOCKED:       REASON_ACCOUNT_BLOCKED,
+	CODE_REJECTED_SYSTEM_TIMEOUT:        REASON_SYSTEM_TIMEOUT,
+	CODE_REJECTED_DUPLICATE_TRANSACTION: REASON_DUPLICATE_TRANSACTION,
+}
+
+/*
+- Reason of the response code, GENERIC_ERROR when the code is not catalogued
+*/
+func ResponseReason(code string) string {
+	reason, ok := responseReasons[code]
+	if !ok {
+		return REASON_GENERIC_ERROR
+	}
+
+	return reason
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+/*
+  - Caps of the account when CategoryID is zero, otherwise of the category. A zero
+    cap is not enforced
+  - Amounts are in the currency of the account, and the account caps sum the
+    debits of every category of a transaction
+*/
+type SpendingLimit struct {
+	CategoryID         uint
+	DailyAmount        decimal.Decimal
+	MonthlyAmount      decimal.Decimal
+	TransactionAmount  decimal.Decimal
+	HourlyTransactions int
+
+	Usage SpendingUsage
+}
+
+/*
+- Amount spent in the current day and month and transactions in the current hour, in UTC
+- The active holds are spent in every window, until they are captured, voided or expire
+*/
+type SpendingUsage struct {
+	DailyAmount        decimal.Decimal
+	MonthlyAmount      decimal.Decimal
+	HourlyTransactions int
+}
+
+type SpendingLimits []SpendingLimit
+
+/*
+  - Amount debited per category by the transactions, along with their total under
+    the category zero, the key of the account limits
+  - Debits in other currencies are converted into the account currency with the
+    rates of SpendingRates
+*/
+func (a *Account) SpendingByCategory(transactions map[int]Transaction) (map[uint]decimal.Decimal, *CustomError) {
+	spending := make(map[uint]decimal.Decimal)
+
+	for _, transaction := range transactions {
+		if transaction.EntryType != TRANSACTION_ENTRY_DEBIT {
+			continue
+		}
+
+		amount, cErr := a.spendingAmount(transaction.Amount, transaction.Currency)
+		if cErr != nil {
+			return make(map[uint]decimal.Decimal), cErr
+		}
+
+		spending[transaction.CategoryID] = spending[transaction.CategoryID].Add(amount)
+		spending[0] = spending[0].Add(amount)
+	}
+
+	return spending, nil
+}
+
+/*
+- Amount held per category by the holds, keyed as in SpendingByCategory
+*/
+func (a *Account) SpendingByHolds(holds map[int]Hold) (map[uint]decimal.Decimal, *CustomError) {
+	spending := make(map[uint]decimal.Decimal)
+
+	for _, hold := range holds {
+		amount, cErr := a.spendingAmount(hold.Amount, hold.Currency)
+		if cErr != nil {
+			return make(map[uint]decimal.Decimal), cErr
+		}
+
+		spending[hold.CategoryID] = spending[hold.CategoryID].Add(amount)
+		spending[0] = spending[0].Add(amount)
+	}
+
+	return spending, nil
+}
+
+func (a *Account) spendingAmount(amount decimal.Decimal, currency string) (decimal.Decimal, *CustomError) {
+	if currency == "" || currency == a.Currency {
+		return amount, nil
+	}
+
+	rate, ok := a.SpendingRates[currency]
+	if !ok || !rate.IsPositive() {
+		return decimal.Zero, NewCustomError(
+			CODE_REJECTED_INVALID_TRANSACTION,
+			fmt.Sprintf("Exchange rate from %s to %s not found to check the spending limits", currency, a.Currency),
+		)
+	}
+
+	return convertAmount(amount, rate, a.Currency), nil
+}
+
+func (sl SpendingLimits) check(spending map[uint]decimal.Decimal) *CustomError {
+	return sl.checkSpending(spending, false)
+}
+
+/*
+  - The captured holds are already in the usage, and their authorization was
+    already counted as a transaction of its hour
+*/
+func (sl SpendingLimits) checkCaptured(spending map[uint]decimal.Decimal) *CustomError {
+	return sl.checkSpending(spending, true)
+}
+
+func (sl SpendingLimits) checkSpending(spending map[uint]decimal.Decimal, held bool) *CustomError {
+	for _, limit := range sl {
+		amount, ok := spending[limit.CategoryID]
+		if !ok || !amount.IsPositive() {
+			continue
+		}
+
+		if breach := limit.breach(amount, held); breach != "" {
+			return NewCustomError(
+				CODE_REJECTED_LIMIT_EXCEEDED,
+				fmt.Sprintf("%s limit exceeded for %s", breach, limit.scope()),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (l SpendingLimit) breach(amount decimal.Decimal, held bool) string {
+	usage := l.Usage
+	if held {
+		usage.DailyAmount = usage.DailyAmount.Sub(amount)
+		usage.MonthlyAmount = usage.MonthlyAmount.Sub(amount)
+	}
+
+	switch {
+	case l.TransactionAmount.IsPositive() && amount.GreaterThan(l.TransactionAmount):
+		return "transaction amount"
+	case l.DailyAmount.IsPositive() && usage.DailyAmount.Add(amount).GreaterThan(l.DailyAmount):
+		return "daily amount"
+	case l.MonthlyAmount.IsPositive() && usage.MonthlyAmount.Add(amount).GreaterThan(l.MonthlyAmount):
+		return "monthly amount"
+	case !held && l.HourlyTransactions > 0 && usage.HourlyTransactions >= l.HourlyTransactions:
+		return "hourly transactions"
+	}
+
+	return ""
+}
+
+func (l SpendingLimit) scope() string {
+	if l.CategoryID == 0 {
+		return "account"
+	}
+
+	return fmt.Sprintf("category %d", l.CategoryID)
+}
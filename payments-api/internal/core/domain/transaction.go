@@ -5,12 +5,83 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+/*
+  - As a ledger entry, Amount is the debited or credited amount of the category
+    and BalanceBefore/BalanceAfter are the posted category balance around it
+  - Currency is the currency of Amount, and OriginalAmount is the share of the
+    requested amount in OriginalCurrency converted into it with ExchangeRate
+  - CardID is the card the transaction was paid with, zero when it was not
+*/
 type Transaction struct {
-	UID          uuid.UUID
-	AccountID    uint
-	AccountUID   uuid.UUID
-	CategoryID   uint
-	MCC          string
-	Amount       decimal.Decimal
-	MerchantName string
+	UID              uuid.UUID
+	AccountID        uint
+	AccountUID       uuid.UUID
+	CardID           uint
+	CategoryID       uint
+	MCC              string
+	Amount           decimal.Decimal
+	Currency         string
+	MerchantName     string
+	OriginalUID      uuid.UUID
+	Operation        string
+	EntryType        string
+	BalanceBefore    decimal.Decimal
+	BalanceAfter     decimal.Decimal
+	OriginalAmount   decimal.Decimal
+	OriginalCurrency string
+	ExchangeRate     decimal.Decimal
+}
+
+func (t Transaction) withOriginalAmount(currency string, amount, rate decimal.Decimal) Transaction {
+	t.OriginalCurrency = currency
+	t.OriginalAmount = amount
+	t.ExchangeRate = rate
+	return t
+}
+
+/*
+  - AmountCaptured and AmountRefunded are in the currency of the category, and
+    OriginalAmount and OriginalRefunded their shares in OriginalCurrency, the
+    currency of the transaction, converted with ExchangeRate
+  - Without OriginalCurrency the transaction was not converted
+*/
+type TransactionCaptured struct {
+	CategoryID       uint
+	Priority         int
+	AmountCaptured   decimal.Decimal
+	AmountRefunded   decimal.Decimal
+	OriginalAmount   decimal.Decimal
+	OriginalRefunded decimal.Decimal
+	OriginalCurrency string
+	ExchangeRate     decimal.Decimal
+}
+
+func (tc *TransactionCaptured) Refundable() decimal.Decimal {
+	refundable := tc.AmountCaptured.Sub(tc.AmountRefunded)
+	if refundable.IsNegative() {
+		return decimal.Zero
+	}
+
+	return refundable
+}
+
+func (tc *TransactionCaptured) RefundableOriginal() decimal.Decimal {
+	if tc.OriginalCurrency == "" {
+		return tc.Refundable()
+	}
+
+	refundable := tc.OriginalAmount.Sub(tc.OriginalRefunded)
+	if refundable.IsNegative() {
+		return decimal.Zero
+	}
+
+	return refundable
+}
+
+func (tc *TransactionCaptured) rate() decimal.Decimal {
+	if tc.OriginalCurrency == "" || !tc.ExchangeRate.IsPositive() {
+		return decimal.NewFromInt(1)
+	}
+
+	return tc.ExchangeRate
 }
@@ -2,17 +2,25 @@ package port
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 )
 
+var ErrAccountNotFound = errors.New("account not found")
+
 type AccountEntity struct {
-	ID      uint
-	UID     uuid.UUID
-	Balance BalanceEntity
+	ID                 uint
+	UID                uuid.UUID
+	Status             string
+	CurrencyConversion bool
+	Currency           string
+	Balance            BalanceEntity
 }
 
 type AccountRepository interface {
 	FindByUID(ctx context.Context, uid uuid.UUID) (AccountEntity, error)
-	SaveTransactions(ctx context.Context, transactions map[int]TransactionEntity) error
+	FindTransactionsByUID(ctx context.Context, uid uuid.UUID) (map[int]TransactionCapturedEntity, error)
+	FindTransactionsByAccountUID(ctx context.Context, filter TransactionHistoryFilterEntity) ([]TransactionHistoryEntity, error)
+	SaveTransactions(ctx context.Context, transactions map[int]TransactionEntity, fencingToken int64) error
 }
@@ -0,0 +1,167 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ADMIN_LIST_DEFAULT_LIMIT = 50
+	ADMIN_LIST_MAX_LIMIT     = 500
+)
+
+var (
+	ErrInvalidAdminRequest     = errors.New("invalid admin request")
+	ErrCategoryNotFound        = errors.New("category not found")
+	ErrCategoryAlreadyAttached = errors.New("category already attached to account")
+	ErrCategoryNotAttached     = errors.New("category not attached to account")
+	ErrMCCAlreadyAssigned      = errors.New("mcc already assigned to a category")
+	ErrAccountStatusTransition = errors.New("account status transition not allowed")
+)
+
+type AccountCreateRequest struct {
+	Name               string `json:"name" validate:"required,min=3,max=255" binding:"required" example:"Jonh Doe"`
+	CurrencyConversion bool   `json:"currencyConversion" example:"false"`
+}
+
+type AccountListRequest struct {
+	Cursor string `form:"cursor" json:"cursor" example:"MQ"`
+	Limit  int    `form:"limit" json:"limit" validate:"omitempty,min=1,max=500" example:"50"`
+}
+
+type AccountCategoryRequest struct {
+	AccountUID  uuid.UUID `json:"-" swaggerignore:"true"`
+	CategoryUID uuid.UUID `json:"-" swaggerignore:"true"`
+}
+
+/*
+- Action is BLOCK, UNBLOCK or CANCEL, taken from the route
+*/
+type AccountStatusRequest struct {
+	AccountUID uuid.UUID `json:"-" swaggerignore:"true"`
+	Action     string    `json:"-" swaggerignore:"true"`
+	Reason     string    `json:"reason" validate:"max=255" example:"card reported stolen"`
+}
+
+type CategoryCreateRequest struct {
+	Name     string `json:"name" validate:"required,min=3,max=255" binding:"required" example:"MOBILITY"`
+	Priority int    `json:"priority" validate:"required,min=1" binding:"required" example:"3"`
+	Currency string `json:"currency" validate:"omitempty,len=3" example:"BRL"`
+
+	FallbackExcluded bool `json:"fallbackExcluded" example:"false"`
+}
+
+type MCCAssignRequest struct {
+	CategoryUID uuid.UUID `json:"-" swaggerignore:"true"`
+	MCC         string    `json:"mcc" validate:"required,numeric,min=4,max=4" binding:"required" example:"4121"`
+}
+
+type CategoryResponse struct {
+	UID      string   `json:"uid" example:"809d8fa8-b726-4ddc-92da-b565fdcad75a"`
+	Name     string   `json:"name" example:"MOBILITY"`
+	Priority int      `json:"priority" example:"3"`
+	Currency string   `json:"currency" example:"BRL"`
+	MCCs     []string `json:"mccs" example:"4121"`
+
+	FallbackExcluded bool `json:"fallbackExcluded" example:"false"`
+}
+
+type AccountResponse struct {
+	UID                string             `json:"uid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name               string             `json:"name" example:"Jonh Doe"`
+	CurrencyConversion bool               `json:"currencyConversion" example:"false"`
+	Status             string             `json:"status" example:"ACTIVE"`
+	Categories         []CategoryResponse `json:"categories"`
+	CreatedAt          time.Time          `json:"createdAt" example:"2024-12-04T21:50:21Z"`
+}
+
+type AccountStatusChangeResponse struct {
+	AccountUID     string    `json:"account" example:"123e4567-e89b-12d3-a456-426614174000"`
+	PreviousStatus string    `json:"previousStatus" example:"ACTIVE"`
+	Status         string    `json:"status" example:"BLOCKED"`
+	Reason         string    `json:"reason" example:"card reported stolen"`
+	CreatedAt      time.Time `json:"createdAt" example:"2024-12-04T21:50:21Z"`
+}
+
+type AccountStatusChangeListResponse struct {
+	Changes []AccountStatusChangeResponse `json:"changes"`
+}
+
+type AccountListResponse struct {
+	Accounts   []AccountResponse `json:"accounts"`
+	NextCursor string            `json:"nextCursor,omitempty" example:"MQ"`
+}
+
+type MCCResponse struct {
+	UID         string `json:"uid" example:"3f77143d-28bb-4d7f-bcf7-0ecff815aab4"`
+	CategoryUID string `json:"category" example:"809d8fa8-b726-4ddc-92da-b565fdcad75a"`
+	MCC         string `json:"mcc" example:"4121"`
+}
+
+type AccountAdminEntity struct {
+	ID                 uint
+	UID                uuid.UUID
+	Name               string
+	CurrencyConversion bool
+	Status             string
+	Categories         []CategoryAdminEntity
+	CreatedAt          time.Time
+}
+
+type AccountStatusChangeEntity struct {
+	AccountUID     uuid.UUID
+	PreviousStatus string
+	Status         string
+	Reason         string
+	CreatedAt      time.Time
+}
+
+type CategoryAdminEntity struct {
+	ID       uint
+	UID      uuid.UUID
+	Name     string
+	Priority int
+	Currency string
+	MCCs     []string
+
+	FallbackExcluded bool
+}
+
+type MCCAdminEntity struct {
+	UID         uuid.UUID
+	CategoryUID uuid.UUID
+	MCC         string
+}
+
+/*
+- CursorID is exclusive: only accounts created after it (higher ID) are returned
+*/
+type AccountListFilterEntity struct {
+	CursorID uint
+	Limit    int
+}
+
+/*
+  - Deletions are soft deletes (`deleted_at`), as everywhere else in the schema
+  - Attaching a category opens its balance with a zero amount transaction when
+    the account never had one, so it shows in the account balance
+  - An MCC belongs to a single active category
+  - A status change only applies while the account is still in PreviousStatus,
+    otherwise ErrAccountStatusTransition is returned. Every change is kept in
+    the `account_status_changes` audit trail, oldest first
+*/
+type AdminRepository interface {
+	CreateAccount(ctx context.Context, account AccountAdminEntity) (AccountAdminEntity, error)
+	DeleteAccount(ctx context.Context, uid uuid.UUID) error
+	FindAccounts(ctx context.Context, filter AccountListFilterEntity) ([]AccountAdminEntity, error)
+	FindAccountStatus(ctx context.Context, uid uuid.UUID) (string, error)
+	ChangeAccountStatus(ctx context.Context, change AccountStatusChangeEntity) (AccountStatusChangeEntity, error)
+	FindAccountStatusChanges(ctx context.Context, uid uuid.UUID) ([]AccountStatusChangeEntity, error)
+	AttachCategory(ctx context.Context, accountUID, categoryUID uuid.UUID) error
+	DetachCategory(ctx context.Context, accountUID, categoryUID uuid.UUID) error
+	CreateCategory(ctx context.Context, category CategoryAdminEntity) (CategoryAdminEntity, error)
+	AssignMCC(ctx context.Context, mcc MCCAdminEntity) (MCCAdminEntity, error)
+}
@@ -1,16 +1,69 @@
 package port
 
-import "github.com/jtonynet/go-payments-api/internal/core/domain"
+import (
+	"errors"
+
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+)
+
+const (
+	CODE_APPROVED                       = domain.CODE_APPROVED
+	CODE_REJECTED_GENERIC               = domain.CODE_REJECTED_GENERIC
+	CODE_REJECTED_INVALID_TRANSACTION   = domain.CODE_REJECTED_INVALID_TRANSACTION
+	CODE_REJECTED_INVALID_AMOUNT        = domain.CODE_REJECTED_INVALID_AMOUNT
+	CODE_REJECTED_INVALID_ACCOUNT       = domain.CODE_REJECTED_INVALID_ACCOUNT
+	CODE_REJECTED_RECORD_NOT_FOUND      = domain.CODE_REJECTED_RECORD_NOT_FOUND
+	CODE_REJECTED_ACCOUNT_CANCELLED     = domain.CODE_REJECTED_ACCOUNT_CANCELLED
+	CODE_REJECTED_INSUFICIENT_FUNDS     = domain.CODE_REJECTED_INSUFICIENT_FUNDS
+	CODE_REJECTED_EXPIRED_CARD          = domain.CODE_REJECTED_EXPIRED_CARD
+	CODE_REJECTED_CARD_NOT_PERMITTED    = domain.CODE_REJECTED_CARD_NOT_PERMITTED
+	CODE_REJECTED_SUSPECTED_FRAUD       = domain.CODE_REJECTED_SUSPECTED_FRAUD
+	CODE_REJECTED_LIMIT_EXCEEDED        = domain.CODE_REJECTED_LIMIT_EXCEEDED
+	CODE_REJECTED_ACCOUNT_BLOCKED       = domain.CODE_REJECTED_ACCOUNT_BLOCKED
+	CODE_REJECTED_SYSTEM_TIMEOUT        = domain.CODE_REJECTED_SYSTEM_TIMEOUT
+	CODE_REJECTED_DUPLICATE_TRANSACTION = domain.CODE_REJECTED_DUPLICATE_TRANSACTION
+)
 
 const (
-	CODE_APPROVED                   = domain.CODE_APPROVED
-	CODE_REJECTED_GENERIC           = domain.CODE_REJECTED_GENERIC
-	CODE_REJECTED_INSUFICIENT_FUNDS = domain.CODE_REJECTED_INSUFICIENT_FUNDS
+	ACCOUNT_ACTION_BLOCK   = domain.ACCOUNT_ACTION_BLOCK
+	ACCOUNT_ACTION_UNBLOCK = domain.ACCOUNT_ACTION_UNBLOCK
+	ACCOUNT_ACTION_CANCEL  = domain.ACCOUNT_ACTION_CANCEL
 )
 
+var (
+	ErrInvalidCursor        = errors.New("invalid pagination cursor")
+	ErrInvalidAmount        = errors.New("invalid transaction amount")
+	ErrInvalidCurrency      = errors.New("invalid transaction currency")
+	ErrDuplicateTransaction = errors.New("duplicate transaction")
+	ErrTransactionNotFound  = errors.New("transaction not found")
+)
+
+/*
+- Machine-readable reason of a response code, returned alongside the code
+*/
+func ResponseReason(code string) string {
+	return domain.ResponseReason(code)
+}
+
 type TimeoutSLA int64
 
+type AuthorizationHoldTTL int64
+
+type CreditBatchWorkers int
+
+type MerchantSimilarityThreshold float64
+
+type FraudRulesReloadInterval int64
+
+type TransactionEventRelayInterval int64
+
+type TransactionEventRelayBatchSize int
+
 type APIhealthResponse struct {
 	Message string `json:"message" example:"OK"`
 	Sumary  string `json:"sumary" example:"payments-api:8080 in TagVersion: 0.0.0 on Envoriment:dev responds OK"`
 }
+
+type APIerrorResponse struct {
+	Message string `json:"message" example:"field Limit is invalid"`
+}
@@ -1,10 +1,47 @@
 package port
 
 import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
+/*
+- HoldsExpireAt is the nearest expiry of the active holds, zero without them
+*/
 type BalanceEntity struct {
-	AmountTotal decimal.Decimal
-	Categories  map[int]TransactionByCategoryEntity
+	AmountTotal   decimal.Decimal
+	Categories    map[int]TransactionByCategoryEntity
+	HoldsExpireAt time.Time
+}
+
+type BalanceRequest struct {
+	AccountUID   uuid.UUID `json:"-" swaggerignore:"true"`
+	IncludeHolds bool      `form:"includeHolds" json:"includeHolds" example:"false"`
+}
+
+type BalanceCategoryResponse struct {
+	Name       string           `json:"name" example:"FOOD"`
+	Priority   int              `json:"priority" example:"1"`
+	Currency   string           `json:"currency" example:"BRL"`
+	MCCs       []string         `json:"mccs" example:"5411,5412"`
+	Amount     decimal.Decimal  `json:"amount" example:"105.02"`
+	AmountHeld *decimal.Decimal `json:"amountHeld,omitempty" example:"10.00"`
+}
+
+type BalanceResponse struct {
+	AccountUID  string                    `json:"account" example:"123e4567-e89b-12d3-a456-426614174000"`
+	AmountTotal decimal.Decimal           `json:"amountTotal" example:"930.66"`
+	AmountHeld  *decimal.Decimal          `json:"amountHeld,omitempty" example:"10.00"`
+	Categories  []BalanceCategoryResponse `json:"categories"`
+}
+
+/*
+  - Read side of the account balance, may be served by a cache:
+    never use it to approve transactions, use AccountRepository.FindByUID instead
+*/
+type BalanceRepository interface {
+	FindByAccountUID(ctx context.Context, uid uuid.UUID) (AccountEntity, error)
 }
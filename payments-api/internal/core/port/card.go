@@ -0,0 +1,86 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrCardNotFound   = errors.New("card not found")
+	ErrCardTokenTaken = errors.New("card token already registered")
+	ErrCardCancelled  = errors.New("card is cancelled")
+)
+
+/*
+  - Token is the card id the acquirer sends in the payments, the PAN is only kept
+    masked (first six and last four digits)
+  - Expiry is the MM/YY printed on the card. A zero cap is not enforced
+*/
+type CardCreateRequest struct {
+	AccountUID        uuid.UUID       `json:"-" swaggerignore:"true"`
+	Token             string          `json:"token" validate:"required,min=8,max=64" binding:"required" example:"tok_4f9a2c7e1b3d5a60"`
+	MaskedPAN         string          `json:"maskedPan" validate:"required" binding:"required" example:"411111******1111"`
+	Expiry            string          `json:"expiry" validate:"required,len=5" binding:"required" example:"12/30"`
+	TransactionAmount decimal.Decimal `json:"transactionAmount" example:"80.00"`
+	DailyAmount       decimal.Decimal `json:"dailyAmount" example:"150.00"`
+}
+
+/*
+- Replaces the status, expiry and limits of the card. A cancelled card is final
+*/
+type CardUpdateRequest struct {
+	CardUID           uuid.UUID       `json:"-" swaggerignore:"true"`
+	Status            string          `json:"status" validate:"required" binding:"required" example:"BLOCKED"`
+	Expiry            string          `json:"expiry" validate:"required,len=5" binding:"required" example:"12/30"`
+	TransactionAmount decimal.Decimal `json:"transactionAmount" example:"80.00"`
+	DailyAmount       decimal.Decimal `json:"dailyAmount" example:"150.00"`
+}
+
+type CardResponse struct {
+	UID               string          `json:"uid" example:"5f0c3a4e-8d2b-4c1a-9e7f-2b6d8a1c3e5f"`
+	AccountUID        string          `json:"account" example:"123e4567-e89b-12d3-a456-426614174000"`
+	MaskedPAN         string          `json:"maskedPan" example:"411111******1111"`
+	Status            string          `json:"status" example:"ACTIVE"`
+	Expiry            string          `json:"expiry" example:"12/30"`
+	TransactionAmount decimal.Decimal `json:"transactionAmount" example:"80.00"`
+	DailyAmount       decimal.Decimal `json:"dailyAmount" example:"150.00"`
+	CreatedAt         time.Time       `json:"createdAt" example:"2024-12-04T21:50:21Z"`
+}
+
+type CardListResponse struct {
+	Cards []CardResponse `json:"cards"`
+}
+
+type CardEntity struct {
+	ID                uint
+	UID               uuid.UUID
+	AccountID         uint
+	AccountUID        uuid.UUID
+	Token             string
+	MaskedPAN         string
+	Status            string
+	ExpiresAt         time.Time
+	TransactionAmount decimal.Decimal
+	DailyAmount       decimal.Decimal
+	CreatedAt         time.Time
+}
+
+/*
+  - Cards kept in the `cards` table, a token belongs to a single active card
+  - FindByToken returns ErrCardNotFound for unknown tokens and for cards of
+    deleted accounts
+  - FindDailyUsage sums the AUTHORIZATION debits paid with the card since the
+    start of the UTC day of at
+*/
+type CardRepository interface {
+	FindByToken(ctx context.Context, token string) (CardEntity, error)
+	FindByUID(ctx context.Context, uid uuid.UUID) (CardEntity, error)
+	FindByAccountUID(ctx context.Context, accountUID uuid.UUID) ([]CardEntity, error)
+	FindDailyUsage(ctx context.Context, cardID uint, at time.Time) (decimal.Decimal, error)
+	Create(ctx context.Context, card CardEntity) (CardEntity, error)
+	Update(ctx context.Context, card CardEntity) (CardEntity, error)
+}
@@ -3,6 +3,9 @@ package port
 type CategoryEntity struct {
 	ID       uint
 	Name     string
+	Currency string
 	MCCs     []string
 	Priority int
+
+	FallbackExcluded bool
 }
@@ -0,0 +1,82 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const CATEGORY_RULE_MAX_FALLBACKS = 20
+
+var ErrCategoryFallbackExcluded = errors.New("category excluded from fallbacks")
+
+/*
+  - Replaces the fallback chain of the category matched by the MCC, the chain used
+    when no category matches it when Category is empty. Without Account the chain
+    is the default of every account, otherwise an override of the account
+  - An empty Fallbacks removes the chain
+*/
+type CategoryRuleRequest struct {
+	AccountUID  string   `json:"account" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CategoryUID string   `json:"category" example:"e5ce3deb-7dea-4382-a1fd-1428c9888bdc"`
+	Fallbacks   []string `json:"fallbacks" validate:"max=20" example:"32e04519-a979-4de2-a20e-77e8342d718f,4cfdc9f0-a9d8-409d-ba8e-58a36e126ec1"`
+}
+
+type CategoryRuleFallbackResponse struct {
+	UID  string `json:"uid" example:"32e04519-a979-4de2-a20e-77e8342d718f"`
+	Name string `json:"name" example:"FOOD"`
+}
+
+type CategoryRuleResponse struct {
+	AccountUID  string                         `json:"account,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CategoryUID string                         `json:"category,omitempty" example:"e5ce3deb-7dea-4382-a1fd-1428c9888bdc"`
+	Fallbacks   []CategoryRuleFallbackResponse `json:"fallbacks"`
+}
+
+type CategoryRuleListResponse struct {
+	Rules []CategoryRuleResponse `json:"rules"`
+}
+
+/*
+- Category tried to pay a transaction, by the rule that selected it: `MCC`, `FALLBACK` or `DEFAULT_FALLBACK`
+*/
+type CategoryAttemptResponse struct {
+	Category string          `json:"category" example:"FOOD"`
+	Rule     string          `json:"rule" example:"MCC"`
+	Amount   decimal.Decimal `json:"amount" example:"100.09"`
+	Currency string          `json:"currency" example:"BRL"`
+}
+
+/*
+- AccountID and CategoryID are zero for the default chain and the chain without MCC category
+*/
+type CategoryRuleEntity struct {
+	AccountID          uint
+	CategoryID         uint
+	FallbackCategoryID uint
+	Position           int
+}
+
+/*
+- AccountUID and CategoryUID are nil for the default chain and the chain without MCC category
+*/
+type CategoryRuleChainEntity struct {
+	AccountUID  uuid.UUID
+	CategoryUID uuid.UUID
+	Fallbacks   []CategoryAdminEntity
+}
+
+/*
+  - Category resolution rules kept in the `category_rules` table, one row per
+    fallback of a chain ordered by its position
+  - FindByAccountID returns the default rules along with the rules of the account
+  - SaveChain soft deletes the current chain before writing the new one, rejecting
+    fallbacks excluded from fallbacks
+*/
+type CategoryRuleRepository interface {
+	FindByAccountID(ctx context.Context, accountID uint) ([]CategoryRuleEntity, error)
+	SaveChain(ctx context.Context, chain CategoryRuleChainEntity) (CategoryRuleChainEntity, error)
+	FindChains(ctx context.Context, accountUID uuid.UUID) ([]CategoryRuleChainEntity, error)
+}
@@ -0,0 +1,48 @@
+package port
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const CREDIT_BATCH_MAX_SIZE = 100000
+
+var ErrCreditBatchTooLarge = errors.New("credit batch exceeds the maximum size")
+
+type TransactionCreditRequest struct {
+	AccountUID     uuid.UUID       `json:"account" validate:"required,uuid" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TransactionUID uuid.UUID       `json:"-" swaggerignore:"true"`
+	Category       string          `json:"category" validate:"required,min=3,max=255" binding:"required" example:"FOOD"`
+	TotalAmount    decimal.Decimal `json:"totalAmount" validate:"required,min=0.01" binding:"required" example:"500.00"`
+}
+
+/*
+  - `transaction` is the idempotency key of each credit, so a payroll file can be
+    resent after a partial failure without crediting the same line twice
+*/
+type TransactionCreditBatchItemRequest struct {
+	TransactionUID uuid.UUID       `json:"transaction" example:"3f77143d-28bb-4d7f-bcf7-0ecff815aab4"`
+	AccountUID     uuid.UUID       `json:"account" validate:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Category       string          `json:"category" validate:"required,min=3,max=255" example:"FOOD"`
+	TotalAmount    decimal.Decimal `json:"totalAmount" validate:"required,min=0.01" example:"500.00"`
+}
+
+type TransactionCreditBatchRequest struct {
+	Credits []TransactionCreditBatchItemRequest `json:"credits" validate:"required,min=1,max=100000,dive" binding:"required"`
+}
+
+type TransactionCreditRejectionResponse struct {
+	Index          int    `json:"index" example:"3"`
+	AccountUID     string `json:"account" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TransactionUID string `json:"transaction" example:"3f77143d-28bb-4d7f-bcf7-0ecff815aab4"`
+	Code           string `json:"code" example:"14"`
+	Reason         string `json:"reason" example:"INVALID_ACCOUNT"`
+}
+
+type TransactionCreditBatchResponse struct {
+	Approved   int                                  `json:"approved" example:"99999"`
+	Rejected   int                                  `json:"rejected" example:"1"`
+	Rejections []TransactionCreditRejectionResponse `json:"rejections"`
+}
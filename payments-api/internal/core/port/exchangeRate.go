@@ -0,0 +1,27 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+var ErrExchangeRateNotFound = errors.New("exchange rate not found")
+
+/*
+- Rate is the amount of ToCurrency bought by one unit of FromCurrency
+*/
+type ExchangeRateEntity struct {
+	FromCurrency string
+	ToCurrency   string
+	Rate         decimal.Decimal
+}
+
+/*
+  - Provider of the rates converting transactions into the currency of the
+    account categories, replaceable by any other source of rates
+*/
+type ExchangeRateRepository interface {
+	FindRate(ctx context.Context, fromCurrency, toCurrency string) (ExchangeRateEntity, error)
+}
@@ -0,0 +1,66 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+/*
+  - Pre-authorization risk stage of the payment pipeline, run once the merchant
+    is matched and before the funds are debited or held
+  - Decision is APPROVE, REVIEW or DECLINE, with the Reasons of the decision.
+    A REVIEW goes on to the approval, a DECLINE rejects the transaction
+*/
+type RiskStage interface {
+	Assess(ctx context.Context, payment RiskPaymentEntity) (RiskDecisionEntity, error)
+}
+
+type RiskPaymentEntity struct {
+	TransactionUID uuid.UUID
+	AccountID      uint
+	AccountUID     uuid.UUID
+	MCC            string
+	MerchantName   string
+	Amount         decimal.Decimal
+	Currency       string
+	At             time.Time
+}
+
+type RiskDecisionEntity struct {
+	Decision string
+	Reasons  []string
+}
+
+type FraudRuleEntity struct {
+	ID       uint
+	Name     string
+	Kind     string
+	Decision string
+	MCCs     []string
+	Amount   decimal.Decimal
+	Count    int
+	Window   time.Duration
+}
+
+/*
+- Payments are the AUTHORIZATION debits of the ledger, one per transaction UID
+*/
+type FraudPaymentEntity struct {
+	UID          uuid.UUID
+	MCC          string
+	MerchantName string
+	Amount       decimal.Decimal
+	CreatedAt    time.Time
+}
+
+type FraudRuleRepository interface {
+	FindEnabled(ctx context.Context) ([]FraudRuleEntity, error)
+}
+
+type FraudHistoryRepository interface {
+	FindPayments(ctx context.Context, accountID uint, since time.Time) ([]FraudPaymentEntity, error)
+	MerchantSeen(ctx context.Context, accountID uint, merchantName string) (bool, error)
+}
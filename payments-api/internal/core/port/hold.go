@@ -0,0 +1,49 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var ErrHoldNotFound = errors.New("authorization hold not found")
+
+const (
+	HOLD_STATUS_AUTHORIZED = "AUTHORIZED"
+	HOLD_STATUS_CAPTURED   = "CAPTURED"
+	HOLD_STATUS_VOIDED     = "VOIDED"
+)
+
+type HoldEntity struct {
+	ID               uint
+	UID              uuid.UUID
+	AccountID        uint
+	AccountUID       uuid.UUID
+	CategoryID       uint
+	Amount           decimal.Decimal
+	Currency         string
+	MCC              string
+	MerchantName     string
+	Status           string
+	ExpiresAt        time.Time
+	CardID           uint
+	OriginalAmount   decimal.Decimal
+	OriginalCurrency string
+	ExchangeRate     decimal.Decimal
+}
+
+/*
+- Reserve funds of an authorization per category without posting transactions
+- Retrieve the active (AUTHORIZED) holds of an authorization
+- Capture: post the transactions and settle the holds in the same unit of work
+- Void: release the holds
+*/
+type HoldRepository interface {
+	SaveHolds(ctx context.Context, holds map[int]HoldEntity) error
+	FindByUID(ctx context.Context, uid uuid.UUID) (map[int]HoldEntity, error)
+	Capture(ctx context.Context, uid uuid.UUID, transactions map[int]TransactionEntity, fencingToken int64) error
+	Void(ctx context.Context, uid uuid.UUID) error
+}
@@ -0,0 +1,57 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type LedgerConsistencyRequest struct {
+	AccountUID uuid.UUID `json:"-" swaggerignore:"true"`
+	Cursor     string    `form:"cursor" json:"cursor" example:"MQ"`
+	Limit      int       `form:"limit" json:"limit" validate:"omitempty,min=1,max=500" example:"50"`
+}
+
+type LedgerBalanceResponse struct {
+	AccountUID    string          `json:"account" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Category      string          `json:"category" example:"FOOD"`
+	Balance       decimal.Decimal `json:"balance" example:"105.02"`
+	LedgerBalance decimal.Decimal `json:"ledgerBalance" example:"100.02"`
+	BrokenEntries int             `json:"brokenEntries" example:"1"`
+}
+
+type LedgerConsistencyResponse struct {
+	Checked         int                     `json:"checked" example:"150"`
+	Inconsistencies []LedgerBalanceResponse `json:"inconsistencies"`
+	NextCursor      string                  `json:"nextCursor,omitempty" example:"MQ"`
+}
+
+/*
+- CursorID is exclusive: only accounts created after it (higher ID) are checked
+- Limit is the number of accounts checked, not of balances
+- A zero AccountUID checks every account
+*/
+type LedgerFilterEntity struct {
+	AccountUID uuid.UUID
+	CursorID   uint
+	Limit      int
+}
+
+type LedgerBalanceEntity struct {
+	AccountID     uint
+	AccountUID    uuid.UUID
+	CategoryID    uint
+	CategoryName  string
+	Balance       decimal.Decimal
+	LedgerBalance decimal.Decimal
+	BrokenEntries int
+}
+
+/*
+  - Recomputes each account category balance from its ledger entries, next to
+    the balance recorded by the last entry, ordered by account ID
+*/
+type LedgerRepository interface {
+	FindLedgerBalances(ctx context.Context, filter LedgerFilterEntity) ([]LedgerBalanceEntity, error)
+}
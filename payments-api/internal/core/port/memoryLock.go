@@ -2,20 +2,49 @@ package port
 
 import (
 	"context"
+	"errors"
 )
 
+var (
+	ErrMemoryLockNotOwned = errors.New("memory lock not owned by the transaction")
+	ErrStaleFencingToken  = errors.New("stale fencing token")
+	ErrMemoryLockTimeout  = errors.New("timeout waiting for lock release")
+)
+
+type MemoryLockQueueResponse struct {
+	AccountUID string `json:"account" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Depth      int64  `json:"depth" example:"3"`
+}
+
 type MemoryLockEntity struct {
-	Key         string //accountUID
-	Transcation string //transactionUID
-	Timestamp   int64  //startTimestamp.UnixMilli
+	Key          string //accountUID
+	Transcation  string //transactionUID
+	Timestamp    int64  //startTimestamp.UnixMilli
+	FencingToken int64  //increases at each acquisition of the Key
 }
 
 /*
 - Prevent two or more transactions from the same `accountUID` from occurring concurrently
-  - Retrieve or create, if it doesn’t exist, a representation of `MemoryLockEntity` in my lock source
-  - Remove a representation of `MemoryLockEntity` from my lock source
+  - Atomically create, if it doesn’t exist, a representation of `MemoryLockEntity` owned by
+    its transaction in my lock source, handing out a new `FencingToken`, or wait for its release
+  - Waiters of the same `Key` are granted the lock in arrival order, across every instance,
+    and `QueueDepth` reports how many of them are waiting
+  - Remove a representation of `MemoryLockEntity` from my lock source, only when owned by its
+    transaction (`ErrMemoryLockNotOwned` otherwise)
+  - Writes under the lock carry the `FencingToken`, so a holder whose lock expired and was
+    acquired by another transaction is rejected with `ErrStaleFencingToken`
 */
 type MemoryLockRepository interface {
 	Lock(ctx context.Context, mle MemoryLockEntity) (MemoryLockEntity, error)
-	Unlock(ctx context.Context, key string) error
+	Unlock(ctx context.Context, mle MemoryLockEntity) error
+	QueueDepth(ctx context.Context, key string) (int64, error)
+}
+
+/*
+  - Last `FencingToken` written on the account of the `Key`, zero when none. Lock
+    sources that keep their counters out of the database resume from it, so a
+    counter lost on a flush or a restart does not hand out stale tokens
+*/
+type FencingTokenRepository interface {
+	FindFencingToken(ctx context.Context, key string) (int64, error)
 }
@@ -1,12 +1,116 @@
 package port
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrMerchantNotFound      = errors.New("merchant not found")
+	ErrMerchantAlreadyExists = errors.New("merchant already exists")
+	ErrMCCNotFound           = errors.New("mcc not found")
+	ErrMerchantAliasTaken    = errors.New("merchant alias already registered")
+)
 
 type MerchantEntity struct {
-	Name string
-	MCC  string
+	Name    string
+	MCC     string
+	Aliases []string
 }
 
+/*
+  - FindByName matches the name exactly and returns nil when no merchant has it
+  - FindAll returns every active merchant with its aliases, the catalog used to
+    match the names FindByName misses
+*/
 type MerchantRepository interface {
 	FindByName(ctx context.Context, name string) (*MerchantEntity, error)
+	FindAll(ctx context.Context) ([]MerchantEntity, error)
+}
+
+type MerchantCreateRequest struct {
+	Name    string   `json:"name" validate:"required,min=3,max=255" binding:"required" example:"UBER EATS                   SAO PAULO BR"`
+	MCC     string   `json:"mcc" validate:"required,numeric,min=4,max=4" binding:"required" example:"5412"`
+	Aliases []string `json:"aliases" validate:"omitempty,max=20,dive,min=3,max=255" example:"UBER*"`
+}
+
+type MerchantUpdateRequest struct {
+	UID     uuid.UUID `json:"-" swaggerignore:"true"`
+	Name    string    `json:"name" validate:"required,min=3,max=255" binding:"required" example:"UBER EATS                   SAO PAULO BR"`
+	MCC     string    `json:"mcc" validate:"required,numeric,min=4,max=4" binding:"required" example:"5412"`
+	Aliases []string  `json:"aliases" validate:"omitempty,max=20,dive,min=3,max=255" example:"UBER*"`
+}
+
+type MerchantListRequest struct {
+	Cursor string `form:"cursor" json:"cursor" example:"MQ"`
+	Limit  int    `form:"limit" json:"limit" validate:"omitempty,min=1,max=500" example:"50"`
+}
+
+type MerchantResponse struct {
+	UID       string    `json:"uid" example:"0b0364a1-4955-48b6-8c63-8a446b918682"`
+	Name      string    `json:"name" example:"UBER EATS                   SAO PAULO BR"`
+	MCC       string    `json:"mcc" example:"5412"`
+	Aliases   []string  `json:"aliases" example:"UBER*"`
+	CreatedAt time.Time `json:"createdAt" example:"2024-12-04T21:50:21Z"`
+	UpdatedAt time.Time `json:"updatedAt" example:"2024-12-04T21:50:21Z"`
+}
+
+type MerchantListResponse struct {
+	Merchants  []MerchantResponse `json:"merchants"`
+	NextCursor string             `json:"nextCursor,omitempty" example:"MQ"`
+}
+
+type MerchantRegistryEntity struct {
+	ID        uint
+	UID       uuid.UUID
+	Name      string
+	MCC       string
+	Aliases   []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+/*
+- CursorID is exclusive: only merchants created after it (higher ID) are returned
+*/
+type MerchantListFilterEntity struct {
+	CursorID uint
+	Limit    int
+}
+
+/*
+  - Management of the merchants that correct the MCC of a transaction by its
+    merchant name. The MCC must be assigned to an active category.
+  - Names are unique among the active merchants, deletions are soft deletes
+  - Aliases are stored normalized, unique among the active merchants and
+    replaced as a whole on update
+*/
+type MerchantRegistryRepository interface {
+	CreateMerchant(ctx context.Context, merchant MerchantRegistryEntity) (MerchantRegistryEntity, error)
+	FindMerchantByUID(ctx context.Context, uid uuid.UUID) (MerchantRegistryEntity, error)
+	FindMerchants(ctx context.Context, filter MerchantListFilterEntity) ([]MerchantRegistryEntity, error)
+	UpdateMerchant(ctx context.Context, merchant MerchantRegistryEntity) (MerchantRegistryEntity, error)
+	DeleteMerchant(ctx context.Context, uid uuid.UUID) error
+}
+
+type MerchantMatchAuditEntity struct {
+	TransactionUID  uuid.UUID
+	AccountID       uint
+	MerchantName    string
+	MatchedMerchant string
+	MatchRule       string
+	MatchPattern    string
+	OriginalMCC     string
+	MCC             string
+}
+
+/*
+  - Trail of the MCC overrides: which rule and which name or alias of the
+    registered merchant matched the name sent with the transaction
+*/
+type MerchantMatchAuditRepository interface {
+	Save(ctx context.Context, audit MerchantMatchAuditEntity) error
 }
@@ -0,0 +1,89 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+/*
+  - Replaces the limits of the account, or of one of its categories when Category
+    is given. A zero cap is not enforced, and all caps zero remove the limits
+*/
+type SpendingLimitRequest struct {
+	AccountUID         uuid.UUID       `json:"-" swaggerignore:"true"`
+	CategoryUID        string          `json:"category" example:"e5ce3deb-7dea-4382-a1fd-1428c9888bdc"`
+	DailyAmount        decimal.Decimal `json:"dailyAmount" example:"150.00"`
+	MonthlyAmount      decimal.Decimal `json:"monthlyAmount" example:"1500.00"`
+	TransactionAmount  decimal.Decimal `json:"transactionAmount" example:"80.00"`
+	HourlyTransactions int             `json:"hourlyTransactions" validate:"min=0" example:"5"`
+}
+
+type SpendingLimitResponse struct {
+	AccountUID         string          `json:"account" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CategoryUID        string          `json:"category,omitempty" example:"e5ce3deb-7dea-4382-a1fd-1428c9888bdc"`
+	CategoryName       string          `json:"categoryName,omitempty" example:"MEAL"`
+	DailyAmount        decimal.Decimal `json:"dailyAmount" example:"150.00"`
+	MonthlyAmount      decimal.Decimal `json:"monthlyAmount" example:"1500.00"`
+	TransactionAmount  decimal.Decimal `json:"transactionAmount" example:"80.00"`
+	HourlyTransactions int             `json:"hourlyTransactions" example:"5"`
+}
+
+type SpendingLimitListResponse struct {
+	Limits []SpendingLimitResponse `json:"limits"`
+}
+
+/*
+- CategoryID and CategoryUID are zero for the limits of the whole account
+*/
+type SpendingLimitEntity struct {
+	AccountID          uint
+	AccountUID         uuid.UUID
+	CategoryID         uint
+	CategoryUID        uuid.UUID
+	CategoryName       string
+	DailyAmount        decimal.Decimal
+	MonthlyAmount      decimal.Decimal
+	TransactionAmount  decimal.Decimal
+	HourlyTransactions int
+}
+
+/*
+- Usage of the windows containing At: its UTC day and month, and its UTC hour
+*/
+type SpendingUsageEntity struct {
+	AccountID          uint
+	CategoryID         uint
+	At                 time.Time
+	DailyAmount        decimal.Decimal
+	MonthlyAmount      decimal.Decimal
+	HourlyTransactions int
+}
+
+/*
+  - Limits kept in the `spending_limits` table, one row per account and category
+  - Save replaces the limits of the account or category, removing them when every
+    cap is zero
+*/
+type SpendingLimitRepository interface {
+	FindByAccountID(ctx context.Context, accountID uint) ([]SpendingLimitEntity, error)
+	FindByAccountUID(ctx context.Context, accountUID uuid.UUID) ([]SpendingLimitEntity, error)
+	Save(ctx context.Context, limit SpendingLimitEntity) (SpendingLimitEntity, error)
+}
+
+/*
+  - Spending of an account, or of one of its categories when categoryID is not zero,
+    in the account currency, computed from the posted AUTHORIZATION debits of the
+    ledger and the active holds
+  - AddUsage accounts a posted transaction or a hold in the counters that cache
+    the ledger, a repository reading the ledger itself has nothing to do
+  - ResetUsage drops the counters of the hour of at, reloaded from the ledger
+    once a hold is voided
+*/
+type SpendingUsageRepository interface {
+	FindUsage(ctx context.Context, accountID, categoryID uint, at time.Time) (SpendingUsageEntity, error)
+	AddUsage(ctx context.Context, usage SpendingUsageEntity) error
+	ResetUsage(ctx context.Context, accountID, categoryID uint, at time.Time) error
+}
@@ -5,31 +5,89 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+const (
+	TRANSACTION_OPERATION_AUTHORIZATION = "AUTHORIZATION"
+	TRANSACTION_OPERATION_REFUND        = "REFUND"
+	TRANSACTION_OPERATION_ADJUSTMENT    = "ADJUSTMENT"
+
+	TRANSACTION_ENTRY_DEBIT  = "DEBIT"
+	TRANSACTION_ENTRY_CREDIT = "CREDIT"
+)
+
+/*
+  - The account is identified by its UUID or by the token of one of its cards.
+    Both payments and authorizations accept a card token
+*/
 type TransactionPaymentRequest struct {
-	AccountUID     uuid.UUID       `json:"account" validate:"required,uuid" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	AccountUID     uuid.UUID       `json:"account" validate:"required_without=CardToken,uuid" binding:"required_without=CardToken" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CardToken      string          `json:"card" validate:"omitempty,min=8,max=64" example:"tok_4f9a2c7e1b3d5a60"`
 	TransactionUID uuid.UUID       `json:"-" swaggerignore:"true"`
 	TotalAmount    decimal.Decimal `json:"totalAmount" validate:"required,min=0.01" binding:"required" example:"100.09"`
+	Currency       string          `json:"currency" validate:"omitempty,len=3" example:"BRL"`
 	MCC            string          `json:"mcc" validate:"required,min=4,max=4" binding:"required" example:"5411"`
 	Merchant       string          `json:"merchant" validate:"required,min=3,max=255" binding:"required" example:"PADARIA DO ZE              SAO PAULO BR"`
 }
 
+type TransactionRefundRequest struct {
+	AccountUID     uuid.UUID       `json:"account" validate:"required,uuid" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TransactionUID uuid.UUID       `json:"-" swaggerignore:"true"`
+	RefundUID      uuid.UUID       `json:"-" swaggerignore:"true"`
+	TotalAmount    decimal.Decimal `json:"totalAmount" validate:"required,min=0.01" binding:"required" example:"50.05"`
+}
+
+type TransactionHoldRequest struct {
+	AccountUID     uuid.UUID `json:"account" validate:"required,uuid" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TransactionUID uuid.UUID `json:"-" swaggerignore:"true"`
+}
+
+/*
+- Categories lists the categories tried to pay a payment or an authorization, in order
+*/
 type TransactionPaymentResponse struct {
-	Code string `json:"code" example:"00"`
+	Code       string                    `json:"code" example:"00"`
+	Reason     string                    `json:"reason" example:"APPROVED"`
+	Categories []CategoryAttemptResponse `json:"categories,omitempty"`
 }
 
 type TransactionEntity struct {
-	ID           uint
-	UID          uuid.UUID
-	AccountID    uint
-	CategoryID   uint
-	Amount       decimal.Decimal
-	MCC          string
-	MerchantName string
+	ID               uint
+	UID              uuid.UUID
+	AccountID        uint
+	AccountUID       uuid.UUID
+	CardID           uint
+	CategoryID       uint
+	Amount           decimal.Decimal
+	Currency         string
+	MCC              string
+	MerchantName     string
+	OriginalUID      uuid.UUID
+	Operation        string
+	EntryType        string
+	BalanceBefore    decimal.Decimal
+	BalanceAfter     decimal.Decimal
+	OriginalAmount   decimal.Decimal
+	OriginalCurrency string
+	ExchangeRate     decimal.Decimal
+}
+
+type TransactionCapturedEntity struct {
+	UID              uuid.UUID
+	AccountID        uint
+	AccountUID       uuid.UUID
+	CategoryID       uint
+	Priority         int
+	AmountCaptured   decimal.Decimal
+	AmountRefunded   decimal.Decimal
+	OriginalAmount   decimal.Decimal
+	OriginalRefunded decimal.Decimal
+	OriginalCurrency string
+	ExchangeRate     decimal.Decimal
 }
 
 type TransactionByCategoryEntity struct {
-	ID       uint
-	UID      uuid.UUID
-	Amount   decimal.Decimal
-	Category CategoryEntity
+	ID         uint
+	UID        uuid.UUID
+	Amount     decimal.Decimal
+	AmountHeld decimal.Decimal
+	Category   CategoryEntity
 }
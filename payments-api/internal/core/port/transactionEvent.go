@@ -0,0 +1,70 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	TRANSACTION_EVENT_APPROVED = "TRANSACTION_APPROVED"
+	TRANSACTION_EVENT_DECLINED = "TRANSACTION_DECLINED"
+	TRANSACTION_EVENT_REFUNDED = "TRANSACTION_REFUNDED"
+)
+
+/*
+  - Message published to the downstream systems. Delivery is at-least-once, so
+    consumers discard the `id` already handled, and the `sequence` increases in
+    the order the events of an account were written
+  - A rejection of a card not found, requested without an account, has no `account`
+*/
+type TransactionEventMessage struct {
+	UID            string          `json:"id" example:"0b5c8f3e-2d4a-4f6b-9c1e-7a8d3b2f6e4c"`
+	Sequence       uint            `json:"sequence" example:"42"`
+	Type           string          `json:"type" example:"TRANSACTION_APPROVED"`
+	AccountUID     string          `json:"account,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TransactionUID string          `json:"transaction" example:"91ee2159-f59f-4c89-a543-81987d563d7a"`
+	OriginalUID    string          `json:"originalTransaction,omitempty" example:"91ee2159-f59f-4c89-a543-81987d563d7a"`
+	Amount         decimal.Decimal `json:"amount" example:"100.10"`
+	Currency       string          `json:"currency" example:"BRL"`
+	Code           string          `json:"code" example:"00"`
+	Reason         string          `json:"reason" example:"APPROVED"`
+	OccurredAt     time.Time       `json:"occurredAt" example:"2024-12-04T21:50:21Z"`
+}
+
+type TransactionEventEntity struct {
+	ID             uint
+	UID            uuid.UUID
+	Type           string
+	AccountID      uint
+	AccountUID     uuid.UUID
+	TransactionUID uuid.UUID
+	OriginalUID    uuid.UUID
+	Amount         decimal.Decimal
+	Currency       string
+	Code           string
+	CreatedAt      time.Time
+}
+
+/*
+  - Outbox of the transaction events, written in the same database transaction
+    as the ledger rows or the outcome they report
+  - RelayPending hands the oldest pending events to relay, the first pending one of
+    each account ahead of the rest and each account in the order they were
+    written, and marks the events whose IDs it returns as published. A single
+    relay runs at a time, others return without relaying, and relay is called
+    outside of any database transaction
+*/
+type TransactionEventRepository interface {
+	RelayPending(
+		ctx context.Context,
+		limit int,
+		relay func(ctx context.Context, events []TransactionEventEntity) []uint,
+	) (int, error)
+}
+
+type TransactionEventPublisher interface {
+	Publish(ctx context.Context, message TransactionEventMessage) error
+}
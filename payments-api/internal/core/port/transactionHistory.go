@@ -0,0 +1,72 @@
+package port
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	TRANSACTION_HISTORY_DEFAULT_LIMIT = 50
+	TRANSACTION_HISTORY_MAX_LIMIT     = 500
+)
+
+type TransactionHistoryRequest struct {
+	AccountUID uuid.UUID `json:"-" swaggerignore:"true"`
+	Cursor     string    `form:"cursor" json:"cursor" example:"MTIz"`
+	Limit      int       `form:"limit" json:"limit" validate:"omitempty,min=1,max=500" example:"50"`
+	From       time.Time `form:"from" json:"from" time_format:"2006-01-02T15:04:05Z07:00" example:"2024-12-01T00:00:00Z"`
+	To         time.Time `form:"to" json:"to" time_format:"2006-01-02T15:04:05Z07:00" example:"2024-12-31T00:00:00Z"`
+	Category   string    `form:"category" json:"category" validate:"omitempty,max=255" example:"FOOD"`
+	MCC        string    `form:"mcc" json:"mcc" validate:"omitempty,min=4,max=4" example:"5411"`
+	Merchant   string    `form:"merchant" json:"merchant" validate:"omitempty,max=255" example:"PADARIA DO ZE"`
+}
+
+type TransactionHistoryItemResponse struct {
+	TransactionUID string          `json:"transaction,omitempty" example:"91ee2159-f59f-4c89-a543-81987d563d7a"`
+	OriginalUID    string          `json:"original,omitempty" example:"91ee2159-f59f-4c89-a543-81987d563d7a"`
+	Category       string          `json:"category" example:"FOOD"`
+	Operation      string          `json:"operation" example:"AUTHORIZATION"`
+	Amount         decimal.Decimal `json:"amount" example:"-100.09"`
+	Balance        decimal.Decimal `json:"balance" example:"105.02"`
+	Currency       string          `json:"currency" example:"BRL"`
+	MCC            string          `json:"mcc,omitempty" example:"5411"`
+	Merchant       string          `json:"merchant,omitempty" example:"PADARIA DO ZE              SAO PAULO BR"`
+	CreatedAt      time.Time       `json:"createdAt" example:"2024-12-04T21:50:21Z"`
+}
+
+type TransactionHistoryResponse struct {
+	Transactions []TransactionHistoryItemResponse `json:"transactions"`
+	NextCursor   string                           `json:"nextCursor,omitempty" example:"MTIz"`
+}
+
+/*
+- CursorID is exclusive: only transactions older (lower ID) than it are returned
+- Zero values disable the corresponding filter
+*/
+type TransactionHistoryFilterEntity struct {
+	AccountUID   uuid.UUID
+	CursorID     uint
+	Limit        int
+	From         time.Time
+	To           time.Time
+	CategoryName string
+	MCC          string
+	MerchantName string
+}
+
+type TransactionHistoryEntity struct {
+	ID           uint
+	UID          uuid.UUID
+	OriginalUID  uuid.UUID
+	CategoryID   uint
+	CategoryName string
+	Operation    string
+	Amount       decimal.Decimal
+	Balance      decimal.Decimal
+	Currency     string
+	MCC          string
+	MerchantName string
+	CreatedAt    time.Time
+}
@@ -0,0 +1,37 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+/*
+  - Amount and Currency are not kept with the outcome, only in its declined event
+  - AccountID is zero when the account or card was not found, which only reports
+    a declined event
+*/
+type TransactionOutcomeEntity struct {
+	UID        uuid.UUID
+	AccountID  uint
+	AccountUID uuid.UUID
+	Code       string
+	Amount     decimal.Decimal
+	Currency   string
+}
+
+/*
+  - Outcomes are keyed by the client supplied transaction UID (idempotency key),
+    so a replayed request returns the original response code without reprocessing
+  - FindByUID returns nil when the transaction was never processed
+  - Save writes the TRANSACTION_DECLINED event of an outcome other than approved
+    in the same database transaction
+  - SaveDeclinedEvent writes only the TRANSACTION_DECLINED event of a rejection
+    that is not a decision, such as a timeout, leaving a retry free to run again
+*/
+type TransactionOutcomeRepository interface {
+	FindByUID(ctx context.Context, uid uuid.UUID) (*TransactionOutcomeEntity, error)
+	Save(ctx context.Context, outcome TransactionOutcomeEntity) error
+	SaveDeclinedEvent(ctx context.Context, outcome TransactionOutcomeEntity) error
+}
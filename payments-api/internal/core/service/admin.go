@@ -0,0 +1,840 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/support/logger"
+)
+
+var mccPattern = regexp.MustCompile(`^[0-9]{4}$`)
+
+type Admin struct {
+	timeoutSLA                 port.TimeoutSLA
+	adminRepository            port.AdminRepository
+	merchantRegistryRepository port.MerchantRegistryRepository
+	ledgerRepository           port.LedgerRepository
+	categoryRuleRepository     port.CategoryRuleRepository
+	spendingLimitRepository    port.SpendingLimitRepository
+	cardRepository             port.CardRepository
+	memoryLockRepository       port.MemoryLockRepository
+
+	log logger.Logger
+}
+
+func NewAdmin(
+	timeoutSLA port.TimeoutSLA,
+
+	adRepository port.AdminRepository,
+	mrRepository port.MerchantRegistryRepository,
+	lRepository port.LedgerRepository,
+	crRepository port.CategoryRuleRepository,
+	slRepository port.SpendingLimitRepository,
+	cRepository port.CardRepository,
+	mlRepository port.MemoryLockRepository,
+
+	log logger.Logger,
+) *Admin {
+	return &Admin{
+		timeoutSLA:                 timeoutSLA,
+		adminRepository:            adRepository,
+		merchantRegistryRepository: mrRepository,
+		ledgerRepository:           lRepository,
+		categoryRuleRepository:     crRepository,
+		spendingLimitRepository:    slRepository,
+		cardRepository:             cRepository,
+		memoryLockRepository:       mlRepository,
+
+		log: log,
+	}
+}
+
+func (ad *Admin) CreateAccount(acr port.AccountCreateRequest) (port.AccountResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	name := strings.TrimSpace(acr.Name)
+	if name == "" {
+		return port.AccountResponse{}, ad.invalidRequestErr(ctx, "account name is required")
+	}
+
+	accountEntity, err := ad.adminRepository.CreateAccount(
+		ctx,
+		port.AccountAdminEntity{
+			UID:                uuid.New(),
+			Name:               name,
+			CurrencyConversion: acr.CurrencyConversion,
+			Status:             domain.ACCOUNT_STATUS_ACTIVE,
+		},
+	)
+	if err != nil {
+		return port.AccountResponse{}, ad.failedErr(ctx, err)
+	}
+
+	ad.log.Info(ctx, fmt.Sprintf("account %s created", accountEntity.UID.String()))
+
+	return mapAccountAdminEntityToResponse(accountEntity), nil
+}
+
+func (ad *Admin) DeleteAccount(accountUID uuid.UUID) error {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	err := ad.adminRepository.DeleteAccount(ctx, accountUID)
+	if err != nil {
+		return ad.failedErr(ctx, err)
+	}
+
+	ad.log.Info(ctx, fmt.Sprintf("account %s deleted", accountUID.String()))
+
+	return nil
+}
+
+/*
+  - Reads one page more than requested to know if there is a next page,
+    the cursor is the ID of the last account returned
+*/
+func (ad *Admin) ListAccounts(alr port.AccountListRequest) (port.AccountListResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	cursorID, err := decodeCursor(alr.Cursor)
+	if err != nil {
+		ad.log.Warn(ctx, err.Error())
+		return port.AccountListResponse{}, err
+	}
+
+	pageSize := adminPageSize(alr.Limit)
+
+	accountEntities, err := ad.adminRepository.FindAccounts(
+		ctx,
+		port.AccountListFilterEntity{CursorID: cursorID, Limit: pageSize + 1},
+	)
+	if err != nil {
+		return port.AccountListResponse{}, ad.failedErr(ctx, err)
+	}
+
+	nextCursor := ""
+	if len(accountEntities) > pageSize {
+		accountEntities = accountEntities[:pageSize]
+		nextCursor = encodeCursor(accountEntities[pageSize-1].ID)
+	}
+
+	accounts := []port.AccountResponse{}
+	for _, accountEntity := range accountEntities {
+		accounts = append(accounts, mapAccountAdminEntityToResponse(accountEntity))
+	}
+
+	return port.AccountListResponse{
+		Accounts:   accounts,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+func (ad *Admin) AttachCategory(acr port.AccountCategoryRequest) error {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	err := ad.adminRepository.AttachCategory(ctx, acr.AccountUID, acr.CategoryUID)
+	if err != nil {
+		return ad.failedErr(ctx, err)
+	}
+
+	ad.log.Info(ctx, fmt.Sprintf("category %s attached to account %s", acr.CategoryUID.String(), acr.AccountUID.String()))
+
+	return nil
+}
+
+func (ad *Admin) DetachCategory(acr port.AccountCategoryRequest) error {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	err := ad.adminRepository.DetachCategory(ctx, acr.AccountUID, acr.CategoryUID)
+	if err != nil {
+		return ad.failedErr(ctx, err)
+	}
+
+	ad.log.Info(ctx, fmt.Sprintf("category %s detached from account %s", acr.CategoryUID.String(), acr.AccountUID.String()))
+
+	return nil
+}
+
+func (ad *Admin) CreateCategory(ccr port.CategoryCreateRequest) (port.CategoryResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	name := strings.ToUpper(strings.TrimSpace(ccr.Name))
+	if name == "" {
+		return port.CategoryResponse{}, ad.invalidRequestErr(ctx, "category name is required")
+	}
+
+	if ccr.Priority < 1 {
+		return port.CategoryResponse{}, ad.invalidRequestErr(ctx, fmt.Sprintf("category priority %d must be positive", ccr.Priority))
+	}
+
+	currency, err := domain.NormalizeCurrency(ccr.Currency)
+	if err != nil {
+		return port.CategoryResponse{}, ad.invalidRequestErr(ctx, err.Error())
+	}
+
+	categoryEntity, err := ad.adminRepository.CreateCategory(
+		ctx,
+		port.CategoryAdminEntity{
+			UID:              uuid.New(),
+			Name:             name,
+			Priority:         ccr.Priority,
+			Currency:         currency,
+			FallbackExcluded: ccr.FallbackExcluded,
+		},
+	)
+	if err != nil {
+		return port.CategoryResponse{}, ad.failedErr(ctx, err)
+	}
+
+	ad.log.Info(ctx, fmt.Sprintf("category %s created", categoryEntity.UID.String()))
+
+	return mapCategoryAdminEntityToResponse(categoryEntity), nil
+}
+
+func (ad *Admin) AssignMCC(mar port.MCCAssignRequest) (port.MCCResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	if !mccPattern.MatchString(mar.MCC) {
+		return port.MCCResponse{}, ad.invalidRequestErr(ctx, fmt.Sprintf("mcc %s must have 4 digits", mar.MCC))
+	}
+
+	mccEntity, err := ad.adminRepository.AssignMCC(
+		ctx,
+		port.MCCAdminEntity{UID: uuid.New(), CategoryUID: mar.CategoryUID, MCC: mar.MCC},
+	)
+	if err != nil {
+		return port.MCCResponse{}, ad.failedErr(ctx, err)
+	}
+
+	ad.log.Info(ctx, fmt.Sprintf("mcc %s assigned to category %s", mccEntity.MCC, mccEntity.CategoryUID.String()))
+
+	return port.MCCResponse{
+		UID:         mccEntity.UID.String(),
+		CategoryUID: mccEntity.CategoryUID.String(),
+		MCC:         mccEntity.MCC,
+	}, nil
+}
+
+func (ad *Admin) CreateMerchant(mcr port.MerchantCreateRequest) (port.MerchantResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	aliases, err := ad.validateMerchant(ctx, mcr.Name, mcr.MCC, mcr.Aliases)
+	if err != nil {
+		return port.MerchantResponse{}, err
+	}
+
+	merchantEntity, err := ad.merchantRegistryRepository.CreateMerchant(
+		ctx,
+		port.MerchantRegistryEntity{UID: uuid.New(), Name: mcr.Name, MCC: mcr.MCC, Aliases: aliases},
+	)
+	if err != nil {
+		return port.MerchantResponse{}, ad.failedErr(ctx, err)
+	}
+
+	ad.log.Info(ctx, fmt.Sprintf("merchant %s created with mcc %s", merchantEntity.UID.String(), merchantEntity.MCC))
+
+	return mapMerchantRegistryEntityToResponse(merchantEntity), nil
+}
+
+func (ad *Admin) GetMerchant(merchantUID uuid.UUID) (port.MerchantResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	merchantEntity, err := ad.merchantRegistryRepository.FindMerchantByUID(ctx, merchantUID)
+	if err != nil {
+		return port.MerchantResponse{}, ad.failedErr(ctx, err)
+	}
+
+	return mapMerchantRegistryEntityToResponse(merchantEntity), nil
+}
+
+func (ad *Admin) ListMerchants(mlr port.MerchantListRequest) (port.MerchantListResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	cursorID, err := decodeCursor(mlr.Cursor)
+	if err != nil {
+		ad.log.Warn(ctx, err.Error())
+		return port.MerchantListResponse{}, err
+	}
+
+	pageSize := adminPageSize(mlr.Limit)
+
+	merchantEntities, err := ad.merchantRegistryRepository.FindMerchants(
+		ctx,
+		port.MerchantListFilterEntity{CursorID: cursorID, Limit: pageSize + 1},
+	)
+	if err != nil {
+		return port.MerchantListResponse{}, ad.failedErr(ctx, err)
+	}
+
+	nextCursor := ""
+	if len(merchantEntities) > pageSize {
+		merchantEntities = merchantEntities[:pageSize]
+		nextCursor = encodeCursor(merchantEntities[pageSize-1].ID)
+	}
+
+	merchants := []port.MerchantResponse{}
+	for _, merchantEntity := range merchantEntities {
+		merchants = append(merchants, mapMerchantRegistryEntityToResponse(merchantEntity))
+	}
+
+	return port.MerchantListResponse{
+		Merchants:  merchants,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+func (ad *Admin) UpdateMerchant(mur port.MerchantUpdateRequest) (port.MerchantResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	aliases, err := ad.validateMerchant(ctx, mur.Name, mur.MCC, mur.Aliases)
+	if err != nil {
+		return port.MerchantResponse{}, err
+	}
+
+	merchantEntity, err := ad.merchantRegistryRepository.UpdateMerchant(
+		ctx,
+		port.MerchantRegistryEntity{UID: mur.UID, Name: mur.Name, MCC: mur.MCC, Aliases: aliases},
+	)
+	if err != nil {
+		return port.MerchantResponse{}, ad.failedErr(ctx, err)
+	}
+
+	ad.log.Info(ctx, fmt.Sprintf("merchant %s updated with mcc %s", merchantEntity.UID.String(), merchantEntity.MCC))
+
+	return mapMerchantRegistryEntityToResponse(merchantEntity), nil
+}
+
+func (ad *Admin) DeleteMerchant(merchantUID uuid.UUID) error {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	err := ad.merchantRegistryRepository.DeleteMerchant(ctx, merchantUID)
+	if err != nil {
+		return ad.failedErr(ctx, err)
+	}
+
+	ad.log.Info(ctx, fmt.Sprintf("merchant %s deleted", merchantUID.String()))
+
+	return nil
+}
+
+/*
+  - The name is kept as sent, since it must match the merchant name of the
+    transactions exactly, padding spaces included
+  - Aliases are returned normalized and deduplicated. Only a trailing `*` is a
+    wildcard, it must follow at least 3 characters so a prefix never matches
+    every merchant
+*/
+/*
+  - Recomputes the balances of a page of accounts from their ledger entries and
+    reports the inconsistent ones, the cursor is the ID of the last account checked
+*/
+func (ad *Admin) CheckLedger(lcr port.LedgerConsistencyRequest) (port.LedgerConsistencyResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	cursorID, err := decodeCursor(lcr.Cursor)
+	if err != nil {
+		ad.log.Warn(ctx, err.Error())
+		return port.LedgerConsistencyResponse{}, err
+	}
+
+	pageSize := adminPageSize(lcr.Limit)
+
+	lbEntities, err := ad.ledgerRepository.FindLedgerBalances(
+		ctx,
+		port.LedgerFilterEntity{AccountUID: lcr.AccountUID, CursorID: cursorID, Limit: pageSize + 1},
+	)
+	if err != nil {
+		return port.LedgerConsistencyResponse{}, ad.failedErr(ctx, err)
+	}
+
+	response := port.LedgerConsistencyResponse{
+		Inconsistencies: []port.LedgerBalanceResponse{},
+	}
+
+	accounts := 0
+	lastAccountID := uint(0)
+	for _, lbEntity := range lbEntities {
+		if lbEntity.AccountID != lastAccountID {
+			if accounts == pageSize {
+				response.NextCursor = encodeCursor(lastAccountID)
+				break
+			}
+
+			accounts++
+			lastAccountID = lbEntity.AccountID
+		}
+
+		response.Checked++
+
+		ledgerBalance := mapLedgerBalanceEntityToDomain(lbEntity)
+		if ledgerBalance.IsConsistent() {
+			continue
+		}
+
+		ad.log.Warn(
+			ctx,
+			fmt.Sprintf(
+				"ledger inconsistency on account %s category %s: balance %s, recomputed %s, %d broken entries",
+				lbEntity.AccountUID.String(),
+				lbEntity.CategoryName,
+				lbEntity.Balance.String(),
+				lbEntity.LedgerBalance.String(),
+				lbEntity.BrokenEntries,
+			),
+		)
+
+		response.Inconsistencies = append(response.Inconsistencies, mapLedgerBalanceEntityToResponse(lbEntity))
+	}
+
+	return response, nil
+}
+
+/*
+  - Replaces the fallback chain of a category, by default or for an account. The
+    chain is tried in order once the category matched by the MCC runs out of funds
+*/
+func (ad *Admin) SetCategoryRule(crr port.CategoryRuleRequest) (port.CategoryRuleResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	chain := port.CategoryRuleChainEntity{
+		Fallbacks: []port.CategoryAdminEntity{},
+	}
+
+	var err error
+	if crr.AccountUID != "" {
+		chain.AccountUID, err = uuid.Parse(crr.AccountUID)
+		if err != nil {
+			return port.CategoryRuleResponse{}, ad.invalidRequestErr(ctx, fmt.Sprintf("invalid account uid: %s", err.Error()))
+		}
+	}
+
+	if crr.CategoryUID != "" {
+		chain.CategoryUID, err = uuid.Parse(crr.CategoryUID)
+		if err != nil {
+			return port.CategoryRuleResponse{}, ad.invalidRequestErr(ctx, fmt.Sprintf("invalid category uid: %s", err.Error()))
+		}
+	}
+
+	if len(crr.Fallbacks) > port.CATEGORY_RULE_MAX_FALLBACKS {
+		return port.CategoryRuleResponse{}, ad.invalidRequestErr(
+			ctx,
+			fmt.Sprintf("category rule has more than %d fallbacks", port.CATEGORY_RULE_MAX_FALLBACKS),
+		)
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for _, fallback := range crr.Fallbacks {
+		fallbackUID, err := uuid.Parse(fallback)
+		if err != nil {
+			return port.CategoryRuleResponse{}, ad.invalidRequestErr(ctx, fmt.Sprintf("invalid fallback uid: %s", err.Error()))
+		}
+
+		if fallbackUID == chain.CategoryUID || seen[fallbackUID] {
+			return port.CategoryRuleResponse{}, ad.invalidRequestErr(
+				ctx,
+				fmt.Sprintf("fallback %s repeats a category of the rule", fallbackUID.String()),
+			)
+		}
+
+		seen[fallbackUID] = true
+		chain.Fallbacks = append(chain.Fallbacks, port.CategoryAdminEntity{UID: fallbackUID})
+	}
+
+	chainEntity, err := ad.categoryRuleRepository.SaveChain(ctx, chain)
+	if err != nil {
+		return port.CategoryRuleResponse{}, ad.failedErr(ctx, err)
+	}
+
+	ad.log.Info(
+		ctx,
+		fmt.Sprintf(
+			"category rule of category %s for account %s set with %d fallbacks",
+			chainEntity.CategoryUID.String(),
+			chainEntity.AccountUID.String(),
+			len(chainEntity.Fallbacks),
+		),
+	)
+
+	return mapCategoryRuleChainEntityToResponse(chainEntity), nil
+}
+
+/*
+- Lists the default category rules, along with the overrides of the account when given
+*/
+func (ad *Admin) ListCategoryRules(accountUID uuid.UUID) (port.CategoryRuleListResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	chainEntities, err := ad.categoryRuleRepository.FindChains(ctx, accountUID)
+	if err != nil {
+		return port.CategoryRuleListResponse{}, ad.failedErr(ctx, err)
+	}
+
+	response := port.CategoryRuleListResponse{
+		Rules: []port.CategoryRuleResponse{},
+	}
+
+	for _, chainEntity := range chainEntities {
+		response.Rules = append(response.Rules, mapCategoryRuleChainEntityToResponse(chainEntity))
+	}
+
+	return response, nil
+}
+
+/*
+  - Replaces the spending limits of the account, or of one of its categories. The
+    caps are evaluated from the ledger before approving payments and authorizations
+*/
+func (ad *Admin) SetSpendingLimit(slr port.SpendingLimitRequest) (port.SpendingLimitResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	limit := port.SpendingLimitEntity{
+		AccountUID:         slr.AccountUID,
+		DailyAmount:        slr.DailyAmount,
+		MonthlyAmount:      slr.MonthlyAmount,
+		TransactionAmount:  slr.TransactionAmount,
+		HourlyTransactions: slr.HourlyTransactions,
+	}
+
+	if slr.CategoryUID != "" {
+		categoryUID, err := uuid.Parse(slr.CategoryUID)
+		if err != nil {
+			return port.SpendingLimitResponse{}, ad.invalidRequestErr(ctx, fmt.Sprintf("invalid category uid: %s", err.Error()))
+		}
+
+		limit.CategoryUID = categoryUID
+	}
+
+	if limit.DailyAmount.IsNegative() || limit.MonthlyAmount.IsNegative() || limit.TransactionAmount.IsNegative() || limit.HourlyTransactions < 0 {
+		return port.SpendingLimitResponse{}, ad.invalidRequestErr(ctx, "spending limits must not be negative")
+	}
+
+	limitEntity, err := ad.spendingLimitRepository.Save(ctx, limit)
+	if err != nil {
+		return port.SpendingLimitResponse{}, ad.failedErr(ctx, err)
+	}
+
+	ad.log.Info(
+		ctx,
+		fmt.Sprintf(
+			"spending limits of category %s for account %s set",
+			limitEntity.CategoryUID.String(),
+			limitEntity.AccountUID.String(),
+		),
+	)
+
+	return mapSpendingLimitEntityToResponse(limitEntity), nil
+}
+
+func (ad *Admin) ListSpendingLimits(accountUID uuid.UUID) (port.SpendingLimitListResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	limitEntities, err := ad.spendingLimitRepository.FindByAccountUID(ctx, accountUID)
+	if err != nil {
+		return port.SpendingLimitListResponse{}, ad.failedErr(ctx, err)
+	}
+
+	response := port.SpendingLimitListResponse{
+		Limits: []port.SpendingLimitResponse{},
+	}
+
+	for _, limitEntity := range limitEntities {
+		response.Limits = append(response.Limits, mapSpendingLimitEntityToResponse(limitEntity))
+	}
+
+	return response, nil
+}
+
+/*
+  - Registers a tokenized card of the account, which can then pay with the token
+    instead of the account UUID. Cards are created active
+*/
+func (ad *Admin) CreateCard(ccr port.CardCreateRequest) (port.CardResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	token := strings.TrimSpace(ccr.Token)
+	if len(token) < 8 {
+		return port.CardResponse{}, ad.invalidRequestErr(ctx, "card token must have at least 8 characters")
+	}
+
+	if !domain.IsMaskedPAN(ccr.MaskedPAN) {
+		return port.CardResponse{}, ad.invalidRequestErr(ctx, "card pan must be masked, keeping only its first six and last four digits")
+	}
+
+	expiresAt, err := domain.ParseCardExpiry(ccr.Expiry)
+	if err != nil {
+		return port.CardResponse{}, ad.invalidRequestErr(ctx, err.Error())
+	}
+
+	if ccr.TransactionAmount.IsNegative() || ccr.DailyAmount.IsNegative() {
+		return port.CardResponse{}, ad.invalidRequestErr(ctx, "card limits must not be negative")
+	}
+
+	cardEntity, err := ad.cardRepository.Create(ctx, port.CardEntity{
+		UID:               uuid.New(),
+		AccountUID:        ccr.AccountUID,
+		Token:             token,
+		MaskedPAN:         ccr.MaskedPAN,
+		Status:            domain.CARD_STATUS_ACTIVE,
+		ExpiresAt:         expiresAt,
+		TransactionAmount: ccr.TransactionAmount,
+		DailyAmount:       ccr.DailyAmount,
+	})
+	if err != nil {
+		return port.CardResponse{}, ad.failedErr(ctx, err)
+	}
+
+	ad.log.Info(ctx, fmt.Sprintf("card %s created for account %s", cardEntity.UID.String(), cardEntity.AccountUID.String()))
+
+	return mapCardEntityToResponse(cardEntity), nil
+}
+
+/*
+- Replaces the status, expiry and limits of the card. A cancelled card can't be changed
+*/
+func (ad *Admin) UpdateCard(cur port.CardUpdateRequest) (port.CardResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	status := strings.ToUpper(strings.TrimSpace(cur.Status))
+	if !domain.IsCardStatus(status) {
+		return port.CardResponse{}, ad.invalidRequestErr(ctx, fmt.Sprintf("card status %s is invalid", cur.Status))
+	}
+
+	expiresAt, err := domain.ParseCardExpiry(cur.Expiry)
+	if err != nil {
+		return port.CardResponse{}, ad.invalidRequestErr(ctx, err.Error())
+	}
+
+	if cur.TransactionAmount.IsNegative() || cur.DailyAmount.IsNegative() {
+		return port.CardResponse{}, ad.invalidRequestErr(ctx, "card limits must not be negative")
+	}
+
+	cardEntity, err := ad.cardRepository.FindByUID(ctx, cur.CardUID)
+	if err != nil {
+		return port.CardResponse{}, ad.failedErr(ctx, err)
+	}
+
+	if cardEntity.Status == domain.CARD_STATUS_CANCELLED {
+		err := fmt.Errorf("%w: %s", port.ErrCardCancelled, cur.CardUID.String())
+		ad.log.Warn(ctx, err.Error())
+
+		return port.CardResponse{}, err
+	}
+
+	cardEntity.Status = status
+	cardEntity.ExpiresAt = expiresAt
+	cardEntity.TransactionAmount = cur.TransactionAmount
+	cardEntity.DailyAmount = cur.DailyAmount
+
+	cardEntity, err = ad.cardRepository.Update(ctx, cardEntity)
+	if err != nil {
+		return port.CardResponse{}, ad.failedErr(ctx, err)
+	}
+
+	ad.log.Info(ctx, fmt.Sprintf("card %s updated with status %s", cardEntity.UID.String(), cardEntity.Status))
+
+	return mapCardEntityToResponse(cardEntity), nil
+}
+
+func (ad *Admin) ListCards(accountUID uuid.UUID) (port.CardListResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	cardEntities, err := ad.cardRepository.FindByAccountUID(ctx, accountUID)
+	if err != nil {
+		return port.CardListResponse{}, ad.failedErr(ctx, err)
+	}
+
+	response := port.CardListResponse{
+		Cards: []port.CardResponse{},
+	}
+
+	for _, cardEntity := range cardEntities {
+		response.Cards = append(response.Cards, mapCardEntityToResponse(cardEntity))
+	}
+
+	return response, nil
+}
+
+/*
+  - Blocks, unblocks or cancels the account. Blocked accounts decline debits and
+    cancelled ones decline every transaction, a cancellation is final
+  - The change only applies to the status it was computed from, a concurrent
+    change fails the transition instead of overwriting it
+*/
+func (ad *Admin) ChangeAccountStatus(asr port.AccountStatusRequest) (port.AccountStatusChangeResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	action := strings.ToUpper(strings.TrimSpace(asr.Action))
+	if !domain.IsAccountAction(action) {
+		return port.AccountStatusChangeResponse{}, ad.invalidRequestErr(ctx, fmt.Sprintf("account action %s is invalid", asr.Action))
+	}
+
+	currentStatus, err := ad.adminRepository.FindAccountStatus(ctx, asr.AccountUID)
+	if err != nil {
+		return port.AccountStatusChangeResponse{}, ad.failedErr(ctx, err)
+	}
+
+	nextStatus, ok := domain.AccountStatusTransition(currentStatus, action)
+	if !ok {
+		err := fmt.Errorf(
+			"%w: cannot %s account %s with status %s",
+			port.ErrAccountStatusTransition,
+			strings.ToLower(action),
+			asr.AccountUID.String(),
+			currentStatus,
+		)
+		ad.log.Warn(ctx, err.Error())
+
+		return port.AccountStatusChangeResponse{}, err
+	}
+
+	changeEntity, err := ad.adminRepository.ChangeAccountStatus(
+		ctx,
+		port.AccountStatusChangeEntity{
+			AccountUID:     asr.AccountUID,
+			PreviousStatus: currentStatus,
+			Status:         nextStatus,
+			Reason:         strings.TrimSpace(asr.Reason),
+		},
+	)
+	if err != nil {
+		return port.AccountStatusChangeResponse{}, ad.failedErr(ctx, err)
+	}
+
+	ad.log.Info(
+		ctx,
+		fmt.Sprintf(
+			"account %s status changed from %s to %s",
+			changeEntity.AccountUID.String(),
+			changeEntity.PreviousStatus,
+			changeEntity.Status,
+		),
+	)
+
+	return mapAccountStatusChangeEntityToResponse(changeEntity), nil
+}
+
+func (ad *Admin) ListAccountStatusChanges(accountUID uuid.UUID) (port.AccountStatusChangeListResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	changeEntities, err := ad.adminRepository.FindAccountStatusChanges(ctx, accountUID)
+	if err != nil {
+		return port.AccountStatusChangeListResponse{}, ad.failedErr(ctx, err)
+	}
+
+	response := port.AccountStatusChangeListResponse{
+		Changes: []port.AccountStatusChangeResponse{},
+	}
+
+	for _, changeEntity := range changeEntities {
+		response.Changes = append(response.Changes, mapAccountStatusChangeEntityToResponse(changeEntity))
+	}
+
+	return response, nil
+}
+
+/*
+  - Reports how many transactions are waiting for the lock of the account, a
+    burst of them hints the account is about to reach the SLA timeout
+*/
+func (ad *Admin) LockQueue(accountUID uuid.UUID) (port.MemoryLockQueueResponse, error) {
+	ctx, cancel := ad.newContext()
+	defer cancel()
+
+	depth, err := ad.memoryLockRepository.QueueDepth(ctx, accountUID.String())
+	if err != nil {
+		return port.MemoryLockQueueResponse{}, ad.failedErr(ctx, err)
+	}
+
+	return port.MemoryLockQueueResponse{
+		AccountUID: accountUID.String(),
+		Depth:      depth,
+	}, nil
+}
+
+func (ad *Admin) validateMerchant(ctx context.Context, name, mcc string, aliases []string) ([]string, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, ad.invalidRequestErr(ctx, "merchant name is required")
+	}
+
+	if !mccPattern.MatchString(mcc) {
+		return nil, ad.invalidRequestErr(ctx, fmt.Sprintf("mcc %s must have 4 digits", mcc))
+	}
+
+	normalizedAliases := []string{}
+	seen := make(map[string]bool)
+
+	for _, alias := range aliases {
+		normalized := domain.NormalizeMerchantName(alias)
+		prefix := strings.TrimSuffix(normalized, domain.MERCHANT_ALIAS_PREFIX_WILDCARD)
+
+		if len([]rune(prefix)) < 3 {
+			return nil, ad.invalidRequestErr(ctx, fmt.Sprintf("merchant alias %s is invalid", alias))
+		}
+
+		if !seen[normalized] {
+			seen[normalized] = true
+			normalizedAliases = append(normalizedAliases, normalized)
+		}
+	}
+
+	return normalizedAliases, nil
+}
+
+func adminPageSize(limit int) int {
+	if limit <= 0 {
+		return port.ADMIN_LIST_DEFAULT_LIMIT
+	}
+
+	if limit > port.ADMIN_LIST_MAX_LIMIT {
+		return port.ADMIN_LIST_MAX_LIMIT
+	}
+
+	return limit
+}
+
+func (ad *Admin) newContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(
+		context.Background(),
+		time.Duration(ad.timeoutSLA),
+	)
+}
+
+func (ad *Admin) invalidRequestErr(ctx context.Context, message string) error {
+	ad.log.Warn(ctx, message)
+
+	return fmt.Errorf("%w: %s", port.ErrInvalidAdminRequest, message)
+}
+
+func (ad *Admin) failedErr(ctx context.Context, err error) error {
+	ad.log.Error(ctx, err.Error())
+
+	return err
+}
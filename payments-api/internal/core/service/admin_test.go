@@ -0,0 +1,881 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+type AdminRepoFake struct {
+	accounts          map[uuid.UUID]port.AccountAdminEntity
+	categories        map[uuid.UUID]port.CategoryAdminEntity
+	accountsDeleted   map[uuid.UUID]bool
+	assignedMCCs      map[string]uuid.UUID
+	lastAccountID     uint
+	lastCategoryID    uint
+	attachedByAccount map[uuid.UUID][]uuid.UUID
+	statusChanges     map[uuid.UUID][]port.AccountStatusChangeEntity
+}
+
+func newAdminRepoFake() *AdminRepoFake {
+	return &AdminRepoFake{
+		accounts:          make(map[uuid.UUID]port.AccountAdminEntity),
+		categories:        make(map[uuid.UUID]port.CategoryAdminEntity),
+		accountsDeleted:   make(map[uuid.UUID]bool),
+		assignedMCCs:      make(map[string]uuid.UUID),
+		attachedByAccount: make(map[uuid.UUID][]uuid.UUID),
+		statusChanges:     make(map[uuid.UUID][]port.AccountStatusChangeEntity),
+	}
+}
+
+func (arf *AdminRepoFake) CreateAccount(_ context.Context, account port.AccountAdminEntity) (port.AccountAdminEntity, error) {
+	arf.lastAccountID++
+
+	account.ID = arf.lastAccountID
+	account.CreatedAt = time.Now()
+	arf.accounts[account.UID] = account
+
+	return account, nil
+}
+
+func (arf *AdminRepoFake) DeleteAccount(_ context.Context, uid uuid.UUID) error {
+	if _, ok := arf.findAccount(uid); !ok {
+		return fmt.Errorf("%w: %s", port.ErrAccountNotFound, uid)
+	}
+
+	arf.accountsDeleted[uid] = true
+	delete(arf.attachedByAccount, uid)
+
+	return nil
+}
+
+func (arf *AdminRepoFake) FindAccounts(_ context.Context, filter port.AccountListFilterEntity) ([]port.AccountAdminEntity, error) {
+	accounts := []port.AccountAdminEntity{}
+
+	for uid, account := range arf.accounts {
+		if arf.accountsDeleted[uid] || account.ID <= filter.CursorID {
+			continue
+		}
+
+		for _, categoryUID := range arf.attachedByAccount[uid] {
+			account.Categories = append(account.Categories, arf.categories[categoryUID])
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].ID < accounts[j].ID
+	})
+
+	if len(accounts) > filter.Limit {
+		accounts = accounts[:filter.Limit]
+	}
+
+	return accounts, nil
+}
+
+func (arf *AdminRepoFake) FindAccountStatus(_ context.Context, uid uuid.UUID) (string, error) {
+	account, ok := arf.findAccount(uid)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", port.ErrAccountNotFound, uid)
+	}
+
+	return account.Status, nil
+}
+
+func (arf *AdminRepoFake) ChangeAccountStatus(_ context.Context, change port.AccountStatusChangeEntity) (port.AccountStatusChangeEntity, error) {
+	account, ok := arf.findAccount(change.AccountUID)
+	if !ok {
+		return port.AccountStatusChangeEntity{}, fmt.Errorf("%w: %s", port.ErrAccountNotFound, change.AccountUID)
+	}
+
+	if account.Status != change.PreviousStatus {
+		return port.AccountStatusChangeEntity{}, fmt.Errorf("%w: account %s is no longer %s", port.ErrAccountStatusTransition, change.AccountUID, change.PreviousStatus)
+	}
+
+	account.Status = change.Status
+	arf.accounts[change.AccountUID] = account
+
+	change.CreatedAt = time.Now()
+	arf.statusChanges[change.AccountUID] = append(arf.statusChanges[change.AccountUID], change)
+
+	return change, nil
+}
+
+func (arf *AdminRepoFake) FindAccountStatusChanges(_ context.Context, uid uuid.UUID) ([]port.AccountStatusChangeEntity, error) {
+	if _, ok := arf.findAccount(uid); !ok {
+		return nil, fmt.Errorf("%w: %s", port.ErrAccountNotFound, uid)
+	}
+
+	return arf.statusChanges[uid], nil
+}
+
+func (arf *AdminRepoFake) AttachCategory(_ context.Context, accountUID, categoryUID uuid.UUID) error {
+	if _, ok := arf.findAccount(accountUID); !ok {
+		return fmt.Errorf("%w: %s", port.ErrAccountNotFound, accountUID)
+	}
+
+	if _, ok := arf.categories[categoryUID]; !ok {
+		return fmt.Errorf("%w: %s", port.ErrCategoryNotFound, categoryUID)
+	}
+
+	for _, attached := range arf.attachedByAccount[accountUID] {
+		if attached == categoryUID {
+			return fmt.Errorf("%w: %s", port.ErrCategoryAlreadyAttached, categoryUID)
+		}
+	}
+
+	arf.attachedByAccount[accountUID] = append(arf.attachedByAccount[accountUID], categoryUID)
+
+	return nil
+}
+
+func (arf *AdminRepoFake) DetachCategory(_ context.Context, accountUID, categoryUID uuid.UUID) error {
+	attached := arf.attachedByAccount[accountUID]
+
+	for i, attachedUID := range attached {
+		if attachedUID == categoryUID {
+			arf.attachedByAccount[accountUID] = append(attached[:i], attached[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", port.ErrCategoryNotAttached, categoryUID)
+}
+
+func (arf *AdminRepoFake) CreateCategory(_ context.Context, category port.CategoryAdminEntity) (port.CategoryAdminEntity, error) {
+	arf.lastCategoryID++
+
+	category.ID = arf.lastCategoryID
+	arf.categories[category.UID] = category
+
+	return category, nil
+}
+
+func (arf *AdminRepoFake) AssignMCC(_ context.Context, mcc port.MCCAdminEntity) (port.MCCAdminEntity, error) {
+	category, ok := arf.categories[mcc.CategoryUID]
+	if !ok {
+		return port.MCCAdminEntity{}, fmt.Errorf("%w: %s", port.ErrCategoryNotFound, mcc.CategoryUID)
+	}
+
+	if _, assigned := arf.assignedMCCs[mcc.MCC]; assigned {
+		return port.MCCAdminEntity{}, fmt.Errorf("%w: %s", port.ErrMCCAlreadyAssigned, mcc.MCC)
+	}
+
+	arf.assignedMCCs[mcc.MCC] = mcc.CategoryUID
+	category.MCCs = append(category.MCCs, mcc.MCC)
+	arf.categories[mcc.CategoryUID] = category
+
+	return mcc, nil
+}
+
+func (arf *AdminRepoFake) findAccount(uid uuid.UUID) (port.AccountAdminEntity, bool) {
+	account, ok := arf.accounts[uid]
+	if !ok || arf.accountsDeleted[uid] {
+		return port.AccountAdminEntity{}, false
+	}
+
+	return account, true
+}
+
+type MerchantRegistryRepoFake struct {
+	merchants      map[uuid.UUID]port.MerchantRegistryEntity
+	lastMerchantID uint
+}
+
+func newMerchantRegistryRepoFake() *MerchantRegistryRepoFake {
+	return &MerchantRegistryRepoFake{
+		merchants: make(map[uuid.UUID]port.MerchantRegistryEntity),
+	}
+}
+
+func (mrf *MerchantRegistryRepoFake) CreateMerchant(_ context.Context, merchant port.MerchantRegistryEntity) (port.MerchantRegistryEntity, error) {
+	if mrf.nameTaken(merchant) {
+		return port.MerchantRegistryEntity{}, fmt.Errorf("%w: %s", port.ErrMerchantAlreadyExists, merchant.Name)
+	}
+
+	mrf.lastMerchantID++
+
+	merchant.ID = mrf.lastMerchantID
+	merchant.CreatedAt = time.Now()
+	merchant.UpdatedAt = merchant.CreatedAt
+	mrf.merchants[merchant.UID] = merchant
+
+	return merchant, nil
+}
+
+func (mrf *MerchantRegistryRepoFake) FindMerchantByUID(_ context.Context, uid uuid.UUID) (port.MerchantRegistryEntity, error) {
+	merchant, ok := mrf.merchants[uid]
+	if !ok {
+		return port.MerchantRegistryEntity{}, fmt.Errorf("%w: %s", port.ErrMerchantNotFound, uid)
+	}
+
+	return merchant, nil
+}
+
+func (mrf *MerchantRegistryRepoFake) FindMerchants(_ context.Context, filter port.MerchantListFilterEntity) ([]port.MerchantRegistryEntity, error) {
+	merchants := []port.MerchantRegistryEntity{}
+
+	for _, merchant := range mrf.merchants {
+		if merchant.ID > filter.CursorID {
+			merchants = append(merchants, merchant)
+		}
+	}
+
+	sort.Slice(merchants, func(i, j int) bool {
+		return merchants[i].ID < merchants[j].ID
+	})
+
+	if len(merchants) > filter.Limit {
+		merchants = merchants[:filter.Limit]
+	}
+
+	return merchants, nil
+}
+
+func (mrf *MerchantRegistryRepoFake) UpdateMerchant(_ context.Context, merchant port.MerchantRegistryEntity) (port.MerchantRegistryEntity, error) {
+	previous, ok := mrf.merchants[merchant.UID]
+	if !ok {
+		return port.MerchantRegistryEntity{}, fmt.Errorf("%w: %s", port.ErrMerchantNotFound, merchant.UID)
+	}
+
+	if mrf.nameTaken(merchant) {
+		return port.MerchantRegistryEntity{}, fmt.Errorf("%w: %s", port.ErrMerchantAlreadyExists, merchant.Name)
+	}
+
+	merchant.ID = previous.ID
+	merchant.CreatedAt = previous.CreatedAt
+	merchant.UpdatedAt = time.Now()
+	mrf.merchants[merchant.UID] = merchant
+
+	return merchant, nil
+}
+
+func (mrf *MerchantRegistryRepoFake) DeleteMerchant(_ context.Context, uid uuid.UUID) error {
+	if _, ok := mrf.merchants[uid]; !ok {
+		return fmt.Errorf("%w: %s", port.ErrMerchantNotFound, uid)
+	}
+
+	delete(mrf.merchants, uid)
+
+	return nil
+}
+
+func (mrf *MerchantRegistryRepoFake) nameTaken(merchant port.MerchantRegistryEntity) bool {
+	for uid, registered := range mrf.merchants {
+		if uid != merchant.UID && registered.Name == merchant.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+type LedgerRepoFake struct {
+	balances []port.LedgerBalanceEntity
+}
+
+func newLedgerRepoFake(balances ...port.LedgerBalanceEntity) *LedgerRepoFake {
+	return &LedgerRepoFake{balances: balances}
+}
+
+func (lrf *LedgerRepoFake) FindLedgerBalances(_ context.Context, filter port.LedgerFilterEntity) ([]port.LedgerBalanceEntity, error) {
+	balances := []port.LedgerBalanceEntity{}
+	accounts := make(map[uint]bool)
+
+	for _, balance := range lrf.balances {
+		if balance.AccountID <= filter.CursorID {
+			continue
+		}
+
+		if filter.AccountUID != uuid.Nil && balance.AccountUID != filter.AccountUID {
+			continue
+		}
+
+		if !accounts[balance.AccountID] && len(accounts) == filter.Limit {
+			break
+		}
+
+		accounts[balance.AccountID] = true
+		balances = append(balances, balance)
+	}
+
+	return balances, nil
+}
+
+type AdminSuite struct {
+	suite.Suite
+}
+
+func (suite *AdminSuite) newAdminService(
+	repoFake *AdminRepoFake,
+	mrRepoFake *MerchantRegistryRepoFake,
+	lRepoFake *LedgerRepoFake,
+) *Admin {
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	return NewAdmin(
+		timeoutSLA,
+		repoFake,
+		mrRepoFake,
+		lRepoFake,
+		newCategoryRuleRepoFake(newDBfake()),
+		newSpendingLimitRepoFake(newDBfake()),
+		newCardRepoFake(&DBfake{}),
+		newMemoryLockRepoFake(newInMemoryDBfake()),
+		newFakeLog(),
+	)
+}
+
+func (suite *AdminSuite) TestCreateAccountAndAttachCategoriesSuccess() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	account, _ := adminService.CreateAccount(port.AccountCreateRequest{Name: "Jonh Doe"})
+	cash, _ := adminService.CreateCategory(port.CategoryCreateRequest{Name: "cash", Priority: 3})
+	food, _ := adminService.CreateCategory(port.CategoryCreateRequest{Name: "food", Priority: 1})
+
+	accountUID, _ := uuid.Parse(account.UID)
+	cashUID, _ := uuid.Parse(cash.UID)
+	foodUID, _ := uuid.Parse(food.UID)
+
+	//Act
+	errCash := adminService.AttachCategory(port.AccountCategoryRequest{AccountUID: accountUID, CategoryUID: cashUID})
+	errFood := adminService.AttachCategory(port.AccountCategoryRequest{AccountUID: accountUID, CategoryUID: foodUID})
+	_, errMCC := adminService.AssignMCC(port.MCCAssignRequest{CategoryUID: foodUID, MCC: "5411"})
+
+	accountList, errList := adminService.ListAccounts(port.AccountListRequest{})
+
+	//Assert
+	assert.Equal(suite.T(), errCash, nil)
+	assert.Equal(suite.T(), errFood, nil)
+	assert.Equal(suite.T(), errMCC, nil)
+	assert.Equal(suite.T(), errList, nil)
+	assert.Equal(suite.T(), len(accountList.Accounts), 1)
+	assert.Equal(suite.T(), accountList.NextCursor, "")
+
+	categories := accountList.Accounts[0].Categories
+	assert.Equal(suite.T(), len(categories), 2)
+	assert.Equal(suite.T(), categories[0].Name, "FOOD")
+	assert.Equal(suite.T(), categories[0].MCCs, []string{"5411"})
+	assert.Equal(suite.T(), categories[1].Name, "CASH")
+	assert.Equal(suite.T(), categories[1].MCCs, []string{})
+}
+
+func (suite *AdminSuite) TestCreateAccountInvalidName() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	//Act
+	_, err := adminService.CreateAccount(port.AccountCreateRequest{Name: "   "})
+
+	//Assert
+	assert.Equal(suite.T(), errors.Is(err, port.ErrInvalidAdminRequest), true)
+}
+
+func (suite *AdminSuite) TestCreateCategoryInvalidPriority() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	//Act
+	_, err := adminService.CreateCategory(port.CategoryCreateRequest{Name: "MOBILITY", Priority: 0})
+
+	//Assert
+	assert.Equal(suite.T(), errors.Is(err, port.ErrInvalidAdminRequest), true)
+}
+
+func (suite *AdminSuite) TestAssignMCCInvalidCode() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	//Act
+	_, err := adminService.AssignMCC(port.MCCAssignRequest{CategoryUID: uuid.New(), MCC: "54A1"})
+
+	//Assert
+	assert.Equal(suite.T(), errors.Is(err, port.ErrInvalidAdminRequest), true)
+}
+
+func (suite *AdminSuite) TestAssignMCCAlreadyAssigned() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	food, _ := adminService.CreateCategory(port.CategoryCreateRequest{Name: "FOOD", Priority: 1})
+	meal, _ := adminService.CreateCategory(port.CategoryCreateRequest{Name: "MEAL", Priority: 2})
+
+	foodUID, _ := uuid.Parse(food.UID)
+	mealUID, _ := uuid.Parse(meal.UID)
+
+	_, _ = adminService.AssignMCC(port.MCCAssignRequest{CategoryUID: foodUID, MCC: "5411"})
+
+	//Act
+	_, err := adminService.AssignMCC(port.MCCAssignRequest{CategoryUID: mealUID, MCC: "5411"})
+
+	//Assert
+	assert.Equal(suite.T(), errors.Is(err, port.ErrMCCAlreadyAssigned), true)
+}
+
+func (suite *AdminSuite) TestAttachCategoryAlreadyAttached() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	account, _ := adminService.CreateAccount(port.AccountCreateRequest{Name: "Jonh Doe"})
+	food, _ := adminService.CreateCategory(port.CategoryCreateRequest{Name: "FOOD", Priority: 1})
+
+	accountCategory := port.AccountCategoryRequest{
+		AccountUID:  uuid.MustParse(account.UID),
+		CategoryUID: uuid.MustParse(food.UID),
+	}
+
+	_ = adminService.AttachCategory(accountCategory)
+
+	//Act
+	err := adminService.AttachCategory(accountCategory)
+
+	//Assert
+	assert.Equal(suite.T(), errors.Is(err, port.ErrCategoryAlreadyAttached), true)
+}
+
+func (suite *AdminSuite) TestDetachCategoryNotAttached() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	account, _ := adminService.CreateAccount(port.AccountCreateRequest{Name: "Jonh Doe"})
+
+	//Act
+	err := adminService.DetachCategory(port.AccountCategoryRequest{
+		AccountUID:  uuid.MustParse(account.UID),
+		CategoryUID: uuid.New(),
+	})
+
+	//Assert
+	assert.Equal(suite.T(), errors.Is(err, port.ErrCategoryNotAttached), true)
+}
+
+func (suite *AdminSuite) TestDeleteAccountRemovesFromList() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	account, _ := adminService.CreateAccount(port.AccountCreateRequest{Name: "Jonh Doe"})
+
+	//Act
+	errDelete := adminService.DeleteAccount(uuid.MustParse(account.UID))
+	errDeleteAgain := adminService.DeleteAccount(uuid.MustParse(account.UID))
+
+	accountList, _ := adminService.ListAccounts(port.AccountListRequest{})
+
+	//Assert
+	assert.Equal(suite.T(), errDelete, nil)
+	assert.Equal(suite.T(), errors.Is(errDeleteAgain, port.ErrAccountNotFound), true)
+	assert.Equal(suite.T(), len(accountList.Accounts), 0)
+}
+
+func (suite *AdminSuite) TestChangeAccountStatusLifecycleAudited() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	account, _ := adminService.CreateAccount(port.AccountCreateRequest{Name: "Jonh Doe"})
+	accountUID := uuid.MustParse(account.UID)
+
+	//Act
+	blocked, errBlock := adminService.ChangeAccountStatus(port.AccountStatusRequest{AccountUID: accountUID, Action: "BLOCK", Reason: " card reported stolen "})
+	_, errBlockAgain := adminService.ChangeAccountStatus(port.AccountStatusRequest{AccountUID: accountUID, Action: "BLOCK"})
+	_, errUnblock := adminService.ChangeAccountStatus(port.AccountStatusRequest{AccountUID: accountUID, Action: "UNBLOCK"})
+	cancelled, errCancel := adminService.ChangeAccountStatus(port.AccountStatusRequest{AccountUID: accountUID, Action: "CANCEL"})
+	_, errUnblockCancelled := adminService.ChangeAccountStatus(port.AccountStatusRequest{AccountUID: accountUID, Action: "UNBLOCK"})
+
+	changes, errList := adminService.ListAccountStatusChanges(accountUID)
+	accountList, _ := adminService.ListAccounts(port.AccountListRequest{})
+
+	//Assert
+	assert.Equal(suite.T(), account.Status, "ACTIVE")
+	assert.Equal(suite.T(), errBlock, nil)
+	assert.Equal(suite.T(), blocked.PreviousStatus, "ACTIVE")
+	assert.Equal(suite.T(), blocked.Status, "BLOCKED")
+	assert.Equal(suite.T(), blocked.Reason, "card reported stolen")
+	assert.Equal(suite.T(), errors.Is(errBlockAgain, port.ErrAccountStatusTransition), true)
+	assert.Equal(suite.T(), errUnblock, nil)
+	assert.Equal(suite.T(), errCancel, nil)
+	assert.Equal(suite.T(), cancelled.Status, "CANCELLED")
+	assert.Equal(suite.T(), errors.Is(errUnblockCancelled, port.ErrAccountStatusTransition), true)
+
+	assert.Equal(suite.T(), errList, nil)
+	assert.Equal(suite.T(), len(changes.Changes), 3)
+	assert.Equal(suite.T(), changes.Changes[1].PreviousStatus, "BLOCKED")
+	assert.Equal(suite.T(), changes.Changes[1].Status, "ACTIVE")
+	assert.Equal(suite.T(), accountList.Accounts[0].Status, "CANCELLED")
+}
+
+func (suite *AdminSuite) TestChangeAccountStatusInvalidAction() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	account, _ := adminService.CreateAccount(port.AccountCreateRequest{Name: "Jonh Doe"})
+
+	//Act
+	_, err := adminService.ChangeAccountStatus(port.AccountStatusRequest{AccountUID: uuid.MustParse(account.UID), Action: "FREEZE"})
+
+	//Assert
+	assert.Equal(suite.T(), errors.Is(err, port.ErrInvalidAdminRequest), true)
+}
+
+func (suite *AdminSuite) TestChangeAccountStatusAccountNotFound() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	//Act
+	_, err := adminService.ChangeAccountStatus(port.AccountStatusRequest{AccountUID: uuid.New(), Action: "BLOCK"})
+
+	//Assert
+	assert.Equal(suite.T(), errors.Is(err, port.ErrAccountNotFound), true)
+}
+
+func (suite *AdminSuite) TestListAccountsPagination() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	for _, name := range []string{"Jonh Doe", "Jane Doe", "Baby Doe"} {
+		_, _ = adminService.CreateAccount(port.AccountCreateRequest{Name: name})
+	}
+
+	//Act
+	firstPage, errFirst := adminService.ListAccounts(port.AccountListRequest{Limit: 2})
+	lastPage, errLast := adminService.ListAccounts(port.AccountListRequest{Cursor: firstPage.NextCursor, Limit: 2})
+	_, errCursor := adminService.ListAccounts(port.AccountListRequest{Cursor: "!invalid"})
+
+	//Assert
+	assert.Equal(suite.T(), errFirst, nil)
+	assert.Equal(suite.T(), errLast, nil)
+	assert.Equal(suite.T(), len(firstPage.Accounts), 2)
+	assert.Equal(suite.T(), firstPage.Accounts[0].Name, "Jonh Doe")
+	assert.NotEqual(suite.T(), firstPage.NextCursor, "")
+	assert.Equal(suite.T(), len(lastPage.Accounts), 1)
+	assert.Equal(suite.T(), lastPage.Accounts[0].Name, "Baby Doe")
+	assert.Equal(suite.T(), lastPage.NextCursor, "")
+	assert.Equal(suite.T(), errors.Is(errCursor, port.ErrInvalidCursor), true)
+}
+
+func (suite *AdminSuite) TestCreateAndUpdateMerchantSuccess() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	created, errCreate := adminService.CreateMerchant(port.MerchantCreateRequest{
+		Name: "UBER EATS                   SAO PAULO BR",
+		MCC:  "5412",
+	})
+
+	//Act
+	updated, errUpdate := adminService.UpdateMerchant(port.MerchantUpdateRequest{
+		UID:  uuid.MustParse(created.UID),
+		Name: "UBER TRIP                   SAO PAULO BR",
+		MCC:  "4121",
+	})
+
+	found, errGet := adminService.GetMerchant(uuid.MustParse(created.UID))
+
+	//Assert
+	assert.Equal(suite.T(), errCreate, nil)
+	assert.Equal(suite.T(), errUpdate, nil)
+	assert.Equal(suite.T(), errGet, nil)
+	assert.Equal(suite.T(), created.Name, "UBER EATS                   SAO PAULO BR")
+	assert.Equal(suite.T(), updated.UID, created.UID)
+	assert.Equal(suite.T(), found.Name, "UBER TRIP                   SAO PAULO BR")
+	assert.Equal(suite.T(), found.MCC, "4121")
+}
+
+func (suite *AdminSuite) TestCreateMerchantInvalidRequest() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	//Act
+	_, errName := adminService.CreateMerchant(port.MerchantCreateRequest{Name: "   ", MCC: "5412"})
+	_, errMCC := adminService.CreateMerchant(port.MerchantCreateRequest{Name: "PADARIA DO ZE", MCC: "541"})
+
+	//Assert
+	assert.Equal(suite.T(), errors.Is(errName, port.ErrInvalidAdminRequest), true)
+	assert.Equal(suite.T(), errors.Is(errMCC, port.ErrInvalidAdminRequest), true)
+}
+
+func (suite *AdminSuite) TestCreateMerchantAliasesNormalized() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	//Act
+	merchant, err := adminService.CreateMerchant(port.MerchantCreateRequest{
+		Name:    "PADARIA DO ZE               SAO PAULO BR",
+		MCC:     "5411",
+		Aliases: []string{"pag *padaria*", "Panificadora  Ze", "PAG*PADARIA*"},
+	})
+	_, errAlias := adminService.CreateMerchant(port.MerchantCreateRequest{
+		Name:    "PAGSEGURO",
+		MCC:     "5411",
+		Aliases: []string{"PA*"},
+	})
+
+	//Assert
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), merchant.Aliases, []string{"PAG*PADARIA*", "PANIFICADORA"})
+	assert.Equal(suite.T(), errors.Is(errAlias, port.ErrInvalidAdminRequest), true)
+}
+
+func (suite *AdminSuite) TestCreateMerchantAlreadyExists() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	merchant := port.MerchantCreateRequest{Name: "PADARIA DO ZE               SAO PAULO BR", MCC: "5411"}
+	_, _ = adminService.CreateMerchant(merchant)
+
+	//Act
+	_, err := adminService.CreateMerchant(merchant)
+
+	//Assert
+	assert.Equal(suite.T(), errors.Is(err, port.ErrMerchantAlreadyExists), true)
+}
+
+func (suite *AdminSuite) TestDeleteMerchantAndListPagination() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	merchantUIDs := []string{}
+	for _, name := range []string{"PADARIA DO ZE", "MERCADINHO DA ANA", "POSTO DO JOAO"} {
+		merchant, _ := adminService.CreateMerchant(port.MerchantCreateRequest{Name: name, MCC: "5411"})
+		merchantUIDs = append(merchantUIDs, merchant.UID)
+	}
+
+	//Act
+	errDelete := adminService.DeleteMerchant(uuid.MustParse(merchantUIDs[1]))
+	errDeleteAgain := adminService.DeleteMerchant(uuid.MustParse(merchantUIDs[1]))
+
+	firstPage, errFirst := adminService.ListMerchants(port.MerchantListRequest{Limit: 1})
+	lastPage, errLast := adminService.ListMerchants(port.MerchantListRequest{Cursor: firstPage.NextCursor, Limit: 1})
+
+	//Assert
+	assert.Equal(suite.T(), errDelete, nil)
+	assert.Equal(suite.T(), errors.Is(errDeleteAgain, port.ErrMerchantNotFound), true)
+	assert.Equal(suite.T(), errFirst, nil)
+	assert.Equal(suite.T(), errLast, nil)
+	assert.Equal(suite.T(), firstPage.Merchants[0].Name, "PADARIA DO ZE")
+	assert.NotEqual(suite.T(), firstPage.NextCursor, "")
+	assert.Equal(suite.T(), len(lastPage.Merchants), 1)
+	assert.Equal(suite.T(), lastPage.Merchants[0].Name, "POSTO DO JOAO")
+	assert.Equal(suite.T(), lastPage.NextCursor, "")
+}
+
+func (suite *AdminSuite) TestCheckLedgerReportsInconsistenciesAndPaginates() {
+	//Arrange
+	firstAccountUID, secondAccountUID, thirdAccountUID := uuid.New(), uuid.New(), uuid.New()
+
+	lRepoFake := newLedgerRepoFake(
+		port.LedgerBalanceEntity{
+			AccountID:     1,
+			AccountUID:    firstAccountUID,
+			CategoryName:  "FOOD",
+			Balance:       decimal.NewFromFloat(105.02),
+			LedgerBalance: decimal.NewFromFloat(105.02),
+		},
+		port.LedgerBalanceEntity{
+			AccountID:     1,
+			AccountUID:    firstAccountUID,
+			CategoryName:  "CASH",
+			Balance:       decimal.NewFromFloat(90.22),
+			LedgerBalance: decimal.NewFromFloat(100.22),
+		},
+		port.LedgerBalanceEntity{
+			AccountID:     2,
+			AccountUID:    secondAccountUID,
+			CategoryName:  "MEAL",
+			Balance:       decimal.NewFromFloat(10),
+			LedgerBalance: decimal.NewFromFloat(10),
+			BrokenEntries: 1,
+		},
+		port.LedgerBalanceEntity{
+			AccountID:     3,
+			AccountUID:    thirdAccountUID,
+			CategoryName:  "FOOD",
+			Balance:       decimal.NewFromFloat(1),
+			LedgerBalance: decimal.NewFromFloat(2),
+		},
+	)
+
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), lRepoFake)
+
+	//Act
+	firstPage, errFirst := adminService.CheckLedger(port.LedgerConsistencyRequest{Limit: 2})
+	lastPage, errLast := adminService.CheckLedger(port.LedgerConsistencyRequest{Cursor: firstPage.NextCursor, Limit: 2})
+	account, errAccount := adminService.CheckLedger(port.LedgerConsistencyRequest{AccountUID: secondAccountUID})
+
+	//Assert
+	assert.Equal(suite.T(), errFirst, nil)
+	assert.Equal(suite.T(), firstPage.Checked, 3)
+	assert.Equal(suite.T(), len(firstPage.Inconsistencies), 2)
+	assert.Equal(suite.T(), firstPage.Inconsistencies[0].Category, "CASH")
+	assert.Equal(suite.T(), firstPage.Inconsistencies[1].BrokenEntries, 1)
+	assert.NotEqual(suite.T(), firstPage.NextCursor, "")
+
+	assert.Equal(suite.T(), errLast, nil)
+	assert.Equal(suite.T(), lastPage.Checked, 1)
+	assert.Equal(suite.T(), lastPage.Inconsistencies[0].AccountUID, thirdAccountUID.String())
+	assert.Equal(suite.T(), lastPage.NextCursor, "")
+
+	assert.Equal(suite.T(), errAccount, nil)
+	assert.Equal(suite.T(), account.Checked, 1)
+	assert.Equal(suite.T(), len(account.Inconsistencies), 1)
+}
+
+func (suite *AdminSuite) TestCheckLedgerInvalidCursor() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	//Act
+	_, err := adminService.CheckLedger(port.LedgerConsistencyRequest{Cursor: "invalid"})
+
+	//Assert
+	assert.Equal(suite.T(), errors.Is(err, port.ErrInvalidCursor), true)
+}
+
+func (suite *AdminSuite) TestSetCategoryRuleInvalidFallbacks() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+	categoryUID := uuid.NewString()
+	fallbackUID := uuid.NewString()
+
+	//Act
+	_, errUID := adminService.SetCategoryRule(port.CategoryRuleRequest{Fallbacks: []string{"xxxxxxxx"}})
+	_, errSelf := adminService.SetCategoryRule(port.CategoryRuleRequest{CategoryUID: categoryUID, Fallbacks: []string{categoryUID}})
+	_, errRepeated := adminService.SetCategoryRule(port.CategoryRuleRequest{Fallbacks: []string{fallbackUID, fallbackUID}})
+
+	chain, err := adminService.SetCategoryRule(port.CategoryRuleRequest{CategoryUID: categoryUID, Fallbacks: []string{fallbackUID}})
+
+	//Assert
+	assert.Equal(suite.T(), errors.Is(errUID, port.ErrInvalidAdminRequest), true)
+	assert.Equal(suite.T(), errors.Is(errSelf, port.ErrInvalidAdminRequest), true)
+	assert.Equal(suite.T(), errors.Is(errRepeated, port.ErrInvalidAdminRequest), true)
+
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), chain.CategoryUID, categoryUID)
+	assert.Equal(suite.T(), chain.AccountUID, "")
+	assert.Equal(suite.T(), chain.Fallbacks[0].UID, fallbackUID)
+}
+
+func (suite *AdminSuite) TestCreateCardAndUpdateStatusSuccess() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	card, errCreate := adminService.CreateCard(port.CardCreateRequest{
+		AccountUID:        accountUIDtoTransact,
+		Token:             "tok_4f9a2c7e1b3d5a60",
+		MaskedPAN:         "411111******1111",
+		Expiry:            "12/30",
+		TransactionAmount: decimal.NewFromFloat(80.00),
+	})
+	cardUID, _ := uuid.Parse(card.UID)
+
+	//Act
+	updated, errUpdate := adminService.UpdateCard(port.CardUpdateRequest{
+		CardUID: cardUID,
+		Status:  "blocked",
+		Expiry:  "12/31",
+	})
+	cards, errList := adminService.ListCards(accountUIDtoTransact)
+
+	//Assert
+	assert.Equal(suite.T(), errCreate, nil)
+	assert.Equal(suite.T(), card.Status, "ACTIVE") // domain.CARD_STATUS_ACTIVE
+	assert.Equal(suite.T(), card.Expiry, "12/30")
+	assert.Equal(suite.T(), card.AccountUID, accountUIDtoTransact.String())
+
+	assert.Equal(suite.T(), errUpdate, nil)
+	assert.Equal(suite.T(), updated.Status, "BLOCKED") // domain.CARD_STATUS_BLOCKED
+	assert.Equal(suite.T(), updated.Expiry, "12/31")
+	assert.Equal(suite.T(), updated.TransactionAmount.IsZero(), true)
+
+	assert.Equal(suite.T(), errList, nil)
+	assert.Equal(suite.T(), len(cards.Cards), 1)
+}
+
+func (suite *AdminSuite) TestCreateCardInvalidRequestRejected() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	//Act
+	_, errPAN := adminService.CreateCard(port.CardCreateRequest{
+		AccountUID: accountUIDtoTransact,
+		Token:      "tok_4f9a2c7e1b3d5a60",
+		MaskedPAN:  "4111111111111111",
+		Expiry:     "12/30",
+	})
+	_, errExpiry := adminService.CreateCard(port.CardCreateRequest{
+		AccountUID: accountUIDtoTransact,
+		Token:      "tok_4f9a2c7e1b3d5a60",
+		MaskedPAN:  "411111******1111",
+		Expiry:     "13/30",
+	})
+
+	//Assert
+	assert.Equal(suite.T(), errors.Is(errPAN, port.ErrInvalidAdminRequest), true)
+	assert.Equal(suite.T(), errors.Is(errExpiry, port.ErrInvalidAdminRequest), true)
+}
+
+func (suite *AdminSuite) TestUpdateCancelledCardRejected() {
+	//Arrange
+	adminService := suite.newAdminService(newAdminRepoFake(), newMerchantRegistryRepoFake(), newLedgerRepoFake())
+
+	card, _ := adminService.CreateCard(port.CardCreateRequest{
+		AccountUID: accountUIDtoTransact,
+		Token:      "tok_4f9a2c7e1b3d5a60",
+		MaskedPAN:  "411111******1111",
+		Expiry:     "12/30",
+	})
+	cardUID, _ := uuid.Parse(card.UID)
+
+	_, errCancel := adminService.UpdateCard(port.CardUpdateRequest{CardUID: cardUID, Status: "CANCELLED", Expiry: "12/30"})
+
+	//Act
+	_, err := adminService.UpdateCard(port.CardUpdateRequest{CardUID: cardUID, Status: "ACTIVE", Expiry: "12/30"})
+
+	//Assert
+	assert.Equal(suite.T(), errCancel, nil)
+	assert.Equal(suite.T(), errors.Is(err, port.ErrCardCancelled), true)
+}
+
+func (suite *AdminSuite) TestLockQueueReportsWaiters() {
+	//Arrange
+	inMemoryDBfake := newInMemoryDBfake()
+	inMemoryDBfake.Waiters[accountUIDtoTransact.String()] = 3
+
+	adminService := NewAdmin(
+		port.TimeoutSLA(time.Duration(timeoutSLAcfg)*time.Millisecond),
+		newAdminRepoFake(),
+		newMerchantRegistryRepoFake(),
+		newLedgerRepoFake(),
+		newCategoryRuleRepoFake(newDBfake()),
+		newSpendingLimitRepoFake(newDBfake()),
+		newCardRepoFake(&DBfake{}),
+		newMemoryLockRepoFake(inMemoryDBfake),
+		newFakeLog(),
+	)
+
+	//Act
+	lockQueue, err := adminService.LockQueue(accountUIDtoTransact)
+
+	//Assert
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), lockQueue.AccountUID, accountUIDtoTransact.String())
+	assert.Equal(suite.T(), lockQueue.Depth, int64(3))
+}
+
+func TestAdminSuite(t *testing.T) {
+	suite.Run(t, new(AdminSuite))
+}
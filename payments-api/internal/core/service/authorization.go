@@ -0,0 +1,468 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/support/logger"
+)
+
+type Authorization struct {
+	timeoutSLA             port.TimeoutSLA
+	holdTTL                port.AuthorizationHoldTTL
+	accountRepository      port.AccountRepository
+	cardRepository         port.CardRepository
+	merchantMatcher        *MerchantMatcher
+	riskStage              port.RiskStage
+	exchangeRateRepository port.ExchangeRateRepository
+	categoryRuleRepository port.CategoryRuleRepository
+	holdRepository         port.HoldRepository
+	memoryLockRepository   port.MemoryLockRepository
+
+	spendingLimitRepository port.SpendingLimitRepository
+	spendingUsageRepository port.SpendingUsageRepository
+
+	transactionOutcomeRepository port.TransactionOutcomeRepository
+
+	log logger.Logger
+}
+
+func NewAuthorization(
+	timeoutSLA port.TimeoutSLA,
+	holdTTL port.AuthorizationHoldTTL,
+
+	aRepository port.AccountRepository,
+	cRepository port.CardRepository,
+	merchantMatcher *MerchantMatcher,
+	riskStage port.RiskStage,
+	erRepository port.ExchangeRateRepository,
+	crRepository port.CategoryRuleRepository,
+	slRepository port.SpendingLimitRepository,
+	suRepository port.SpendingUsageRepository,
+	hRepository port.HoldRepository,
+	toRepository port.TransactionOutcomeRepository,
+	mlRepository port.MemoryLockRepository,
+
+	log logger.Logger,
+) *Authorization {
+	return &Authorization{
+		timeoutSLA:             timeoutSLA,
+		holdTTL:                holdTTL,
+		accountRepository:      aRepository,
+		cardRepository:         cRepository,
+		merchantMatcher:        merchantMatcher,
+		riskStage:              riskStage,
+		exchangeRateRepository: erRepository,
+		categoryRuleRepository: crRepository,
+		holdRepository:         hRepository,
+		memoryLockRepository:   mlRepository,
+
+		spendingLimitRepository: slRepository,
+		spendingUsageRepository: suRepository,
+
+		transactionOutcomeRepository: toRepository,
+
+		log: log,
+	}
+}
+
+/*
+- The response lists the categories tried to reserve the funds, as in Payment.Execute
+- As a payment, an authorization may identify the account by a card token
+*/
+func (au *Authorization) Authorize(tpr port.TransactionPaymentRequest) (port.TransactionPaymentResponse, error) {
+	attempts := []domain.CategoryAttempt{}
+
+	code, err := au.authorize(tpr, &attempts)
+
+	return mapTransactionPaymentResponse(code, attempts), err
+}
+
+func (au *Authorization) authorize(tpr port.TransactionPaymentRequest, attempts *[]domain.CategoryAttempt) (string, error) {
+	ctx, cancel := au.newContext(tpr.TransactionUID.String(), tpr.AccountUID.String())
+	defer cancel()
+
+	outcome := mapPaymentRequestToOutcomeEntity(tpr)
+
+	// The card resolves the account to lock, so no lock is held to release yet
+	cardEntity, err := findPaymentCard(ctx, au.cardRepository, &tpr)
+	if err != nil {
+		return au.declinedEventErr(ctx, outcome, err), err
+	}
+
+	outcome.AccountUID = tpr.AccountUID
+
+	ctx = context.WithValue(ctx, logger.CtxAccountUIDKey, tpr.AccountUID.String())
+
+	// The merchant catalog does not depend on the account, so it is matched before the lock
+	merchant, err := au.merchantMatcher.Match(ctx, tpr.Merchant)
+	if err != nil {
+		return au.declinedEventErr(ctx, outcome, err), err
+	}
+
+	transactionLocked, err := au.memoryLockRepository.Lock(
+		ctx,
+		mapTransactionRequestToMemoryLockEntity(tpr),
+	)
+	if err != nil {
+		return au.rejectedEventErr(
+			ctx,
+			transactionLocked,
+			outcome,
+			fmt.Errorf("failed concurrent transaction locked: %w", err),
+		)
+	}
+
+	outcomeEntity, err := au.transactionOutcomeRepository.FindByUID(ctx, tpr.TransactionUID)
+	if err != nil {
+		return au.rejectedEventErr(
+			ctx,
+			transactionLocked,
+			outcome,
+			fmt.Errorf("failed to retrieve transaction outcome: %w", err),
+		)
+	}
+
+	if outcomeEntity != nil {
+		return au.replayedOutcome(ctx, transactionLocked, tpr.AccountUID, *outcomeEntity)
+	}
+
+	currency, err := paymentCurrency(tpr)
+	if err != nil {
+		return au.rejectedEventErr(ctx, transactionLocked, outcome, err)
+	}
+
+	accountEntity, err := au.accountRepository.FindByUID(ctx, tpr.AccountUID)
+	if err != nil {
+		return au.rejectedEventErr(
+			ctx,
+			transactionLocked,
+			outcome,
+			fmt.Errorf("failed to retrieve account entity: %w", err),
+		)
+	}
+
+	if accountEntity.ID == 0 {
+		return au.rejectedEventErr(
+			ctx,
+			transactionLocked,
+			outcome,
+			fmt.Errorf("%w: %s", port.ErrAccountNotFound, tpr.AccountUID.String()),
+		)
+	}
+
+	account := mapAccountEntityToDomain(accountEntity, au.log)
+	outcome.AccountID = account.ID
+
+	err = loadExchangeRates(ctx, au.exchangeRateRepository, &account, currency)
+	if err != nil {
+		return au.rejectedEventErr(ctx, transactionLocked, outcome, err)
+	}
+
+	err = loadCategoryRules(ctx, au.categoryRuleRepository, &account)
+	if err != nil {
+		return au.rejectedEventErr(ctx, transactionLocked, outcome, err)
+	}
+
+	now := time.Now()
+	err = loadSpendingLimits(ctx, au.spendingLimitRepository, au.spendingUsageRepository, au.exchangeRateRepository, &account, now)
+	if err != nil {
+		return au.rejectedEventErr(ctx, transactionLocked, outcome, err)
+	}
+
+	if cardEntity != nil {
+		err = loadCard(ctx, au.cardRepository, *cardEntity, &account, now)
+		if err != nil {
+			return au.rejectedEventErr(ctx, transactionLocked, outcome, err)
+		}
+	}
+
+	transaction := merchant.NewTransaction(
+		tpr.TransactionUID,
+		tpr.MCC,
+		tpr.TotalAmount,
+		currency,
+		tpr.Merchant,
+		account,
+	)
+
+	au.merchantMatcher.Audit(ctx, tpr.MCC, merchant, transaction)
+
+	cErr := account.CheckDebitAllowed(now)
+	if cErr != nil {
+		au.saveOutcome(ctx, mapTransactionOutcomeToEntity(transaction, account, cErr.Code))
+		return au.rejectedCustomErr(ctx, transactionLocked, cErr)
+	}
+
+	cErr, err = assessRisk(ctx, au.riskStage, transaction, au.log)
+	if err != nil {
+		return au.rejectedEventErr(ctx, transactionLocked, outcome, err)
+	}
+
+	if cErr != nil {
+		au.saveOutcome(ctx, mapTransactionOutcomeToEntity(transaction, account, cErr.Code))
+		return au.rejectedCustomErr(ctx, transactionLocked, cErr)
+	}
+
+	expiresAt := now.Add(time.Duration(au.holdTTL))
+	holds, categoryAttempts, cErr := account.AuthorizeTransaction(ctx, transaction, expiresAt)
+	*attempts = categoryAttempts
+	if cErr != nil {
+		au.saveOutcome(ctx, mapTransactionOutcomeToEntity(transaction, account, cErr.Code))
+		return au.rejectedCustomErr(ctx, transactionLocked, cErr)
+	}
+
+	err = au.holdRepository.SaveHolds(ctx, mapHoldDomainsToEntities(holds))
+	if err != nil {
+		return au.rejectedEventErr(
+			ctx,
+			transactionLocked,
+			outcome,
+			fmt.Errorf("failed to save hold entity: %w", err),
+		)
+	}
+
+	spending, cErr := account.SpendingByHolds(holds)
+	recordSpendingUsage(ctx, au.spendingUsageRepository, account.ID, spending, cErr, now, au.log)
+
+	au.saveOutcome(ctx, mapTransactionOutcomeToEntity(transaction, account, domain.CODE_APPROVED))
+
+	_ = au.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return domain.CODE_APPROVED, nil
+}
+
+func (au *Authorization) Capture(thr port.TransactionHoldRequest) (string, error) {
+	ctx, cancel := au.newContext(thr.TransactionUID.String(), thr.AccountUID.String())
+	defer cancel()
+
+	transactionLocked, err := au.memoryLockRepository.Lock(
+		ctx,
+		mapHoldRequestToMemoryLockEntity(thr),
+	)
+	if err != nil {
+		return au.rejectedGenericErr(
+			ctx,
+			transactionLocked,
+			fmt.Errorf("failed concurrent transaction locked: %w", err),
+		)
+	}
+
+	holdEntities, err := au.findAccountHolds(ctx, thr)
+	if err != nil {
+		return au.rejectedGenericErr(ctx, transactionLocked, err)
+	}
+
+	accountEntity, err := au.accountRepository.FindByUID(ctx, thr.AccountUID)
+	if err != nil {
+		return au.rejectedGenericErr(
+			ctx,
+			transactionLocked,
+			fmt.Errorf("failed to retrieve account entity: %w", err),
+		)
+	}
+
+	if accountEntity.ID == 0 {
+		return au.rejectedGenericErr(
+			ctx,
+			transactionLocked,
+			fmt.Errorf("%w: %s", port.ErrAccountNotFound, thr.AccountUID.String()),
+		)
+	}
+
+	account := mapAccountEntityToDomain(accountEntity, au.log)
+
+	capturedAt := time.Now()
+	err = loadSpendingLimits(ctx, au.spendingLimitRepository, au.spendingUsageRepository, au.exchangeRateRepository, &account, capturedAt)
+	if err != nil {
+		return au.rejectedGenericErr(ctx, transactionLocked, err)
+	}
+
+	capturedTransactions, cErr := account.CaptureHolds(ctx, mapHoldEntitiesToDomains(holdEntities), capturedAt)
+	if cErr != nil {
+		return au.rejectedCustomErr(ctx, transactionLocked, cErr)
+	}
+
+	err = au.holdRepository.Capture(
+		ctx,
+		thr.TransactionUID,
+		mapTransactionDomainsToEntities(capturedTransactions),
+		transactionLocked.FencingToken,
+	)
+	if err != nil {
+		return au.rejectedGenericErr(
+			ctx,
+			transactionLocked,
+			fmt.Errorf("failed to capture hold entity: %w", err),
+		)
+	}
+
+	_ = au.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return domain.CODE_APPROVED, nil
+}
+
+func (au *Authorization) Void(thr port.TransactionHoldRequest) (string, error) {
+	ctx, cancel := au.newContext(thr.TransactionUID.String(), thr.AccountUID.String())
+	defer cancel()
+
+	transactionLocked, err := au.memoryLockRepository.Lock(
+		ctx,
+		mapHoldRequestToMemoryLockEntity(thr),
+	)
+	if err != nil {
+		return au.rejectedGenericErr(
+			ctx,
+			transactionLocked,
+			fmt.Errorf("failed concurrent transaction locked: %w", err),
+		)
+	}
+
+	holdEntities, err := au.findAccountHolds(ctx, thr)
+	if err != nil {
+		return au.rejectedGenericErr(ctx, transactionLocked, err)
+	}
+
+	if len(holdEntities) == 0 {
+		return au.rejectedGenericErr(
+			ctx,
+			transactionLocked,
+			fmt.Errorf("%w to void: %s", port.ErrHoldNotFound, thr.TransactionUID.String()),
+		)
+	}
+
+	err = au.holdRepository.Void(ctx, thr.TransactionUID)
+	if err != nil {
+		return au.rejectedGenericErr(
+			ctx,
+			transactionLocked,
+			fmt.Errorf("failed to void hold entity: %w", err),
+		)
+	}
+
+	resetSpendingUsage(ctx, au.spendingUsageRepository, holdEntities, time.Now(), au.log)
+
+	_ = au.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return domain.CODE_APPROVED, nil
+}
+
+func (au *Authorization) newContext(transactionUID, accountUID string) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		time.Duration(au.timeoutSLA),
+	)
+	ctx = context.WithValue(ctx, logger.CtxTransactionUIDKey, transactionUID)
+	ctx = context.WithValue(ctx, logger.CtxAccountUIDKey, accountUID)
+
+	return ctx, cancel
+}
+
+func (au *Authorization) saveOutcome(ctx context.Context, outcome port.TransactionOutcomeEntity) {
+	err := au.transactionOutcomeRepository.Save(ctx, outcome)
+	if err != nil {
+		au.log.Error(ctx, fmt.Sprintf("failed to save transaction outcome: %s", err.Error()))
+	}
+}
+
+func (au *Authorization) saveDeclinedEvent(ctx context.Context, outcome port.TransactionOutcomeEntity) {
+	err := au.transactionOutcomeRepository.SaveDeclinedEvent(ctx, outcome)
+	if err != nil {
+		au.log.Error(ctx, fmt.Sprintf("failed to save declined transaction event: %s", err.Error()))
+	}
+}
+
+func (au *Authorization) replayedOutcome(
+	ctx context.Context,
+	transactionLocked port.MemoryLockEntity,
+	accountUID uuid.UUID,
+	outcome port.TransactionOutcomeEntity,
+) (string, error) {
+	code, err := replayTransactionOutcome(accountUID, outcome)
+	if err != nil {
+		au.log.Warn(ctx, err.Error())
+	} else {
+		au.log.Info(ctx, fmt.Sprintf("authorization already processed, replaying code %s", code))
+	}
+
+	_ = au.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return code, err
+}
+
+func (au *Authorization) findAccountHolds(ctx context.Context, thr port.TransactionHoldRequest) (map[int]port.HoldEntity, error) {
+	holdEntities, err := au.holdRepository.FindByUID(ctx, thr.TransactionUID)
+	if err != nil {
+		return holdEntities, fmt.Errorf("failed to retrieve holds with UID %s: %w", thr.TransactionUID.String(), err)
+	}
+
+	for _, holdEntity := range holdEntities {
+		if holdEntity.AccountUID != thr.AccountUID {
+			return holdEntities, fmt.Errorf(
+				"%w: %s does not belong to account %s",
+				port.ErrHoldNotFound,
+				thr.TransactionUID.String(),
+				thr.AccountUID.String(),
+			)
+		}
+	}
+
+	return holdEntities, nil
+}
+
+func (au *Authorization) rejectedGenericErr(ctx context.Context, transactionLocked port.MemoryLockEntity, err error) (string, error) {
+	au.log.Error(ctx, err.Error())
+
+	_ = au.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return rejectionCode(err), err
+}
+
+/*
+- Rejects an authorization, which reports its declined event unlike captures and voids
+*/
+func (au *Authorization) rejectedEventErr(
+	ctx context.Context,
+	transactionLocked port.MemoryLockEntity,
+	outcome port.TransactionOutcomeEntity,
+	err error,
+) (string, error) {
+	code := au.declinedEventErr(ctx, outcome, err)
+
+	_ = au.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return code, err
+}
+
+/*
+  - Reports the rejection by err with its declined event only, without an outcome:
+    a timeout or a failure is not a decision, so a retry runs the transaction again
+  - The event is written even when ctx is done. A duplicate reports nothing, its
+    transaction was already reported
+*/
+func (au *Authorization) declinedEventErr(ctx context.Context, outcome port.TransactionOutcomeEntity, err error) string {
+	au.log.Error(ctx, err.Error())
+
+	outcome.Code = rejectionCode(err)
+	if outcome.Code != domain.CODE_REJECTED_DUPLICATE_TRANSACTION {
+		au.saveDeclinedEvent(context.WithoutCancel(ctx), outcome)
+	}
+
+	return outcome.Code
+}
+
+func (au *Authorization) rejectedCustomErr(ctx context.Context, transactionLocked port.MemoryLockEntity, cErr *domain.CustomError) (string, error) {
+	if cErr.Code == domain.CODE_REJECTED_GENERIC {
+		au.log.Error(ctx, cErr.Error())
+	} else {
+		au.log.Warn(ctx, cErr.Error())
+	}
+
+	_ = au.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return cErr.Code, fmt.Errorf("failed to process authorization: %s", cErr.Message)
+}
@@ -0,0 +1,584 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+var holdTTLcfg = 60000
+
+type HoldRepoFake struct {
+	db           DBfake
+	accountRepo  port.AccountRepository
+	capturedUIDs []uuid.UUID
+}
+
+func newHoldRepoFake(db DBfake) *HoldRepoFake {
+	return &HoldRepoFake{
+		db:          db,
+		accountRepo: newAccountRepoFake(db),
+	}
+}
+
+func (hrf *HoldRepoFake) SaveHolds(_ context.Context, holds map[int]port.HoldEntity) error {
+	for key, h := range holds {
+		if _, ok := hrf.db.Holds[h.UID]; !ok {
+			hrf.db.Holds[h.UID] = make(map[int]port.HoldEntity)
+		}
+
+		hrf.db.Holds[h.UID][key] = h
+	}
+
+	return nil
+}
+
+func (hrf *HoldRepoFake) FindByUID(_ context.Context, uid uuid.UUID) (map[int]port.HoldEntity, error) {
+	holds := make(map[int]port.HoldEntity)
+	for key, h := range hrf.db.Holds[uid] {
+		if h.Status == port.HOLD_STATUS_AUTHORIZED {
+			holds[key] = h
+		}
+	}
+
+	return holds, nil
+}
+
+func (hrf *HoldRepoFake) Capture(ctx context.Context, uid uuid.UUID, transactions map[int]port.TransactionEntity, fencingToken int64) error {
+	err := hrf.accountRepo.SaveTransactions(ctx, transactions, fencingToken)
+	if err != nil {
+		return err
+	}
+
+	hrf.capturedUIDs = append(hrf.capturedUIDs, uid)
+	return hrf.updateStatus(uid, port.HOLD_STATUS_CAPTURED)
+}
+
+func (hrf *HoldRepoFake) Void(_ context.Context, uid uuid.UUID) error {
+	return hrf.updateStatus(uid, port.HOLD_STATUS_VOIDED)
+}
+
+func (hrf *HoldRepoFake) updateStatus(uid uuid.UUID, status string) error {
+	holds, ok := hrf.db.Holds[uid]
+	if !ok {
+		return fmt.Errorf("no authorized holds %s to update to %s", uid, status)
+	}
+
+	for key, h := range holds {
+		h.Status = status
+		holds[key] = h
+	}
+
+	return nil
+}
+
+type AuthorizationSuite struct {
+	suite.Suite
+}
+
+func (suite *AuthorizationSuite) newAuthorizationService(dbFake *DBfake, holdRepo port.HoldRepository) *Authorization {
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	holdTTL := port.AuthorizationHoldTTL(
+		time.Duration(holdTTLcfg) * time.Millisecond,
+	)
+
+	return NewAuthorization(
+		timeoutSLA,
+		holdTTL,
+		newAccountRepoFake(*dbFake),
+		newCardRepoFake(dbFake),
+		newMerchantMatcherFake(newMerchantRepoFake(*dbFake)),
+		newFraudRulesFake(newFraudRuleRepoFake(*dbFake), newFraudHistoryRepoFake(*dbFake)),
+		newExchangeRateRepoFake(*dbFake),
+		newCategoryRuleRepoFake(*dbFake),
+		newSpendingLimitRepoFake(*dbFake),
+		newSpendingUsageRepoFake(*dbFake),
+		holdRepo,
+		newTransactionOutcomeRepoFake(*dbFake),
+		newMemoryLockRepoFake(newInMemoryDBfake()),
+		newFakeLog(),
+	)
+}
+
+func (suite *AuthorizationSuite) TestAuthorizeFallbackApproved() {
+	//Arrange
+	dbFake := newDBfake()
+	holdRepo := newHoldRepoFake(dbFake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsFallbackApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	response, err := suite.newAuthorizationService(&dbFake, holdRepo).Authorize(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), response.Code, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+
+	amountHeld := decimal.Zero
+	for _, h := range dbFake.Holds[tRequest.TransactionUID] {
+		assert.Equal(suite.T(), h.Status, port.HOLD_STATUS_AUTHORIZED)
+		amountHeld = amountHeld.Add(h.Amount)
+	}
+	assert.Equal(suite.T(), amountHeld.String(), amountFoodFundsFallbackApproved.String())
+}
+
+func (suite *AuthorizationSuite) TestAuthorizeInsufficientFundsRejected() {
+	//Arrange
+	dbFake := newDBfake()
+	holdRepo := newHoldRepoFake(dbFake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsRejected,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	response, err := suite.newAuthorizationService(&dbFake, holdRepo).Authorize(tRequest)
+
+	//Assert
+	codeRejected := "51" // domain.CODE_REJECTED_INSUFICIENT_FUNDS
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Holds), 0)
+}
+
+func (suite *AuthorizationSuite) TestAuthorizeSpendingLimitRejected() {
+	//Arrange
+	dbFake := newDBfake()
+	dbFake.SpendingLimits = []port.SpendingLimitEntity{
+		{AccountID: 1, CategoryID: foodCategoryID, TransactionAmount: decimal.NewFromFloat(50)},
+	}
+	holdRepo := newHoldRepoFake(dbFake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	response, err := suite.newAuthorizationService(&dbFake, holdRepo).Authorize(tRequest)
+
+	//Assert
+	codeRejected := "61" // domain.CODE_REJECTED_LIMIT_EXCEEDED
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Holds), 0)
+}
+
+func (suite *AuthorizationSuite) TestAuthorizeHeldUsageDailyLimitRejected() {
+	//Arrange
+	dbFake := newDBfake()
+	dbFake.SpendingLimits = []port.SpendingLimitEntity{
+		{AccountID: 1, DailyAmount: decimal.NewFromFloat(150)},
+	}
+	holdRepo := newHoldRepoFake(dbFake)
+	authorizationService := suite.newAuthorizationService(&dbFake, holdRepo)
+
+	newRequest := func() port.TransactionPaymentRequest {
+		return port.TransactionPaymentRequest{
+			AccountUID:     accountUIDtoTransact,
+			TransactionUID: uuid.New(),
+			TotalAmount:    amountFoodFundsApproved,
+			MCC:            correctFoodMCC,
+			Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+		}
+	}
+
+	//Act
+	firstResponse, firstErr := authorizationService.Authorize(newRequest())
+	secondResponse, secondErr := authorizationService.Authorize(newRequest())
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), firstResponse.Code, codeApproved)
+	assert.Equal(suite.T(), firstErr, nil)
+	assert.Equal(suite.T(), dbFake.SpendingUsages[0].DailyAmount.String(), amountFoodFundsApproved.String())
+
+	codeRejected := "61" // domain.CODE_REJECTED_LIMIT_EXCEEDED
+	assert.Equal(suite.T(), secondResponse.Code, codeRejected)
+	assert.NotEqual(suite.T(), secondErr, nil)
+	assert.Equal(suite.T(), len(dbFake.Holds), 1)
+}
+
+func (suite *AuthorizationSuite) TestAuthorizeWithCardTokenCapturedWithCard() {
+	//Arrange
+	dbFake := newDBfake()
+	dbFake.Cards = append(dbFake.Cards, newCardEntityFake("ACTIVE", time.Now().AddDate(1, 0, 0))) // domain.CARD_STATUS_ACTIVE
+	holdRepo := newHoldRepoFake(dbFake)
+	authorizationService := suite.newAuthorizationService(&dbFake, holdRepo)
+
+	transactionUID := uuid.New()
+	tRequest := port.TransactionPaymentRequest{
+		CardToken:      cardTokenToTransact,
+		TransactionUID: transactionUID,
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	response, err := authorizationService.Authorize(tRequest)
+	returnCode, captureErr := authorizationService.Capture(
+		port.TransactionHoldRequest{AccountUID: accountUIDtoTransact, TransactionUID: transactionUID},
+	)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), response.Code, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), returnCode, codeApproved)
+	assert.Equal(suite.T(), captureErr, nil)
+
+	foodTransaction, err := getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: foodCategoryID})
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), foodTransaction.CardID, dbFake.Cards[0].ID)
+}
+
+func (suite *AuthorizationSuite) TestAuthorizeWithBlockedCardRejected() {
+	//Arrange
+	dbFake := newDBfake()
+	dbFake.Cards = append(dbFake.Cards, newCardEntityFake("BLOCKED", time.Now().AddDate(1, 0, 0))) // domain.CARD_STATUS_BLOCKED
+	holdRepo := newHoldRepoFake(dbFake)
+
+	tRequest := port.TransactionPaymentRequest{
+		CardToken:      cardTokenToTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	response, err := suite.newAuthorizationService(&dbFake, holdRepo).Authorize(tRequest)
+
+	//Assert
+	codeRejected := "57" // domain.CODE_REJECTED_CARD_NOT_ALLOWED
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Holds), 0)
+}
+
+func (suite *AuthorizationSuite) TestAuthorizeWithUnknownCardTokenRejectedWithoutOutcome() {
+	//Arrange
+	dbFake := newDBfake()
+	holdRepo := newHoldRepoFake(dbFake)
+
+	tRequest := port.TransactionPaymentRequest{
+		CardToken:      cardTokenToTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	response, err := suite.newAuthorizationService(&dbFake, holdRepo).Authorize(tRequest)
+
+	//Assert
+	codeRejected := "14" // domain.CODE_REJECTED_INVALID_ACCOUNT
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Holds), 0)
+
+	_, saved := dbFake.Outcomes[tRequest.TransactionUID]
+	assert.Equal(suite.T(), saved, false)
+
+	declined := dbFake.DeclinedEvents[tRequest.TransactionUID]
+	assert.Equal(suite.T(), declined.Code, codeRejected)
+	assert.Equal(suite.T(), declined.AccountID, uint(0))
+	assert.Equal(suite.T(), declined.AccountUID, uuid.Nil)
+}
+
+func (suite *AuthorizationSuite) TestAuthorizeFraudRuleDeclined() {
+	//Arrange
+	dbFake := newDBfake()
+	dbFake.FraudRules = []port.FraudRuleEntity{
+		{Name: "blocked-mccs", Kind: "MCC_BLOCKLIST", Decision: "DECLINE", MCCs: []string{correctFoodMCC}},
+	}
+	holdRepo := newHoldRepoFake(dbFake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	response, err := suite.newAuthorizationService(&dbFake, holdRepo).Authorize(tRequest)
+
+	//Assert
+	codeRejected := "59" // domain.CODE_REJECTED_SUSPECTED_FRAUD
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Holds), 0)
+}
+
+func (suite *AuthorizationSuite) TestCaptureApproved() {
+	//Arrange
+	dbFake := newDBfake()
+	holdRepo := newHoldRepoFake(dbFake)
+	transactionUID := uuid.New()
+
+	amountHeld := decimal.NewFromFloat(100.10)
+	suite.heldFoodAccount(&dbFake, amountHeld)
+
+	dbFake.Holds[transactionUID] = map[int]port.HoldEntity{
+		1: {
+			UID:        transactionUID,
+			AccountID:  1,
+			AccountUID: accountUIDtoTransact,
+			CategoryID: foodCategoryID,
+			Amount:     amountHeld,
+			MCC:        correctFoodMCC,
+			Status:     port.HOLD_STATUS_AUTHORIZED,
+			ExpiresAt:  time.Now().Add(time.Minute),
+		},
+	}
+
+	//Act
+	returnCode, err := suite.newAuthorizationService(&dbFake, holdRepo).Capture(
+		port.TransactionHoldRequest{AccountUID: accountUIDtoTransact, TransactionUID: transactionUID},
+	)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), returnCode, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+
+	foodTransaction, err := getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: foodCategoryID})
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), foodTransaction.BalanceAfter.String(), balanceFoodAmount.Sub(amountHeld).String())
+	assert.Equal(suite.T(), foodTransaction.Amount.String(), amountHeld.String())
+	assert.Equal(suite.T(), foodTransaction.Operation, "AUTHORIZATION") // domain.TRANSACTION_OPERATION_AUTHORIZATION
+	assert.Equal(suite.T(), dbFake.Holds[transactionUID][1].Status, port.HOLD_STATUS_CAPTURED)
+}
+
+func (suite *AuthorizationSuite) TestCaptureExpiredRejected() {
+	//Arrange
+	dbFake := newDBfake()
+	holdRepo := newHoldRepoFake(dbFake)
+	transactionUID := uuid.New()
+
+	amountHeld := decimal.NewFromFloat(100.10)
+	suite.heldFoodAccount(&dbFake, amountHeld)
+
+	dbFake.Holds[transactionUID] = map[int]port.HoldEntity{
+		1: {
+			UID:        transactionUID,
+			AccountID:  1,
+			AccountUID: accountUIDtoTransact,
+			CategoryID: foodCategoryID,
+			Amount:     amountHeld,
+			Status:     port.HOLD_STATUS_AUTHORIZED,
+			ExpiresAt:  time.Now().Add(-time.Minute),
+		},
+	}
+
+	//Act
+	returnCode, err := suite.newAuthorizationService(&dbFake, holdRepo).Capture(
+		port.TransactionHoldRequest{AccountUID: accountUIDtoTransact, TransactionUID: transactionUID},
+	)
+
+	//Assert
+	codeRejected := "12" // domain.CODE_REJECTED_INVALID_TRANSACTION
+	assert.Equal(suite.T(), returnCode, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *AuthorizationSuite) TestCaptureNotFoundRejected() {
+	//Arrange
+	dbFake := newDBfake()
+	holdRepo := newHoldRepoFake(dbFake)
+
+	//Act
+	returnCode, err := suite.newAuthorizationService(&dbFake, holdRepo).Capture(
+		port.TransactionHoldRequest{AccountUID: accountUIDtoTransact, TransactionUID: uuid.New()},
+	)
+
+	//Assert
+	codeRejected := "25" // domain.CODE_REJECTED_RECORD_NOT_FOUND
+	assert.Equal(suite.T(), returnCode, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *AuthorizationSuite) TestCaptureHeldUsageCountedOnceApproved() {
+	//Arrange
+	dbFake := newDBfake()
+	holdRepo := newHoldRepoFake(dbFake)
+	transactionUID := uuid.New()
+
+	amountHeld := decimal.NewFromFloat(100.10)
+	suite.heldFoodAccount(&dbFake, amountHeld)
+
+	dbFake.Holds[transactionUID] = map[int]port.HoldEntity{
+		1: {
+			UID:        transactionUID,
+			AccountID:  1,
+			AccountUID: accountUIDtoTransact,
+			CategoryID: foodCategoryID,
+			Amount:     amountHeld,
+			MCC:        correctFoodMCC,
+			Status:     port.HOLD_STATUS_AUTHORIZED,
+			ExpiresAt:  time.Now().Add(time.Minute),
+		},
+	}
+
+	dbFake.SpendingLimits = []port.SpendingLimitEntity{
+		{AccountID: 1, DailyAmount: decimal.NewFromFloat(150)},
+	}
+	dbFake.SpendingUsages[0] = port.SpendingUsageEntity{
+		DailyAmount:        amountHeld,
+		MonthlyAmount:      amountHeld,
+		HourlyTransactions: 1,
+	}
+
+	//Act
+	returnCode, err := suite.newAuthorizationService(&dbFake, holdRepo).Capture(
+		port.TransactionHoldRequest{AccountUID: accountUIDtoTransact, TransactionUID: transactionUID},
+	)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), returnCode, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), dbFake.Holds[transactionUID][1].Status, port.HOLD_STATUS_CAPTURED)
+	assert.Equal(suite.T(), dbFake.SpendingUsages[0].DailyAmount.String(), amountHeld.String())
+}
+
+func (suite *AuthorizationSuite) TestCaptureSpendingLimitLoweredRejected() {
+	//Arrange
+	dbFake := newDBfake()
+	holdRepo := newHoldRepoFake(dbFake)
+	transactionUID := uuid.New()
+
+	amountHeld := decimal.NewFromFloat(100.10)
+	suite.heldFoodAccount(&dbFake, amountHeld)
+
+	dbFake.Holds[transactionUID] = map[int]port.HoldEntity{
+		1: {
+			UID:        transactionUID,
+			AccountID:  1,
+			AccountUID: accountUIDtoTransact,
+			CategoryID: foodCategoryID,
+			Amount:     amountHeld,
+			MCC:        correctFoodMCC,
+			Status:     port.HOLD_STATUS_AUTHORIZED,
+			ExpiresAt:  time.Now().Add(time.Minute),
+		},
+	}
+
+	dbFake.SpendingLimits = []port.SpendingLimitEntity{
+		{AccountID: 1, DailyAmount: decimal.NewFromFloat(50)},
+	}
+	dbFake.SpendingUsages[0] = port.SpendingUsageEntity{
+		DailyAmount:        amountHeld,
+		MonthlyAmount:      amountHeld,
+		HourlyTransactions: 1,
+	}
+
+	//Act
+	returnCode, err := suite.newAuthorizationService(&dbFake, holdRepo).Capture(
+		port.TransactionHoldRequest{AccountUID: accountUIDtoTransact, TransactionUID: transactionUID},
+	)
+
+	//Assert
+	codeRejected := "61" // domain.CODE_REJECTED_LIMIT_EXCEEDED
+	assert.Equal(suite.T(), returnCode, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), dbFake.Holds[transactionUID][1].Status, port.HOLD_STATUS_AUTHORIZED)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *AuthorizationSuite) TestVoidApproved() {
+	//Arrange
+	dbFake := newDBfake()
+	holdRepo := newHoldRepoFake(dbFake)
+	transactionUID := uuid.New()
+
+	dbFake.Holds[transactionUID] = map[int]port.HoldEntity{
+		1: {
+			UID:        transactionUID,
+			AccountID:  1,
+			AccountUID: accountUIDtoTransact,
+			CategoryID: foodCategoryID,
+			Amount:     decimal.NewFromFloat(100.10),
+			Status:     port.HOLD_STATUS_AUTHORIZED,
+			ExpiresAt:  time.Now().Add(time.Minute),
+		},
+	}
+
+	//Act
+	returnCode, err := suite.newAuthorizationService(&dbFake, holdRepo).Void(
+		port.TransactionHoldRequest{AccountUID: accountUIDtoTransact, TransactionUID: transactionUID},
+	)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), returnCode, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), dbFake.Holds[transactionUID][1].Status, port.HOLD_STATUS_VOIDED)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *AuthorizationSuite) TestVoidNotFoundRejected() {
+	//Arrange
+	dbFake := newDBfake()
+	holdRepo := newHoldRepoFake(dbFake)
+
+	//Act
+	returnCode, err := suite.newAuthorizationService(&dbFake, holdRepo).Void(
+		port.TransactionHoldRequest{AccountUID: accountUIDtoTransact, TransactionUID: uuid.New()},
+	)
+
+	//Assert
+	codeRejected := "25" // domain.CODE_REJECTED_RECORD_NOT_FOUND
+	assert.Equal(suite.T(), returnCode, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+}
+
+/*
+  - Reflects in the fake account balance the amount reserved by a hold,
+    as the account repository reports the available balance
+*/
+func (suite *AuthorizationSuite) heldFoodAccount(dbFake *DBfake, amountHeld decimal.Decimal) {
+	account := dbFake.Accounts[1]
+	food := account.Balance.Categories[1]
+	food.Amount = food.Amount.Sub(amountHeld)
+	food.AmountHeld = amountHeld
+	account.Balance.Categories[1] = food
+	dbFake.Accounts[1] = account
+}
+
+func TestAuthorizationSuite(t *testing.T) {
+	suite.Run(t, new(AuthorizationSuite))
+}
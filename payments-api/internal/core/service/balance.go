@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/support/logger"
+)
+
+type Balance struct {
+	timeoutSLA        port.TimeoutSLA
+	balanceRepository port.BalanceRepository
+
+	log logger.Logger
+}
+
+func NewBalance(
+	timeoutSLA port.TimeoutSLA,
+
+	bRepository port.BalanceRepository,
+
+	log logger.Logger,
+) *Balance {
+	return &Balance{
+		timeoutSLA:        timeoutSLA,
+		balanceRepository: bRepository,
+
+		log: log,
+	}
+}
+
+func (b *Balance) Get(br port.BalanceRequest) (port.BalanceResponse, error) {
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		time.Duration(b.timeoutSLA),
+	)
+	ctx = context.WithValue(ctx, logger.CtxAccountUIDKey, br.AccountUID.String())
+	defer cancel()
+
+	accountEntity, err := b.balanceRepository.FindByAccountUID(ctx, br.AccountUID)
+	if err != nil {
+		b.log.Error(ctx, err.Error())
+		return port.BalanceResponse{}, fmt.Errorf("failed to retrieve account balance: %w", err)
+	}
+
+	if accountEntity.ID == 0 {
+		b.log.Warn(ctx, fmt.Sprintf("account %s not found", br.AccountUID.String()))
+		return port.BalanceResponse{}, fmt.Errorf("%w: %s", port.ErrAccountNotFound, br.AccountUID.String())
+	}
+
+	return mapAccountEntityToBalanceResponse(accountEntity, br.IncludeHolds), nil
+}
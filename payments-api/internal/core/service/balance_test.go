@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+type BalanceRepoFake struct {
+	db DBfake
+}
+
+func newBalanceRepoFake(db DBfake) port.BalanceRepository {
+	return &BalanceRepoFake{
+		db,
+	}
+}
+
+func (brf *BalanceRepoFake) FindByAccountUID(_ context.Context, uid uuid.UUID) (port.AccountEntity, error) {
+	accountEntity, err := brf.db.AccountRepoFindByUID(context.Background(), uid)
+	if err != nil {
+		return port.AccountEntity{}, nil
+	}
+
+	return accountEntity, nil
+}
+
+type BalanceSuite struct {
+	suite.Suite
+}
+
+func (suite *BalanceSuite) newBalanceService(dbFake *DBfake) *Balance {
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	return NewBalance(
+		timeoutSLA,
+		newBalanceRepoFake(*dbFake),
+		newFakeLog(),
+	)
+}
+
+func (suite *BalanceSuite) TestGetByPrioritySuccess() {
+	//Arrange
+	dbFake := newDBfake()
+
+	//Act
+	balance, err := suite.newBalanceService(&dbFake).Get(
+		port.BalanceRequest{AccountUID: accountUIDtoTransact},
+	)
+
+	//Assert
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), balance.AccountUID, accountUIDtoTransact.String())
+	assert.Equal(suite.T(), balance.AmountTotal.String(), decimal.NewFromFloat(930.66).String())
+	assert.Equal(suite.T(), balance.AmountHeld, (*decimal.Decimal)(nil))
+	assert.Equal(suite.T(), len(balance.Categories), 3)
+
+	assert.Equal(suite.T(), balance.Categories[0].Name, "FOOD")
+	assert.Equal(suite.T(), balance.Categories[0].MCCs, []string{"5411", "5412"})
+	assert.Equal(suite.T(), balance.Categories[0].Amount.String(), balanceFoodAmount.String())
+	assert.Equal(suite.T(), balance.Categories[0].AmountHeld, (*decimal.Decimal)(nil))
+	assert.Equal(suite.T(), balance.Categories[1].Name, "MEAL")
+	assert.Equal(suite.T(), balance.Categories[2].Name, "CASH")
+}
+
+func (suite *BalanceSuite) TestGetIncludeHoldsSuccess() {
+	//Arrange
+	dbFake := newDBfake()
+
+	amountHeld := decimal.NewFromFloat(10.00)
+	food := dbFake.Accounts[1].Balance.Categories[1]
+	food.AmountHeld = amountHeld
+	dbFake.Accounts[1].Balance.Categories[1] = food
+
+	//Act
+	balance, err := suite.newBalanceService(&dbFake).Get(
+		port.BalanceRequest{AccountUID: accountUIDtoTransact, IncludeHolds: true},
+	)
+
+	//Assert
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), balance.AmountHeld.String(), amountHeld.String())
+	assert.Equal(suite.T(), balance.Categories[0].AmountHeld.String(), amountHeld.String())
+	assert.Equal(suite.T(), balance.Categories[1].AmountHeld.String(), "0")
+}
+
+func (suite *BalanceSuite) TestGetAccountNotFound() {
+	//Arrange
+	dbFake := newDBfake()
+
+	//Act
+	_, err := suite.newBalanceService(&dbFake).Get(
+		port.BalanceRequest{AccountUID: uuid.New()},
+	)
+
+	//Assert
+	assert.Equal(suite.T(), errors.Is(err, port.ErrAccountNotFound), true)
+}
+
+func TestBalanceSuite(t *testing.T) {
+	suite.Run(t, new(BalanceSuite))
+}
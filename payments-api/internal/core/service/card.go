@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+/*
+  - Card of a payment sent with a card token, which identifies the account of the
+    payment. A payment naming both must name the account of the card
+  - A payment without a card token has no card
+*/
+func findPaymentCard(
+	ctx context.Context,
+	cRepository port.CardRepository,
+	tpr *port.TransactionPaymentRequest,
+) (*port.CardEntity, error) {
+	if tpr.CardToken == "" {
+		return nil, nil
+	}
+
+	cardEntity, err := cRepository.FindByToken(ctx, tpr.CardToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve card: %w", err)
+	}
+
+	if tpr.AccountUID != uuid.Nil && tpr.AccountUID != cardEntity.AccountUID {
+		return nil, fmt.Errorf(
+			"%w: card %s does not belong to account %s",
+			port.ErrCardNotFound,
+			cardEntity.UID.String(),
+			tpr.AccountUID.String(),
+		)
+	}
+
+	tpr.AccountUID = cardEntity.AccountUID
+
+	return &cardEntity, nil
+}
+
+/*
+- Provides the account with the card of the payment and its usage of the day at the given time
+*/
+func loadCard(
+	ctx context.Context,
+	cRepository port.CardRepository,
+	cardEntity port.CardEntity,
+	account *domain.Account,
+	at time.Time,
+) error {
+	dailyUsage, err := cRepository.FindDailyUsage(ctx, cardEntity.ID, at)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve usage of card %s: %w", cardEntity.UID.String(), err)
+	}
+
+	card := mapCardEntityToDomain(cardEntity, dailyUsage)
+	account.Card = &card
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+/*
+  - Provides the account with the default category rules and its overrides,
+    without rules the account falls back to its default fallback category
+*/
+func loadCategoryRules(
+	ctx context.Context,
+	crRepository port.CategoryRuleRepository,
+	account *domain.Account,
+) error {
+	crEntities, err := crRepository.FindByAccountID(ctx, account.ID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve category rules: %w", err)
+	}
+
+	account.CategoryRules = mapCategoryRuleEntitiesToDomain(crEntities)
+
+	return nil
+}
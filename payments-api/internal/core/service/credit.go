@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/support/logger"
+)
+
+/*
+  - Unlike the other use cases, the lock taken is kept on the call stack and not in the
+    struct, since the same instance processes the credits of a batch concurrently
+*/
+type Credit struct {
+	timeoutSLA                   port.TimeoutSLA
+	batchWorkers                 port.CreditBatchWorkers
+	accountRepository            port.AccountRepository
+	transactionOutcomeRepository port.TransactionOutcomeRepository
+	memoryLockRepository         port.MemoryLockRepository
+
+	log logger.Logger
+}
+
+func NewCredit(
+	timeoutSLA port.TimeoutSLA,
+	batchWorkers port.CreditBatchWorkers,
+
+	aRepository port.AccountRepository,
+	toRepository port.TransactionOutcomeRepository,
+	mlRepository port.MemoryLockRepository,
+
+	log logger.Logger,
+) *Credit {
+	return &Credit{
+		timeoutSLA:                   timeoutSLA,
+		batchWorkers:                 batchWorkers,
+		accountRepository:            aRepository,
+		transactionOutcomeRepository: toRepository,
+		memoryLockRepository:         mlRepository,
+
+		log: log,
+	}
+}
+
+func (c *Credit) Execute(tcr port.TransactionCreditRequest) (string, error) {
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		time.Duration(c.timeoutSLA),
+	)
+	ctx = context.WithValue(ctx, logger.CtxTransactionUIDKey, tcr.TransactionUID.String())
+	ctx = context.WithValue(ctx, logger.CtxAccountUIDKey, tcr.AccountUID.String())
+	defer cancel()
+
+	outcome := mapCreditRequestToOutcomeEntity(tcr)
+
+	transactionLocked, err := c.memoryLockRepository.Lock(
+		ctx,
+		mapCreditRequestToMemoryLockEntity(tcr),
+	)
+	if err != nil {
+		return c.rejectedEventErr(
+			ctx,
+			transactionLocked,
+			outcome,
+			fmt.Errorf("failed concurrent transaction locked: %w", err),
+		)
+	}
+
+	outcomeEntity, err := c.transactionOutcomeRepository.FindByUID(ctx, tcr.TransactionUID)
+	if err != nil {
+		return c.rejectedEventErr(
+			ctx,
+			transactionLocked,
+			outcome,
+			fmt.Errorf("failed to retrieve transaction outcome: %w", err),
+		)
+	}
+
+	if outcomeEntity != nil {
+		return c.replayedOutcome(ctx, transactionLocked, tcr.AccountUID, *outcomeEntity)
+	}
+
+	accountEntity, err := c.accountRepository.FindByUID(ctx, tcr.AccountUID)
+	if err != nil {
+		return c.rejectedEventErr(
+			ctx,
+			transactionLocked,
+			outcome,
+			fmt.Errorf("failed to retrieve account entity: %w", err),
+		)
+	}
+
+	if accountEntity.ID == 0 {
+		return c.rejectedEventErr(
+			ctx,
+			transactionLocked,
+			outcome,
+			fmt.Errorf("%w: %s", port.ErrAccountNotFound, tcr.AccountUID.String()),
+		)
+	}
+
+	account := mapAccountEntityToDomain(accountEntity, c.log)
+	outcome.AccountID = account.ID
+	transaction := mapCreditRequestToTransactionDomain(tcr, account)
+
+	cErr := account.CheckCreditAllowed()
+	if cErr != nil {
+		c.saveOutcome(ctx, mapTransactionOutcomeToEntity(transaction, account, cErr.Code))
+		return c.rejectedCustomErr(ctx, transactionLocked, cErr)
+	}
+
+	approvedTransactions, cErr := account.ApproveCredit(ctx, transaction, tcr.Category)
+	if cErr != nil {
+		c.saveOutcome(ctx, mapTransactionOutcomeToEntity(transaction, account, cErr.Code))
+		return c.rejectedCustomErr(ctx, transactionLocked, cErr)
+	}
+
+	err = c.accountRepository.SaveTransactions(
+		ctx,
+		mapTransactionDomainsToEntities(approvedTransactions),
+		transactionLocked.FencingToken,
+	)
+	if err != nil {
+		return c.rejectedEventErr(
+			ctx,
+			transactionLocked,
+			outcome,
+			fmt.Errorf("failed to save credit transaction entity: %w", err),
+		)
+	}
+
+	c.saveOutcome(ctx, mapTransactionOutcomeToEntity(transaction, account, domain.CODE_APPROVED))
+
+	_ = c.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return domain.CODE_APPROVED, nil
+}
+
+/*
+  - Credits are spread over the workers by account, so the credits of the same
+    account run one after the other in the file order instead of waiting on its lock
+*/
+func (c *Credit) ExecuteBatch(tcrs []port.TransactionCreditRequest) (port.TransactionCreditBatchResponse, error) {
+	if len(tcrs) > port.CREDIT_BATCH_MAX_SIZE {
+		return port.TransactionCreditBatchResponse{}, fmt.Errorf(
+			"%w: %d credits, at most %d",
+			port.ErrCreditBatchTooLarge,
+			len(tcrs),
+			port.CREDIT_BATCH_MAX_SIZE,
+		)
+	}
+
+	workers := int(c.batchWorkers)
+	if workers < 1 {
+		workers = 1
+	}
+
+	codes := make([]string, len(tcrs))
+	partitions := make([]chan int, workers)
+
+	var wg sync.WaitGroup
+	for w := range partitions {
+		partitions[w] = make(chan int, workers)
+
+		wg.Add(1)
+		go func(partition chan int) {
+			defer wg.Done()
+
+			for index := range partition {
+				codes[index], _ = c.Execute(tcrs[index])
+			}
+		}(partitions[w])
+	}
+
+	for index, tcr := range tcrs {
+		partitions[accountPartition(tcr.AccountUID, workers)] <- index
+	}
+
+	for _, partition := range partitions {
+		close(partition)
+	}
+	wg.Wait()
+
+	batchResponse := port.TransactionCreditBatchResponse{
+		Rejections: []port.TransactionCreditRejectionResponse{},
+	}
+
+	for index, code := range codes {
+		if code == domain.CODE_APPROVED {
+			batchResponse.Approved++
+			continue
+		}
+
+		batchResponse.Rejected++
+		batchResponse.Rejections = append(batchResponse.Rejections, port.TransactionCreditRejectionResponse{
+			Index:          index,
+			AccountUID:     tcrs[index].AccountUID.String(),
+			TransactionUID: tcrs[index].TransactionUID.String(),
+			Code:           code,
+			Reason:         domain.ResponseReason(code),
+		})
+	}
+
+	c.log.Info(
+		context.Background(),
+		fmt.Sprintf("credit batch processed: %d approved, %d rejected", batchResponse.Approved, batchResponse.Rejected),
+	)
+
+	return batchResponse, nil
+}
+
+func accountPartition(accountUID uuid.UUID, partitions int) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write(accountUID[:])
+
+	return int(hash.Sum32() % uint32(partitions))
+}
+
+func (c *Credit) saveOutcome(ctx context.Context, outcome port.TransactionOutcomeEntity) {
+	err := c.transactionOutcomeRepository.Save(ctx, outcome)
+	if err != nil {
+		c.log.Error(ctx, fmt.Sprintf("failed to save transaction outcome: %s", err.Error()))
+	}
+}
+
+func (c *Credit) saveDeclinedEvent(ctx context.Context, outcome port.TransactionOutcomeEntity) {
+	err := c.transactionOutcomeRepository.SaveDeclinedEvent(ctx, outcome)
+	if err != nil {
+		c.log.Error(ctx, fmt.Sprintf("failed to save declined transaction event: %s", err.Error()))
+	}
+}
+
+func (c *Credit) replayedOutcome(
+	ctx context.Context,
+	transactionLocked port.MemoryLockEntity,
+	accountUID uuid.UUID,
+	outcome port.TransactionOutcomeEntity,
+) (string, error) {
+	code, err := replayTransactionOutcome(accountUID, outcome)
+	if err != nil {
+		c.log.Warn(ctx, err.Error())
+	} else {
+		c.log.Info(ctx, fmt.Sprintf("transaction already processed, replaying code %s", code))
+	}
+
+	_ = c.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return code, err
+}
+
+/*
+  - Reports the rejection by err with its declined event only, without an outcome:
+    a timeout or a failure is not a decision, so a resent credit runs again
+*/
+func (c *Credit) rejectedEventErr(
+	ctx context.Context,
+	transactionLocked port.MemoryLockEntity,
+	outcome port.TransactionOutcomeEntity,
+	err error,
+) (string, error) {
+	c.log.Error(ctx, err.Error())
+
+	outcome.Code = rejectionCode(err)
+	if outcome.Code != domain.CODE_REJECTED_DUPLICATE_TRANSACTION {
+		c.saveDeclinedEvent(context.WithoutCancel(ctx), outcome)
+	}
+
+	_ = c.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return outcome.Code, err
+}
+
+func (c *Credit) rejectedCustomErr(ctx context.Context, transactionLocked port.MemoryLockEntity, cErr *domain.CustomError) (string, error) {
+	if cErr.Code == domain.CODE_REJECTED_GENERIC {
+		c.log.Error(ctx, cErr.Error())
+	} else {
+		c.log.Warn(ctx, cErr.Error())
+	}
+
+	_ = c.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return cErr.Code, fmt.Errorf("failed to approve credit: %s", cErr.Message)
+}
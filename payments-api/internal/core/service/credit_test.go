@@ -0,0 +1,272 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+var (
+	amountCredit = decimal.NewFromFloat(500.00)
+)
+
+type CreditSuite struct {
+	suite.Suite
+}
+
+/*
+- A single worker keeps the batch sequential, the repository fakes are not safe for concurrent use
+*/
+func (suite *CreditSuite) newCreditService(dbFake *DBfake) *Credit {
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	return NewCredit(
+		timeoutSLA,
+		port.CreditBatchWorkers(1),
+		newAccountRepoFake(*dbFake),
+		newTransactionOutcomeRepoFake(*dbFake),
+		newMemoryLockRepoFake(newInMemoryDBfake()),
+		newFakeLog(),
+	)
+}
+
+func (suite *CreditSuite) TestCreditExecuteApproved() {
+	//Arrange
+	dbFake := newDBfake()
+
+	tRequest := port.TransactionCreditRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		Category:       "meal",
+		TotalAmount:    amountCredit,
+	}
+
+	//Act
+	returnCode, err := suite.newCreditService(&dbFake).Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), returnCode, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 1)
+
+	mealTransaction, err := getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: mealCategoryID})
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), mealTransaction.BalanceAfter.String(), balanceFoodAmount.Add(amountCredit).String())
+	assert.Equal(suite.T(), mealTransaction.Amount.String(), amountCredit.String())
+	assert.Equal(suite.T(), mealTransaction.Operation, "CREDIT") // domain.TRANSACTION_OPERATION_CREDIT
+	assert.Equal(suite.T(), dbFake.Outcomes[tRequest.TransactionUID].Code, codeApproved)
+}
+
+func (suite *CreditSuite) TestCreditExecuteCategoryNotAttachedRejected() {
+	//Arrange
+	dbFake := newDBfake()
+
+	tRequest := port.TransactionCreditRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		Category:       "MOBILITY",
+		TotalAmount:    amountCredit,
+	}
+
+	//Act
+	returnCode, err := suite.newCreditService(&dbFake).Execute(tRequest)
+
+	//Assert
+	codeRejected := "12" // domain.CODE_REJECTED_INVALID_TRANSACTION
+	assert.Equal(suite.T(), returnCode, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *CreditSuite) TestCreditExecuteUnknownAccountRejectedWithoutOutcome() {
+	//Arrange
+	dbFake := newDBfake()
+
+	unknownAccountUID := uuid.New()
+	tRequest := port.TransactionCreditRequest{
+		AccountUID:     unknownAccountUID,
+		TransactionUID: uuid.New(),
+		Category:       "MEAL",
+		TotalAmount:    amountCredit,
+	}
+
+	//Act
+	returnCode, err := suite.newCreditService(&dbFake).Execute(tRequest)
+
+	//Assert
+	codeRejected := "14" // domain.CODE_REJECTED_INVALID_ACCOUNT
+	assert.Equal(suite.T(), returnCode, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+
+	_, saved := dbFake.Outcomes[tRequest.TransactionUID]
+	assert.Equal(suite.T(), saved, false)
+
+	declined := dbFake.DeclinedEvents[tRequest.TransactionUID]
+	assert.Equal(suite.T(), declined.Code, codeRejected)
+	assert.Equal(suite.T(), declined.AccountID, uint(0))
+	assert.Equal(suite.T(), declined.AccountUID, unknownAccountUID)
+}
+
+func (suite *CreditSuite) TestCreditExecuteNotPositiveAmountRejected() {
+	//Arrange
+	dbFake := newDBfake()
+
+	tRequest := port.TransactionCreditRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		Category:       "FOOD",
+		TotalAmount:    decimal.NewFromFloat(-10.00),
+	}
+
+	//Act
+	returnCode, err := suite.newCreditService(&dbFake).Execute(tRequest)
+
+	//Assert
+	codeRejected := "13" // domain.CODE_REJECTED_INVALID_AMOUNT
+	assert.Equal(suite.T(), returnCode, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *CreditSuite) TestCreditExecuteBlockedAccountApproved() {
+	//Arrange
+	dbFake := newDBfake()
+
+	account := dbFake.Accounts[1]
+	account.Status = "BLOCKED" // domain.ACCOUNT_STATUS_BLOCKED
+	dbFake.Accounts[1] = account
+
+	tRequest := port.TransactionCreditRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		Category:       "MEAL",
+		TotalAmount:    amountCredit,
+	}
+
+	//Act
+	returnCode, err := suite.newCreditService(&dbFake).Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), returnCode, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 1)
+}
+
+func (suite *CreditSuite) TestCreditExecuteCancelledAccountRejected() {
+	//Arrange
+	dbFake := newDBfake()
+
+	account := dbFake.Accounts[1]
+	account.Status = "CANCELLED" // domain.ACCOUNT_STATUS_CANCELLED
+	dbFake.Accounts[1] = account
+
+	tRequest := port.TransactionCreditRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		Category:       "MEAL",
+		TotalAmount:    amountCredit,
+	}
+
+	//Act
+	returnCode, err := suite.newCreditService(&dbFake).Execute(tRequest)
+
+	//Assert
+	codeRejected := "46" // domain.CODE_REJECTED_ACCOUNT_CANCELLED
+	assert.Equal(suite.T(), returnCode, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+	assert.Equal(suite.T(), dbFake.Outcomes[tRequest.TransactionUID].Code, codeRejected)
+}
+
+func (suite *CreditSuite) TestCreditExecuteReplayedNotCreditedAgain() {
+	//Arrange
+	dbFake := newDBfake()
+	creditService := suite.newCreditService(&dbFake)
+
+	tRequest := port.TransactionCreditRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		Category:       "FOOD",
+		TotalAmount:    amountCredit,
+	}
+
+	_, _ = creditService.Execute(tRequest)
+	delete(dbFake.Transactions, 1)
+
+	//Act
+	returnCode, err := creditService.Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), returnCode, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *CreditSuite) TestCreditExecuteBatchReportsRejections() {
+	//Arrange
+	dbFake := newDBfake()
+
+	tRequests := []port.TransactionCreditRequest{
+		{AccountUID: accountUIDtoTransact, TransactionUID: uuid.New(), Category: "FOOD", TotalAmount: amountCredit},
+		{AccountUID: uuid.New(), TransactionUID: uuid.New(), Category: "FOOD", TotalAmount: amountCredit},
+		{AccountUID: accountUIDtoTransact, TransactionUID: uuid.New(), Category: "CASH", TotalAmount: amountCredit},
+		{AccountUID: accountUIDtoTransact, TransactionUID: uuid.New(), Category: "MOBILITY", TotalAmount: amountCredit},
+	}
+
+	//Act
+	batchResponse, err := suite.newCreditService(&dbFake).ExecuteBatch(tRequests)
+
+	//Assert
+	codeRejected := "14" // domain.CODE_REJECTED_INVALID_ACCOUNT
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), batchResponse.Approved, 2)
+	assert.Equal(suite.T(), batchResponse.Rejected, 2)
+	assert.Equal(suite.T(), batchResponse.Rejections[0].Index, 1)
+	assert.Equal(suite.T(), batchResponse.Rejections[0].AccountUID, tRequests[1].AccountUID.String())
+	assert.Equal(suite.T(), batchResponse.Rejections[0].Code, codeRejected)
+	assert.Equal(suite.T(), batchResponse.Rejections[0].Reason, "INVALID_ACCOUNT")
+	assert.Equal(suite.T(), batchResponse.Rejections[1].Index, 3)
+}
+
+func (suite *CreditSuite) TestCreditExecuteBatchTooLarge() {
+	//Arrange
+	dbFake := newDBfake()
+
+	tRequests := make([]port.TransactionCreditRequest, port.CREDIT_BATCH_MAX_SIZE+1)
+
+	//Act
+	_, err := suite.newCreditService(&dbFake).ExecuteBatch(tRequests)
+
+	//Assert
+	assert.Equal(suite.T(), errors.Is(err, port.ErrCreditBatchTooLarge), true)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *CreditSuite) TestAccountPartitionIsStable() {
+	//Arrange
+	accountUID := uuid.New()
+
+	//Act
+	partition := accountPartition(accountUID, 16)
+
+	//Assert
+	assert.Equal(suite.T(), partition, accountPartition(accountUID, 16))
+	assert.Equal(suite.T(), partition >= 0 && partition < 16, true)
+}
+
+func TestCreditSuite(t *testing.T) {
+	suite.Run(t, new(CreditSuite))
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+/*
+  - Currency of a payment request, the default currency when not sent, whose
+    positive amount must fit the minor units of the currency
+*/
+func paymentCurrency(tpr port.TransactionPaymentRequest) (string, error) {
+	currency, err := domain.NormalizeCurrency(tpr.Currency)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", port.ErrInvalidCurrency, err.Error())
+	}
+
+	if !tpr.TotalAmount.IsPositive() {
+		return "", fmt.Errorf("%w: %s is not positive", port.ErrInvalidAmount, tpr.TotalAmount.String())
+	}
+
+	if !domain.AmountFitsCurrency(tpr.TotalAmount, currency) {
+		return "", fmt.Errorf(
+			"%w: %s exceeds the %d minor units of currency %s",
+			port.ErrInvalidAmount,
+			tpr.TotalAmount.String(),
+			domain.CurrencyMinorUnits(currency),
+			currency,
+		)
+	}
+
+	return currency, nil
+}
+
+/*
+  - Provides the account with the rates from the transaction currency to the
+    currencies of its categories, when it allows converting them. A missing rate
+    is left to the account, which rejects the transaction if it needs it
+*/
+func loadExchangeRates(
+	ctx context.Context,
+	erRepository port.ExchangeRateRepository,
+	account *domain.Account,
+	currency string,
+) error {
+	account.ExchangeRates = make(domain.ExchangeRates)
+
+	for _, toCurrency := range account.CurrenciesToConvert(currency) {
+		rateEntity, err := erRepository.FindRate(ctx, currency, toCurrency)
+		if errors.Is(err, port.ErrExchangeRateNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to retrieve exchange rate from %s to %s: %w", currency, toCurrency, err)
+		}
+
+		account.ExchangeRates[toCurrency] = rateEntity.Rate
+	}
+
+	return nil
+}
+
+func currencyOrDefault(currency string) string {
+	if currency == "" {
+		return domain.DEFAULT_CURRENCY
+	}
+
+	return currency
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/support/logger"
+)
+
+/*
+  - Built-in RiskStage, assessing the payment with the enabled rules of the
+    database against the recent payments of the account
+  - The rules are reloaded once older than the reload interval, so a change in
+    the database applies without a restart. A failed reload keeps the rules
+    already loaded
+*/
+type FraudRules struct {
+	reloadInterval         port.FraudRulesReloadInterval
+	fraudRuleRepository    port.FraudRuleRepository
+	fraudHistoryRepository port.FraudHistoryRepository
+
+	log logger.Logger
+
+	mu       sync.RWMutex
+	rules    domain.FraudRules
+	loadedAt time.Time
+}
+
+func NewFraudRules(
+	reloadInterval port.FraudRulesReloadInterval,
+
+	frRepository port.FraudRuleRepository,
+	fhRepository port.FraudHistoryRepository,
+
+	log logger.Logger,
+) *FraudRules {
+	return &FraudRules{
+		reloadInterval:         reloadInterval,
+		fraudRuleRepository:    frRepository,
+		fraudHistoryRepository: fhRepository,
+
+		log: log,
+	}
+}
+
+func (fr *FraudRules) Assess(ctx context.Context, rp port.RiskPaymentEntity) (port.RiskDecisionEntity, error) {
+	rules, err := fr.currentRules(ctx)
+	if err != nil {
+		return port.RiskDecisionEntity{}, err
+	}
+
+	decision := port.RiskDecisionEntity{
+		Decision: domain.RISK_DECISION_APPROVE,
+		Reasons:  []string{},
+	}
+
+	if len(rules) == 0 {
+		return decision, nil
+	}
+
+	fpEntities, err := fr.fraudHistoryRepository.FindPayments(ctx, rp.AccountID, rp.At.Add(-rules.Lookback()))
+	if err != nil {
+		return decision, fmt.Errorf("failed to retrieve fraud history: %w", err)
+	}
+
+	merchantSeen, err := fr.fraudHistoryRepository.MerchantSeen(ctx, rp.AccountID, rp.MerchantName)
+	if err != nil {
+		return decision, fmt.Errorf("failed to retrieve fraud history of merchant %s: %w", rp.MerchantName, err)
+	}
+
+	assessment := rules.Assess(
+		domain.FraudPayment{
+			UID:          rp.TransactionUID,
+			MCC:          rp.MCC,
+			MerchantName: rp.MerchantName,
+			Amount:       rp.Amount,
+			At:           rp.At,
+		},
+		domain.FraudHistory{
+			Payments:     mapFraudPaymentEntitiesToDomain(fpEntities),
+			MerchantSeen: merchantSeen,
+		},
+	)
+
+	decision.Decision = assessment.Decision
+	decision.Reasons = assessment.Reasons()
+
+	return decision, nil
+}
+
+func (fr *FraudRules) currentRules(ctx context.Context) (domain.FraudRules, error) {
+	fr.mu.RLock()
+	rules, loadedAt := fr.rules, fr.loadedAt
+	fr.mu.RUnlock()
+
+	if !loadedAt.IsZero() && time.Since(loadedAt) < time.Duration(fr.reloadInterval) {
+		return rules, nil
+	}
+
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	if !fr.loadedAt.Equal(loadedAt) {
+		return fr.rules, nil
+	}
+
+	frEntities, err := fr.fraudRuleRepository.FindEnabled(ctx)
+	if err != nil {
+		if fr.loadedAt.IsZero() {
+			return nil, fmt.Errorf("failed to retrieve fraud rules: %w", err)
+		}
+
+		fr.log.Error(ctx, fmt.Sprintf("failed to reload fraud rules, keeping the loaded ones: %s", err.Error()))
+		fr.loadedAt = time.Now()
+		return fr.rules, nil
+	}
+
+	fr.rules = mapFraudRuleEntitiesToDomain(frEntities)
+	fr.loadedAt = time.Now()
+
+	return fr.rules, nil
+}
+
+/*
+  - Runs the risk stage and logs its decision with the transaction UID. A DECLINE
+    is returned as a custom error, logged by the rejection, and a REVIEW goes on
+    to the approval
+*/
+func assessRisk(
+	ctx context.Context,
+	riskStage port.RiskStage,
+	transaction domain.Transaction,
+	log logger.Logger,
+) (*domain.CustomError, error) {
+	decision, err := riskStage.Assess(ctx, mapTransactionToRiskPaymentEntity(transaction, time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to assess transaction risk: %w", err)
+	}
+
+	message := fmt.Sprintf("risk decision %s for transaction %s", decision.Decision, transaction.UID)
+	if len(decision.Reasons) > 0 {
+		message = fmt.Sprintf("%s: %s", message, strings.Join(decision.Reasons, "; "))
+	}
+
+	switch decision.Decision {
+	case domain.RISK_DECISION_DECLINE:
+		return domain.NewCustomError(domain.CODE_REJECTED_SUSPECTED_FRAUD, message), nil
+	case domain.RISK_DECISION_REVIEW:
+		log.Warn(ctx, message)
+	default:
+		log.Info(ctx, message)
+	}
+
+	return nil, nil
+}
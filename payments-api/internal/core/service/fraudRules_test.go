@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+/*
+- Rules edited by the tests between assessments, as rows changed in the database
+*/
+type EditableFraudRuleRepoFake struct {
+	rules []port.FraudRuleEntity
+	err   error
+	calls int
+}
+
+func (efrrf *EditableFraudRuleRepoFake) FindEnabled(_ context.Context) ([]port.FraudRuleEntity, error) {
+	efrrf.calls++
+	return efrrf.rules, efrrf.err
+}
+
+type FraudRulesSuite struct {
+	suite.Suite
+}
+
+func (suite *FraudRulesSuite) newRiskPayment(merchantName string) port.RiskPaymentEntity {
+	return port.RiskPaymentEntity{
+		TransactionUID: uuid.New(),
+		AccountID:      1,
+		AccountUID:     accountUIDtoTransact,
+		MCC:            correctFoodMCC,
+		MerchantName:   merchantName,
+		Amount:         amountFoodFundsApproved,
+		Currency:       "BRL",
+		At:             time.Now(),
+	}
+}
+
+func (suite *FraudRulesSuite) TestMerchantLocation() {
+	assert.Equal(suite.T(), domain.MerchantLocation("UBER EATS                   SAO PAULO BR"), "SAO PAULO BR")
+	assert.Equal(suite.T(), domain.MerchantLocation("  uber eats   rio  de janeiro br "), "RIO DE JANEIRO BR")
+	assert.Equal(suite.T(), domain.MerchantLocation("UBER EATS"), "")
+}
+
+func (suite *FraudRulesSuite) TestAssessTakesMostSevereDecision() {
+	//Arrange
+	dbFake := newDBfake()
+	dbFake.FraudRules = []port.FraudRuleEntity{
+		{Name: "first-seen-merchant", Kind: domain.FRAUD_RULE_FIRST_SEEN_MERCHANT, Decision: domain.RISK_DECISION_REVIEW},
+		{Name: "blocked-mccs", Kind: domain.FRAUD_RULE_MCC_BLOCKLIST, Decision: domain.RISK_DECISION_DECLINE, MCCs: []string{correctFoodMCC}},
+		{Name: "gambling-mccs", Kind: domain.FRAUD_RULE_MCC_BLOCKLIST, Decision: domain.RISK_DECISION_DECLINE, MCCs: []string{"7995"}},
+	}
+	fraudRules := newFraudRulesFake(newFraudRuleRepoFake(dbFake), newFraudHistoryRepoFake(dbFake))
+
+	//Act
+	decision, err := fraudRules.Assess(context.Background(), suite.newRiskPayment("PADARIA DO ZE               SAO PAULO BR"))
+
+	//Assert
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), decision.Decision, domain.RISK_DECISION_DECLINE)
+	assert.Equal(suite.T(), decision.Reasons, []string{
+		"first-seen-merchant: first payment at merchant PADARIA DO ZE with amount 100.1, at least 0",
+		"blocked-mccs: mcc 5411 is blocklisted",
+	})
+}
+
+func (suite *FraudRulesSuite) TestAssessFirstSeenMerchantBelowAmountApproved() {
+	//Arrange
+	dbFake := newDBfake()
+	dbFake.FraudRules = []port.FraudRuleEntity{
+		{Name: "first-seen-merchant", Kind: domain.FRAUD_RULE_FIRST_SEEN_MERCHANT, Decision: domain.RISK_DECISION_REVIEW, Amount: decimal.NewFromFloat(1000)},
+	}
+	fraudRules := newFraudRulesFake(newFraudRuleRepoFake(dbFake), newFraudHistoryRepoFake(dbFake))
+
+	//Act
+	decision, err := fraudRules.Assess(context.Background(), suite.newRiskPayment("PADARIA DO ZE               SAO PAULO BR"))
+
+	//Assert
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), decision.Decision, domain.RISK_DECISION_APPROVE)
+	assert.Equal(suite.T(), len(decision.Reasons), 0)
+}
+
+func (suite *FraudRulesSuite) TestAssessSameLocationWithinWindowApproved() {
+	//Arrange
+	dbFake := newDBfake()
+	dbFake.FraudRules = []port.FraudRuleEntity{
+		{Name: "impossible-velocity", Kind: domain.FRAUD_RULE_IMPOSSIBLE_VELOCITY, Decision: domain.RISK_DECISION_DECLINE, Window: time.Hour},
+	}
+	dbFake.FraudPayments = []port.FraudPaymentEntity{
+		{UID: uuid.New(), MerchantName: "UBER EATS                   SAO PAULO BR", CreatedAt: time.Now().Add(-5 * time.Minute)},
+		{UID: uuid.New(), MerchantName: "DELI ON FIFTH               NEW YORK US", CreatedAt: time.Now().Add(-2 * time.Hour)},
+	}
+	fraudRules := newFraudRulesFake(newFraudRuleRepoFake(dbFake), newFraudHistoryRepoFake(dbFake))
+
+	//Act
+	decision, err := fraudRules.Assess(context.Background(), suite.newRiskPayment("PADARIA DO ZE               SAO PAULO BR"))
+
+	//Assert
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), decision.Decision, domain.RISK_DECISION_APPROVE)
+}
+
+func (suite *FraudRulesSuite) TestAssessReloadsChangedRules() {
+	//Arrange
+	dbFake := newDBfake()
+	ruleRepo := &EditableFraudRuleRepoFake{}
+	fraudRules := NewFraudRules(0, ruleRepo, newFraudHistoryRepoFake(dbFake), newFakeLog())
+
+	//Act
+	before, errBefore := fraudRules.Assess(context.Background(), suite.newRiskPayment("PADARIA DO ZE               SAO PAULO BR"))
+
+	ruleRepo.rules = []port.FraudRuleEntity{
+		{Name: "blocked-mccs", Kind: domain.FRAUD_RULE_MCC_BLOCKLIST, Decision: domain.RISK_DECISION_DECLINE, MCCs: []string{correctFoodMCC}},
+	}
+
+	after, errAfter := fraudRules.Assess(context.Background(), suite.newRiskPayment("PADARIA DO ZE               SAO PAULO BR"))
+
+	//Assert
+	assert.Equal(suite.T(), errBefore, nil)
+	assert.Equal(suite.T(), before.Decision, domain.RISK_DECISION_APPROVE)
+	assert.Equal(suite.T(), errAfter, nil)
+	assert.Equal(suite.T(), after.Decision, domain.RISK_DECISION_DECLINE)
+	assert.Equal(suite.T(), ruleRepo.calls, 2)
+}
+
+func (suite *FraudRulesSuite) TestAssessKeepsRulesWithinReloadInterval() {
+	//Arrange
+	dbFake := newDBfake()
+	ruleRepo := &EditableFraudRuleRepoFake{}
+	reloadInterval := port.FraudRulesReloadInterval(time.Hour)
+	fraudRules := NewFraudRules(reloadInterval, ruleRepo, newFraudHistoryRepoFake(dbFake), newFakeLog())
+
+	//Act
+	_, _ = fraudRules.Assess(context.Background(), suite.newRiskPayment("PADARIA DO ZE               SAO PAULO BR"))
+
+	ruleRepo.rules = []port.FraudRuleEntity{
+		{Name: "blocked-mccs", Kind: domain.FRAUD_RULE_MCC_BLOCKLIST, Decision: domain.RISK_DECISION_DECLINE, MCCs: []string{correctFoodMCC}},
+	}
+
+	decision, err := fraudRules.Assess(context.Background(), suite.newRiskPayment("PADARIA DO ZE               SAO PAULO BR"))
+
+	//Assert
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), decision.Decision, domain.RISK_DECISION_APPROVE)
+	assert.Equal(suite.T(), ruleRepo.calls, 1)
+}
+
+func (suite *FraudRulesSuite) TestAssessKeepsLoadedRulesOnReloadFailure() {
+	//Arrange
+	dbFake := newDBfake()
+	ruleRepo := &EditableFraudRuleRepoFake{
+		rules: []port.FraudRuleEntity{
+			{Name: "blocked-mccs", Kind: domain.FRAUD_RULE_MCC_BLOCKLIST, Decision: domain.RISK_DECISION_DECLINE, MCCs: []string{correctFoodMCC}},
+		},
+	}
+	fraudRules := NewFraudRules(0, ruleRepo, newFraudHistoryRepoFake(dbFake), newFakeLog())
+
+	//Act
+	_, _ = fraudRules.Assess(context.Background(), suite.newRiskPayment("PADARIA DO ZE               SAO PAULO BR"))
+
+	ruleRepo.rules = nil
+	ruleRepo.err = errors.New("connection refused")
+
+	decision, err := fraudRules.Assess(context.Background(), suite.newRiskPayment("PADARIA DO ZE               SAO PAULO BR"))
+
+	//Assert
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), decision.Decision, domain.RISK_DECISION_DECLINE)
+}
+
+func (suite *FraudRulesSuite) TestAssessWithoutLoadedRulesFails() {
+	//Arrange
+	dbFake := newDBfake()
+	ruleRepo := &EditableFraudRuleRepoFake{err: errors.New("connection refused")}
+	fraudRules := NewFraudRules(0, ruleRepo, newFraudHistoryRepoFake(dbFake), newFakeLog())
+
+	//Act
+	_, err := fraudRules.Assess(context.Background(), suite.newRiskPayment("PADARIA DO ZE               SAO PAULO BR"))
+
+	//Assert
+	assert.NotEqual(suite.T(), err, nil)
+}
+
+func TestFraudRulesSuite(t *testing.T) {
+	suite.Run(t, new(FraudRulesSuite))
+}
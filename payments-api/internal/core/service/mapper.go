@@ -1,8 +1,13 @@
 package service
 
 import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jtonynet/go-payments-api/internal/core/domain"
 	"github.com/jtonynet/go-payments-api/internal/core/port"
 	"github.com/jtonynet/go-payments-api/internal/support/logger"
@@ -17,6 +22,67 @@ func mapTransactionRequestToMemoryLockEntity(tMemoryLock port.TransactionPayment
 	}
 }
 
+func mapRefundRequestToMemoryLockEntity(trMemoryLock port.TransactionRefundRequest) port.MemoryLockEntity {
+	return port.MemoryLockEntity{
+		Key:         trMemoryLock.AccountUID.String(),
+		Transcation: trMemoryLock.RefundUID.String(),
+		Timestamp:   time.Now().UnixMilli(),
+	}
+}
+
+func mapHoldRequestToMemoryLockEntity(thMemoryLock port.TransactionHoldRequest) port.MemoryLockEntity {
+	return port.MemoryLockEntity{
+		Key:         thMemoryLock.AccountUID.String(),
+		Transcation: thMemoryLock.TransactionUID.String(),
+		Timestamp:   time.Now().UnixMilli(),
+	}
+}
+
+func mapCreditRequestToMemoryLockEntity(tcMemoryLock port.TransactionCreditRequest) port.MemoryLockEntity {
+	return port.MemoryLockEntity{
+		Key:         tcMemoryLock.AccountUID.String(),
+		Transcation: tcMemoryLock.TransactionUID.String(),
+		Timestamp:   time.Now().UnixMilli(),
+	}
+}
+
+func mapCreditRequestToTransactionDomain(tcr port.TransactionCreditRequest, account domain.Account) domain.Transaction {
+	return domain.Transaction{
+		UID:        tcr.TransactionUID,
+		AccountID:  account.ID,
+		AccountUID: account.UID,
+		Amount:     tcr.TotalAmount,
+	}
+}
+
+func mapRefundRequestToTransactionDomain(trr port.TransactionRefundRequest, account domain.Account) domain.Transaction {
+	return domain.Transaction{
+		UID:         trr.RefundUID,
+		AccountID:   account.ID,
+		AccountUID:  account.UID,
+		Amount:      trr.TotalAmount,
+		OriginalUID: trr.TransactionUID,
+	}
+}
+
+func mapTransactionCapturedEntitiesToDomains(tcEntities map[int]port.TransactionCapturedEntity) map[int]domain.TransactionCaptured {
+	capturedTransactions := make(map[int]domain.TransactionCaptured)
+	for key, tcEntity := range tcEntities {
+		capturedTransactions[key] = domain.TransactionCaptured{
+			CategoryID:       tcEntity.CategoryID,
+			Priority:         tcEntity.Priority,
+			AmountCaptured:   tcEntity.AmountCaptured,
+			AmountRefunded:   tcEntity.AmountRefunded,
+			OriginalAmount:   tcEntity.OriginalAmount,
+			OriginalRefunded: tcEntity.OriginalRefunded,
+			OriginalCurrency: tcEntity.OriginalCurrency,
+			ExchangeRate:     tcEntity.ExchangeRate,
+		}
+	}
+
+	return capturedTransactions
+}
+
 func mapAccountEntityToDomain(aEntity port.AccountEntity, log logger.Logger) domain.Account {
 	amountTotal := decimal.NewFromFloat(10)
 
@@ -29,15 +95,23 @@ func mapAccountEntityToDomain(aEntity port.AccountEntity, log logger.Logger) dom
 			CategoryID: item.Category.ID,
 			Name:       item.Category.Name,
 			Amount:     item.Amount,
+			AmountHeld: item.AmountHeld,
+			Currency:   currencyOrDefault(item.Category.Currency),
 			MCCs:       item.Category.MCCs,
 			Priority:   priority,
+
+			FallbackExcluded: item.Category.FallbackExcluded,
 		}
 	}
 
 	return domain.Account{
-		ID:  aEntity.ID,
-		UID: aEntity.UID,
-		Log: log,
+		ID:     aEntity.ID,
+		UID:    aEntity.UID,
+		Status: aEntity.Status,
+		Log:    log,
+
+		CurrencyConversion: aEntity.CurrencyConversion,
+		Currency:           currencyOrDefault(aEntity.Currency),
 
 		Balance: domain.Balance{
 			AmountTotal: amountTotal,
@@ -48,10 +122,52 @@ func mapAccountEntityToDomain(aEntity port.AccountEntity, log logger.Logger) dom
 	}
 }
 
-func mapMerchantEntityToDomain(mEntity *port.MerchantEntity) domain.Merchant {
+func mapCardEntityToDomain(cEntity port.CardEntity, dailyUsage decimal.Decimal) domain.Card {
+	return domain.Card{
+		ID:        cEntity.ID,
+		UID:       cEntity.UID,
+		MaskedPAN: cEntity.MaskedPAN,
+		Status:    cEntity.Status,
+		ExpiresAt: cEntity.ExpiresAt,
+
+		TransactionAmount: cEntity.TransactionAmount,
+		DailyAmount:       cEntity.DailyAmount,
+
+		DailyUsage: dailyUsage,
+	}
+}
+
+func mapCardEntityToResponse(cEntity port.CardEntity) port.CardResponse {
+	return port.CardResponse{
+		UID:               cEntity.UID.String(),
+		AccountUID:        cEntity.AccountUID.String(),
+		MaskedPAN:         cEntity.MaskedPAN,
+		Status:            cEntity.Status,
+		Expiry:            domain.FormatCardExpiry(cEntity.ExpiresAt),
+		TransactionAmount: cEntity.TransactionAmount,
+		DailyAmount:       cEntity.DailyAmount,
+		CreatedAt:         cEntity.CreatedAt,
+	}
+}
+
+func mapMerchantEntityToDomain(mEntity port.MerchantEntity) domain.Merchant {
 	return domain.Merchant{
-		Name: mEntity.Name,
-		MCC:  mEntity.MCC,
+		Name:    mEntity.Name,
+		MCC:     mEntity.MCC,
+		Aliases: mEntity.Aliases,
+	}
+}
+
+func mapMerchantMatchToAuditEntity(originalMCC string, merchant domain.Merchant, tDomain domain.Transaction) port.MerchantMatchAuditEntity {
+	return port.MerchantMatchAuditEntity{
+		TransactionUID:  tDomain.UID,
+		AccountID:       tDomain.AccountID,
+		MerchantName:    tDomain.MerchantName,
+		MatchedMerchant: merchant.Name,
+		MatchRule:       merchant.MatchRule,
+		MatchPattern:    merchant.MatchPattern,
+		OriginalMCC:     originalMCC,
+		MCC:             tDomain.MCC,
 	}
 }
 
@@ -59,14 +175,461 @@ func mapTransactionDomainsToEntities(approvedTransactions map[int]domain.Transac
 	transactionEntities := make(map[int]port.TransactionEntity)
 	for priority, tDomain := range approvedTransactions {
 		transactionEntities[priority] = port.TransactionEntity{
-			UID:          tDomain.UID,
-			AccountID:    tDomain.AccountID,
-			Amount:       tDomain.Amount,
-			MCC:          tDomain.MCC,
-			MerchantName: tDomain.MerchantName,
-			CategoryID:   tDomain.CategoryID,
+			UID:              tDomain.UID,
+			AccountID:        tDomain.AccountID,
+			AccountUID:       tDomain.AccountUID,
+			CardID:           tDomain.CardID,
+			Amount:           tDomain.Amount,
+			Currency:         tDomain.Currency,
+			MCC:              tDomain.MCC,
+			MerchantName:     tDomain.MerchantName,
+			CategoryID:       tDomain.CategoryID,
+			OriginalUID:      tDomain.OriginalUID,
+			Operation:        tDomain.Operation,
+			EntryType:        tDomain.EntryType,
+			BalanceBefore:    tDomain.BalanceBefore,
+			BalanceAfter:     tDomain.BalanceAfter,
+			OriginalAmount:   tDomain.OriginalAmount,
+			OriginalCurrency: tDomain.OriginalCurrency,
+			ExchangeRate:     tDomain.ExchangeRate,
 		}
 	}
 
 	return transactionEntities
 }
+
+func mapHoldDomainsToEntities(holds map[int]domain.Hold) map[int]port.HoldEntity {
+	holdEntities := make(map[int]port.HoldEntity)
+	for key, hDomain := range holds {
+		holdEntities[key] = port.HoldEntity{
+			UID:              hDomain.UID,
+			AccountID:        hDomain.AccountID,
+			AccountUID:       hDomain.AccountUID,
+			CategoryID:       hDomain.CategoryID,
+			Amount:           hDomain.Amount,
+			Currency:         hDomain.Currency,
+			MCC:              hDomain.MCC,
+			MerchantName:     hDomain.MerchantName,
+			Status:           port.HOLD_STATUS_AUTHORIZED,
+			ExpiresAt:        hDomain.ExpiresAt,
+			CardID:           hDomain.CardID,
+			OriginalAmount:   hDomain.OriginalAmount,
+			OriginalCurrency: hDomain.OriginalCurrency,
+			ExchangeRate:     hDomain.ExchangeRate,
+		}
+	}
+
+	return holdEntities
+}
+
+func mapHoldEntitiesToDomains(holdEntities map[int]port.HoldEntity) map[int]domain.Hold {
+	holds := make(map[int]domain.Hold)
+	for key, hEntity := range holdEntities {
+		holds[key] = domain.Hold{
+			UID:              hEntity.UID,
+			AccountID:        hEntity.AccountID,
+			AccountUID:       hEntity.AccountUID,
+			CategoryID:       hEntity.CategoryID,
+			Amount:           hEntity.Amount,
+			Currency:         currencyOrDefault(hEntity.Currency),
+			MCC:              hEntity.MCC,
+			MerchantName:     hEntity.MerchantName,
+			ExpiresAt:        hEntity.ExpiresAt,
+			CardID:           hEntity.CardID,
+			OriginalAmount:   hEntity.OriginalAmount,
+			OriginalCurrency: hEntity.OriginalCurrency,
+			ExchangeRate:     hEntity.ExchangeRate,
+		}
+	}
+
+	return holds
+}
+
+func mapTransactionOutcomeToEntity(t domain.Transaction, account domain.Account, code string) port.TransactionOutcomeEntity {
+	return port.TransactionOutcomeEntity{
+		UID:        t.UID,
+		AccountID:  account.ID,
+		AccountUID: account.UID,
+		Code:       code,
+		Amount:     t.Amount,
+		Currency:   t.Currency,
+	}
+}
+
+/*
+  - Outcome of a payment or authorization rejected before its transaction is built,
+    without the account until it is found
+*/
+func mapPaymentRequestToOutcomeEntity(tpr port.TransactionPaymentRequest) port.TransactionOutcomeEntity {
+	currency, _ := domain.NormalizeCurrency(tpr.Currency)
+
+	return port.TransactionOutcomeEntity{
+		UID:        tpr.TransactionUID,
+		AccountUID: tpr.AccountUID,
+		Amount:     tpr.TotalAmount,
+		Currency:   currency,
+	}
+}
+
+func mapCreditRequestToOutcomeEntity(tcr port.TransactionCreditRequest) port.TransactionOutcomeEntity {
+	return port.TransactionOutcomeEntity{
+		UID:        tcr.TransactionUID,
+		AccountUID: tcr.AccountUID,
+		Amount:     tcr.TotalAmount,
+	}
+}
+
+func mapTransactionHistoryRequestToFilterEntity(thr port.TransactionHistoryRequest) (port.TransactionHistoryFilterEntity, error) {
+	cursorID, err := decodeCursor(thr.Cursor)
+	if err != nil {
+		return port.TransactionHistoryFilterEntity{}, err
+	}
+
+	limit := thr.Limit
+	if limit <= 0 {
+		limit = port.TRANSACTION_HISTORY_DEFAULT_LIMIT
+	}
+
+	if limit > port.TRANSACTION_HISTORY_MAX_LIMIT {
+		limit = port.TRANSACTION_HISTORY_MAX_LIMIT
+	}
+
+	return port.TransactionHistoryFilterEntity{
+		AccountUID:   thr.AccountUID,
+		CursorID:     cursorID,
+		Limit:        limit,
+		From:         thr.From,
+		To:           thr.To,
+		CategoryName: thr.Category,
+		MCC:          thr.MCC,
+		MerchantName: thr.Merchant,
+	}, nil
+}
+
+func mapTransactionHistoryEntitiesToResponse(thEntities []port.TransactionHistoryEntity, nextCursor string) port.TransactionHistoryResponse {
+	items := []port.TransactionHistoryItemResponse{}
+	for _, thEntity := range thEntities {
+		item := port.TransactionHistoryItemResponse{
+			Category:  thEntity.CategoryName,
+			Operation: thEntity.Operation,
+			Amount:    thEntity.Amount,
+			Balance:   thEntity.Balance,
+			Currency:  currencyOrDefault(thEntity.Currency),
+			MCC:       thEntity.MCC,
+			Merchant:  thEntity.MerchantName,
+			CreatedAt: thEntity.CreatedAt,
+		}
+
+		if thEntity.UID != uuid.Nil {
+			item.TransactionUID = thEntity.UID.String()
+		}
+
+		if thEntity.OriginalUID != uuid.Nil {
+			item.OriginalUID = thEntity.OriginalUID.String()
+		}
+
+		items = append(items, item)
+	}
+
+	return port.TransactionHistoryResponse{
+		Transactions: items,
+		NextCursor:   nextCursor,
+	}
+}
+
+func encodeCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(id), 10)))
+}
+
+func decodeCursor(cursor string) (uint, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", port.ErrInvalidCursor, cursor)
+	}
+
+	id, err := strconv.ParseUint(string(decoded), 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%w: %s", port.ErrInvalidCursor, cursor)
+	}
+
+	return uint(id), nil
+}
+
+/*
+  - Categories are listed by priority, amounts are the available balance
+    (held amounts are already subtracted by the repository)
+*/
+func mapAccountEntityToBalanceResponse(aEntity port.AccountEntity, includeHolds bool) port.BalanceResponse {
+	categories := []port.BalanceCategoryResponse{}
+	amountHeldTotal := decimal.Zero
+
+	for _, tcEntity := range aEntity.Balance.Categories {
+		category := port.BalanceCategoryResponse{
+			Name:     tcEntity.Category.Name,
+			Priority: tcEntity.Category.Priority,
+			Currency: currencyOrDefault(tcEntity.Category.Currency),
+			MCCs:     tcEntity.Category.MCCs,
+			Amount:   tcEntity.Amount,
+		}
+
+		if includeHolds {
+			amountHeld := tcEntity.AmountHeld
+			category.AmountHeld = &amountHeld
+			amountHeldTotal = amountHeldTotal.Add(amountHeld)
+		}
+
+		categories = append(categories, category)
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Priority < categories[j].Priority
+	})
+
+	balance := port.BalanceResponse{
+		AccountUID:  aEntity.UID.String(),
+		AmountTotal: aEntity.Balance.AmountTotal,
+		Categories:  categories,
+	}
+
+	if includeHolds {
+		balance.AmountHeld = &amountHeldTotal
+	}
+
+	return balance
+}
+
+func mapAccountAdminEntityToResponse(aaEntity port.AccountAdminEntity) port.AccountResponse {
+	categories := []port.CategoryResponse{}
+	for _, caEntity := range aaEntity.Categories {
+		categories = append(categories, mapCategoryAdminEntityToResponse(caEntity))
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Priority < categories[j].Priority
+	})
+
+	return port.AccountResponse{
+		UID:                aaEntity.UID.String(),
+		Name:               aaEntity.Name,
+		CurrencyConversion: aaEntity.CurrencyConversion,
+		Status:             aaEntity.Status,
+		Categories:         categories,
+		CreatedAt:          aaEntity.CreatedAt,
+	}
+}
+
+func mapAccountStatusChangeEntityToResponse(ascEntity port.AccountStatusChangeEntity) port.AccountStatusChangeResponse {
+	return port.AccountStatusChangeResponse{
+		AccountUID:     ascEntity.AccountUID.String(),
+		PreviousStatus: ascEntity.PreviousStatus,
+		Status:         ascEntity.Status,
+		Reason:         ascEntity.Reason,
+		CreatedAt:      ascEntity.CreatedAt,
+	}
+}
+
+func mapCategoryAdminEntityToResponse(caEntity port.CategoryAdminEntity) port.CategoryResponse {
+	mccs := caEntity.MCCs
+	if mccs == nil {
+		mccs = []string{}
+	}
+
+	return port.CategoryResponse{
+		UID:      caEntity.UID.String(),
+		Name:     caEntity.Name,
+		Priority: caEntity.Priority,
+		Currency: currencyOrDefault(caEntity.Currency),
+		MCCs:     mccs,
+
+		FallbackExcluded: caEntity.FallbackExcluded,
+	}
+}
+
+func mapMerchantRegistryEntityToResponse(mrEntity port.MerchantRegistryEntity) port.MerchantResponse {
+	return port.MerchantResponse{
+		UID:       mrEntity.UID.String(),
+		Name:      mrEntity.Name,
+		MCC:       mrEntity.MCC,
+		Aliases:   mrEntity.Aliases,
+		CreatedAt: mrEntity.CreatedAt,
+		UpdatedAt: mrEntity.UpdatedAt,
+	}
+}
+
+func mapLedgerBalanceEntityToDomain(lbEntity port.LedgerBalanceEntity) domain.LedgerBalance {
+	return domain.LedgerBalance{
+		Balance:       lbEntity.Balance,
+		LedgerBalance: lbEntity.LedgerBalance,
+		BrokenEntries: lbEntity.BrokenEntries,
+	}
+}
+
+func mapLedgerBalanceEntityToResponse(lbEntity port.LedgerBalanceEntity) port.LedgerBalanceResponse {
+	return port.LedgerBalanceResponse{
+		AccountUID:    lbEntity.AccountUID.String(),
+		Category:      lbEntity.CategoryName,
+		Balance:       lbEntity.Balance,
+		LedgerBalance: lbEntity.LedgerBalance,
+		BrokenEntries: lbEntity.BrokenEntries,
+	}
+}
+
+func mapCategoryRuleEntitiesToDomain(crEntities []port.CategoryRuleEntity) domain.CategoryRules {
+	categoryRules := make(domain.CategoryRules, 0, len(crEntities))
+	for _, crEntity := range crEntities {
+		categoryRules = append(categoryRules, domain.CategoryRule{
+			AccountID:          crEntity.AccountID,
+			CategoryID:         crEntity.CategoryID,
+			FallbackCategoryID: crEntity.FallbackCategoryID,
+			Position:           crEntity.Position,
+		})
+	}
+
+	return categoryRules
+}
+
+func mapCategoryRuleChainEntityToResponse(crcEntity port.CategoryRuleChainEntity) port.CategoryRuleResponse {
+	response := port.CategoryRuleResponse{
+		Fallbacks: []port.CategoryRuleFallbackResponse{},
+	}
+
+	if crcEntity.AccountUID != uuid.Nil {
+		response.AccountUID = crcEntity.AccountUID.String()
+	}
+
+	if crcEntity.CategoryUID != uuid.Nil {
+		response.CategoryUID = crcEntity.CategoryUID.String()
+	}
+
+	for _, fallback := range crcEntity.Fallbacks {
+		response.Fallbacks = append(response.Fallbacks, port.CategoryRuleFallbackResponse{
+			UID:  fallback.UID.String(),
+			Name: fallback.Name,
+		})
+	}
+
+	return response
+}
+
+func mapTransactionPaymentResponse(code string, attempts []domain.CategoryAttempt) port.TransactionPaymentResponse {
+	response := port.TransactionPaymentResponse{
+		Code:   code,
+		Reason: domain.ResponseReason(code),
+	}
+
+	for _, attempt := range attempts {
+		response.Categories = append(response.Categories, port.CategoryAttemptResponse{
+			Category: attempt.Name,
+			Rule:     attempt.Rule,
+			Amount:   attempt.Amount,
+			Currency: attempt.Currency,
+		})
+	}
+
+	return response
+}
+
+func mapSpendingLimitEntityToDomain(slEntity port.SpendingLimitEntity, suEntity port.SpendingUsageEntity) domain.SpendingLimit {
+	return domain.SpendingLimit{
+		CategoryID:         slEntity.CategoryID,
+		DailyAmount:        slEntity.DailyAmount,
+		MonthlyAmount:      slEntity.MonthlyAmount,
+		TransactionAmount:  slEntity.TransactionAmount,
+		HourlyTransactions: slEntity.HourlyTransactions,
+		Usage: domain.SpendingUsage{
+			DailyAmount:        suEntity.DailyAmount,
+			MonthlyAmount:      suEntity.MonthlyAmount,
+			HourlyTransactions: suEntity.HourlyTransactions,
+		},
+	}
+}
+
+func mapSpendingLimitEntityToResponse(slEntity port.SpendingLimitEntity) port.SpendingLimitResponse {
+	response := port.SpendingLimitResponse{
+		AccountUID:         slEntity.AccountUID.String(),
+		DailyAmount:        slEntity.DailyAmount,
+		MonthlyAmount:      slEntity.MonthlyAmount,
+		TransactionAmount:  slEntity.TransactionAmount,
+		HourlyTransactions: slEntity.HourlyTransactions,
+	}
+
+	if slEntity.CategoryUID != uuid.Nil {
+		response.CategoryUID = slEntity.CategoryUID.String()
+		response.CategoryName = slEntity.CategoryName
+	}
+
+	return response
+}
+
+func mapTransactionToRiskPaymentEntity(t domain.Transaction, at time.Time) port.RiskPaymentEntity {
+	return port.RiskPaymentEntity{
+		TransactionUID: t.UID,
+		AccountID:      t.AccountID,
+		AccountUID:     t.AccountUID,
+		MCC:            t.MCC,
+		MerchantName:   t.MerchantName,
+		Amount:         t.Amount,
+		Currency:       t.Currency,
+		At:             at,
+	}
+}
+
+func mapFraudRuleEntitiesToDomain(frEntities []port.FraudRuleEntity) domain.FraudRules {
+	rules := make(domain.FraudRules, 0, len(frEntities))
+	for _, frEntity := range frEntities {
+		rules = append(rules, domain.FraudRule{
+			Name:     frEntity.Name,
+			Kind:     frEntity.Kind,
+			Decision: frEntity.Decision,
+			MCCs:     frEntity.MCCs,
+			Amount:   frEntity.Amount,
+			Count:    frEntity.Count,
+			Window:   frEntity.Window,
+		})
+	}
+
+	return rules
+}
+
+func mapFraudPaymentEntitiesToDomain(fpEntities []port.FraudPaymentEntity) []domain.FraudPayment {
+	payments := make([]domain.FraudPayment, 0, len(fpEntities))
+	for _, fpEntity := range fpEntities {
+		payments = append(payments, domain.FraudPayment{
+			UID:          fpEntity.UID,
+			MCC:          fpEntity.MCC,
+			MerchantName: fpEntity.MerchantName,
+			Amount:       fpEntity.Amount,
+			At:           fpEntity.CreatedAt,
+		})
+	}
+
+	return payments
+}
+
+func mapTransactionEventEntityToMessage(teEntity port.TransactionEventEntity) port.TransactionEventMessage {
+	message := port.TransactionEventMessage{
+		UID:            teEntity.UID.String(),
+		Sequence:       teEntity.ID,
+		Type:           teEntity.Type,
+		TransactionUID: teEntity.TransactionUID.String(),
+		Amount:         teEntity.Amount,
+		Currency:       teEntity.Currency,
+		Code:           teEntity.Code,
+		Reason:         domain.ResponseReason(teEntity.Code),
+		OccurredAt:     teEntity.CreatedAt,
+	}
+
+	if teEntity.AccountUID != uuid.Nil {
+		message.AccountUID = teEntity.AccountUID.String()
+	}
+
+	if teEntity.OriginalUID != uuid.Nil {
+		message.OriginalUID = teEntity.OriginalUID.String()
+	}
+
+	return message
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/support/logger"
+)
+
+type MerchantMatcher struct {
+	similarityThreshold          port.MerchantSimilarityThreshold
+	merchantRepository           port.MerchantRepository
+	merchantMatchAuditRepository port.MerchantMatchAuditRepository
+
+	log logger.Logger
+}
+
+func NewMerchantMatcher(
+	similarityThreshold port.MerchantSimilarityThreshold,
+
+	mRepository port.MerchantRepository,
+	maRepository port.MerchantMatchAuditRepository,
+
+	log logger.Logger,
+) *MerchantMatcher {
+	return &MerchantMatcher{
+		similarityThreshold:          similarityThreshold,
+		merchantRepository:           mRepository,
+		merchantMatchAuditRepository: maRepository,
+
+		log: log,
+	}
+}
+
+/*
+  - The exact name is looked up first, the merchant catalog is only matched
+    by the domain rules when it misses
+  - An unmatched name returns a merchant without MCC, so the transaction keeps
+    the MCC it is sent with
+*/
+func (mm *MerchantMatcher) Match(ctx context.Context, name string) (domain.Merchant, error) {
+	merchantEntity, err := mm.merchantRepository.FindByName(ctx, name)
+	if err != nil {
+		return domain.Merchant{}, fmt.Errorf("failed to retrieve merchant entity with name %s: %w", name, err)
+	}
+
+	if merchantEntity != nil {
+		merchant := mapMerchantEntityToDomain(*merchantEntity)
+		merchant.MatchRule = domain.MERCHANT_MATCH_EXACT
+		merchant.MatchPattern = merchantEntity.Name
+
+		return merchant, nil
+	}
+
+	merchantEntities, err := mm.merchantRepository.FindAll(ctx)
+	if err != nil {
+		return domain.Merchant{}, fmt.Errorf("failed to retrieve merchant catalog: %w", err)
+	}
+
+	merchants := make([]domain.Merchant, 0, len(merchantEntities))
+	for _, mEntity := range merchantEntities {
+		merchants = append(merchants, mapMerchantEntityToDomain(mEntity))
+	}
+
+	matcher := domain.MerchantMatcher{
+		Merchants:           merchants,
+		SimilarityThreshold: float64(mm.similarityThreshold),
+	}
+
+	merchant, _ := matcher.Match(name)
+
+	return merchant, nil
+}
+
+/*
+- A failure here is only logged: the audit trail must not reject the transaction
+*/
+func (mm *MerchantMatcher) Audit(ctx context.Context, originalMCC string, merchant domain.Merchant, transaction domain.Transaction) {
+	if merchant.MatchRule == "" {
+		return
+	}
+
+	mm.log.Info(
+		ctx,
+		fmt.Sprintf(
+			"merchant %s matched %s by %s rule with %s, mcc %s overridden by %s",
+			transaction.MerchantName,
+			merchant.Name,
+			merchant.MatchRule,
+			merchant.MatchPattern,
+			originalMCC,
+			transaction.MCC,
+		),
+	)
+
+	err := mm.merchantMatchAuditRepository.Save(ctx, mapMerchantMatchToAuditEntity(originalMCC, merchant, transaction))
+	if err != nil {
+		mm.log.Error(ctx, fmt.Sprintf("failed to save merchant match audit: %s", err.Error()))
+	}
+}
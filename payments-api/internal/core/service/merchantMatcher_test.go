@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+type MerchantMatcherSuite struct {
+	suite.Suite
+}
+
+func (suite *MerchantMatcherSuite) newMerchantMatcher(
+	threshold port.MerchantSimilarityThreshold,
+	auditRepo *MerchantMatchAuditRepoFake,
+) *MerchantMatcher {
+	dbFake := newDBfake()
+	dbFake.Merchants[2] = port.MerchantEntity{
+		Name:    "PAGSEGURO",
+		MCC:     "5811",
+		Aliases: []string{"PAG*"},
+	}
+	dbFake.Merchants[3] = port.MerchantEntity{
+		Name:    "PADARIA DO ZE               SAO PAULO BR",
+		MCC:     "5411",
+		Aliases: []string{"PANIFICADORA ZE", "PAG*PADARIA*"},
+	}
+
+	return NewMerchantMatcher(threshold, newMerchantRepoFake(dbFake), auditRepo, newFakeLog())
+}
+
+func (suite *MerchantMatcherSuite) TestNormalizeMerchantName() {
+	assert.Equal(suite.T(), domain.NormalizeMerchantName("UBER EATS                   SAO PAULO BR"), "UBER EATS")
+	assert.Equal(suite.T(), domain.NormalizeMerchantName("  uber\teats "), "UBER EATS")
+	assert.Equal(suite.T(), domain.NormalizeMerchantName("PAG *JoseDaSilva          RIO DE JANEI BR"), "PAG*JOSEDASILVA")
+}
+
+func (suite *MerchantMatcherSuite) TestMatchRules() {
+	//Arrange
+	merchantMatcher := suite.newMerchantMatcher(0, &MerchantMatchAuditRepoFake{})
+
+	testCases := []struct {
+		name    string
+		rule    string
+		pattern string
+		mcc     string
+	}{
+		{"UBER EATS                   SAO PAULO BR", domain.MERCHANT_MATCH_EXACT, "UBER EATS                   SAO PAULO BR", "5412"},
+		{"Uber Eats      OSASCO BR", domain.MERCHANT_MATCH_NORMALIZED, "UBER EATS                   SAO PAULO BR", "5412"},
+		{"PANIFICADORA ZE             CAMPINAS BR", domain.MERCHANT_MATCH_ALIAS, "PANIFICADORA ZE", "5411"},
+		{"PAG*PadariaDoZe             SAO PAULO BR", domain.MERCHANT_MATCH_PREFIX, "PAG*PADARIA*", "5411"},
+		{"PAG*JoseDaSilva             RIO DE JANEI BR", domain.MERCHANT_MATCH_PREFIX, "PAG*", "5811"},
+		{"UBER EATZ                   SAO PAULO BR", "", "", ""},
+	}
+
+	for _, tc := range testCases {
+		//Act
+		merchant, err := merchantMatcher.Match(context.Background(), tc.name)
+
+		//Assert
+		assert.Equal(suite.T(), err, nil)
+		assert.Equal(suite.T(), merchant.MatchRule, tc.rule)
+		assert.Equal(suite.T(), merchant.MatchPattern, tc.pattern)
+		assert.Equal(suite.T(), merchant.MCC, tc.mcc)
+	}
+}
+
+func (suite *MerchantMatcherSuite) TestMatchBySimilarityThreshold() {
+	//Arrange
+	merchantMatcher := suite.newMerchantMatcher(0.85, &MerchantMatchAuditRepoFake{})
+
+	//Act
+	similar, errSimilar := merchantMatcher.Match(context.Background(), "UBER EATZ                   SAO PAULO BR")
+	distant, errDistant := merchantMatcher.Match(context.Background(), "UBER TRIP                   SAO PAULO BR")
+
+	//Assert
+	assert.Equal(suite.T(), errSimilar, nil)
+	assert.Equal(suite.T(), errDistant, nil)
+	assert.Equal(suite.T(), similar.MatchRule, domain.MERCHANT_MATCH_SIMILARITY)
+	assert.Equal(suite.T(), similar.MCC, "5412")
+	assert.Equal(suite.T(), distant.MatchRule, "")
+}
+
+func (suite *MerchantMatcherSuite) TestAuditRecordsOverride() {
+	//Arrange
+	auditRepo := &MerchantMatchAuditRepoFake{}
+	merchantMatcher := suite.newMerchantMatcher(0, auditRepo)
+
+	account := domain.Account{ID: 1, UID: accountUIDtoTransact}
+	transactionUID := uuid.New()
+
+	matched, _ := merchantMatcher.Match(context.Background(), "PAG*PadariaDoZe             SAO PAULO BR")
+	unmatched, _ := merchantMatcher.Match(context.Background(), "POSTO DO JOAO               SAO PAULO BR")
+
+	matchedTransaction := matched.NewTransaction(transactionUID, "5912", decimal.NewFromFloat(10), "BRL", "PAG*PadariaDoZe             SAO PAULO BR", account)
+	unmatchedTransaction := unmatched.NewTransaction(uuid.New(), "5912", decimal.NewFromFloat(10), "BRL", "POSTO DO JOAO               SAO PAULO BR", account)
+
+	//Act
+	merchantMatcher.Audit(context.Background(), "5912", matched, matchedTransaction)
+	merchantMatcher.Audit(context.Background(), "5912", unmatched, unmatchedTransaction)
+
+	//Assert
+	assert.Equal(suite.T(), len(auditRepo.audits), 1)
+	assert.Equal(suite.T(), auditRepo.audits[0].TransactionUID, transactionUID)
+	assert.Equal(suite.T(), auditRepo.audits[0].MatchedMerchant, "PADARIA DO ZE               SAO PAULO BR")
+	assert.Equal(suite.T(), auditRepo.audits[0].MatchRule, domain.MERCHANT_MATCH_PREFIX)
+	assert.Equal(suite.T(), auditRepo.audits[0].MatchPattern, "PAG*PADARIA*")
+	assert.Equal(suite.T(), auditRepo.audits[0].OriginalMCC, "5912")
+	assert.Equal(suite.T(), auditRepo.audits[0].MCC, "5411")
+}
+
+func TestMerchantMatcherSuite(t *testing.T) {
+	suite.Run(t, new(MerchantMatcherSuite))
+}
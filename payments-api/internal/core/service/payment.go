@@ -5,129 +5,308 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jtonynet/go-payments-api/internal/core/domain"
 	"github.com/jtonynet/go-payments-api/internal/core/port"
 	"github.com/jtonynet/go-payments-api/internal/support/logger"
 )
 
 type Payment struct {
-	timeoutSLA           port.TimeoutSLA
-	accountRepository    port.AccountRepository
-	merchantRepository   port.MerchantRepository
-	memoryLockRepository port.MemoryLockRepository
+	timeoutSLA                   port.TimeoutSLA
+	accountRepository            port.AccountRepository
+	cardRepository               port.CardRepository
+	merchantMatcher              *MerchantMatcher
+	riskStage                    port.RiskStage
+	exchangeRateRepository       port.ExchangeRateRepository
+	categoryRuleRepository       port.CategoryRuleRepository
+	spendingLimitRepository      port.SpendingLimitRepository
+	spendingUsageRepository      port.SpendingUsageRepository
+	transactionOutcomeRepository port.TransactionOutcomeRepository
+	memoryLockRepository         port.MemoryLockRepository
 
-	log               logger.Logger
-	transactionLocked port.MemoryLockEntity
+	log logger.Logger
 }
 
 func NewPayment(
 	timeoutSLA port.TimeoutSLA,
 
 	aRepository port.AccountRepository,
-	mRepository port.MerchantRepository,
+	cRepository port.CardRepository,
+	merchantMatcher *MerchantMatcher,
+	riskStage port.RiskStage,
+	erRepository port.ExchangeRateRepository,
+	crRepository port.CategoryRuleRepository,
+	slRepository port.SpendingLimitRepository,
+	suRepository port.SpendingUsageRepository,
+	toRepository port.TransactionOutcomeRepository,
 	mlRepository port.MemoryLockRepository,
 
 	log logger.Logger,
 ) *Payment {
 	return &Payment{
-		timeoutSLA:           timeoutSLA,
-		accountRepository:    aRepository,
-		merchantRepository:   mRepository,
-		memoryLockRepository: mlRepository,
+		timeoutSLA:                   timeoutSLA,
+		accountRepository:            aRepository,
+		cardRepository:               cRepository,
+		merchantMatcher:              merchantMatcher,
+		riskStage:                    riskStage,
+		exchangeRateRepository:       erRepository,
+		categoryRuleRepository:       crRepository,
+		spendingLimitRepository:      slRepository,
+		spendingUsageRepository:      suRepository,
+		transactionOutcomeRepository: toRepository,
+		memoryLockRepository:         mlRepository,
 
 		log: log,
 	}
 }
 
-func (p *Payment) Execute(tpr port.TransactionPaymentRequest) (string, error) {
+/*
+  - The response lists the categories tried to pay the transaction. A replayed
+    transaction only has its code, the outcome does not keep the categories
+*/
+func (p *Payment) Execute(tpr port.TransactionPaymentRequest) (port.TransactionPaymentResponse, error) {
+	attempts := []domain.CategoryAttempt{}
+
+	code, err := p.execute(tpr, &attempts)
+
+	return mapTransactionPaymentResponse(code, attempts), err
+}
+
+func (p *Payment) execute(tpr port.TransactionPaymentRequest, attempts *[]domain.CategoryAttempt) (string, error) {
 	ctx, cancel := context.WithTimeout(
 		context.Background(),
 		time.Duration(p.timeoutSLA),
 	)
 	ctx = context.WithValue(ctx, logger.CtxTransactionUIDKey, tpr.TransactionUID.String())
-	ctx = context.WithValue(ctx, logger.CtxAccountUIDKey, tpr.AccountUID.String())
 	defer cancel()
 
+	outcome := mapPaymentRequestToOutcomeEntity(tpr)
+
+	// The card resolves the account to lock, so no lock is held to release yet
+	cardEntity, err := findPaymentCard(ctx, p.cardRepository, &tpr)
+	if err != nil {
+		return p.declinedEventErr(ctx, outcome, err), err
+	}
+
+	outcome.AccountUID = tpr.AccountUID
+
+	ctx = context.WithValue(ctx, logger.CtxAccountUIDKey, tpr.AccountUID.String())
+
+	// The merchant catalog does not depend on the account, so it is matched before the lock
+	merchant, err := p.merchantMatcher.Match(ctx, tpr.Merchant)
+	if err != nil {
+		return p.declinedEventErr(ctx, outcome, err), err
+	}
+
 	transactionLocked, err := p.memoryLockRepository.Lock(
 		ctx,
 		mapTransactionRequestToMemoryLockEntity(tpr),
 	)
 	if err != nil {
-		return p.rejectedGenericErr(
+		return p.rejectedEventErr(
 			ctx,
+			transactionLocked,
+			outcome,
 			fmt.Errorf("failed concurrent transaction locked: %w", err),
 		)
 	}
-	p.transactionLocked = transactionLocked
+
+	outcomeEntity, err := p.transactionOutcomeRepository.FindByUID(ctx, tpr.TransactionUID)
+	if err != nil {
+		return p.rejectedEventErr(
+			ctx,
+			transactionLocked,
+			outcome,
+			fmt.Errorf("failed to retrieve transaction outcome: %w", err),
+		)
+	}
+
+	if outcomeEntity != nil {
+		return p.replayedOutcome(ctx, transactionLocked, tpr.AccountUID, *outcomeEntity)
+	}
+
+	currency, err := paymentCurrency(tpr)
+	if err != nil {
+		return p.rejectedEventErr(ctx, transactionLocked, outcome, err)
+	}
 
 	accountEntity, err := p.accountRepository.FindByUID(ctx, tpr.AccountUID)
 	if err != nil {
-		return p.rejectedGenericErr(
+		return p.rejectedEventErr(
 			ctx,
+			transactionLocked,
+			outcome,
 			fmt.Errorf("failed to retrieve account entity: %w", err),
 		)
 	}
 
+	if accountEntity.ID == 0 {
+		return p.rejectedEventErr(
+			ctx,
+			transactionLocked,
+			outcome,
+			fmt.Errorf("%w: %s", port.ErrAccountNotFound, tpr.AccountUID.String()),
+		)
+	}
+
 	account := mapAccountEntityToDomain(accountEntity, p.log)
+	outcome.AccountID = account.ID
 
-	var merchant domain.Merchant
-	merchantEntity, err := p.merchantRepository.FindByName(ctx, tpr.Merchant)
+	err = loadExchangeRates(ctx, p.exchangeRateRepository, &account, currency)
 	if err != nil {
-		return p.rejectedGenericErr(
-			ctx,
-			fmt.Errorf("failed to retrieve merchant entity with name %s", tpr.Merchant),
-		)
+		return p.rejectedEventErr(ctx, transactionLocked, outcome, err)
 	}
 
-	if merchantEntity != nil {
-		merchant = mapMerchantEntityToDomain(merchantEntity)
+	err = loadCategoryRules(ctx, p.categoryRuleRepository, &account)
+	if err != nil {
+		return p.rejectedEventErr(ctx, transactionLocked, outcome, err)
+	}
+
+	now := time.Now()
+	err = loadSpendingLimits(ctx, p.spendingLimitRepository, p.spendingUsageRepository, p.exchangeRateRepository, &account, now)
+	if err != nil {
+		return p.rejectedEventErr(ctx, transactionLocked, outcome, err)
+	}
+
+	if cardEntity != nil {
+		err = loadCard(ctx, p.cardRepository, *cardEntity, &account, now)
+		if err != nil {
+			return p.rejectedEventErr(ctx, transactionLocked, outcome, err)
+		}
 	}
 
 	transaction := merchant.NewTransaction(
 		tpr.TransactionUID,
 		tpr.MCC,
 		tpr.TotalAmount,
+		currency,
 		tpr.Merchant,
 		account,
 	)
 
-	approvedTransactions, cErr := account.ApproveTransaction(ctx, transaction)
+	p.merchantMatcher.Audit(ctx, tpr.MCC, merchant, transaction)
+
+	cErr := account.CheckDebitAllowed(now)
+	if cErr != nil {
+		p.saveOutcome(ctx, mapTransactionOutcomeToEntity(transaction, account, cErr.Code))
+		return p.rejectedCustomErr(ctx, transactionLocked, cErr)
+	}
+
+	cErr, err = assessRisk(ctx, p.riskStage, transaction, p.log)
+	if err != nil {
+		return p.rejectedEventErr(ctx, transactionLocked, outcome, err)
+	}
+
 	if cErr != nil {
-		return p.rejectedCustomErr(ctx, cErr)
+		p.saveOutcome(ctx, mapTransactionOutcomeToEntity(transaction, account, cErr.Code))
+		return p.rejectedCustomErr(ctx, transactionLocked, cErr)
+	}
+
+	approvedTransactions, categoryAttempts, cErr := account.ApproveTransaction(ctx, transaction)
+	*attempts = categoryAttempts
+	if cErr != nil {
+		p.saveOutcome(ctx, mapTransactionOutcomeToEntity(transaction, account, cErr.Code))
+		return p.rejectedCustomErr(ctx, transactionLocked, cErr)
 	}
 
 	err = p.accountRepository.SaveTransactions(
 		ctx,
 		mapTransactionDomainsToEntities(approvedTransactions),
+		transactionLocked.FencingToken,
 	)
 	if err != nil {
-		return p.rejectedGenericErr(
+		return p.rejectedEventErr(
 			ctx,
+			transactionLocked,
+			outcome,
 			fmt.Errorf("failed to save transaction entity: %w", err),
 		)
 	}
 
-	_ = p.memoryLockRepository.Unlock(ctx, p.transactionLocked.Key)
+	spending, cErr := account.SpendingByCategory(approvedTransactions)
+	recordSpendingUsage(ctx, p.spendingUsageRepository, account.ID, spending, cErr, now, p.log)
+
+	p.saveOutcome(ctx, mapTransactionOutcomeToEntity(transaction, account, domain.CODE_APPROVED))
+
+	_ = p.memoryLockRepository.Unlock(ctx, transactionLocked)
 
 	return domain.CODE_APPROVED, nil
 }
 
-func (p *Payment) rejectedGenericErr(ctx context.Context, err error) (string, error) {
+/*
+  - A failure here is only logged: the posted transactions are already committed
+    and the unique (uid, category_id) constraint rejects a replay that misses the outcome
+*/
+func (p *Payment) saveOutcome(ctx context.Context, outcome port.TransactionOutcomeEntity) {
+	err := p.transactionOutcomeRepository.Save(ctx, outcome)
+	if err != nil {
+		p.log.Error(ctx, fmt.Sprintf("failed to save transaction outcome: %s", err.Error()))
+	}
+}
+
+func (p *Payment) saveDeclinedEvent(ctx context.Context, outcome port.TransactionOutcomeEntity) {
+	err := p.transactionOutcomeRepository.SaveDeclinedEvent(ctx, outcome)
+	if err != nil {
+		p.log.Error(ctx, fmt.Sprintf("failed to save declined transaction event: %s", err.Error()))
+	}
+}
+
+func (p *Payment) replayedOutcome(
+	ctx context.Context,
+	transactionLocked port.MemoryLockEntity,
+	accountUID uuid.UUID,
+	outcome port.TransactionOutcomeEntity,
+) (string, error) {
+	code, err := replayTransactionOutcome(accountUID, outcome)
+	if err != nil {
+		p.log.Warn(ctx, err.Error())
+	} else {
+		p.log.Info(ctx, fmt.Sprintf("transaction already processed, replaying code %s", code))
+	}
+
+	_ = p.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return code, err
+}
+
+func (p *Payment) rejectedEventErr(
+	ctx context.Context,
+	transactionLocked port.MemoryLockEntity,
+	outcome port.TransactionOutcomeEntity,
+	err error,
+) (string, error) {
+	code := p.declinedEventErr(ctx, outcome, err)
+
+	_ = p.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return code, err
+}
+
+/*
+  - Reports the rejection by err with its declined event only, without an outcome:
+    a timeout or a failure is not a decision, so a retry runs the transaction again
+  - The event is written even when ctx is done. A duplicate reports nothing, its
+    transaction was already reported
+*/
+func (p *Payment) declinedEventErr(ctx context.Context, outcome port.TransactionOutcomeEntity, err error) string {
 	p.log.Error(ctx, err.Error())
 
-	_ = p.memoryLockRepository.Unlock(ctx, p.transactionLocked.Key)
+	outcome.Code = rejectionCode(err)
+	if outcome.Code != domain.CODE_REJECTED_DUPLICATE_TRANSACTION {
+		p.saveDeclinedEvent(context.WithoutCancel(ctx), outcome)
+	}
 
-	return domain.CODE_REJECTED_GENERIC, err
+	return outcome.Code
 }
 
-func (p *Payment) rejectedCustomErr(ctx context.Context, cErr *domain.CustomError) (string, error) {
+func (p *Payment) rejectedCustomErr(ctx context.Context, transactionLocked port.MemoryLockEntity, cErr *domain.CustomError) (string, error) {
 	if cErr.Code == domain.CODE_REJECTED_GENERIC {
 		p.log.Error(ctx, cErr.Error())
 	} else {
 		p.log.Warn(ctx, cErr.Error())
 	}
 
-	_ = p.memoryLockRepository.Unlock(ctx, p.transactionLocked.Key)
+	_ = p.memoryLockRepository.Unlock(ctx, transactionLocked)
 
 	return cErr.Code, fmt.Errorf("failed to approve transaction: %s", cErr.Message)
 }
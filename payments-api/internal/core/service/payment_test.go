@@ -2,8 +2,8 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strconv"
 	"testing"
 	"time"
 
@@ -21,6 +21,7 @@ var (
 	timeoutSLAcfg = 100
 
 	accountUIDtoTransact, _ = uuid.Parse("123e4567-e89b-12d3-a456-426614174000")
+	cardTokenToTransact     = "tok_4f9a2c7e1b3d5a60"
 
 	balanceFoodAmount = decimal.NewFromFloat(205.11)
 	foodCategoryID    = uint(1)
@@ -50,15 +51,37 @@ func (fl FakeLog) Warn(ctx context.Context, msg string, args ...interface{})  {}
 func (fl FakeLog) Error(ctx context.Context, msg string, args ...interface{}) {}
 
 type DBfake struct {
-	Accounts     map[uint]port.AccountEntity
-	Transactions map[uint]port.TransactionEntity
-	Merchants    map[uint]port.MerchantEntity
+	Accounts             map[uint]port.AccountEntity
+	Transactions         map[uint]port.TransactionEntity
+	TransactionsCaptured map[uuid.UUID]map[int]port.TransactionCapturedEntity
+	Holds                map[uuid.UUID]map[int]port.HoldEntity
+	Outcomes             map[uuid.UUID]port.TransactionOutcomeEntity
+	DeclinedEvents       map[uuid.UUID]port.TransactionOutcomeEntity
+	History              []port.TransactionHistoryEntity
+	Merchants            map[uint]port.MerchantEntity
+	FencingTokens        map[uint]int64
+	ExchangeRates        map[string]decimal.Decimal
+	CategoryRules        []port.CategoryRuleEntity
+	SpendingLimits       []port.SpendingLimitEntity
+	SpendingUsages       map[uint]port.SpendingUsageEntity
+	FraudRules           []port.FraudRuleEntity
+	FraudPayments        []port.FraudPaymentEntity
+	Cards                []port.CardEntity
+	CardUsages           map[uint]decimal.Decimal
 }
 
 func newDBfake() DBfake {
 	db := DBfake{}
 
 	db.Transactions = make(map[uint]port.TransactionEntity)
+	db.TransactionsCaptured = make(map[uuid.UUID]map[int]port.TransactionCapturedEntity)
+	db.Holds = make(map[uuid.UUID]map[int]port.HoldEntity)
+	db.Outcomes = make(map[uuid.UUID]port.TransactionOutcomeEntity)
+	db.DeclinedEvents = make(map[uuid.UUID]port.TransactionOutcomeEntity)
+	db.FencingTokens = make(map[uint]int64)
+	db.ExchangeRates = make(map[string]decimal.Decimal)
+	db.SpendingUsages = make(map[uint]port.SpendingUsageEntity)
+	db.CardUsages = make(map[uint]decimal.Decimal)
 
 	categories := make(map[int]port.TransactionByCategoryEntity)
 	foodCategoryUID, _ := uuid.Parse("32e04519-a979-4de2-a20e-77e8342d718f")
@@ -117,7 +140,40 @@ func (dbf *DBfake) AccountRepoFindByUID(_ context.Context, uid uuid.UUID) (port.
 		}
 	}
 
-	return port.AccountEntity{}, fmt.Errorf("account with AccountUID %s not found", uid.String())
+	return port.AccountEntity{}, nil
+}
+
+func (dbf *DBfake) AccountRepoFindTransactionsByUID(_ context.Context, uid uuid.UUID) (map[int]port.TransactionCapturedEntity, error) {
+	if transactionsCaptured, ok := dbf.TransactionsCaptured[uid]; ok {
+		return transactionsCaptured, nil
+	}
+
+	return make(map[int]port.TransactionCapturedEntity), nil
+}
+
+/*
+- History is kept from the newest to the oldest transaction, as the repository returns it
+*/
+func (dbf *DBfake) AccountRepoFindTransactionsByAccountUID(_ context.Context, filter port.TransactionHistoryFilterEntity) ([]port.TransactionHistoryEntity, error) {
+	transactions := []port.TransactionHistoryEntity{}
+
+	for _, th := range dbf.History {
+		if filter.CursorID > 0 && th.ID >= filter.CursorID {
+			continue
+		}
+
+		if filter.MCC != "" && th.MCC != filter.MCC {
+			continue
+		}
+
+		if len(transactions) == filter.Limit {
+			break
+		}
+
+		transactions = append(transactions, th)
+	}
+
+	return transactions, nil
 }
 
 type AccountRepoFake struct {
@@ -135,7 +191,25 @@ func (arf *AccountRepoFake) FindByUID(_ context.Context, uid uuid.UUID) (port.Ac
 	return accountEntity, err
 }
 
-func (arf *AccountRepoFake) SaveTransactions(_ context.Context, transactions map[int]port.TransactionEntity) error {
+func (arf *AccountRepoFake) FindTransactionsByUID(_ context.Context, uid uuid.UUID) (map[int]port.TransactionCapturedEntity, error) {
+	return arf.db.AccountRepoFindTransactionsByUID(context.Background(), uid)
+}
+
+func (arf *AccountRepoFake) FindTransactionsByAccountUID(_ context.Context, filter port.TransactionHistoryFilterEntity) ([]port.TransactionHistoryEntity, error) {
+	return arf.db.AccountRepoFindTransactionsByAccountUID(context.Background(), filter)
+}
+
+func (arf *AccountRepoFake) SaveTransactions(_ context.Context, transactions map[int]port.TransactionEntity, fencingToken int64) error {
+	for _, t := range transactions {
+		if fencingToken < arf.db.FencingTokens[t.AccountID] {
+			return fmt.Errorf("%w: %d on account %d", port.ErrStaleFencingToken, fencingToken, t.AccountID)
+		}
+	}
+
+	for _, t := range transactions {
+		arf.db.FencingTokens[t.AccountID] = fencingToken
+	}
+
 	maxID := uint(1)
 
 	for _, t := range transactions {
@@ -147,6 +221,18 @@ func (arf *AccountRepoFake) SaveTransactions(_ context.Context, transactions map
 			MCC:          t.MCC,
 			MerchantName: t.MerchantName,
 			CategoryID:   t.CategoryID,
+			OriginalUID:  t.OriginalUID,
+			CardID:       t.CardID,
+
+			Currency:         t.Currency,
+			OriginalAmount:   t.OriginalAmount,
+			OriginalCurrency: t.OriginalCurrency,
+			ExchangeRate:     t.ExchangeRate,
+
+			Operation:     t.Operation,
+			EntryType:     t.EntryType,
+			BalanceBefore: t.BalanceBefore,
+			BalanceAfter:  t.BalanceAfter,
 		}
 
 		maxID = maxID + 1
@@ -170,6 +256,32 @@ func (m *MerchantRepoFake) FindByName(_ context.Context, name string) (*port.Mer
 	return MerchantEntity, err
 }
 
+func (m *MerchantRepoFake) FindAll(_ context.Context) ([]port.MerchantEntity, error) {
+	merchants := []port.MerchantEntity{}
+	for _, merchant := range m.db.Merchants {
+		merchants = append(merchants, merchant)
+	}
+
+	return merchants, nil
+}
+
+type MerchantMatchAuditRepoFake struct {
+	audits []port.MerchantMatchAuditEntity
+}
+
+func (marf *MerchantMatchAuditRepoFake) Save(_ context.Context, audit port.MerchantMatchAuditEntity) error {
+	marf.audits = append(marf.audits, audit)
+	return nil
+}
+
+func newMerchantMatcherFake(mRepository port.MerchantRepository) *MerchantMatcher {
+	return NewMerchantMatcher(0, mRepository, &MerchantMatchAuditRepoFake{}, newFakeLog())
+}
+
+func newFraudRulesFake(frRepository port.FraudRuleRepository, fhRepository port.FraudHistoryRepository) *FraudRules {
+	return NewFraudRules(0, frRepository, fhRepository, newFakeLog())
+}
+
 func (dbf *DBfake) MerchantRepoFindByName(Name string) (*port.MerchantEntity, error) {
 	for _, m := range dbf.Merchants {
 		if m.Name == Name {
@@ -180,13 +292,294 @@ func (dbf *DBfake) MerchantRepoFindByName(Name string) (*port.MerchantEntity, er
 	return nil, nil
 }
 
+type ExchangeRateRepoFake struct {
+	db DBfake
+}
+
+func newExchangeRateRepoFake(db DBfake) port.ExchangeRateRepository {
+	return &ExchangeRateRepoFake{
+		db,
+	}
+}
+
+func (errf *ExchangeRateRepoFake) FindRate(_ context.Context, fromCurrency, toCurrency string) (port.ExchangeRateEntity, error) {
+	rate, ok := errf.db.ExchangeRates[fromCurrency+toCurrency]
+	if !ok {
+		return port.ExchangeRateEntity{}, fmt.Errorf("%w: from %s to %s", port.ErrExchangeRateNotFound, fromCurrency, toCurrency)
+	}
+
+	return port.ExchangeRateEntity{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		Rate:         rate,
+	}, nil
+}
+
+type CategoryRuleRepoFake struct {
+	db DBfake
+}
+
+func newCategoryRuleRepoFake(db DBfake) port.CategoryRuleRepository {
+	return &CategoryRuleRepoFake{
+		db,
+	}
+}
+
+func (crrf *CategoryRuleRepoFake) FindByAccountID(_ context.Context, accountID uint) ([]port.CategoryRuleEntity, error) {
+	ruleEntities := []port.CategoryRuleEntity{}
+	for _, rule := range crrf.db.CategoryRules {
+		if rule.AccountID == 0 || rule.AccountID == accountID {
+			ruleEntities = append(ruleEntities, rule)
+		}
+	}
+
+	return ruleEntities, nil
+}
+
+func (crrf *CategoryRuleRepoFake) SaveChain(_ context.Context, chain port.CategoryRuleChainEntity) (port.CategoryRuleChainEntity, error) {
+	return chain, nil
+}
+
+func (crrf *CategoryRuleRepoFake) FindChains(_ context.Context, _ uuid.UUID) ([]port.CategoryRuleChainEntity, error) {
+	return []port.CategoryRuleChainEntity{}, nil
+}
+
+type SpendingLimitRepoFake struct {
+	db DBfake
+}
+
+func newSpendingLimitRepoFake(db DBfake) port.SpendingLimitRepository {
+	return &SpendingLimitRepoFake{
+		db,
+	}
+}
+
+func (slrf *SpendingLimitRepoFake) FindByAccountID(_ context.Context, accountID uint) ([]port.SpendingLimitEntity, error) {
+	slEntities := []port.SpendingLimitEntity{}
+	for _, limit := range slrf.db.SpendingLimits {
+		if limit.AccountID == accountID {
+			slEntities = append(slEntities, limit)
+		}
+	}
+
+	return slEntities, nil
+}
+
+func (slrf *SpendingLimitRepoFake) FindByAccountUID(_ context.Context, accountUID uuid.UUID) ([]port.SpendingLimitEntity, error) {
+	slEntities := []port.SpendingLimitEntity{}
+	for _, limit := range slrf.db.SpendingLimits {
+		if limit.AccountUID == accountUID {
+			slEntities = append(slEntities, limit)
+		}
+	}
+
+	return slEntities, nil
+}
+
+func (slrf *SpendingLimitRepoFake) Save(_ context.Context, limit port.SpendingLimitEntity) (port.SpendingLimitEntity, error) {
+	return limit, nil
+}
+
+/*
+- Usage by category ID, increased by the recorded transactions as the cached counters
+*/
+type SpendingUsageRepoFake struct {
+	db DBfake
+}
+
+func newSpendingUsageRepoFake(db DBfake) port.SpendingUsageRepository {
+	return &SpendingUsageRepoFake{
+		db,
+	}
+}
+
+func (surf *SpendingUsageRepoFake) FindUsage(_ context.Context, accountID, categoryID uint, at time.Time) (port.SpendingUsageEntity, error) {
+	usage := surf.db.SpendingUsages[categoryID]
+	usage.AccountID = accountID
+	usage.CategoryID = categoryID
+	usage.At = at
+
+	return usage, nil
+}
+
+func (surf *SpendingUsageRepoFake) AddUsage(_ context.Context, usage port.SpendingUsageEntity) error {
+	current := surf.db.SpendingUsages[usage.CategoryID]
+	current.DailyAmount = current.DailyAmount.Add(usage.DailyAmount)
+	current.MonthlyAmount = current.MonthlyAmount.Add(usage.MonthlyAmount)
+	current.HourlyTransactions += usage.HourlyTransactions
+	surf.db.SpendingUsages[usage.CategoryID] = current
+
+	return nil
+}
+
+func (surf *SpendingUsageRepoFake) ResetUsage(_ context.Context, _, _ uint, _ time.Time) error {
+	return nil
+}
+
+/*
+- Cards kept by the fake, pointing to its DBfake so the tests can register them after arranging
+*/
+type CardRepoFake struct {
+	db *DBfake
+}
+
+func newCardRepoFake(db *DBfake) port.CardRepository {
+	return &CardRepoFake{
+		db,
+	}
+}
+
+func (crf *CardRepoFake) FindByToken(_ context.Context, token string) (port.CardEntity, error) {
+	for _, card := range crf.db.Cards {
+		if card.Token == token {
+			return card, nil
+		}
+	}
+
+	return port.CardEntity{}, port.ErrCardNotFound
+}
+
+func (crf *CardRepoFake) FindByUID(_ context.Context, uid uuid.UUID) (port.CardEntity, error) {
+	for _, card := range crf.db.Cards {
+		if card.UID == uid {
+			return card, nil
+		}
+	}
+
+	return port.CardEntity{}, fmt.Errorf("%w: %s", port.ErrCardNotFound, uid)
+}
+
+func (crf *CardRepoFake) FindByAccountUID(_ context.Context, accountUID uuid.UUID) ([]port.CardEntity, error) {
+	cEntities := []port.CardEntity{}
+	for _, card := range crf.db.Cards {
+		if card.AccountUID == accountUID {
+			cEntities = append(cEntities, card)
+		}
+	}
+
+	return cEntities, nil
+}
+
+func (crf *CardRepoFake) FindDailyUsage(_ context.Context, cardID uint, _ time.Time) (decimal.Decimal, error) {
+	return crf.db.CardUsages[cardID], nil
+}
+
+func (crf *CardRepoFake) Create(_ context.Context, card port.CardEntity) (port.CardEntity, error) {
+	for _, existing := range crf.db.Cards {
+		if existing.Token == card.Token {
+			return port.CardEntity{}, port.ErrCardTokenTaken
+		}
+	}
+
+	card.ID = uint(len(crf.db.Cards) + 1)
+	card.CreatedAt = time.Now()
+	crf.db.Cards = append(crf.db.Cards, card)
+
+	return card, nil
+}
+
+func (crf *CardRepoFake) Update(_ context.Context, card port.CardEntity) (port.CardEntity, error) {
+	for i, existing := range crf.db.Cards {
+		if existing.ID == card.ID {
+			crf.db.Cards[i] = card
+			return card, nil
+		}
+	}
+
+	return port.CardEntity{}, fmt.Errorf("%w: %s", port.ErrCardNotFound, card.UID)
+}
+
+type FraudRuleRepoFake struct {
+	db DBfake
+}
+
+func newFraudRuleRepoFake(db DBfake) port.FraudRuleRepository {
+	return &FraudRuleRepoFake{
+		db,
+	}
+}
+
+func (frrf *FraudRuleRepoFake) FindEnabled(_ context.Context) ([]port.FraudRuleEntity, error) {
+	return frrf.db.FraudRules, nil
+}
+
+/*
+- Payments of every account, the fake holds a single account history
+*/
+type FraudHistoryRepoFake struct {
+	db DBfake
+}
+
+func newFraudHistoryRepoFake(db DBfake) port.FraudHistoryRepository {
+	return &FraudHistoryRepoFake{
+		db,
+	}
+}
+
+func (fhrf *FraudHistoryRepoFake) FindPayments(_ context.Context, _ uint, since time.Time) ([]port.FraudPaymentEntity, error) {
+	payments := []port.FraudPaymentEntity{}
+	for _, payment := range fhrf.db.FraudPayments {
+		if !payment.CreatedAt.Before(since) {
+			payments = append(payments, payment)
+		}
+	}
+
+	return payments, nil
+}
+
+func (fhrf *FraudHistoryRepoFake) MerchantSeen(_ context.Context, _ uint, merchantName string) (bool, error) {
+	for _, payment := range fhrf.db.FraudPayments {
+		if payment.MerchantName == merchantName {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+type TransactionOutcomeRepoFake struct {
+	db DBfake
+}
+
+func newTransactionOutcomeRepoFake(db DBfake) port.TransactionOutcomeRepository {
+	return &TransactionOutcomeRepoFake{
+		db,
+	}
+}
+
+func (torf *TransactionOutcomeRepoFake) FindByUID(_ context.Context, uid uuid.UUID) (*port.TransactionOutcomeEntity, error) {
+	if outcome, ok := torf.db.Outcomes[uid]; ok {
+		return &outcome, nil
+	}
+
+	return nil, nil
+}
+
+func (torf *TransactionOutcomeRepoFake) Save(_ context.Context, outcome port.TransactionOutcomeEntity) error {
+	if _, ok := torf.db.Outcomes[outcome.UID]; ok {
+		return fmt.Errorf("transaction outcome %s already exists", outcome.UID.String())
+	}
+
+	torf.db.Outcomes[outcome.UID] = outcome
+	return nil
+}
+
+func (torf *TransactionOutcomeRepoFake) SaveDeclinedEvent(_ context.Context, outcome port.TransactionOutcomeEntity) error {
+	torf.db.DeclinedEvents[outcome.UID] = outcome
+	return nil
+}
+
 type InMemoryDBfake struct {
-	Lock map[string]string
+	Lock          map[string]string
+	FencingTokens map[string]int64
+	Waiters       map[string]int64
 }
 
 func newInMemoryDBfake() InMemoryDBfake {
 	imdbf := InMemoryDBfake{}
 	imdbf.Lock = make(map[string]string)
+	imdbf.FencingTokens = make(map[string]int64)
+	imdbf.Waiters = make(map[string]int64)
 
 	return imdbf
 }
@@ -197,15 +590,20 @@ func (suite *PaymentSuite) getInMemoryDBfake() InMemoryDBfake {
 }
 
 func (imdbf *InMemoryDBfake) MemoryLockRepoLock(_ context.Context, mle port.MemoryLockEntity) (port.MemoryLockEntity, error) {
-	imdbf.Lock[mle.Key] = strconv.FormatInt(mle.Timestamp, 10)
+	imdbf.Lock[mle.Key] = mle.Transcation
+	imdbf.FencingTokens[mle.Key]++
+
+	mle.FencingToken = imdbf.FencingTokens[mle.Key]
 	return mle, nil
 }
 
-func (imdbf *InMemoryDBfake) MemoryLockRepoUnlock(_ context.Context, key string) error {
-	if _, exists := imdbf.Lock[key]; exists {
-		delete(imdbf.Lock, key)
-		return nil
+func (imdbf *InMemoryDBfake) MemoryLockRepoUnlock(_ context.Context, mle port.MemoryLockEntity) error {
+	owner, exists := imdbf.Lock[mle.Key]
+	if !exists || owner != mle.Transcation {
+		return fmt.Errorf("%w: %s on key %s", port.ErrMemoryLockNotOwned, mle.Transcation, mle.Key)
 	}
+
+	delete(imdbf.Lock, mle.Key)
 	return nil
 }
 
@@ -223,8 +621,12 @@ func (m *MemoryLockRepoFake) Lock(_ context.Context, mle port.MemoryLockEntity)
 	return m.memoryDB.MemoryLockRepoLock(context.Background(), mle)
 }
 
-func (m *MemoryLockRepoFake) Unlock(_ context.Context, key string) error {
-	return m.memoryDB.MemoryLockRepoUnlock(context.Background(), key)
+func (m *MemoryLockRepoFake) QueueDepth(_ context.Context, key string) (int64, error) {
+	return m.memoryDB.Waiters[key], nil
+}
+
+func (m *MemoryLockRepoFake) Unlock(_ context.Context, mle port.MemoryLockEntity) error {
+	return m.memoryDB.MemoryLockRepoUnlock(context.Background(), mle)
 }
 
 type PaymentSuite struct {
@@ -236,6 +638,17 @@ func (suite *PaymentSuite) getDBfake() *DBfake {
 	return &dbFake
 }
 
+/*
+- Lock whose release is never published, as a lock held past the timeout SLA
+*/
+type TimeoutMemoryLockRepoFake struct {
+	MemoryLockRepoFake
+}
+
+func (tmlrf *TimeoutMemoryLockRepoFake) Lock(_ context.Context, mle port.MemoryLockEntity) (port.MemoryLockEntity, error) {
+	return port.MemoryLockEntity{}, fmt.Errorf("%w on key: %s", port.ErrMemoryLockTimeout, mle.Key)
+}
+
 func (suite *PaymentSuite) getMemoryLockRepoFake(memoryDB InMemoryDBfake) port.MemoryLockRepository {
 	return newMemoryLockRepoFake(memoryDB)
 }
@@ -244,43 +657,71 @@ func (suite *PaymentSuite) getAllRepositories(dbFake *DBfake) *repository.AllRep
 	allRepos := repository.AllRepos{}
 	allRepos.Account = newAccountRepoFake(*dbFake)
 	allRepos.Merchant = newMerchantRepoFake(*dbFake)
+	allRepos.ExchangeRate = newExchangeRateRepoFake(*dbFake)
+	allRepos.CategoryRule = newCategoryRuleRepoFake(*dbFake)
+	allRepos.SpendingLimit = newSpendingLimitRepoFake(*dbFake)
+	allRepos.SpendingUsage = newSpendingUsageRepoFake(*dbFake)
+	allRepos.Card = newCardRepoFake(dbFake)
+	allRepos.FraudRule = newFraudRuleRepoFake(*dbFake)
+	allRepos.FraudHistory = newFraudHistoryRepoFake(*dbFake)
+	allRepos.TransactionOutcome = newTransactionOutcomeRepoFake(*dbFake)
 
 	return &allRepos
 }
 
-func (suite *PaymentSuite) TestL1PaymentExecuteGenericRejected() {
+func (suite *PaymentSuite) TestL1PaymentExecuteInvalidAccountRejected() {
 	//Arrange
 	timeoutSLA := port.TimeoutSLA(
 		time.Duration(timeoutSLAcfg) * time.Millisecond,
 	)
 
-	dbFake := DBfake{}
+	dbFake := DBfake{
+		Outcomes:       make(map[uuid.UUID]port.TransactionOutcomeEntity),
+		DeclinedEvents: make(map[uuid.UUID]port.TransactionOutcomeEntity),
+	}
 	allRepos := suite.getAllRepositories(&dbFake)
 
 	inMemoryDBfake := suite.getInMemoryDBfake()
 	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
 
 	tRequest := port.TransactionPaymentRequest{
-		AccountUID:  accountUIDtoTransact,
-		TotalAmount: amountFoodFundsApproved,
-		MCC:         correctFoodMCC,
-		Merchant:    "PADARIA DO ZE               SAO PAULO BR",
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
 	}
 
 	//Act
 	paymentService := NewPayment(
 		timeoutSLA,
 		allRepos.Account,
-		allRepos.Merchant,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
 		memoryLockRepo,
 		newFakeLog(),
 	)
 
-	returnCode, _ := paymentService.Execute(tRequest)
+	response, _ := paymentService.Execute(tRequest)
 
 	//Assert
-	codeRejected := "07" // domain.CODE_REJECTED_GENERIC
-	assert.Equal(suite.T(), returnCode, codeRejected)
+	codeRejected := "14" // domain.CODE_REJECTED_INVALID_ACCOUNT
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.Equal(suite.T(), response.Reason, "INVALID_ACCOUNT")
+
+	assert.Equal(suite.T(), len(dbFake.Outcomes), 0)
+
+	declined := dbFake.DeclinedEvents[tRequest.TransactionUID]
+	assert.Equal(suite.T(), declined.Code, codeRejected)
+	assert.Equal(suite.T(), declined.AccountID, uint(0))
+	assert.Equal(suite.T(), declined.AccountUID, accountUIDtoTransact)
+	assert.Equal(suite.T(), declined.Amount.String(), amountFoodFundsApproved.String())
 }
 
 func (suite *PaymentSuite) TestL1PaymentExecuteCorrectMCCWithFundsRejected() {
@@ -306,17 +747,24 @@ func (suite *PaymentSuite) TestL1PaymentExecuteCorrectMCCWithFundsRejected() {
 	paymentService := NewPayment(
 		timeoutSLA,
 		allRepos.Account,
-		allRepos.Merchant,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
 		memoryLockRepo,
 		newFakeLog(),
 	)
 
-	returnCode, err := paymentService.Execute(tRequest)
+	response, err := paymentService.Execute(tRequest)
 	assert.NotEqual(suite.T(), err, nil)
 
 	//Assert
 	codeRejected := "51" // domain.CODE_REJECTED_INSUFICIENT_FUNDS
-	assert.Equal(suite.T(), returnCode, codeRejected)
+	assert.Equal(suite.T(), response.Code, codeRejected)
 
 }
 
@@ -343,20 +791,30 @@ func (suite *PaymentSuite) TestL1PaymentExecuteCorrectMCCWithFundsApproved() {
 	paymentService := NewPayment(
 		timeoutSLA,
 		allRepos.Account,
-		allRepos.Merchant,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
 		memoryLockRepo,
 		newFakeLog(),
 	)
-	returnCode, err := paymentService.Execute(tRequest)
+	response, err := paymentService.Execute(tRequest)
 
 	//Assert
 	codeApproved := "00" // domain.CODE_APPROVED
 
-	assert.Equal(suite.T(), returnCode, codeApproved)
+	assert.Equal(suite.T(), response.Code, codeApproved)
+	assert.Equal(suite.T(), response.Reason, "APPROVED")
 	assert.Equal(suite.T(), err, nil)
 
 	foodTransaction, err := getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: foodCategoryID})
-	assert.Equal(suite.T(), foodTransaction.Amount, decimal.NewFromFloat(105.01))
+	assert.Equal(suite.T(), foodTransaction.BalanceAfter, decimal.NewFromFloat(105.01))
+	assert.Equal(suite.T(), foodTransaction.Amount, amountFoodFundsApproved)
+	assert.Equal(suite.T(), foodTransaction.EntryType, "DEBIT") // domain.TRANSACTION_ENTRY_DEBIT
 	assert.Equal(suite.T(), err, nil)
 }
 
@@ -383,25 +841,34 @@ func (suite *PaymentSuite) TestL2PaymentExecuteCorrectMCCFallbackApproved() {
 	paymentService := NewPayment(
 		timeoutSLA,
 		allRepos.Account,
-		allRepos.Merchant,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
 		memoryLockRepo,
 		newFakeLog(),
 	)
-	returnCode, err := paymentService.Execute(tRequest)
+	response, err := paymentService.Execute(tRequest)
 
 	//Assert
 	codeApproved := "00" // domain.CODE_APPROVED
 
-	assert.Equal(suite.T(), returnCode, codeApproved)
+	assert.Equal(suite.T(), response.Code, codeApproved)
 	assert.Equal(suite.T(), err, nil)
 
 	foodTransaction, _ := getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: foodCategoryID})
 	assert.Equal(suite.T(), err, nil)
-	assert.Equal(suite.T(), foodTransaction.Amount, decimal.NewFromFloat(0))
+	assert.Equal(suite.T(), foodTransaction.BalanceAfter, decimal.NewFromFloat(0))
+	assert.Equal(suite.T(), foodTransaction.Amount, balanceFoodAmount)
 
 	cashTransaction, _ := getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: cashCategoryID})
 	assert.Equal(suite.T(), err, nil)
-	assert.Equal(suite.T(), cashTransaction.Amount, decimal.NewFromFloat(90.22))
+	assert.Equal(suite.T(), cashTransaction.BalanceAfter, decimal.NewFromFloat(90.22))
+	assert.Equal(suite.T(), cashTransaction.Amount.String(), "114.89")
 }
 
 func (suite *PaymentSuite) TestL3PaymentExecuteNameMCCWithFundsApproved() {
@@ -427,20 +894,29 @@ func (suite *PaymentSuite) TestL3PaymentExecuteNameMCCWithFundsApproved() {
 	paymentService := NewPayment(
 		timeoutSLA,
 		allRepos.Account,
-		allRepos.Merchant,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
 		memoryLockRepo,
 		newFakeLog(),
 	)
-	returnCode, err := paymentService.Execute(tRequest)
+	response, err := paymentService.Execute(tRequest)
 
 	//Assert
 	codeApproved := "00" // domain.CODE_APPROVED
 
-	assert.Equal(suite.T(), returnCode, codeApproved)
+	assert.Equal(suite.T(), response.Code, codeApproved)
 	assert.Equal(suite.T(), err, nil)
 
 	foodTransaction, err := getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: foodCategoryID})
-	assert.Equal(suite.T(), foodTransaction.Amount, decimal.NewFromFloat(105.01))
+	assert.Equal(suite.T(), foodTransaction.BalanceAfter, decimal.NewFromFloat(105.01))
+	assert.Equal(suite.T(), foodTransaction.Amount, amountFoodFundsApproved)
+	assert.Equal(suite.T(), foodTransaction.EntryType, "DEBIT") // domain.TRANSACTION_ENTRY_DEBIT
 	assert.Equal(suite.T(), err, nil)
 }
 
@@ -467,25 +943,1897 @@ func (suite *PaymentSuite) TestL3PaymentExecuteNameMCCFallbackApproved() {
 	paymentService := NewPayment(
 		timeoutSLA,
 		allRepos.Account,
-		allRepos.Merchant,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
 		memoryLockRepo,
 		newFakeLog(),
 	)
-	returnCode, err := paymentService.Execute(tRequest)
+	response, err := paymentService.Execute(tRequest)
 
 	//Assert
 	codeApproved := "00" // domain.CODE_APPROVED
 
-	assert.Equal(suite.T(), returnCode, codeApproved)
+	assert.Equal(suite.T(), response.Code, codeApproved)
 	assert.Equal(suite.T(), err, nil)
 
 	foodTransaction, _ := getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: foodCategoryID})
 	assert.Equal(suite.T(), err, nil)
-	assert.Equal(suite.T(), foodTransaction.Amount, decimal.NewFromFloat(0))
+	assert.Equal(suite.T(), foodTransaction.BalanceAfter, decimal.NewFromFloat(0))
+	assert.Equal(suite.T(), foodTransaction.Amount, balanceFoodAmount)
 
 	cashTransaction, _ := getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: cashCategoryID})
 	assert.Equal(suite.T(), err, nil)
-	assert.Equal(suite.T(), cashTransaction.Amount, decimal.NewFromFloat(90.22))
+	assert.Equal(suite.T(), cashTransaction.BalanceAfter, decimal.NewFromFloat(90.22))
+	assert.Equal(suite.T(), cashTransaction.Amount.String(), "114.89")
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteReplayedApprovedNotDebitedAgain() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	_, _ = paymentService.Execute(tRequest)
+
+	for key := range dbFake.Transactions {
+		delete(dbFake.Transactions, key)
+	}
+
+	//Act
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+
+	assert.Equal(suite.T(), response.Code, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteReplayedRejectedKeepsOriginalCode() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsRejected,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	_, _ = paymentService.Execute(tRequest)
+
+	//Act
+	tRequest.TotalAmount = amountFoodFundsApproved
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "51" // domain.CODE_REJECTED_INSUFICIENT_FUNDS
+
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteReplayedByAnotherAccountRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	transactionUID := uuid.New()
+	dbFake.Outcomes[transactionUID] = port.TransactionOutcomeEntity{
+		UID:        transactionUID,
+		AccountID:  2,
+		AccountUID: uuid.New(),
+		Code:       "00", // domain.CODE_APPROVED
+	}
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: transactionUID,
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "94" // domain.CODE_REJECTED_DUPLICATE_TRANSACTION
+
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteStaleFencingTokenRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	// A newer lock holder has already written on the account
+	dbFake.FencingTokens[1] = 2
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "07" // domain.CODE_REJECTED_GENERIC
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.Equal(suite.T(), errors.Is(err, port.ErrStaleFencingToken), true)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+	assert.Equal(suite.T(), len(inMemoryDBfake.Lock), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteCurrencyMismatchRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	// The rate exists, but the account does not allow converting currencies
+	dbFake.ExchangeRates["USDBRL"] = decimal.NewFromFloat(5.1234)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(20),
+		Currency:       "USD",
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "12" // domain.CODE_REJECTED_INVALID_TRANSACTION
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteCurrencyConvertedApproved() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	account := dbFake.Accounts[1]
+	account.CurrencyConversion = true
+	dbFake.Accounts[1] = account
+
+	exchangeRate := decimal.NewFromFloat(5.1234)
+	dbFake.ExchangeRates["USDBRL"] = exchangeRate
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(20),
+		Currency:       "USD",
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), response.Code, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+
+	foodTransaction, err := getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: foodCategoryID})
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), foodTransaction.Currency, "BRL")
+	assert.Equal(suite.T(), foodTransaction.Amount.String(), "102.47")
+	assert.Equal(suite.T(), foodTransaction.BalanceAfter.String(), "102.64")
+	assert.Equal(suite.T(), foodTransaction.OriginalCurrency, "USD")
+	assert.Equal(suite.T(), foodTransaction.OriginalAmount.String(), "20")
+	assert.Equal(suite.T(), foodTransaction.ExchangeRate.String(), exchangeRate.String())
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteAmountExceedsCurrencyMinorUnitsRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(100.10),
+		Currency:       "CLP",
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "13" // domain.CODE_REJECTED_INVALID_AMOUNT
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteExchangeRateNotFoundRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	// The account allows converting currencies, but there is no USD to BRL rate
+	account := dbFake.Accounts[1]
+	account.CurrencyConversion = true
+	dbFake.Accounts[1] = account
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(20),
+		Currency:       "USD",
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "12" // domain.CODE_REJECTED_INVALID_TRANSACTION
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.Equal(suite.T(), response.Reason, "INVALID_TRANSACTION")
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteUnsupportedCurrencyRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(20),
+		Currency:       "XYZ",
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "12" // domain.CODE_REJECTED_INVALID_TRANSACTION
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+	_, saved := dbFake.Outcomes[tRequest.TransactionUID]
+	assert.Equal(suite.T(), saved, false)
+	assert.Equal(suite.T(), dbFake.DeclinedEvents[tRequest.TransactionUID].Code, codeRejected)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteCategoryRuleFallbackChainApproved() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	dbFake.CategoryRules = []port.CategoryRuleEntity{
+		{CategoryID: mealCategoryID, FallbackCategoryID: foodCategoryID, Position: 1},
+		{CategoryID: mealCategoryID, FallbackCategoryID: cashCategoryID, Position: 2},
+	}
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(500),
+		MCC:            "5811",
+		Merchant:       "RESTAURANTE DA ESQUINA      SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), response.Code, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+
+	assert.Equal(suite.T(), len(response.Categories), 3)
+	assert.Equal(suite.T(), response.Categories[0].Category, "MEAL")
+	assert.Equal(suite.T(), response.Categories[0].Rule, "MCC")
+	assert.Equal(suite.T(), response.Categories[0].Amount.String(), balanceFoodAmount.String())
+	assert.Equal(suite.T(), response.Categories[1].Category, "FOOD")
+	assert.Equal(suite.T(), response.Categories[1].Rule, "FALLBACK")
+	assert.Equal(suite.T(), response.Categories[1].Amount.String(), balanceFoodAmount.String())
+	assert.Equal(suite.T(), response.Categories[2].Category, "CASH")
+	assert.Equal(suite.T(), response.Categories[2].Rule, "FALLBACK")
+	assert.Equal(suite.T(), response.Categories[2].Amount.String(), "89.78")
+
+	cashTransaction, err := getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: cashCategoryID})
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), cashTransaction.Amount.String(), "89.78")
+	assert.Equal(suite.T(), cashTransaction.BalanceAfter.String(), "115.33")
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteCategoryRuleAccountOverrideApproved() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	dbFake.CategoryRules = []port.CategoryRuleEntity{
+		{CategoryID: mealCategoryID, FallbackCategoryID: foodCategoryID, Position: 1},
+		{AccountID: 1, CategoryID: mealCategoryID, FallbackCategoryID: cashCategoryID, Position: 1},
+	}
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(300),
+		MCC:            "5811",
+		Merchant:       "RESTAURANTE DA ESQUINA      SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), response.Code, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+
+	assert.Equal(suite.T(), len(response.Categories), 2)
+	assert.Equal(suite.T(), response.Categories[1].Category, "CASH")
+	assert.Equal(suite.T(), response.Categories[1].Amount.String(), "94.89")
+
+	_, err = getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: foodCategoryID})
+	assert.NotEqual(suite.T(), err, nil)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteCategoryRuleFallbackExcludedSkipped() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	dbFake.CategoryRules = []port.CategoryRuleEntity{
+		{CategoryID: mealCategoryID, FallbackCategoryID: cashCategoryID, Position: 1},
+		{CategoryID: mealCategoryID, FallbackCategoryID: foodCategoryID, Position: 2},
+	}
+
+	cashCategory := dbFake.Accounts[1].Balance.Categories[3]
+	cashCategory.Category.FallbackExcluded = true
+	dbFake.Accounts[1].Balance.Categories[3] = cashCategory
+
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(300),
+		MCC:            "5811",
+		Merchant:       "RESTAURANTE DA ESQUINA      SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), response.Code, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+
+	assert.Equal(suite.T(), len(response.Categories), 2)
+	assert.Equal(suite.T(), response.Categories[1].Category, "FOOD")
+	assert.Equal(suite.T(), response.Categories[1].Amount.String(), "94.89")
+
+	_, err = getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: cashCategoryID})
+	assert.NotEqual(suite.T(), err, nil)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteCategoryRuleChainExhaustedRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	dbFake.CategoryRules = []port.CategoryRuleEntity{
+		{CategoryID: mealCategoryID, FallbackCategoryID: foodCategoryID, Position: 1},
+	}
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(500),
+		MCC:            "5811",
+		Merchant:       "RESTAURANTE DA ESQUINA      SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "51" // domain.CODE_REJECTED_INSUFICIENT_FUNDS
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+
+	assert.Equal(suite.T(), len(response.Categories), 2)
+	assert.Equal(suite.T(), response.Categories[0].Category, "MEAL")
+	assert.Equal(suite.T(), response.Categories[1].Category, "FOOD")
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteSpendingLimitTransactionAmountRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	dbFake.SpendingLimits = []port.SpendingLimitEntity{
+		{AccountID: 1, TransactionAmount: decimal.NewFromFloat(50)},
+	}
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "61" // domain.CODE_REJECTED_LIMIT_EXCEEDED
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteSpendingLimitCategoryDailyAmountRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	dbFake.SpendingLimits = []port.SpendingLimitEntity{
+		{AccountID: 1, CategoryID: foodCategoryID, DailyAmount: decimal.NewFromFloat(150)},
+	}
+	dbFake.SpendingUsages[foodCategoryID] = port.SpendingUsageEntity{
+		DailyAmount:   decimal.NewFromFloat(60),
+		MonthlyAmount: decimal.NewFromFloat(60),
+	}
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "61" // domain.CODE_REJECTED_LIMIT_EXCEEDED
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteSpendingLimitHourlyTransactionsRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	dbFake.SpendingLimits = []port.SpendingLimitEntity{
+		{AccountID: 1, HourlyTransactions: 3},
+	}
+	dbFake.SpendingUsages[0] = port.SpendingUsageEntity{HourlyTransactions: 3}
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "61" // domain.CODE_REJECTED_LIMIT_EXCEEDED
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteSpendingLimitOtherCategoryIgnoredApproved() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	dbFake.SpendingLimits = []port.SpendingLimitEntity{
+		{AccountID: 1, CategoryID: mealCategoryID, TransactionAmount: decimal.NewFromFloat(10)},
+	}
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), response.Code, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteSpendingLimitRecordsUsageApproved() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	dbFake.SpendingLimits = []port.SpendingLimitEntity{
+		{AccountID: 1, MonthlyAmount: decimal.NewFromFloat(1000), HourlyTransactions: 3},
+	}
+	dbFake.SpendingUsages[0] = port.SpendingUsageEntity{
+		DailyAmount:        decimal.NewFromFloat(200),
+		MonthlyAmount:      decimal.NewFromFloat(200),
+		HourlyTransactions: 2,
+	}
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), response.Code, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+
+	assert.Equal(suite.T(), dbFake.SpendingUsages[0].MonthlyAmount.String(), "300.1")
+	assert.Equal(suite.T(), dbFake.SpendingUsages[0].HourlyTransactions, 3)
+	assert.Equal(suite.T(), dbFake.SpendingUsages[foodCategoryID].DailyAmount.String(), amountFoodFundsApproved.String())
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteSpendingLimitConvertedToAccountCurrencyApproved() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	account := dbFake.Accounts[1]
+	account.Currency = "USD"
+	dbFake.Accounts[1] = account
+	dbFake.ExchangeRates["BRLUSD"] = decimal.NewFromFloat(0.2)
+	dbFake.SpendingLimits = []port.SpendingLimitEntity{
+		{AccountID: 1, TransactionAmount: decimal.NewFromFloat(50)},
+	}
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), response.Code, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+
+	assert.Equal(suite.T(), dbFake.SpendingUsages[0].DailyAmount.String(), "20.02")
+	assert.Equal(suite.T(), dbFake.SpendingUsages[foodCategoryID].DailyAmount.String(), "20.02")
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteSpendingLimitWithoutAccountCurrencyRateRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	account := dbFake.Accounts[1]
+	account.Currency = "USD"
+	dbFake.Accounts[1] = account
+	dbFake.SpendingLimits = []port.SpendingLimitEntity{
+		{AccountID: 1, TransactionAmount: decimal.NewFromFloat(50)},
+	}
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "12" // domain.CODE_REJECTED_INVALID_TRANSACTION
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteFraudRuleMCCBlocklistDeclined() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	dbFake.FraudRules = []port.FraudRuleEntity{
+		{Name: "blocked-mccs", Kind: "MCC_BLOCKLIST", Decision: "DECLINE", MCCs: []string{"7995", correctFoodMCC}},
+	}
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "59" // domain.CODE_REJECTED_SUSPECTED_FRAUD
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+	assert.Equal(suite.T(), dbFake.Outcomes[tRequest.TransactionUID].Code, codeRejected)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteFraudRuleFirstSeenMerchantReviewApproved() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	dbFake.FraudRules = []port.FraudRuleEntity{
+		{Name: "first-seen-merchant", Kind: "FIRST_SEEN_MERCHANT", Decision: "REVIEW", Amount: decimal.NewFromFloat(50)},
+	}
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), response.Code, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 1)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteFraudRuleRapidRepeatDeclined() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	dbFake.FraudRules = []port.FraudRuleEntity{
+		{Name: "rapid-repeats", Kind: "RAPID_REPEAT", Decision: "DECLINE", Count: 2, Window: time.Minute},
+	}
+	dbFake.FraudPayments = []port.FraudPaymentEntity{
+		{UID: uuid.New(), MCC: correctFoodMCC, MerchantName: "PADARIA DO ZE               SAO PAULO BR", Amount: amountFoodFundsApproved, CreatedAt: time.Now().Add(-10 * time.Second)},
+		{UID: uuid.New(), MCC: correctFoodMCC, MerchantName: "PADARIA DO ZE               SAO PAULO BR", Amount: amountFoodFundsApproved, CreatedAt: time.Now().Add(-30 * time.Second)},
+	}
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "59" // domain.CODE_REJECTED_SUSPECTED_FRAUD
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteFraudRuleRepeatOutsideWindowApproved() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	dbFake.FraudRules = []port.FraudRuleEntity{
+		{Name: "rapid-repeats", Kind: "RAPID_REPEAT", Decision: "DECLINE", Count: 2, Window: time.Minute},
+	}
+	dbFake.FraudPayments = []port.FraudPaymentEntity{
+		{UID: uuid.New(), MCC: correctFoodMCC, MerchantName: "PADARIA DO ZE               SAO PAULO BR", Amount: amountFoodFundsApproved, CreatedAt: time.Now().Add(-10 * time.Second)},
+		{UID: uuid.New(), MCC: correctFoodMCC, MerchantName: "PADARIA DO ZE               SAO PAULO BR", Amount: amountFoodFundsApproved, CreatedAt: time.Now().Add(-5 * time.Minute)},
+	}
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), response.Code, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 1)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteFraudRuleImpossibleVelocityDeclined() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	dbFake.FraudRules = []port.FraudRuleEntity{
+		{Name: "impossible-velocity", Kind: "IMPOSSIBLE_VELOCITY", Decision: "DECLINE", Window: time.Hour},
+	}
+	dbFake.FraudPayments = []port.FraudPaymentEntity{
+		{UID: uuid.New(), MCC: correctFoodMCC, MerchantName: "DELI ON FIFTH               NEW YORK US", Amount: amountFoodFundsApproved, CreatedAt: time.Now().Add(-20 * time.Minute)},
+	}
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "59" // domain.CODE_REJECTED_SUSPECTED_FRAUD
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteLockTimeoutRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	memoryLockRepo := &TimeoutMemoryLockRepoFake{}
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "91" // domain.CODE_REJECTED_SYSTEM_TIMEOUT
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.Equal(suite.T(), response.Reason, "SYSTEM_TIMEOUT")
+	assert.Equal(suite.T(), errors.Is(err, port.ErrMemoryLockTimeout), true)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+	_, saved := dbFake.Outcomes[tRequest.TransactionUID]
+	assert.Equal(suite.T(), saved, false)
+	assert.Equal(suite.T(), dbFake.DeclinedEvents[tRequest.TransactionUID].Code, codeRejected)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteNonPositiveAmountRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(-10.00),
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "13" // domain.CODE_REJECTED_INVALID_AMOUNT
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.Equal(suite.T(), response.Reason, "INVALID_AMOUNT")
+	assert.Equal(suite.T(), errors.Is(err, port.ErrInvalidAmount), true)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteBlockedAccountRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	account := dbFake.Accounts[1]
+	account.Status = "BLOCKED" // domain.ACCOUNT_STATUS_BLOCKED
+	dbFake.Accounts[1] = account
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "62" // domain.CODE_REJECTED_ACCOUNT_BLOCKED
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.Equal(suite.T(), response.Reason, "ACCOUNT_BLOCKED")
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+	assert.Equal(suite.T(), dbFake.Outcomes[tRequest.TransactionUID].Code, codeRejected)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteCancelledAccountRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	account := dbFake.Accounts[1]
+	account.Status = "CANCELLED" // domain.ACCOUNT_STATUS_CANCELLED
+	dbFake.Accounts[1] = account
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "46" // domain.CODE_REJECTED_ACCOUNT_CANCELLED
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.Equal(suite.T(), response.Reason, "ACCOUNT_CANCELLED")
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+	assert.Equal(suite.T(), dbFake.Outcomes[tRequest.TransactionUID].Code, codeRejected)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteWithCardTokenApproved() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	dbFake.Cards = append(dbFake.Cards, newCardEntityFake("ACTIVE", time.Now().AddDate(1, 0, 0))) // domain.CARD_STATUS_ACTIVE
+
+	tRequest := port.TransactionPaymentRequest{
+		CardToken:      cardTokenToTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), response.Code, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+
+	foodTransaction, err := getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: foodCategoryID})
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), foodTransaction.Amount, amountFoodFundsApproved)
+	assert.Equal(suite.T(), foodTransaction.CardID, dbFake.Cards[0].ID)
+}
+
+/*
+- Counts the unlocks, so a request can not release a lock it does not hold
+*/
+type UnlockCountingMemoryLockRepoFake struct {
+	port.MemoryLockRepository
+	unlocks int
+}
+
+func (ucmlrf *UnlockCountingMemoryLockRepoFake) Unlock(ctx context.Context, mle port.MemoryLockEntity) error {
+	ucmlrf.unlocks++
+	return ucmlrf.MemoryLockRepository.Unlock(ctx, mle)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteWithUnknownCardTokenRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := &UnlockCountingMemoryLockRepoFake{MemoryLockRepository: suite.getMemoryLockRepoFake(inMemoryDBfake)}
+
+	tRequest := port.TransactionPaymentRequest{
+		CardToken:      cardTokenToTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "14" // domain.CODE_REJECTED_INVALID_ACCOUNT
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+	assert.Equal(suite.T(), memoryLockRepo.unlocks, 0)
+	_, saved := dbFake.Outcomes[tRequest.TransactionUID]
+	assert.Equal(suite.T(), saved, false)
+	assert.Equal(suite.T(), dbFake.DeclinedEvents[tRequest.TransactionUID].Code, codeRejected)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteWithExpiredCardRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	dbFake.Cards = append(dbFake.Cards, newCardEntityFake("ACTIVE", time.Now().AddDate(0, -1, 0))) // domain.CARD_STATUS_ACTIVE
+
+	tRequest := port.TransactionPaymentRequest{
+		CardToken:      cardTokenToTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "54" // domain.CODE_REJECTED_EXPIRED_CARD
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.Equal(suite.T(), response.Reason, "EXPIRED_CARD")
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+	assert.Equal(suite.T(), dbFake.Outcomes[tRequest.TransactionUID].Code, codeRejected)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteWithBlockedCardRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	dbFake.Cards = append(dbFake.Cards, newCardEntityFake("BLOCKED", time.Now().AddDate(1, 0, 0))) // domain.CARD_STATUS_BLOCKED
+
+	tRequest := port.TransactionPaymentRequest{
+		CardToken:      cardTokenToTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "57" // domain.CODE_REJECTED_CARD_NOT_PERMITTED
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.Equal(suite.T(), response.Reason, "CARD_NOT_PERMITTED")
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteCardDailyLimitExceededRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	card := newCardEntityFake("ACTIVE", time.Now().AddDate(1, 0, 0)) // domain.CARD_STATUS_ACTIVE
+	card.DailyAmount = decimal.NewFromFloat(150.00)
+	dbFake.Cards = append(dbFake.Cards, card)
+	dbFake.CardUsages[card.ID] = decimal.NewFromFloat(60.00)
+
+	tRequest := port.TransactionPaymentRequest{
+		CardToken:      cardTokenToTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "61" // domain.CODE_REJECTED_LIMIT_EXCEEDED
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteCardOfAnotherAccountRejected() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	card := newCardEntityFake("ACTIVE", time.Now().AddDate(1, 0, 0)) // domain.CARD_STATUS_ACTIVE
+	card.AccountUID = uuid.New()
+	dbFake.Cards = append(dbFake.Cards, card)
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		CardToken:      cardTokenToTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		allRepos.TransactionOutcome,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "14" // domain.CODE_REJECTED_INVALID_ACCOUNT
+	assert.Equal(suite.T(), response.Code, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+/*
+- Runs another request of the same service while the request looks up its outcome
+*/
+type InterleavingTransactionOutcomeRepoFake struct {
+	port.TransactionOutcomeRepository
+	interleave func()
+}
+
+func (itorf *InterleavingTransactionOutcomeRepoFake) FindByUID(ctx context.Context, uid uuid.UUID) (*port.TransactionOutcomeEntity, error) {
+	if interleave := itorf.interleave; interleave != nil {
+		itorf.interleave = nil
+		interleave()
+	}
+
+	return itorf.TransactionOutcomeRepository.FindByUID(ctx, uid)
+}
+
+func (suite *PaymentSuite) TestPaymentExecuteInterleavedRequestsReleaseOwnLocks() {
+	//Arrange
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	dbFake := suite.getDBfake()
+	allRepos := suite.getAllRepositories(dbFake)
+
+	inMemoryDBfake := suite.getInMemoryDBfake()
+	memoryLockRepo := suite.getMemoryLockRepoFake(inMemoryDBfake)
+
+	outcomeRepo := &InterleavingTransactionOutcomeRepoFake{TransactionOutcomeRepository: allRepos.TransactionOutcome}
+
+	paymentService := NewPayment(
+		timeoutSLA,
+		allRepos.Account,
+		allRepos.Card,
+		newMerchantMatcherFake(allRepos.Merchant),
+		newFraudRulesFake(allRepos.FraudRule, allRepos.FraudHistory),
+		allRepos.ExchangeRate,
+		allRepos.CategoryRule,
+		allRepos.SpendingLimit,
+		allRepos.SpendingUsage,
+		outcomeRepo,
+		memoryLockRepo,
+		newFakeLog(),
+	)
+
+	var interleavedCode string
+	outcomeRepo.interleave = func() {
+		interleaved, _ := paymentService.Execute(port.TransactionPaymentRequest{
+			AccountUID:     uuid.New(),
+			TransactionUID: uuid.New(),
+			TotalAmount:    amountFoodFundsApproved,
+			MCC:            correctFoodMCC,
+			Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+		})
+		interleavedCode = interleaved.Code
+	}
+
+	tRequest := port.TransactionPaymentRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		TotalAmount:    amountFoodFundsApproved,
+		MCC:            correctFoodMCC,
+		Merchant:       "PADARIA DO ZE               SAO PAULO BR",
+	}
+
+	//Act
+	response, err := paymentService.Execute(tRequest)
+
+	//Assert
+	codeRejected := "14" // domain.CODE_REJECTED_INVALID_ACCOUNT
+	assert.Equal(suite.T(), interleavedCode, codeRejected)
+	assert.Equal(suite.T(), response.Code, "00") // domain.CODE_APPROVED
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(inMemoryDBfake.Lock), 0)
+	assert.Equal(suite.T(), dbFake.FencingTokens[1], int64(1))
+}
+
+func newCardEntityFake(status string, expiresAt time.Time) port.CardEntity {
+	return port.CardEntity{
+		ID:         1,
+		UID:        uuid.New(),
+		AccountID:  1,
+		AccountUID: accountUIDtoTransact,
+		Token:      cardTokenToTransact,
+		MaskedPAN:  "411111******1111",
+		Status:     status,
+		ExpiresAt:  expiresAt,
+	}
 }
 
 func getLastTransaction(transactions map[uint]port.TransactionEntity, tParams port.TransactionEntity) (*port.TransactionEntity, error) {
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/support/logger"
+)
+
+type Refund struct {
+	timeoutSLA                   port.TimeoutSLA
+	accountRepository            port.AccountRepository
+	transactionOutcomeRepository port.TransactionOutcomeRepository
+	memoryLockRepository         port.MemoryLockRepository
+
+	log logger.Logger
+}
+
+func NewRefund(
+	timeoutSLA port.TimeoutSLA,
+
+	aRepository port.AccountRepository,
+	toRepository port.TransactionOutcomeRepository,
+	mlRepository port.MemoryLockRepository,
+
+	log logger.Logger,
+) *Refund {
+	return &Refund{
+		timeoutSLA:                   timeoutSLA,
+		accountRepository:            aRepository,
+		transactionOutcomeRepository: toRepository,
+		memoryLockRepository:         mlRepository,
+
+		log: log,
+	}
+}
+
+func (r *Refund) Execute(trr port.TransactionRefundRequest) (string, error) {
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		time.Duration(r.timeoutSLA),
+	)
+	ctx = context.WithValue(ctx, logger.CtxTransactionUIDKey, trr.RefundUID.String())
+	ctx = context.WithValue(ctx, logger.CtxAccountUIDKey, trr.AccountUID.String())
+	defer cancel()
+
+	transactionLocked, err := r.memoryLockRepository.Lock(
+		ctx,
+		mapRefundRequestToMemoryLockEntity(trr),
+	)
+	if err != nil {
+		return r.rejectedGenericErr(
+			ctx,
+			transactionLocked,
+			fmt.Errorf("failed concurrent transaction locked: %w", err),
+		)
+	}
+
+	outcomeEntity, err := r.transactionOutcomeRepository.FindByUID(ctx, trr.RefundUID)
+	if err != nil {
+		return r.rejectedGenericErr(
+			ctx,
+			transactionLocked,
+			fmt.Errorf("failed to retrieve transaction outcome: %w", err),
+		)
+	}
+
+	if outcomeEntity != nil {
+		return r.replayedOutcome(ctx, transactionLocked, trr.AccountUID, *outcomeEntity)
+	}
+
+	accountEntity, err := r.accountRepository.FindByUID(ctx, trr.AccountUID)
+	if err != nil {
+		return r.rejectedGenericErr(
+			ctx,
+			transactionLocked,
+			fmt.Errorf("failed to retrieve account entity: %w", err),
+		)
+	}
+
+	if accountEntity.ID == 0 {
+		return r.rejectedGenericErr(
+			ctx,
+			transactionLocked,
+			fmt.Errorf("%w: %s", port.ErrAccountNotFound, trr.AccountUID.String()),
+		)
+	}
+
+	capturedEntities, err := r.accountRepository.FindTransactionsByUID(ctx, trr.TransactionUID)
+	if err != nil {
+		return r.rejectedGenericErr(
+			ctx,
+			transactionLocked,
+			fmt.Errorf("failed to retrieve transactions with UID %s: %w", trr.TransactionUID.String(), err),
+		)
+	}
+
+	for _, capturedEntity := range capturedEntities {
+		if capturedEntity.AccountUID != trr.AccountUID {
+			return r.rejectedGenericErr(
+				ctx,
+				transactionLocked,
+				fmt.Errorf("%w: %s does not belong to account %s", port.ErrTransactionNotFound, trr.TransactionUID.String(), trr.AccountUID.String()),
+			)
+		}
+	}
+
+	account := mapAccountEntityToDomain(accountEntity, r.log)
+	transaction := mapRefundRequestToTransactionDomain(trr, account)
+
+	cErr := account.CheckCreditAllowed()
+	if cErr != nil {
+		r.saveOutcome(ctx, mapTransactionOutcomeToEntity(transaction, account, cErr.Code))
+		return r.rejectedCustomErr(ctx, transactionLocked, cErr)
+	}
+
+	approvedTransactions, cErr := account.ApproveRefund(
+		ctx,
+		transaction,
+		mapTransactionCapturedEntitiesToDomains(capturedEntities),
+	)
+	if cErr != nil {
+		r.saveOutcome(ctx, mapTransactionOutcomeToEntity(transaction, account, cErr.Code))
+		return r.rejectedCustomErr(ctx, transactionLocked, cErr)
+	}
+
+	err = r.accountRepository.SaveTransactions(
+		ctx,
+		mapTransactionDomainsToEntities(approvedTransactions),
+		transactionLocked.FencingToken,
+	)
+	if err != nil {
+		return r.rejectedGenericErr(
+			ctx,
+			transactionLocked,
+			fmt.Errorf("failed to save refund transaction entity: %w", err),
+		)
+	}
+
+	r.saveOutcome(ctx, mapTransactionOutcomeToEntity(transaction, account, domain.CODE_APPROVED))
+
+	_ = r.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return domain.CODE_APPROVED, nil
+}
+
+func (r *Refund) saveOutcome(ctx context.Context, outcome port.TransactionOutcomeEntity) {
+	err := r.transactionOutcomeRepository.Save(ctx, outcome)
+	if err != nil {
+		r.log.Error(ctx, fmt.Sprintf("failed to save transaction outcome: %s", err.Error()))
+	}
+}
+
+func (r *Refund) replayedOutcome(
+	ctx context.Context,
+	transactionLocked port.MemoryLockEntity,
+	accountUID uuid.UUID,
+	outcome port.TransactionOutcomeEntity,
+) (string, error) {
+	code, err := replayTransactionOutcome(accountUID, outcome)
+	if err != nil {
+		r.log.Warn(ctx, err.Error())
+	} else {
+		r.log.Info(ctx, fmt.Sprintf("transaction already processed, replaying code %s", code))
+	}
+
+	_ = r.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return code, err
+}
+
+func (r *Refund) rejectedGenericErr(ctx context.Context, transactionLocked port.MemoryLockEntity, err error) (string, error) {
+	r.log.Error(ctx, err.Error())
+
+	_ = r.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return rejectionCode(err), err
+}
+
+func (r *Refund) rejectedCustomErr(ctx context.Context, transactionLocked port.MemoryLockEntity, cErr *domain.CustomError) (string, error) {
+	if cErr.Code == domain.CODE_REJECTED_GENERIC {
+		r.log.Error(ctx, cErr.Error())
+	} else {
+		r.log.Warn(ctx, cErr.Error())
+	}
+
+	_ = r.memoryLockRepository.Unlock(ctx, transactionLocked)
+
+	return cErr.Code, fmt.Errorf("failed to approve refund: %s", cErr.Message)
+}
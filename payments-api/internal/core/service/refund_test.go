@@ -0,0 +1,268 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+var (
+	transactionUIDtoRefund, _ = uuid.Parse("8c6c5b0e-3f2a-4b53-9f0e-6a3f5e2d4c11")
+
+	amountFoodCaptured = decimal.NewFromFloat(100.10)
+	amountCashCaptured = decimal.NewFromFloat(50.00)
+)
+
+type RefundSuite struct {
+	suite.Suite
+}
+
+func (suite *RefundSuite) getDBfakeWithCapturedTransaction() *DBfake {
+	dbFake := newDBfake()
+
+	dbFake.TransactionsCaptured[transactionUIDtoRefund] = map[int]port.TransactionCapturedEntity{
+		1: {
+			UID:            transactionUIDtoRefund,
+			AccountID:      1,
+			AccountUID:     accountUIDtoTransact,
+			CategoryID:     foodCategoryID,
+			Priority:       1,
+			AmountCaptured: amountFoodCaptured,
+			AmountRefunded: decimal.Zero,
+		},
+		3: {
+			UID:            transactionUIDtoRefund,
+			AccountID:      1,
+			AccountUID:     accountUIDtoTransact,
+			CategoryID:     cashCategoryID,
+			Priority:       3,
+			AmountCaptured: amountCashCaptured,
+			AmountRefunded: decimal.NewFromFloat(20.00),
+		},
+	}
+
+	return &dbFake
+}
+
+func (suite *RefundSuite) newRefundService(dbFake *DBfake) *Refund {
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	memoryLockRepo := newMemoryLockRepoFake(newInMemoryDBfake())
+
+	return NewRefund(
+		timeoutSLA,
+		newAccountRepoFake(*dbFake),
+		newTransactionOutcomeRepoFake(*dbFake),
+		memoryLockRepo,
+		newFakeLog(),
+	)
+}
+
+func (suite *RefundSuite) TestRefundExecutePartialApproved() {
+	//Arrange
+	dbFake := suite.getDBfakeWithCapturedTransaction()
+
+	tRequest := port.TransactionRefundRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: transactionUIDtoRefund,
+		RefundUID:      uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(40.00),
+	}
+
+	//Act
+	returnCode, err := suite.newRefundService(dbFake).Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), returnCode, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+
+	cashTransaction, err := getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: cashCategoryID})
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), cashTransaction.BalanceAfter.String(), balanceFoodAmount.Add(decimal.NewFromFloat(30.00)).String())
+	assert.Equal(suite.T(), cashTransaction.Amount.String(), decimal.NewFromFloat(30.00).String())
+	assert.Equal(suite.T(), cashTransaction.Operation, "REFUND") // domain.TRANSACTION_OPERATION_REFUND
+	assert.Equal(suite.T(), cashTransaction.OriginalUID, transactionUIDtoRefund)
+
+	foodTransaction, err := getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: foodCategoryID})
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), foodTransaction.BalanceAfter.String(), balanceFoodAmount.Add(decimal.NewFromFloat(10.00)).String())
+}
+
+func (suite *RefundSuite) TestRefundExecuteReplayedWithSameRefundUID() {
+	//Arrange
+	dbFake := suite.getDBfakeWithCapturedTransaction()
+	refundService := suite.newRefundService(dbFake)
+
+	tRequest := port.TransactionRefundRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: transactionUIDtoRefund,
+		RefundUID:      uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(40.00),
+	}
+
+	firstCode, firstErr := refundService.Execute(tRequest)
+	refundedTransactions := len(dbFake.Transactions)
+
+	//Act
+	returnCode, err := refundService.Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), firstCode, codeApproved)
+	assert.Equal(suite.T(), firstErr, nil)
+	assert.Equal(suite.T(), returnCode, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), refundedTransactions)
+	assert.Equal(suite.T(), dbFake.Outcomes[tRequest.RefundUID].Code, codeApproved)
+}
+
+func (suite *RefundSuite) TestRefundExecuteExceedsCapturedRejected() {
+	//Arrange
+	dbFake := suite.getDBfakeWithCapturedTransaction()
+
+	tRequest := port.TransactionRefundRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: transactionUIDtoRefund,
+		RefundUID:      uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(130.11),
+	}
+
+	//Act
+	returnCode, err := suite.newRefundService(dbFake).Execute(tRequest)
+
+	//Assert
+	codeRejected := "13" // domain.CODE_REJECTED_INVALID_AMOUNT
+	assert.Equal(suite.T(), returnCode, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+	assert.Equal(suite.T(), dbFake.Outcomes[tRequest.RefundUID].Code, codeRejected)
+}
+
+func (suite *RefundSuite) TestRefundExecuteTransactionNotFoundRejected() {
+	//Arrange
+	dbFake := suite.getDBfakeWithCapturedTransaction()
+
+	tRequest := port.TransactionRefundRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: uuid.New(),
+		RefundUID:      uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(10.00),
+	}
+
+	//Act
+	returnCode, err := suite.newRefundService(dbFake).Execute(tRequest)
+
+	//Assert
+	codeRejected := "25" // domain.CODE_REJECTED_RECORD_NOT_FOUND
+	assert.Equal(suite.T(), returnCode, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+}
+
+func (suite *RefundSuite) getDBfakeWithConvertedTransaction() *DBfake {
+	dbFake := newDBfake()
+
+	dbFake.TransactionsCaptured[transactionUIDtoRefund] = map[int]port.TransactionCapturedEntity{
+		1: {
+			UID:              transactionUIDtoRefund,
+			AccountID:        1,
+			AccountUID:       accountUIDtoTransact,
+			CategoryID:       foodCategoryID,
+			Priority:         1,
+			AmountCaptured:   decimal.NewFromFloat(108.64),
+			AmountRefunded:   decimal.Zero,
+			OriginalAmount:   decimal.NewFromFloat(20.00),
+			OriginalRefunded: decimal.Zero,
+			OriginalCurrency: "USD",
+			ExchangeRate:     decimal.NewFromFloat(5.4321),
+		},
+	}
+
+	return &dbFake
+}
+
+func (suite *RefundSuite) TestRefundExecuteConvertedWithOriginalRateApproved() {
+	//Arrange
+	dbFake := suite.getDBfakeWithConvertedTransaction()
+
+	tRequest := port.TransactionRefundRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: transactionUIDtoRefund,
+		RefundUID:      uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(10.00),
+	}
+
+	//Act
+	returnCode, err := suite.newRefundService(dbFake).Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), returnCode, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+
+	foodTransaction, err := getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: foodCategoryID})
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), foodTransaction.Amount.String(), "54.32")
+	assert.Equal(suite.T(), foodTransaction.Currency, "BRL")
+	assert.Equal(suite.T(), foodTransaction.OriginalAmount.String(), "10")
+	assert.Equal(suite.T(), foodTransaction.OriginalCurrency, "USD")
+	assert.Equal(suite.T(), foodTransaction.ExchangeRate.String(), "5.4321")
+}
+
+func (suite *RefundSuite) TestRefundExecuteConvertedInFullCreditsCapturedAmount() {
+	//Arrange
+	dbFake := suite.getDBfakeWithConvertedTransaction()
+
+	tRequest := port.TransactionRefundRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: transactionUIDtoRefund,
+		RefundUID:      uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(20.00),
+	}
+
+	//Act
+	returnCode, err := suite.newRefundService(dbFake).Execute(tRequest)
+
+	//Assert
+	codeApproved := "00" // domain.CODE_APPROVED
+	assert.Equal(suite.T(), returnCode, codeApproved)
+	assert.Equal(suite.T(), err, nil)
+
+	foodTransaction, err := getLastTransaction(dbFake.Transactions, port.TransactionEntity{AccountID: 1, CategoryID: foodCategoryID})
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), foodTransaction.Amount.String(), "108.64")
+	assert.Equal(suite.T(), foodTransaction.BalanceAfter.String(), balanceFoodAmount.Add(decimal.NewFromFloat(108.64)).String())
+}
+
+func (suite *RefundSuite) TestRefundExecuteConvertedExceedsOriginalRejected() {
+	//Arrange
+	dbFake := suite.getDBfakeWithConvertedTransaction()
+
+	tRequest := port.TransactionRefundRequest{
+		AccountUID:     accountUIDtoTransact,
+		TransactionUID: transactionUIDtoRefund,
+		RefundUID:      uuid.New(),
+		TotalAmount:    decimal.NewFromFloat(20.01),
+	}
+
+	//Act
+	returnCode, err := suite.newRefundService(dbFake).Execute(tRequest)
+
+	//Assert
+	codeRejected := "13" // domain.CODE_REJECTED_INVALID_AMOUNT
+	assert.Equal(suite.T(), returnCode, codeRejected)
+	assert.NotEqual(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(dbFake.Transactions), 0)
+}
+
+func TestRefundSuite(t *testing.T) {
+	suite.Run(t, new(RefundSuite))
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+/*
+  - Response code of an error that stopped the transaction before the account
+    decided it. Errors not catalogued, such as a failing repository, are
+    CODE_REJECTED_GENERIC
+*/
+func rejectionCode(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, port.ErrMemoryLockTimeout):
+		return domain.CODE_REJECTED_SYSTEM_TIMEOUT
+	case errors.Is(err, port.ErrAccountNotFound), errors.Is(err, port.ErrCardNotFound):
+		return domain.CODE_REJECTED_INVALID_ACCOUNT
+	case errors.Is(err, port.ErrInvalidAmount):
+		return domain.CODE_REJECTED_INVALID_AMOUNT
+	case errors.Is(err, port.ErrInvalidCurrency), errors.Is(err, port.ErrExchangeRateNotFound):
+		return domain.CODE_REJECTED_INVALID_TRANSACTION
+	case errors.Is(err, port.ErrHoldNotFound), errors.Is(err, port.ErrTransactionNotFound):
+		return domain.CODE_REJECTED_RECORD_NOT_FOUND
+	case errors.Is(err, port.ErrDuplicateTransaction):
+		return domain.CODE_REJECTED_DUPLICATE_TRANSACTION
+	default:
+		return domain.CODE_REJECTED_GENERIC
+	}
+}
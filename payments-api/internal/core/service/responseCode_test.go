@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+type ResponseCodeSuite struct {
+	suite.Suite
+}
+
+func (suite *ResponseCodeSuite) TestRejectionCodeOfCataloguedErrors() {
+	//Arrange
+	rejections := []struct {
+		err    error
+		code   string
+		reason string
+	}{
+		{fmt.Errorf("failed concurrent transaction locked: %w", context.DeadlineExceeded), "91", "SYSTEM_TIMEOUT"},
+		{fmt.Errorf("failed concurrent transaction locked: %w", port.ErrMemoryLockTimeout), "91", "SYSTEM_TIMEOUT"},
+		{fmt.Errorf("%w: unknown", port.ErrAccountNotFound), "14", "INVALID_ACCOUNT"},
+		{fmt.Errorf("%w: unknown", port.ErrCardNotFound), "14", "INVALID_ACCOUNT"},
+		{fmt.Errorf("%w: 0 is not positive", port.ErrInvalidAmount), "13", "INVALID_AMOUNT"},
+		{fmt.Errorf("%w: currency XYZ not supported", port.ErrInvalidCurrency), "12", "INVALID_TRANSACTION"},
+		{fmt.Errorf("failed to retrieve spending usage: %w", port.ErrExchangeRateNotFound), "12", "INVALID_TRANSACTION"},
+		{fmt.Errorf("%w to void: unknown", port.ErrHoldNotFound), "25", "RECORD_NOT_FOUND"},
+		{fmt.Errorf("%w: unknown", port.ErrTransactionNotFound), "25", "RECORD_NOT_FOUND"},
+		{fmt.Errorf("failed to save transaction entity: %w", port.ErrDuplicateTransaction), "94", "DUPLICATE_TRANSACTION"},
+		{errors.New("failed to retrieve account entity: connection refused"), "07", "GENERIC_ERROR"},
+	}
+
+	for _, rejection := range rejections {
+		//Act
+		code := rejectionCode(rejection.err)
+
+		//Assert
+		assert.Equal(suite.T(), code, rejection.code)
+		assert.Equal(suite.T(), port.ResponseReason(code), rejection.reason)
+	}
+}
+
+func TestResponseCodeSuite(t *testing.T) {
+	suite.Run(t, new(ResponseCodeSuite))
+}
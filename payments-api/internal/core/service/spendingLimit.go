@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/support/logger"
+	"github.com/shopspring/decimal"
+)
+
+/*
+  - Provides the account with its spending limits and the usage of each one at the given time
+  - Provides the rates from the currencies of its categories into the account currency,
+    converting the spending checked against the limits
+*/
+func loadSpendingLimits(
+	ctx context.Context,
+	slRepository port.SpendingLimitRepository,
+	suRepository port.SpendingUsageRepository,
+	erRepository port.ExchangeRateRepository,
+	account *domain.Account,
+	at time.Time,
+) error {
+	slEntities, err := slRepository.FindByAccountID(ctx, account.ID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve spending limits: %w", err)
+	}
+
+	account.SpendingLimits = make(domain.SpendingLimits, 0, len(slEntities))
+	for _, slEntity := range slEntities {
+		suEntity, err := suRepository.FindUsage(ctx, account.ID, slEntity.CategoryID, at)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve spending usage of category %d: %w", slEntity.CategoryID, err)
+		}
+
+		account.SpendingLimits = append(account.SpendingLimits, mapSpendingLimitEntityToDomain(slEntity, suEntity))
+	}
+
+	account.SpendingRates = make(domain.ExchangeRates)
+	for _, category := range account.Balance.TransactionByCategories.Itens {
+		if category.Currency == account.Currency {
+			continue
+		}
+
+		if _, loaded := account.SpendingRates[category.Currency]; loaded {
+			continue
+		}
+
+		rateEntity, err := erRepository.FindRate(ctx, category.Currency, account.Currency)
+		if errors.Is(err, port.ErrExchangeRateNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to retrieve exchange rate from %s to %s: %w", category.Currency, account.Currency, err)
+		}
+
+		account.SpendingRates[category.Currency] = rateEntity.Rate
+	}
+
+	return nil
+}
+
+/*
+  - A failure here is only logged: the transactions or holds are already saved
+    and the counters rebuild from the ledger once they expire
+*/
+func recordSpendingUsage(
+	ctx context.Context,
+	suRepository port.SpendingUsageRepository,
+	accountID uint,
+	spending map[uint]decimal.Decimal,
+	cErr *domain.CustomError,
+	at time.Time,
+	log logger.Logger,
+) {
+	if cErr != nil {
+		log.Error(ctx, fmt.Sprintf("failed to record spending usage: %s", cErr.Message))
+		return
+	}
+
+	for categoryID, amount := range spending {
+		err := suRepository.AddUsage(ctx, port.SpendingUsageEntity{
+			AccountID:          accountID,
+			CategoryID:         categoryID,
+			At:                 at,
+			DailyAmount:        amount,
+			MonthlyAmount:      amount,
+			HourlyTransactions: 1,
+		})
+		if err != nil {
+			log.Error(ctx, fmt.Sprintf("failed to record spending usage of category %d: %s", categoryID, err.Error()))
+		}
+	}
+}
+
+/*
+- A failure here is only logged: the counters over count the voided hold until they expire
+*/
+func resetSpendingUsage(
+	ctx context.Context,
+	suRepository port.SpendingUsageRepository,
+	holdEntities map[int]port.HoldEntity,
+	at time.Time,
+	log logger.Logger,
+) {
+	var accountID uint
+	categoryIDs := map[uint]bool{0: true}
+	for _, holdEntity := range holdEntities {
+		accountID = holdEntity.AccountID
+		categoryIDs[holdEntity.CategoryID] = true
+	}
+
+	for categoryID := range categoryIDs {
+		err := suRepository.ResetUsage(ctx, accountID, categoryID, at)
+		if err != nil {
+			log.Error(ctx, fmt.Sprintf("failed to reset spending usage of category %d: %s", categoryID, err.Error()))
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/support/logger"
+)
+
+/*
+  - Publishes the events of the transactional outbox to the downstream systems,
+    at-least-once: an event is only marked as published after its publication
+  - Events are published in the order they were written. Once an event of an
+    account fails, the later events of that account wait for the next pass, so
+    the events of each account keep their order
+*/
+type TransactionEventRelay struct {
+	interval  port.TransactionEventRelayInterval
+	batchSize port.TransactionEventRelayBatchSize
+
+	transactionEventRepository port.TransactionEventRepository
+	transactionEventPublisher  port.TransactionEventPublisher
+
+	log logger.Logger
+}
+
+func NewTransactionEventRelay(
+	interval port.TransactionEventRelayInterval,
+	batchSize port.TransactionEventRelayBatchSize,
+
+	teRepository port.TransactionEventRepository,
+	tePublisher port.TransactionEventPublisher,
+
+	log logger.Logger,
+) *TransactionEventRelay {
+	return &TransactionEventRelay{
+		interval:  interval,
+		batchSize: batchSize,
+
+		transactionEventRepository: teRepository,
+		transactionEventPublisher:  tePublisher,
+
+		log: log,
+	}
+}
+
+/*
+  - Relays the pending events at each interval, until the context is done. An
+    interval of 0 disables the relay
+*/
+func (ter *TransactionEventRelay) Run(ctx context.Context) {
+	if ter.interval <= 0 {
+		ter.log.Warn(ctx, "transaction event relay disabled")
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(ter.interval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				published, err := ter.RelayPending(ctx)
+				if err != nil || published < int(ter.batchSize) {
+					break
+				}
+			}
+		}
+	}
+}
+
+func (ter *TransactionEventRelay) RelayPending(ctx context.Context) (int, error) {
+	published, err := ter.transactionEventRepository.RelayPending(ctx, int(ter.batchSize), ter.publish)
+	if err != nil {
+		ter.log.Error(ctx, fmt.Sprintf("failed to relay transaction events: %s", err.Error()))
+		return published, err
+	}
+
+	if published > 0 {
+		ter.log.Debug(ctx, fmt.Sprintf("%d transaction events published", published))
+	}
+
+	return published, nil
+}
+
+func (ter *TransactionEventRelay) publish(ctx context.Context, events []port.TransactionEventEntity) []uint {
+	published := make([]uint, 0, len(events))
+	failedAccounts := make(map[uint]bool)
+
+	for _, event := range events {
+		if failedAccounts[event.AccountID] {
+			continue
+		}
+
+		err := ter.transactionEventPublisher.Publish(ctx, mapTransactionEventEntityToMessage(event))
+		if err != nil {
+			failedAccounts[event.AccountID] = true
+			ter.log.Warn(ctx, fmt.Sprintf(
+				"failed to publish transaction event %s of account %s: %s",
+				event.UID.String(),
+				event.AccountUID.String(),
+				err.Error(),
+			))
+
+			continue
+		}
+
+		published = append(published, event.ID)
+	}
+
+	return published
+}
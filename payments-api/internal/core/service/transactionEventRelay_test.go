@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+/*
+- Outbox rows in the order they were written, marked as published by the relay
+*/
+type TransactionEventRepoFake struct {
+	events    []port.TransactionEventEntity
+	published map[uint]bool
+}
+
+func (terf *TransactionEventRepoFake) RelayPending(
+	ctx context.Context,
+	limit int,
+	relay func(ctx context.Context, events []port.TransactionEventEntity) []uint,
+) (int, error) {
+	pending := []port.TransactionEventEntity{}
+	for _, event := range terf.events {
+		if !terf.published[event.ID] && len(pending) < limit {
+			pending = append(pending, event)
+		}
+	}
+
+	publishedIDs := relay(ctx, pending)
+	for _, id := range publishedIDs {
+		terf.published[id] = true
+	}
+
+	return len(publishedIDs), nil
+}
+
+type TransactionEventPublisherFake struct {
+	messages      []port.TransactionEventMessage
+	failedAccount string
+}
+
+func (tepf *TransactionEventPublisherFake) Publish(_ context.Context, message port.TransactionEventMessage) error {
+	if message.AccountUID == tepf.failedAccount {
+		return errors.New("connection refused")
+	}
+
+	tepf.messages = append(tepf.messages, message)
+	return nil
+}
+
+type TransactionEventRelaySuite struct {
+	suite.Suite
+
+	repository *TransactionEventRepoFake
+	publisher  *TransactionEventPublisherFake
+	relay      *TransactionEventRelay
+
+	otherAccountUID uuid.UUID
+}
+
+func (suite *TransactionEventRelaySuite) SetupTest() {
+	suite.otherAccountUID = uuid.New()
+
+	newEvent := func(id uint, eventType string, accountID uint, accountUID uuid.UUID, code string) port.TransactionEventEntity {
+		return port.TransactionEventEntity{
+			ID:             id,
+			UID:            uuid.New(),
+			Type:           eventType,
+			AccountID:      accountID,
+			AccountUID:     accountUID,
+			TransactionUID: uuid.New(),
+			Amount:         decimal.NewFromFloat(10.5),
+			Currency:       "BRL",
+			Code:           code,
+			CreatedAt:      time.Now(),
+		}
+	}
+
+	suite.repository = &TransactionEventRepoFake{
+		events: []port.TransactionEventEntity{
+			newEvent(1, port.TRANSACTION_EVENT_APPROVED, 1, accountUIDtoTransact, port.CODE_APPROVED),
+			newEvent(2, port.TRANSACTION_EVENT_APPROVED, 2, suite.otherAccountUID, port.CODE_APPROVED),
+			newEvent(3, port.TRANSACTION_EVENT_DECLINED, 1, accountUIDtoTransact, port.CODE_REJECTED_INSUFICIENT_FUNDS),
+			newEvent(4, port.TRANSACTION_EVENT_REFUNDED, 2, suite.otherAccountUID, port.CODE_APPROVED),
+		},
+		published: map[uint]bool{},
+	}
+	suite.publisher = &TransactionEventPublisherFake{}
+
+	suite.relay = NewTransactionEventRelay(
+		port.TransactionEventRelayInterval(time.Second),
+		port.TransactionEventRelayBatchSize(10),
+		suite.repository,
+		suite.publisher,
+		newFakeLog(),
+	)
+}
+
+func (suite *TransactionEventRelaySuite) TestRelayPendingPublishesInOrder() {
+	//Act
+	published, err := suite.relay.RelayPending(context.Background())
+
+	//Assert
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), published, 4)
+	assert.Equal(suite.T(), len(suite.publisher.messages), 4)
+
+	for i, message := range suite.publisher.messages {
+		assert.Equal(suite.T(), message.Sequence, suite.repository.events[i].ID)
+		assert.Equal(suite.T(), message.UID, suite.repository.events[i].UID.String())
+	}
+
+	declined := suite.publisher.messages[2]
+	assert.Equal(suite.T(), declined.Type, port.TRANSACTION_EVENT_DECLINED)
+	assert.Equal(suite.T(), declined.Code, port.CODE_REJECTED_INSUFICIENT_FUNDS)
+	assert.Equal(suite.T(), declined.Reason, domain.REASON_INSUFICIENT_FUNDS)
+}
+
+func (suite *TransactionEventRelaySuite) TestRelayPendingHoldsLaterEventsOfFailedAccount() {
+	//Arrange
+	suite.publisher.failedAccount = accountUIDtoTransact.String()
+
+	//Act
+	published, err := suite.relay.RelayPending(context.Background())
+
+	//Assert
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), published, 2)
+	assert.Equal(suite.T(), suite.repository.published[1], false)
+	assert.Equal(suite.T(), suite.repository.published[3], false)
+
+	for _, message := range suite.publisher.messages {
+		assert.Equal(suite.T(), message.AccountUID, suite.otherAccountUID.String())
+	}
+}
+
+func (suite *TransactionEventRelaySuite) TestRelayPendingRetriesHeldEvents() {
+	//Arrange
+	suite.publisher.failedAccount = accountUIDtoTransact.String()
+	_, _ = suite.relay.RelayPending(context.Background())
+	suite.publisher.failedAccount = ""
+
+	//Act
+	published, err := suite.relay.RelayPending(context.Background())
+
+	//Assert
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), published, 2)
+	assert.Equal(suite.T(), suite.publisher.messages[2].Sequence, uint(1))
+	assert.Equal(suite.T(), suite.publisher.messages[3].Sequence, uint(3))
+}
+
+func TestTransactionEventRelaySuite(t *testing.T) {
+	suite.Run(t, new(TransactionEventRelaySuite))
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+	"github.com/jtonynet/go-payments-api/internal/support/logger"
+)
+
+type TransactionHistory struct {
+	timeoutSLA        port.TimeoutSLA
+	accountRepository port.AccountRepository
+
+	log logger.Logger
+}
+
+func NewTransactionHistory(
+	timeoutSLA port.TimeoutSLA,
+
+	aRepository port.AccountRepository,
+
+	log logger.Logger,
+) *TransactionHistory {
+	return &TransactionHistory{
+		timeoutSLA:        timeoutSLA,
+		accountRepository: aRepository,
+
+		log: log,
+	}
+}
+
+/*
+  - Reads one page more than requested to know if there is a next page,
+    the cursor is the ID of the last transaction returned
+*/
+func (th *TransactionHistory) List(thr port.TransactionHistoryRequest) (port.TransactionHistoryResponse, error) {
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		time.Duration(th.timeoutSLA),
+	)
+	ctx = context.WithValue(ctx, logger.CtxAccountUIDKey, thr.AccountUID.String())
+	defer cancel()
+
+	filter, err := mapTransactionHistoryRequestToFilterEntity(thr)
+	if err != nil {
+		th.log.Warn(ctx, err.Error())
+		return port.TransactionHistoryResponse{}, err
+	}
+
+	pageSize := filter.Limit
+	filter.Limit = pageSize + 1
+
+	transactionEntities, err := th.accountRepository.FindTransactionsByAccountUID(ctx, filter)
+	if err != nil {
+		th.log.Error(ctx, err.Error())
+		return port.TransactionHistoryResponse{}, fmt.Errorf("failed to retrieve transaction history: %w", err)
+	}
+
+	nextCursor := ""
+	if len(transactionEntities) > pageSize {
+		transactionEntities = transactionEntities[:pageSize]
+		nextCursor = encodeCursor(transactionEntities[pageSize-1].ID)
+	}
+
+	return mapTransactionHistoryEntitiesToResponse(transactionEntities, nextCursor), nil
+}
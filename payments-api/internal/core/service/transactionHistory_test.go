@@ -0,0 +1,125 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+type TransactionHistorySuite struct {
+	suite.Suite
+}
+
+func (suite *TransactionHistorySuite) newTransactionHistoryService(dbFake *DBfake) *TransactionHistory {
+	timeoutSLA := port.TimeoutSLA(
+		time.Duration(timeoutSLAcfg) * time.Millisecond,
+	)
+
+	return NewTransactionHistory(
+		timeoutSLA,
+		newAccountRepoFake(*dbFake),
+		newFakeLog(),
+	)
+}
+
+func (suite *TransactionHistorySuite) historyDBfake() DBfake {
+	dbFake := newDBfake()
+
+	for id := uint(5); id > 0; id-- {
+		mcc := correctFoodMCC
+		if id%2 == 0 {
+			mcc = "5811"
+		}
+
+		dbFake.History = append(dbFake.History, port.TransactionHistoryEntity{
+			ID:           id,
+			UID:          uuid.New(),
+			CategoryID:   foodCategoryID,
+			CategoryName: "FOOD",
+			Amount:       decimal.NewFromFloat(-10.00),
+			Balance:      balanceFoodAmount.Sub(decimal.NewFromInt(int64(10 * id))),
+			MCC:          mcc,
+			MerchantName: "PADARIA DO ZE               SAO PAULO BR",
+			CreatedAt:    time.Now(),
+		})
+	}
+
+	return dbFake
+}
+
+func (suite *TransactionHistorySuite) TestListPaginatedWithCursor() {
+	//Arrange
+	dbFake := suite.historyDBfake()
+	historyService := suite.newTransactionHistoryService(&dbFake)
+
+	//Act
+	firstPage, err := historyService.List(
+		port.TransactionHistoryRequest{AccountUID: accountUIDtoTransact, Limit: 2},
+	)
+	assert.Equal(suite.T(), err, nil)
+
+	lastPage, err := historyService.List(
+		port.TransactionHistoryRequest{AccountUID: accountUIDtoTransact, Limit: 2, Cursor: firstPage.NextCursor},
+	)
+	assert.Equal(suite.T(), err, nil)
+
+	//Assert
+	assert.Equal(suite.T(), len(firstPage.Transactions), 2)
+	assert.NotEqual(suite.T(), firstPage.NextCursor, "")
+	assert.Equal(suite.T(), firstPage.Transactions[0].TransactionUID, dbFake.History[0].UID.String())
+	assert.Equal(suite.T(), firstPage.Transactions[1].TransactionUID, dbFake.History[1].UID.String())
+
+	assert.Equal(suite.T(), len(lastPage.Transactions), 2)
+	assert.Equal(suite.T(), lastPage.Transactions[0].TransactionUID, dbFake.History[2].UID.String())
+
+	finalPage, err := historyService.List(
+		port.TransactionHistoryRequest{AccountUID: accountUIDtoTransact, Limit: 2, Cursor: lastPage.NextCursor},
+	)
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(finalPage.Transactions), 1)
+	assert.Equal(suite.T(), finalPage.NextCursor, "")
+}
+
+func (suite *TransactionHistorySuite) TestListFilteredByMCC() {
+	//Arrange
+	dbFake := suite.historyDBfake()
+
+	//Act
+	history, err := suite.newTransactionHistoryService(&dbFake).List(
+		port.TransactionHistoryRequest{AccountUID: accountUIDtoTransact, MCC: correctFoodMCC},
+	)
+
+	//Assert
+	assert.Equal(suite.T(), err, nil)
+	assert.Equal(suite.T(), len(history.Transactions), 3)
+	assert.Equal(suite.T(), history.NextCursor, "")
+
+	for _, item := range history.Transactions {
+		assert.Equal(suite.T(), item.MCC, correctFoodMCC)
+		assert.Equal(suite.T(), item.Category, "FOOD")
+	}
+}
+
+func (suite *TransactionHistorySuite) TestListInvalidCursorRejected() {
+	//Arrange
+	dbFake := suite.historyDBfake()
+
+	//Act
+	_, err := suite.newTransactionHistoryService(&dbFake).List(
+		port.TransactionHistoryRequest{AccountUID: accountUIDtoTransact, Cursor: "not a cursor"},
+	)
+
+	//Assert
+	assert.Equal(suite.T(), errors.Is(err, port.ErrInvalidCursor), true)
+}
+
+func TestTransactionHistorySuite(t *testing.T) {
+	suite.Run(t, new(TransactionHistorySuite))
+}
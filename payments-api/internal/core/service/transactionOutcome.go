@@ -0,0 +1,35 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/jtonynet/go-payments-api/internal/core/domain"
+	"github.com/jtonynet/go-payments-api/internal/core/port"
+)
+
+/*
+  - Resolves the response of a transaction UID (idempotency key) already processed.
+    A key reused by another account is rejected as a duplicate transaction
+    instead of leaking the outcome.
+*/
+func replayTransactionOutcome(accountUID uuid.UUID, outcome port.TransactionOutcomeEntity) (string, error) {
+	if outcome.AccountUID != accountUID {
+		return domain.CODE_REJECTED_DUPLICATE_TRANSACTION, fmt.Errorf(
+			"%w: transaction %s already processed by another account",
+			port.ErrDuplicateTransaction,
+			outcome.UID.String(),
+		)
+	}
+
+	if outcome.Code != domain.CODE_APPROVED {
+		return outcome.Code, fmt.Errorf(
+			"transaction %s already rejected with code %s",
+			outcome.UID.String(),
+			outcome.Code,
+		)
+	}
+
+	return outcome.Code, nil
+}